@@ -0,0 +1,2 @@
+-- +migrate Up
+CREATE INDEX IF NOT EXISTS "idx_books_deleted_at" ON "books" ("deleted_at") WHERE "deleted_at" IS NOT NULL;
@@ -0,0 +1,3 @@
+-- +migrate Down
+DROP INDEX IF EXISTS "idx_books_search_vector";
+ALTER TABLE "books" DROP COLUMN IF EXISTS "search_vector";
@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE "books" ADD COLUMN IF NOT EXISTS "search_vector" TSVECTOR GENERATED ALWAYS AS (
+  setweight(to_tsvector('english', "title"), 'A') ||
+  setweight(to_tsvector('english', "author"), 'B') ||
+  setweight(to_tsvector('english', "description"), 'C')
+) STORED;
+CREATE INDEX IF NOT EXISTS "idx_books_search_vector" ON "books" USING GIN ("search_vector");
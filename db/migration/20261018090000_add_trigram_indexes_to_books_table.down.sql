@@ -0,0 +1,3 @@
+-- +migrate Down
+DROP INDEX IF EXISTS "idx_books_author_trgm";
+DROP INDEX IF EXISTS "idx_books_title_trgm";
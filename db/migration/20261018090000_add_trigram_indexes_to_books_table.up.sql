@@ -0,0 +1,4 @@
+-- +migrate Up
+CREATE EXTENSION IF NOT EXISTS "pg_trgm";
+CREATE INDEX IF NOT EXISTS "idx_books_title_trgm" ON "books" USING GIN ("title" gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS "idx_books_author_trgm" ON "books" USING GIN ("author" gin_trgm_ops);
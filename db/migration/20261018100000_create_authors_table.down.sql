@@ -0,0 +1,3 @@
+-- +migrate Down
+DROP TABLE IF EXISTS "book_authors";
+DROP TABLE IF EXISTS "authors";
@@ -0,0 +1,17 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "authors" (
+  "id" BIGINT PRIMARY KEY,
+  "name" TEXT NOT NULL,
+  "bio" TEXT NOT NULL DEFAULT '',
+  "name_key" TEXT GENERATED ALWAYS AS (lower(regexp_replace("name", '[[:space:][:punct:]]+', '', 'g'))) STORED,
+  "created_at" TIMESTAMP NOT NULL DEFAULT 'now()',
+  "updated_at" TIMESTAMP NOT NULL DEFAULT 'now()',
+  "deleted_at" TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_authors_name_key" ON "authors" ("name_key") WHERE "deleted_at" IS NULL;
+CREATE TABLE IF NOT EXISTS "book_authors" (
+  "book_id" BIGINT NOT NULL REFERENCES "books" ("id") ON DELETE CASCADE,
+  "author_id" BIGINT NOT NULL REFERENCES "authors" ("id") ON DELETE CASCADE,
+  PRIMARY KEY ("book_id", "author_id")
+);
+CREATE INDEX IF NOT EXISTS "idx_book_authors_author_id" ON "book_authors" ("author_id");
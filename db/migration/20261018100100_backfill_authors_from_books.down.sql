@@ -0,0 +1,14 @@
+-- +migrate Down
+-- only the backfilled rows are removed: the authors with the small
+-- sequential IDs the ID generators never yield, and their links to the books
+-- whose author column they were matched on. Backfilled authors that have
+-- since been linked to other books are kept
+DELETE FROM "book_authors"
+USING "authors", "books"
+WHERE "book_authors"."author_id" = "authors"."id"
+  AND "book_authors"."book_id" = "books"."id"
+  AND "authors"."id" < 2147483648
+  AND "authors"."name_key" = lower(regexp_replace("books"."author", '[[:space:][:punct:]]+', '', 'g'));
+DELETE FROM "authors"
+WHERE "id" < 2147483648
+  AND NOT EXISTS (SELECT 1 FROM "book_authors" WHERE "book_authors"."author_id" = "authors"."id");
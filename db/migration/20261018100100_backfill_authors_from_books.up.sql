@@ -0,0 +1,20 @@
+-- +migrate Up
+-- authors are told apart by their name without spaces & punctuation, so that
+-- "J.K. Rowling" and "J. K. Rowling" become the same author. The backfilled
+-- authors get small sequential IDs, which the ID generators never yield
+INSERT INTO "authors" ("id", "name", "created_at", "updated_at")
+SELECT
+  ROW_NUMBER() OVER (ORDER BY MIN("created_at"), MIN(trim("author"))),
+  MIN(trim("author")),
+  now(),
+  now()
+FROM "books"
+WHERE trim("author") <> ''
+GROUP BY lower(regexp_replace("author", '[[:space:][:punct:]]+', '', 'g'))
+ON CONFLICT DO NOTHING;
+INSERT INTO "book_authors" ("book_id", "author_id")
+SELECT "books"."id", "authors"."id"
+FROM "books"
+JOIN "authors" ON "authors"."name_key" = lower(regexp_replace("books"."author", '[[:space:][:punct:]]+', '', 'g'))
+WHERE "authors"."deleted_at" IS NULL
+ON CONFLICT DO NOTHING;
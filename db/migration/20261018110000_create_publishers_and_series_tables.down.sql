@@ -0,0 +1,11 @@
+-- +migrate Down
+DROP INDEX IF EXISTS "idx_books_work_id";
+DROP INDEX IF EXISTS "idx_books_series_id_series_position";
+DROP INDEX IF EXISTS "idx_books_publisher_id";
+ALTER TABLE "books"
+  DROP COLUMN IF EXISTS "work_id",
+  DROP COLUMN IF EXISTS "series_position",
+  DROP COLUMN IF EXISTS "series_id",
+  DROP COLUMN IF EXISTS "publisher_id";
+DROP TABLE IF EXISTS "series";
+DROP TABLE IF EXISTS "publishers";
@@ -0,0 +1,28 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "publishers" (
+  "id" BIGINT PRIMARY KEY,
+  "name" TEXT NOT NULL,
+  "created_at" TIMESTAMP NOT NULL DEFAULT 'now()',
+  "updated_at" TIMESTAMP NOT NULL DEFAULT 'now()',
+  "deleted_at" TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_publishers_name" ON "publishers" (lower("name")) WHERE "deleted_at" IS NULL;
+CREATE TABLE IF NOT EXISTS "series" (
+  "id" BIGINT PRIMARY KEY,
+  "name" TEXT NOT NULL,
+  "description" TEXT NOT NULL DEFAULT '',
+  "created_at" TIMESTAMP NOT NULL DEFAULT 'now()',
+  "updated_at" TIMESTAMP NOT NULL DEFAULT 'now()',
+  "deleted_at" TIMESTAMP
+);
+ALTER TABLE "books"
+  ADD COLUMN IF NOT EXISTS "publisher_id" BIGINT REFERENCES "publishers" ("id") ON DELETE SET NULL,
+  ADD COLUMN IF NOT EXISTS "series_id" BIGINT REFERENCES "series" ("id") ON DELETE SET NULL,
+  ADD COLUMN IF NOT EXISTS "series_position" INT,
+  ADD COLUMN IF NOT EXISTS "work_id" BIGINT;
+-- every existing book is the only edition of its own work
+UPDATE "books" SET "work_id" = "id" WHERE "work_id" IS NULL;
+ALTER TABLE "books" ALTER COLUMN "work_id" SET NOT NULL;
+CREATE INDEX IF NOT EXISTS "idx_books_publisher_id" ON "books" ("publisher_id");
+CREATE INDEX IF NOT EXISTS "idx_books_series_id_series_position" ON "books" ("series_id", "series_position");
+CREATE INDEX IF NOT EXISTS "idx_books_work_id" ON "books" ("work_id");
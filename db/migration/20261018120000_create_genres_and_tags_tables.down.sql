@@ -0,0 +1,6 @@
+-- +migrate Down
+DROP TABLE IF EXISTS "book_tags";
+DROP TABLE IF EXISTS "tags";
+DROP INDEX IF EXISTS "idx_books_genre_id";
+ALTER TABLE "books" DROP COLUMN IF EXISTS "genre_id";
+DROP TABLE IF EXISTS "genres";
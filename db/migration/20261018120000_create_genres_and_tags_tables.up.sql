@@ -0,0 +1,25 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "genres" (
+  "id" BIGINT PRIMARY KEY,
+  "name" TEXT NOT NULL,
+  "parent_id" BIGINT REFERENCES "genres" ("id"),
+  "created_at" TIMESTAMP NOT NULL DEFAULT 'now()',
+  "updated_at" TIMESTAMP NOT NULL DEFAULT 'now()',
+  "deleted_at" TIMESTAMP
+);
+-- sibling genres have distinct names, the roots being siblings of each other
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_genres_parent_id_name" ON "genres" (COALESCE("parent_id", 0), lower("name")) WHERE "deleted_at" IS NULL;
+CREATE INDEX IF NOT EXISTS "idx_genres_parent_id" ON "genres" ("parent_id");
+ALTER TABLE "books" ADD COLUMN IF NOT EXISTS "genre_id" BIGINT REFERENCES "genres" ("id") ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS "idx_books_genre_id" ON "books" ("genre_id");
+CREATE TABLE IF NOT EXISTS "tags" (
+  "id" BIGINT PRIMARY KEY,
+  "name" TEXT NOT NULL UNIQUE,
+  "created_at" TIMESTAMP NOT NULL DEFAULT 'now()'
+);
+CREATE TABLE IF NOT EXISTS "book_tags" (
+  "book_id" BIGINT NOT NULL REFERENCES "books" ("id") ON DELETE CASCADE,
+  "tag_id" BIGINT NOT NULL REFERENCES "tags" ("id") ON DELETE CASCADE,
+  PRIMARY KEY ("book_id", "tag_id")
+);
+CREATE INDEX IF NOT EXISTS "idx_book_tags_tag_id" ON "book_tags" ("tag_id");
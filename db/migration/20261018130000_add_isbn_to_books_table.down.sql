@@ -0,0 +1,5 @@
+-- +migrate Down
+DROP INDEX IF EXISTS "idx_books_isbn13";
+ALTER TABLE "books"
+  DROP COLUMN IF EXISTS "isbn10",
+  DROP COLUMN IF EXISTS "isbn13";
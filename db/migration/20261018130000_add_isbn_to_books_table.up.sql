@@ -0,0 +1,6 @@
+-- +migrate Up
+ALTER TABLE "books"
+  ADD COLUMN IF NOT EXISTS "isbn13" VARCHAR(13),
+  ADD COLUMN IF NOT EXISTS "isbn10" VARCHAR(10);
+-- a trashed book gives up its ISBN until it is restored
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_books_isbn13" ON "books" ("isbn13") WHERE "deleted_at" IS NULL;
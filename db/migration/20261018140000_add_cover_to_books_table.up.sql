@@ -0,0 +1,3 @@
+-- +migrate Up
+-- the covers themselves live in the blob store, see model.CoverKey
+ALTER TABLE "books" ADD COLUMN IF NOT EXISTS "cover_updated_at" TIMESTAMP;
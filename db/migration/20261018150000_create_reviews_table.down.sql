@@ -0,0 +1,6 @@
+-- +migrate Down
+DROP INDEX IF EXISTS "idx_books_average_rating";
+ALTER TABLE "books" DROP COLUMN IF EXISTS "average_rating";
+ALTER TABLE "books" DROP COLUMN IF EXISTS "ratings_sum";
+ALTER TABLE "books" DROP COLUMN IF EXISTS "ratings_count";
+DROP TABLE IF EXISTS "reviews";
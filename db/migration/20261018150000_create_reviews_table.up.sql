@@ -0,0 +1,20 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "reviews" (
+  "id" BIGINT PRIMARY KEY,
+  "book_id" BIGINT NOT NULL REFERENCES "books" ("id") ON DELETE CASCADE,
+  "rating" SMALLINT NOT NULL CHECK ("rating" BETWEEN 1 AND 5),
+  "body" TEXT NOT NULL DEFAULT '',
+  "reviewer" TEXT NOT NULL,
+  "status" VARCHAR(16) NOT NULL DEFAULT 'pending' CHECK ("status" IN ('pending', 'approved', 'rejected')),
+  "moderated_at" TIMESTAMP,
+  "created_at" TIMESTAMP NOT NULL DEFAULT 'now()',
+  "updated_at" TIMESTAMP NOT NULL DEFAULT 'now()'
+);
+-- a reader reviews a book once
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_reviews_book_id_reviewer" ON "reviews" ("book_id", lower("reviewer"));
+CREATE INDEX IF NOT EXISTS "idx_reviews_book_id_status_created_at" ON "reviews" ("book_id", "status", "created_at" DESC);
+-- the rating aggregate of the approved reviews, written by the review repository only
+ALTER TABLE "books" ADD COLUMN IF NOT EXISTS "ratings_count" BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE "books" ADD COLUMN IF NOT EXISTS "ratings_sum" BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE "books" ADD COLUMN IF NOT EXISTS "average_rating" NUMERIC(3, 2) NOT NULL DEFAULT 0;
+CREATE INDEX IF NOT EXISTS "idx_books_average_rating" ON "books" ("average_rating", "id") WHERE "deleted_at" IS NULL;
@@ -0,0 +1,6 @@
+-- +migrate Down
+ALTER TABLE "books" DROP COLUMN IF EXISTS "available_copies_count";
+ALTER TABLE "books" DROP COLUMN IF EXISTS "copies_count";
+DROP TABLE IF EXISTS "loans";
+DROP TABLE IF EXISTS "book_copies";
+DROP TABLE IF EXISTS "members";
@@ -0,0 +1,39 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "members" (
+  "id" BIGINT PRIMARY KEY,
+  "name" TEXT NOT NULL,
+  "email" TEXT NOT NULL,
+  "created_at" TIMESTAMP NOT NULL DEFAULT 'now()',
+  "updated_at" TIMESTAMP NOT NULL DEFAULT 'now()',
+  "deleted_at" TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_members_email" ON "members" (lower("email")) WHERE "deleted_at" IS NULL;
+CREATE TABLE IF NOT EXISTS "book_copies" (
+  "id" BIGINT PRIMARY KEY,
+  "book_id" BIGINT NOT NULL REFERENCES "books" ("id") ON DELETE CASCADE,
+  "barcode" TEXT NOT NULL,
+  "condition" VARCHAR(16) NOT NULL DEFAULT 'good' CHECK ("condition" IN ('new', 'good', 'fair', 'poor', 'damaged')),
+  "status" VARCHAR(16) NOT NULL DEFAULT 'available' CHECK ("status" IN ('available', 'on_loan')),
+  "created_at" TIMESTAMP NOT NULL DEFAULT 'now()',
+  "updated_at" TIMESTAMP NOT NULL DEFAULT 'now()',
+  "deleted_at" TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_book_copies_barcode" ON "book_copies" ("barcode") WHERE "deleted_at" IS NULL;
+CREATE INDEX IF NOT EXISTS "idx_book_copies_book_id" ON "book_copies" ("book_id") WHERE "deleted_at" IS NULL;
+CREATE TABLE IF NOT EXISTS "loans" (
+  "id" BIGINT PRIMARY KEY,
+  "copy_id" BIGINT NOT NULL REFERENCES "book_copies" ("id") ON DELETE RESTRICT,
+  "book_id" BIGINT NOT NULL REFERENCES "books" ("id") ON DELETE RESTRICT,
+  "member_id" BIGINT NOT NULL REFERENCES "members" ("id") ON DELETE CASCADE,
+  "checked_out_at" TIMESTAMP NOT NULL,
+  "due_at" TIMESTAMP NOT NULL CHECK ("due_at" > "checked_out_at"),
+  "returned_at" TIMESTAMP,
+  "created_at" TIMESTAMP NOT NULL DEFAULT 'now()',
+  "updated_at" TIMESTAMP NOT NULL DEFAULT 'now()'
+);
+-- a copy is out on one loan at a time, the loan repository also locks the copy before checking it out
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_loans_copy_id_open" ON "loans" ("copy_id") WHERE "returned_at" IS NULL;
+CREATE INDEX IF NOT EXISTS "idx_loans_member_id_checked_out_at" ON "loans" ("member_id", "checked_out_at" DESC);
+-- the availability of the copies, written by the book copy and loan repositories only
+ALTER TABLE "books" ADD COLUMN IF NOT EXISTS "copies_count" BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE "books" ADD COLUMN IF NOT EXISTS "available_copies_count" BIGINT NOT NULL DEFAULT 0;
@@ -0,0 +1,9 @@
+-- +migrate Down
+-- the reserved copies are back on the shelf
+UPDATE "books" SET "available_copies_count" = "books"."available_copies_count" + "reserved"."count"
+FROM (SELECT "book_id", count(*) AS "count" FROM "book_copies" WHERE "status" = 'reserved' AND "deleted_at" IS NULL GROUP BY "book_id") AS "reserved"
+WHERE "books"."id" = "reserved"."book_id";
+UPDATE "book_copies" SET "status" = 'available' WHERE "status" = 'reserved';
+ALTER TABLE "book_copies" DROP CONSTRAINT IF EXISTS "book_copies_status_check";
+ALTER TABLE "book_copies" ADD CONSTRAINT "book_copies_status_check" CHECK ("status" IN ('available', 'on_loan'));
+DROP TABLE IF EXISTS "holds";
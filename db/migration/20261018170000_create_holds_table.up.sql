@@ -0,0 +1,22 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "holds" (
+  "id" BIGINT PRIMARY KEY,
+  "book_id" BIGINT NOT NULL REFERENCES "books" ("id") ON DELETE CASCADE,
+  "member_id" BIGINT NOT NULL REFERENCES "members" ("id") ON DELETE CASCADE,
+  "copy_id" BIGINT REFERENCES "book_copies" ("id") ON DELETE CASCADE,
+  "status" VARCHAR(16) NOT NULL DEFAULT 'waiting' CHECK ("status" IN ('waiting', 'ready', 'fulfilled', 'cancelled', 'expired')),
+  "ready_at" TIMESTAMP,
+  "expires_at" TIMESTAMP,
+  "created_at" TIMESTAMP NOT NULL DEFAULT 'now()',
+  "updated_at" TIMESTAMP NOT NULL DEFAULT 'now()',
+  CHECK ("status" <> 'ready' OR ("copy_id" IS NOT NULL AND "expires_at" IS NOT NULL))
+);
+-- a member is queued once for a book, and a copy is reserved for one hold at a time
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_holds_book_id_member_id_active" ON "holds" ("book_id", "member_id") WHERE "status" IN ('waiting', 'ready');
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_holds_copy_id_ready" ON "holds" ("copy_id") WHERE "status" = 'ready';
+-- the queue of a book, first come first served
+CREATE INDEX IF NOT EXISTS "idx_holds_book_id_created_at_active" ON "holds" ("book_id", "created_at", "id") WHERE "status" IN ('waiting', 'ready');
+CREATE INDEX IF NOT EXISTS "idx_holds_expires_at_ready" ON "holds" ("expires_at") WHERE "status" = 'ready';
+-- a copy can be reserved for a hold
+ALTER TABLE "book_copies" DROP CONSTRAINT IF EXISTS "book_copies_status_check";
+ALTER TABLE "book_copies" ADD CONSTRAINT "book_copies_status_check" CHECK ("status" IN ('available', 'on_loan', 'reserved'));
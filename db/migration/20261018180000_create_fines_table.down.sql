@@ -0,0 +1,4 @@
+-- +migrate Down
+DROP INDEX IF EXISTS "idx_loans_due_at_fines_open";
+ALTER TABLE "loans" DROP COLUMN IF EXISTS "fines_closed_at";
+DROP TABLE IF EXISTS "fines";
@@ -0,0 +1,17 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "fines" (
+  "id" BIGINT PRIMARY KEY,
+  "member_id" BIGINT NOT NULL REFERENCES "members" ("id") ON DELETE CASCADE,
+  "loan_id" BIGINT REFERENCES "loans" ("id") ON DELETE RESTRICT,
+  "kind" VARCHAR(16) NOT NULL CHECK ("kind" IN ('accrual', 'payment', 'waiver')),
+  "amount_cents" BIGINT NOT NULL CHECK ("amount_cents" > 0),
+  "overdue_days" BIGINT NOT NULL DEFAULT 0,
+  "note" TEXT NOT NULL DEFAULT '',
+  "created_at" TIMESTAMP NOT NULL DEFAULT 'now()',
+  CHECK ("kind" <> 'accrual' OR "loan_id" IS NOT NULL)
+);
+CREATE INDEX IF NOT EXISTS "idx_fines_member_id_created_at" ON "fines" ("member_id", "created_at" DESC, "id" DESC);
+CREATE INDEX IF NOT EXISTS "idx_fines_loan_id_accrual" ON "fines" ("loan_id") WHERE "kind" = 'accrual';
+-- the fines of a loan stop accruing once they are accrued in full after its return
+ALTER TABLE "loans" ADD COLUMN IF NOT EXISTS "fines_closed_at" TIMESTAMP;
+CREATE INDEX IF NOT EXISTS "idx_loans_due_at_fines_open" ON "loans" ("due_at") WHERE "fines_closed_at" IS NULL;
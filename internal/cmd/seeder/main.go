@@ -11,6 +11,7 @@ import (
 	"github.com/ssentinull/create-apis-using-golang/internal/db"
 	"github.com/ssentinull/create-apis-using-golang/internal/model"
 	"github.com/ssentinull/create-apis-using-golang/internal/repository"
+	"github.com/ssentinull/create-apis-using-golang/internal/usecase"
 	"github.com/ssentinull/create-apis-using-golang/internal/utils"
 )
 
@@ -35,9 +36,20 @@ func initLogger() {
 	logrus.SetLevel(logLevel)
 }
 
+// initialize the generator of the book IDs
+func initIDGenerator() {
+	idGenerator, err := utils.NewIDGenerator(config.IDGenerator(), config.IDGeneratorNodeID())
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	utils.SetIDGenerator(idGenerator)
+}
+
 func init() {
 	config.GetConf()
 	initLogger()
+	initIDGenerator()
 }
 
 func main() {
@@ -49,6 +61,7 @@ func main() {
 
 	cacheRepo := repository.NewCacheRepository(db.RedisClient)
 	bookRepo := repository.NewBookRepository(db.PostgresDB, cacheRepo)
+	bookUsecase := usecase.NewBookUsecase(bookRepo, nil, nil)
 
 	logrus.Infof("Running %d seeds!", *seed)
 
@@ -58,10 +71,10 @@ func main() {
 			Title:         gofakeit.BookTitle(),
 			Author:        gofakeit.BookAuthor(),
 			Description:   gofakeit.Paragraph(1, 3, 10, "."),
-			PublishedDate: gofakeit.Date().Format("2006-01-02"),
+			PublishedDate: gofakeit.Date().Format(utils.DateLayout),
 		}
 
-		if err := bookRepo.Create(context.TODO(), book); err != nil {
+		if _, err := bookUsecase.Create(context.TODO(), book, false); err != nil {
 			logrus.WithField("book", utils.Dump(book)).Error(err)
 		}
 	}
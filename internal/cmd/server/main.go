@@ -1,17 +1,21 @@
 package main
 
 import (
+	"context"
 	"net/http"
 	"os"
 	"time"
 
 	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
 	"github.com/sirupsen/logrus"
 	"github.com/ssentinull/create-apis-using-golang/internal/config"
 	"github.com/ssentinull/create-apis-using-golang/internal/db"
 	_bookHTTPHndlr "github.com/ssentinull/create-apis-using-golang/internal/delivery/http"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
 	_repo "github.com/ssentinull/create-apis-using-golang/internal/repository"
 	_bookUcase "github.com/ssentinull/create-apis-using-golang/internal/usecase"
+	"github.com/ssentinull/create-apis-using-golang/internal/utils"
 )
 
 // initialize logger configurations
@@ -35,22 +39,181 @@ func initLogger() {
 	logrus.SetLevel(logLevel)
 }
 
-// run initLogger() before running main()
+// purgeTrash permanently deletes the expired books in the trash on every purge interval
+func purgeTrash(bookUsecase model.BookUsecase) {
+	ticker := time.NewTicker(config.TrashPurgeInterval())
+	defer ticker.Stop()
+
+	for range ticker.C {
+		count, err := bookUsecase.PurgeTrash(context.Background())
+		if err != nil {
+			logrus.Error(err)
+			continue
+		}
+
+		logrus.Infof("purged %d books from the trash", count)
+	}
+}
+
+// sweepHolds expires the ready holds past their pickup window on every sweep interval
+func sweepHolds(holdUsecase model.HoldUsecase) {
+	ticker := time.NewTicker(config.HoldSweepInterval())
+	defer ticker.Stop()
+
+	for range ticker.C {
+		count, err := holdUsecase.ExpireReady(context.Background())
+		if err != nil {
+			logrus.Error(err)
+			continue
+		}
+
+		logrus.Infof("expired %d holds", count)
+	}
+}
+
+// accrueFines accrues the fines of the overdue loans on every accrual interval
+func accrueFines(fineUsecase model.FineUsecase) {
+	ticker := time.NewTicker(config.FineAccrualInterval())
+	defer ticker.Stop()
+
+	for range ticker.C {
+		count, err := fineUsecase.AccrueOverdue(context.Background())
+		if err != nil {
+			logrus.Error(err)
+			continue
+		}
+
+		logrus.Infof("accrued the fines of %d loans", count)
+	}
+}
+
+// initialize the generator of the book IDs
+func initIDGenerator() {
+	idGenerator, err := utils.NewIDGenerator(config.IDGenerator(), config.IDGeneratorNodeID())
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	utils.SetIDGenerator(idGenerator)
+}
+
+// initialize the metadata provider used to enrich the new books
+func initMetadataProvider(cacheRepo model.CacheRepository) model.MetadataProvider {
+	switch config.MetadataProvider() {
+	case "openlibrary":
+		return _repo.NewOpenLibraryMetadataProvider(&http.Client{}, config.MetadataBaseURL(), cacheRepo, config.MetadataCacheTTL())
+	case "file":
+		metadataProvider, err := _repo.NewFileMetadataProvider(config.MetadataFilePath())
+		if err != nil {
+			logrus.Fatal(err)
+		}
+		return metadataProvider
+	default:
+		logrus.Fatalf("unknown metadata provider %q", config.MetadataProvider())
+		return nil
+	}
+}
+
+// initialize the blob store keeping the book covers
+func initBlobStore() model.BlobStore {
+	switch config.BlobStoreType() {
+	case "fs":
+		return _repo.NewFSBlobStore(config.BlobStoreFSDir())
+	case "s3":
+		return _repo.NewS3BlobStore(&http.Client{Timeout: 30 * time.Second}, _repo.S3Config{
+			Endpoint:        config.BlobStoreS3Endpoint(),
+			Region:          config.BlobStoreS3Region(),
+			Bucket:          config.BlobStoreS3Bucket(),
+			AccessKey:       config.BlobStoreS3AccessKey(),
+			SecretKey:       config.BlobStoreS3SecretKey(),
+			SessionToken:    config.BlobStoreS3SessionToken(),
+			UnsignedPayload: config.BlobStoreS3UnsignedPayload(),
+		})
+	default:
+		logrus.Fatalf("unknown blob store %q", config.BlobStoreType())
+		return nil
+	}
+}
+
+// run initLogger() and initIDGenerator() before running main()
 func init() {
 	config.GetConf()
 	initLogger()
+	initIDGenerator()
 }
 
 func main() {
 	e := echo.New()
+	e.HTTPErrorHandler = _bookHTTPHndlr.HTTPErrorHandler
+	e.Validator = &_bookHTTPHndlr.Validator{}
+	e.Use(middleware.RequestID())
 
 	db.InitializePostgresConn()
 	db.InitializeRedisConn()
 
 	cacheRepo := _repo.NewCacheRepository(db.RedisClient)
 	bookRepo := _repo.NewBookRepository(db.PostgresDB, cacheRepo)
-	bookUsecase := _bookUcase.NewBookUsecase(bookRepo)
-	_bookHTTPHndlr.NewBookHTTPHandler(e, bookUsecase)
+	metadataProvider := initMetadataProvider(cacheRepo)
+	blobStore := initBlobStore()
+	bookUsecase := _bookUcase.NewBookUsecase(bookRepo, metadataProvider, blobStore)
+	_bookHTTPHndlr.NewBookHTTPHandler(e, bookUsecase, cacheRepo)
+
+	coverUsecase := _bookUcase.NewCoverUsecase(bookRepo, blobStore)
+	_bookHTTPHndlr.NewCoverHTTPHandler(e, coverUsecase, cacheRepo)
+
+	authorRepo := _repo.NewAuthorRepository(db.PostgresDB, cacheRepo)
+	authorUsecase := _bookUcase.NewAuthorUsecase(authorRepo)
+	_bookHTTPHndlr.NewAuthorHTTPHandler(e, authorUsecase, cacheRepo)
+
+	publisherRepo := _repo.NewPublisherRepository(db.PostgresDB, cacheRepo)
+	publisherUsecase := _bookUcase.NewPublisherUsecase(publisherRepo)
+	_bookHTTPHndlr.NewPublisherHTTPHandler(e, publisherUsecase, cacheRepo)
+
+	seriesRepo := _repo.NewSeriesRepository(db.PostgresDB, cacheRepo)
+	seriesUsecase := _bookUcase.NewSeriesUsecase(seriesRepo, bookRepo)
+	_bookHTTPHndlr.NewSeriesHTTPHandler(e, seriesUsecase, cacheRepo)
+
+	genreRepo := _repo.NewGenreRepository(db.PostgresDB, cacheRepo)
+	genreUsecase := _bookUcase.NewGenreUsecase(genreRepo)
+	_bookHTTPHndlr.NewGenreHTTPHandler(e, genreUsecase, cacheRepo)
+
+	tagRepo := _repo.NewTagRepository(db.PostgresDB, cacheRepo)
+	tagUsecase := _bookUcase.NewTagUsecase(tagRepo)
+	_bookHTTPHndlr.NewTagHTTPHandler(e, tagUsecase, cacheRepo)
+
+	reviewRepo := _repo.NewReviewRepository(db.PostgresDB, cacheRepo)
+	reviewUsecase := _bookUcase.NewReviewUsecase(reviewRepo)
+	_bookHTTPHndlr.NewReviewHTTPHandler(e, reviewUsecase, cacheRepo)
+
+	memberRepo := _repo.NewMemberRepository(db.PostgresDB, cacheRepo)
+	memberUsecase := _bookUcase.NewMemberUsecase(memberRepo)
+	_bookHTTPHndlr.NewMemberHTTPHandler(e, memberUsecase, cacheRepo)
+
+	bookCopyRepo := _repo.NewBookCopyRepository(db.PostgresDB, cacheRepo)
+	bookCopyUsecase := _bookUcase.NewBookCopyUsecase(bookCopyRepo)
+	_bookHTTPHndlr.NewBookCopyHTTPHandler(e, bookCopyUsecase, cacheRepo)
+
+	// the circulation usecases share a clock, the fines accrue from the dates it tells
+	clock := time.Now
+	loanRepo := _repo.NewLoanRepository(db.PostgresDB, cacheRepo)
+	loanUsecase := _bookUcase.NewLoanUsecase(loanRepo, bookCopyRepo, clock)
+	_bookHTTPHndlr.NewLoanHTTPHandler(e, loanUsecase, cacheRepo)
+
+	holdRepo := _repo.NewHoldRepository(db.PostgresDB, cacheRepo)
+	holdUsecase := _bookUcase.NewHoldUsecase(holdRepo, clock)
+	_bookHTTPHndlr.NewHoldHTTPHandler(e, holdUsecase, cacheRepo)
+
+	fineRepo := _repo.NewFineRepository(db.PostgresDB, cacheRepo)
+	fineUsecase := _bookUcase.NewFineUsecase(fineRepo, model.FeeSchedule{
+		PerDayCents:     config.FinePerDayCents(),
+		GracePeriodDays: config.FineGracePeriodDays(),
+		CapCents:        config.FineCapCents(),
+	}, clock)
+	_bookHTTPHndlr.NewFineHTTPHandler(e, fineUsecase, cacheRepo)
+
+	go purgeTrash(bookUsecase)
+	go sweepHolds(holdUsecase)
+	go accrueFines(fineUsecase)
 
 	s := &http.Server{
 		Addr:         ":" + config.ServerPort(),
@@ -132,3 +132,263 @@ func RedisPassword() string {
 func RedisDB() int {
 	return viper.GetInt("redis.db")
 }
+
+// PaginationDefaultSize :nodoc:
+func PaginationDefaultSize() int64 {
+	if viper.GetInt64("pagination.default_size") <= 0 {
+		return DefaultPaginationDefaultSize
+	}
+
+	return viper.GetInt64("pagination.default_size")
+}
+
+// PaginationMaxSize :nodoc:
+func PaginationMaxSize() int64 {
+	if viper.GetInt64("pagination.max_size") <= 0 {
+		return DefaultPaginationMaxSize
+	}
+
+	return viper.GetInt64("pagination.max_size")
+}
+
+// TrashRetentionDays :nodoc:
+func TrashRetentionDays() int {
+	if viper.GetInt("trash.retention_days") <= 0 {
+		return DefaultTrashRetentionDays
+	}
+
+	return viper.GetInt("trash.retention_days")
+}
+
+// TrashPurgeInterval :nodoc:
+func TrashPurgeInterval() time.Duration {
+	cfg := viper.GetString("trash.purge_interval")
+	return utils.ParseDuration(cfg, DefaultTrashPurgeInterval)
+}
+
+// BatchMaxSize :nodoc:
+func BatchMaxSize() int {
+	if viper.GetInt("batch.max_size") <= 0 {
+		return DefaultBatchMaxSize
+	}
+
+	return viper.GetInt("batch.max_size")
+}
+
+// IdempotencyTTL :nodoc:
+func IdempotencyTTL() time.Duration {
+	cfg := viper.GetString("idempotency.ttl")
+	return utils.ParseDuration(cfg, DefaultIdempotencyTTL)
+}
+
+// IdempotencyLockTTL :nodoc:
+func IdempotencyLockTTL() time.Duration {
+	cfg := viper.GetString("idempotency.lock_ttl")
+	return utils.ParseDuration(cfg, DefaultIdempotencyLockTTL)
+}
+
+// IDGenerator :nodoc:
+func IDGenerator() string {
+	if viper.GetString("id_generator.type") == "" {
+		return DefaultIDGenerator
+	}
+
+	return viper.GetString("id_generator.type")
+}
+
+// IDGeneratorNodeID :nodoc:
+func IDGeneratorNodeID() int64 {
+	return viper.GetInt64("id_generator.node_id")
+}
+
+// SuggestThreshold :nodoc:
+func SuggestThreshold() float64 {
+	threshold := viper.GetFloat64("suggest.similarity_threshold")
+	if threshold <= 0 || threshold > 1 {
+		return DefaultSuggestThreshold
+	}
+
+	return threshold
+}
+
+// SuggestLimit :nodoc:
+func SuggestLimit() int64 {
+	if viper.GetInt64("suggest.limit") <= 0 {
+		return DefaultSuggestLimit
+	}
+
+	return viper.GetInt64("suggest.limit")
+}
+
+// MetadataProvider :nodoc:
+func MetadataProvider() string {
+	if viper.GetString("metadata.provider") == "" {
+		return DefaultMetadataProvider
+	}
+
+	return viper.GetString("metadata.provider")
+}
+
+// MetadataBaseURL :nodoc:
+func MetadataBaseURL() string {
+	if viper.GetString("metadata.base_url") == "" {
+		return DefaultMetadataBaseURL
+	}
+
+	return viper.GetString("metadata.base_url")
+}
+
+// MetadataFilePath :nodoc:
+func MetadataFilePath() string {
+	return viper.GetString("metadata.file_path")
+}
+
+// MetadataTimeout :nodoc:
+func MetadataTimeout() time.Duration {
+	cfg := viper.GetString("metadata.timeout")
+	return utils.ParseDuration(cfg, DefaultMetadataTimeout)
+}
+
+// MetadataCacheTTL :nodoc:
+func MetadataCacheTTL() time.Duration {
+	cfg := viper.GetString("metadata.cache_ttl")
+	return utils.ParseDuration(cfg, DefaultMetadataCacheTTL)
+}
+
+// CoverMaxSize :nodoc:
+func CoverMaxSize() int64 {
+	if viper.GetInt64("cover.max_size") <= 0 {
+		return DefaultCoverMaxSize
+	}
+
+	return viper.GetInt64("cover.max_size")
+}
+
+// CoverMaxPixels :nodoc:
+func CoverMaxPixels() int64 {
+	if viper.GetInt64("cover.max_pixels") <= 0 {
+		return DefaultCoverMaxPixels
+	}
+
+	return viper.GetInt64("cover.max_pixels")
+}
+
+// CoverThumbWidth :nodoc:
+func CoverThumbWidth() int {
+	if viper.GetInt("cover.thumb_width") <= 0 {
+		return DefaultCoverThumbWidth
+	}
+
+	return viper.GetInt("cover.thumb_width")
+}
+
+// BlobStoreType :nodoc:
+func BlobStoreType() string {
+	if viper.GetString("blob_store.type") == "" {
+		return DefaultBlobStoreType
+	}
+
+	return viper.GetString("blob_store.type")
+}
+
+// BlobStoreFSDir :nodoc:
+func BlobStoreFSDir() string {
+	if viper.GetString("blob_store.fs_dir") == "" {
+		return DefaultBlobStoreFSDir
+	}
+
+	return viper.GetString("blob_store.fs_dir")
+}
+
+// BlobStoreS3Endpoint :nodoc:
+func BlobStoreS3Endpoint() string {
+	return viper.GetString("blob_store.s3.endpoint")
+}
+
+// BlobStoreS3Region :nodoc:
+func BlobStoreS3Region() string {
+	if viper.GetString("blob_store.s3.region") == "" {
+		return DefaultBlobStoreS3Region
+	}
+
+	return viper.GetString("blob_store.s3.region")
+}
+
+// BlobStoreS3Bucket :nodoc:
+func BlobStoreS3Bucket() string {
+	return viper.GetString("blob_store.s3.bucket")
+}
+
+// BlobStoreS3AccessKey :nodoc:
+func BlobStoreS3AccessKey() string {
+	return viper.GetString("blob_store.s3.access_key")
+}
+
+// BlobStoreS3SecretKey :nodoc:
+func BlobStoreS3SecretKey() string {
+	return viper.GetString("blob_store.s3.secret_key")
+}
+
+// BlobStoreS3SessionToken :nodoc:
+func BlobStoreS3SessionToken() string {
+	return viper.GetString("blob_store.s3.session_token")
+}
+
+// BlobStoreS3UnsignedPayload :nodoc:
+func BlobStoreS3UnsignedPayload() bool {
+	return viper.GetBool("blob_store.s3.unsigned_payload")
+}
+
+// LoanPeriodDays :nodoc:
+func LoanPeriodDays() int {
+	if viper.GetInt("loan.period_days") <= 0 {
+		return DefaultLoanPeriodDays
+	}
+
+	return viper.GetInt("loan.period_days")
+}
+
+// HoldPickupWindow :nodoc:
+func HoldPickupWindow() time.Duration {
+	cfg := viper.GetString("hold.pickup_window")
+	return utils.ParseDuration(cfg, DefaultHoldPickupWindow)
+}
+
+// HoldSweepInterval :nodoc:
+func HoldSweepInterval() time.Duration {
+	cfg := viper.GetString("hold.sweep_interval")
+	return utils.ParseDuration(cfg, DefaultHoldSweepInterval)
+}
+
+// FinePerDayCents :nodoc:
+func FinePerDayCents() int64 {
+	if viper.GetInt64("fine.per_day_cents") <= 0 {
+		return DefaultFinePerDayCents
+	}
+
+	return viper.GetInt64("fine.per_day_cents")
+}
+
+// FineGracePeriodDays :nodoc:
+func FineGracePeriodDays() int64 {
+	if viper.GetInt64("fine.grace_period_days") < 0 {
+		return DefaultFineGracePeriodDays
+	}
+
+	return viper.GetInt64("fine.grace_period_days")
+}
+
+// FineCapCents is the most a loan can be fined, zero means no cap
+func FineCapCents() int64 {
+	if !viper.IsSet("fine.cap_cents") || viper.GetInt64("fine.cap_cents") < 0 {
+		return DefaultFineCapCents
+	}
+
+	return viper.GetInt64("fine.cap_cents")
+}
+
+// FineAccrualInterval :nodoc:
+func FineAccrualInterval() time.Duration {
+	cfg := viper.GetString("fine.accrual_interval")
+	return utils.ParseDuration(cfg, DefaultFineAccrualInterval)
+}
@@ -8,4 +8,31 @@ const (
 	DefaultPostgresConnMaxLifetime = 1 * time.Hour
 	DefaultPostgresPingInterval    = 1 * time.Second
 	DefaultPostgresRetryAttempts   = 3
+	DefaultPaginationDefaultSize   = 10
+	DefaultPaginationMaxSize       = 100
+	DefaultTrashRetentionDays      = 30
+	DefaultTrashPurgeInterval      = 1 * time.Hour
+	DefaultBatchMaxSize            = 100
+	DefaultIdempotencyTTL          = 24 * time.Hour
+	DefaultIdempotencyLockTTL      = 2 * time.Minute
+	DefaultIDGenerator             = "snowflake"
+	DefaultSuggestThreshold        = 0.3
+	DefaultSuggestLimit            = 10
+	DefaultMetadataProvider        = "openlibrary"
+	DefaultMetadataBaseURL         = "https://openlibrary.org"
+	DefaultMetadataTimeout         = 3 * time.Second
+	DefaultMetadataCacheTTL        = 24 * time.Hour
+	DefaultCoverMaxSize            = 5 << 20
+	DefaultCoverMaxPixels          = 50_000_000
+	DefaultCoverThumbWidth         = 300
+	DefaultBlobStoreType           = "fs"
+	DefaultBlobStoreFSDir          = "data/blobs"
+	DefaultBlobStoreS3Region       = "us-east-1"
+	DefaultLoanPeriodDays          = 14
+	DefaultHoldPickupWindow        = 72 * time.Hour
+	DefaultHoldSweepInterval       = 1 * time.Minute
+	DefaultFinePerDayCents         = 25
+	DefaultFineGracePeriodDays     = 0
+	DefaultFineCapCents            = 1000
+	DefaultFineAccrualInterval     = 24 * time.Hour
 )
@@ -0,0 +1,173 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/ssentinull/create-apis-using-golang/internal/config"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+)
+
+type AuthorHTTPHandler struct {
+	AuthorUsecase model.AuthorUsecase
+}
+
+func NewAuthorHTTPHandler(e *echo.Echo, au model.AuthorUsecase, cacheRepo model.CacheRepository) {
+	handler := AuthorHTTPHandler{AuthorUsecase: au}
+	idempotent := IdempotencyMiddleware(cacheRepo, config.IdempotencyTTL(), config.IdempotencyLockTTL())
+
+	g := e.Group("/v1")
+	g.POST("/authors", handler.CreateAuthor, idempotent)
+	g.GET("/authors", handler.FetchAuthors)
+	g.GET("/authors/:ID", handler.FetchAuthorByID)
+	g.PUT("/authors/:ID", handler.UpdateAuthor, idempotent)
+	g.DELETE("/authors/:ID", handler.DeleteAuthorByID, idempotent)
+	g.GET("/books/:ID/authors", handler.FetchBookAuthors)
+	g.PUT("/books/:ID/authors", handler.ReplaceBookAuthors, idempotent)
+}
+
+func (ah *AuthorHTTPHandler) CreateAuthor(c echo.Context) error {
+	input := new(model.CreateAuthorInput)
+	if err := c.Bind(input); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	if err := c.Validate(input); err != nil {
+		return err
+	}
+
+	author, err := ah.AuthorUsecase.Create(c.Request().Context(), input.ToModel())
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, author)
+}
+
+func (ah *AuthorHTTPHandler) DeleteAuthorByID(c echo.Context) error {
+	ID, err := strconv.ParseInt(c.Param("ID"), 10, 64)
+	if err != nil {
+		logrus.Error(err)
+		return errInvalidIDParam
+	}
+
+	if err := ah.AuthorUsecase.DeleteByID(c.Request().Context(), ID); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (ah *AuthorHTTPHandler) FetchAuthors(c echo.Context) error {
+	queryParams := new(model.GetAuthorsQueryParams)
+	if err := c.Bind(queryParams); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	if err := c.Validate(queryParams); err != nil {
+		return err
+	}
+
+	queryParams.Normalize(config.PaginationDefaultSize(), config.PaginationMaxSize())
+	authors, count, err := ah.AuthorUsecase.FindAll(c.Request().Context(), *queryParams)
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	res := model.NewPaginationResponse(authors, queryParams.Page, queryParams.Size, count)
+	setPageLinkHeader(c, res)
+	return c.JSON(http.StatusOK, res)
+}
+
+func (ah *AuthorHTTPHandler) FetchAuthorByID(c echo.Context) error {
+	ID, err := strconv.ParseInt(c.Param("ID"), 10, 64)
+	if err != nil {
+		logrus.Error(err)
+		return errInvalidIDParam
+	}
+
+	author, err := ah.AuthorUsecase.FindByID(c.Request().Context(), ID)
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	return c.JSON(http.StatusOK, author)
+}
+
+func (ah *AuthorHTTPHandler) UpdateAuthor(c echo.Context) error {
+	ID, err := strconv.ParseInt(c.Param("ID"), 10, 64)
+	if err != nil {
+		logrus.Error(err)
+		return errInvalidIDParam
+	}
+
+	input := new(model.UpdateAuthorInput)
+	if err := c.Bind(input); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	input.ID = ID
+	if err := c.Validate(input); err != nil {
+		return err
+	}
+
+	author, err := ah.AuthorUsecase.Update(c.Request().Context(), input.ToModel())
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	return c.JSON(http.StatusOK, author)
+}
+
+func (ah *AuthorHTTPHandler) FetchBookAuthors(c echo.Context) error {
+	ID, err := strconv.ParseInt(c.Param("ID"), 10, 64)
+	if err != nil {
+		logrus.Error(err)
+		return errInvalidIDParam
+	}
+
+	authors, err := ah.AuthorUsecase.FindAllByBookID(c.Request().Context(), ID)
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	return c.JSON(http.StatusOK, authors)
+}
+
+func (ah *AuthorHTTPHandler) ReplaceBookAuthors(c echo.Context) error {
+	ID, err := strconv.ParseInt(c.Param("ID"), 10, 64)
+	if err != nil {
+		logrus.Error(err)
+		return errInvalidIDParam
+	}
+
+	input := new(model.ReplaceBookAuthorsInput)
+	if err := c.Bind(input); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	input.BookID = ID
+	if err := c.Validate(input); err != nil {
+		return err
+	}
+
+	authors, err := ah.AuthorUsecase.ReplaceBookAuthors(c.Request().Context(), *input)
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	return c.JSON(http.StatusOK, authors)
+}
@@ -0,0 +1,344 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/ssentinull/create-apis-using-golang/internal/config"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+	"github.com/ssentinull/create-apis-using-golang/internal/model/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthorDeliveryHTTP_CreateAuthor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAuthorUsecase := mock.NewMockAuthorUsecase(ctrl)
+	httpHandler := AuthorHTTPHandler{AuthorUsecase: mockAuthorUsecase}
+	e := echo.New()
+	e.Validator = &Validator{}
+
+	author := &model.Author{ID: 1, Name: "J. K. Rowling"}
+
+	t.Run("success", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/v1/authors", strings.NewReader(`{"name":"J. K. Rowling"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+
+		mockAuthorUsecase.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).
+			DoAndReturn(func(_ interface{}, input *model.Author) (*model.Author, error) {
+				assert.Equal(t, author.Name, input.Name)
+				assert.NotZero(t, input.ID)
+				return author, nil
+			})
+
+		err := httpHandler.CreateAuthor(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+	})
+
+	t.Run("failed - name is missing", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/v1/authors", strings.NewReader(`{"bio":"British author"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+
+		err := httpHandler.CreateAuthor(ctx)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+	})
+
+	t.Run("failed - name is taken", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/v1/authors", strings.NewReader(`{"name":"J.K. Rowling"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+
+		mockAuthorUsecase.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(nil, domainerr.Conflict("record already exists", nil))
+
+		err := httpHandler.CreateAuthor(ctx)
+		assert.Error(t, err)
+
+		HTTPErrorHandler(err, ctx)
+		assert.Equal(t, http.StatusConflict, rec.Code)
+		assert.Equal(t, MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+	})
+}
+
+func TestAuthorDeliveryHTTP_DeleteAuthorByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAuthorUsecase := mock.NewMockAuthorUsecase(ctrl)
+	httpHandler := AuthorHTTPHandler{AuthorUsecase: mockAuthorUsecase}
+	e := echo.New()
+
+	t.Run("success", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, "/v1/authors/1", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("1")
+
+		mockAuthorUsecase.EXPECT().DeleteByID(gomock.Any(), int64(1)).Times(1).Return(nil)
+
+		err := httpHandler.DeleteAuthorByID(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+	})
+
+	t.Run("failed - invalid ID param", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, "/v1/authors/abc", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("abc")
+
+		err := httpHandler.DeleteAuthorByID(ctx)
+		assert.Equal(t, errInvalidIDParam, err)
+	})
+
+	t.Run("failed - author not found", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, "/v1/authors/1", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("1")
+
+		mockAuthorUsecase.EXPECT().DeleteByID(gomock.Any(), int64(1)).Times(1).Return(domainerr.NotFound("author 1 not found", nil))
+
+		err := httpHandler.DeleteAuthorByID(ctx)
+		assert.Error(t, err)
+
+		HTTPErrorHandler(err, ctx)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestAuthorDeliveryHTTP_FetchAuthors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAuthorUsecase := mock.NewMockAuthorUsecase(ctrl)
+	httpHandler := AuthorHTTPHandler{AuthorUsecase: mockAuthorUsecase}
+	e := echo.New()
+	e.Validator = &Validator{}
+
+	authors := []*model.Author{{ID: 1, Name: "J. K. Rowling"}}
+
+	t.Run("success", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/authors?name=rowling", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+
+		expectedParams := model.GetAuthorsQueryParams{PageQuery: model.PageQuery{Page: 1, Size: config.DefaultPaginationDefaultSize}, Name: "rowling"}
+		mockAuthorUsecase.EXPECT().FindAll(gomock.Any(), expectedParams).Times(1).Return(authors, int64(11), nil)
+
+		err := httpHandler.FetchAuthors(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		res := model.PaginationResponse{}
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		assert.Equal(t, int64(11), res.TotalItems)
+		assert.Contains(t, rec.Header().Get(HeaderLink), `rel="next"`)
+	})
+
+	t.Run("failed - find all return error", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/authors", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+
+		mockAuthorUsecase.EXPECT().FindAll(gomock.Any(), gomock.Any()).Times(1).Return(nil, int64(0), errors.New("usecase error"))
+
+		err := httpHandler.FetchAuthors(ctx)
+		assert.Error(t, err)
+
+		HTTPErrorHandler(err, ctx)
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+}
+
+func TestAuthorDeliveryHTTP_FetchAuthorByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAuthorUsecase := mock.NewMockAuthorUsecase(ctrl)
+	httpHandler := AuthorHTTPHandler{AuthorUsecase: mockAuthorUsecase}
+	e := echo.New()
+
+	author := &model.Author{ID: 1, Name: "J. K. Rowling"}
+
+	t.Run("success", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/authors/1", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("1")
+
+		mockAuthorUsecase.EXPECT().FindByID(gomock.Any(), int64(1)).Times(1).Return(author, nil)
+
+		err := httpHandler.FetchAuthorByID(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"name":"J. K. Rowling"`)
+	})
+
+	t.Run("failed - invalid ID param", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/authors/abc", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("abc")
+
+		err := httpHandler.FetchAuthorByID(ctx)
+		assert.Equal(t, errInvalidIDParam, err)
+	})
+}
+
+func TestAuthorDeliveryHTTP_UpdateAuthor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAuthorUsecase := mock.NewMockAuthorUsecase(ctrl)
+	httpHandler := AuthorHTTPHandler{AuthorUsecase: mockAuthorUsecase}
+	e := echo.New()
+	e.Validator = &Validator{}
+
+	author := &model.Author{ID: 1, Name: "J. K. Rowling", Bio: "British author"}
+
+	t.Run("success", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/v1/authors/1", strings.NewReader(`{"name":"J. K. Rowling","bio":"British author"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("1")
+
+		mockAuthorUsecase.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).
+			DoAndReturn(func(_ interface{}, input *model.Author) (*model.Author, error) {
+				assert.Equal(t, author.ID, input.ID)
+				assert.Equal(t, author.Bio, input.Bio)
+				return author, nil
+			})
+
+		err := httpHandler.UpdateAuthor(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("failed - name is blank", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/v1/authors/1", strings.NewReader(`{"name":"  "}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("1")
+
+		err := httpHandler.UpdateAuthor(ctx)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+	})
+}
+
+func TestAuthorDeliveryHTTP_FetchBookAuthors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAuthorUsecase := mock.NewMockAuthorUsecase(ctrl)
+	httpHandler := AuthorHTTPHandler{AuthorUsecase: mockAuthorUsecase}
+	e := echo.New()
+
+	authors := []*model.Author{{ID: 1, Name: "J. K. Rowling"}}
+
+	t.Run("success", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/books/10/authors", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("10")
+
+		mockAuthorUsecase.EXPECT().FindAllByBookID(gomock.Any(), int64(10)).Times(1).Return(authors, nil)
+
+		err := httpHandler.FetchBookAuthors(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("failed - find all by book ID return error", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/books/10/authors", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("10")
+
+		mockAuthorUsecase.EXPECT().FindAllByBookID(gomock.Any(), int64(10)).Times(1).Return(nil, errors.New("usecase error"))
+
+		err := httpHandler.FetchBookAuthors(ctx)
+		assert.Error(t, err)
+	})
+}
+
+func TestAuthorDeliveryHTTP_ReplaceBookAuthors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAuthorUsecase := mock.NewMockAuthorUsecase(ctrl)
+	httpHandler := AuthorHTTPHandler{AuthorUsecase: mockAuthorUsecase}
+	e := echo.New()
+	e.Validator = &Validator{}
+
+	authors := []*model.Author{{ID: 1, Name: "J. K. Rowling"}}
+
+	t.Run("success", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/v1/books/10/authors", strings.NewReader(`{"author_ids":[1]}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("10")
+
+		expectedInput := model.ReplaceBookAuthorsInput{BookID: 10, AuthorIDs: []int64{1}}
+		mockAuthorUsecase.EXPECT().ReplaceBookAuthors(gomock.Any(), expectedInput).Times(1).Return(authors, nil)
+
+		err := httpHandler.ReplaceBookAuthors(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("failed - invalid author ID", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/v1/books/10/authors", strings.NewReader(`{"author_ids":[-1]}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("10")
+
+		err := httpHandler.ReplaceBookAuthors(ctx)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+	})
+
+	t.Run("failed - author not found", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/v1/books/10/authors", strings.NewReader(`{"author_ids":[2]}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("10")
+
+		mockAuthorUsecase.EXPECT().ReplaceBookAuthors(gomock.Any(), gomock.Any()).Times(1).Return(nil, domainerr.NotFound("author 2 not found", nil))
+
+		err := httpHandler.ReplaceBookAuthors(ctx)
+		assert.Error(t, err)
+
+		HTTPErrorHandler(err, ctx)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
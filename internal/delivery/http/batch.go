@@ -0,0 +1,55 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+)
+
+// BatchResponse holds the result of every item of a batch request, in the order of the request
+type BatchResponse struct {
+	Succeeded int                 `json:"succeeded"`
+	Failed    int                 `json:"failed"`
+	Results   []BatchItemResponse `json:"results"`
+}
+
+type BatchItemResponse struct {
+	Index  int         `json:"index"`
+	Status int         `json:"status"`
+	ID     int64       `json:"id,omitempty"`
+	Book   *model.Book `json:"book,omitempty"`
+	Error  *Problem    `json:"error,omitempty"`
+}
+
+// writeBatchResponse answers 200 when every item succeeded with successStatus,
+// or 207 when some of them failed
+func writeBatchResponse(c echo.Context, results []model.BatchItemResult, successStatus int) error {
+	res := BatchResponse{Results: make([]BatchItemResponse, 0, len(results))}
+	for _, result := range results {
+		item := BatchItemResponse{
+			Index:  result.Index,
+			Status: successStatus,
+			ID:     result.ID,
+			Book:   result.Book,
+		}
+
+		if result.Err != nil {
+			problem := newProblem(result.Err, c)
+			problem.Instance, problem.RequestID = "", ""
+			item.Status, item.Error = problem.Status, &problem
+			res.Failed++
+		} else {
+			res.Succeeded++
+		}
+
+		res.Results = append(res.Results, item)
+	}
+
+	status := http.StatusOK
+	if res.Failed > 0 {
+		status = http.StatusMultiStatus
+	}
+
+	return c.JSON(status, res)
+}
@@ -0,0 +1,145 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/ssentinull/create-apis-using-golang/internal/config"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+)
+
+type BookCopyHTTPHandler struct {
+	BookCopyUsecase model.BookCopyUsecase
+}
+
+func NewBookCopyHTTPHandler(e *echo.Echo, cu model.BookCopyUsecase, cacheRepo model.CacheRepository) {
+	handler := BookCopyHTTPHandler{BookCopyUsecase: cu}
+	idempotent := IdempotencyMiddleware(cacheRepo, config.IdempotencyTTL(), config.IdempotencyLockTTL())
+
+	g := e.Group("/v1")
+	g.POST("/books/:ID/copies", handler.CreateBookCopy, idempotent)
+	g.GET("/books/:ID/copies", handler.FetchBookCopies)
+	g.GET("/copies/:ID", handler.FetchBookCopyByID)
+	g.PUT("/copies/:ID", handler.UpdateBookCopy, idempotent)
+	g.DELETE("/copies/:ID", handler.DeleteBookCopyByID, idempotent)
+}
+
+// CreateBookCopy adds a copy of the book of the ID param, which is available
+func (ch *BookCopyHTTPHandler) CreateBookCopy(c echo.Context) error {
+	bookID, err := strconv.ParseInt(c.Param("ID"), 10, 64)
+	if err != nil {
+		logrus.Error(err)
+		return errInvalidIDParam
+	}
+
+	input := new(model.CreateBookCopyInput)
+	if err := c.Bind(input); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	input.BookID = bookID
+	if err := c.Validate(input); err != nil {
+		return err
+	}
+
+	bookCopy, err := ch.BookCopyUsecase.Create(c.Request().Context(), input.ToModel())
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, bookCopy)
+}
+
+func (ch *BookCopyHTTPHandler) DeleteBookCopyByID(c echo.Context) error {
+	ID, err := strconv.ParseInt(c.Param("ID"), 10, 64)
+	if err != nil {
+		logrus.Error(err)
+		return errInvalidIDParam
+	}
+
+	if err := ch.BookCopyUsecase.DeleteByID(c.Request().Context(), ID); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// FetchBookCopies lists the copies of the book of the ID param, the status
+// query param narrows them down to the copies in that status
+func (ch *BookCopyHTTPHandler) FetchBookCopies(c echo.Context) error {
+	bookID, err := strconv.ParseInt(c.Param("ID"), 10, 64)
+	if err != nil {
+		logrus.Error(err)
+		return errInvalidIDParam
+	}
+
+	queryParams := new(model.GetBookCopiesQueryParams)
+	if err := c.Bind(queryParams); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	queryParams.BookID = bookID
+	if err := c.Validate(queryParams); err != nil {
+		return err
+	}
+
+	queryParams.Normalize(config.PaginationDefaultSize(), config.PaginationMaxSize())
+	bookCopies, count, err := ch.BookCopyUsecase.FindAllByBookID(c.Request().Context(), *queryParams)
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	res := model.NewPaginationResponse(bookCopies, queryParams.Page, queryParams.Size, count)
+	setPageLinkHeader(c, res)
+	return c.JSON(http.StatusOK, res)
+}
+
+func (ch *BookCopyHTTPHandler) FetchBookCopyByID(c echo.Context) error {
+	ID, err := strconv.ParseInt(c.Param("ID"), 10, 64)
+	if err != nil {
+		logrus.Error(err)
+		return errInvalidIDParam
+	}
+
+	bookCopy, err := ch.BookCopyUsecase.FindByID(c.Request().Context(), ID)
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	return c.JSON(http.StatusOK, bookCopy)
+}
+
+func (ch *BookCopyHTTPHandler) UpdateBookCopy(c echo.Context) error {
+	ID, err := strconv.ParseInt(c.Param("ID"), 10, 64)
+	if err != nil {
+		logrus.Error(err)
+		return errInvalidIDParam
+	}
+
+	input := new(model.UpdateBookCopyInput)
+	if err := c.Bind(input); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	input.ID = ID
+	if err := c.Validate(input); err != nil {
+		return err
+	}
+
+	bookCopy, err := ch.BookCopyUsecase.Update(c.Request().Context(), *input)
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	return c.JSON(http.StatusOK, bookCopy)
+}
@@ -0,0 +1,177 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/ssentinull/create-apis-using-golang/internal/config"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+	"github.com/ssentinull/create-apis-using-golang/internal/model/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBookCopyDeliveryHTTP_CreateBookCopy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBookCopyUsecase := mock.NewMockBookCopyUsecase(ctrl)
+	httpHandler := BookCopyHTTPHandler{BookCopyUsecase: mockBookCopyUsecase}
+	e := echo.New()
+	e.Validator = &Validator{}
+
+	bookCopy := &model.BookCopy{ID: 2, BookID: 1, Barcode: "LIB-0001", Condition: "new", Status: model.BookCopyStatusAvailable}
+
+	t.Run("success", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/v1/books/1/copies", strings.NewReader(`{"barcode":"LIB-0001","condition":"new"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("1")
+
+		mockBookCopyUsecase.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).
+			DoAndReturn(func(_ interface{}, input *model.BookCopy) (*model.BookCopy, error) {
+				assert.Equal(t, int64(1), input.BookID)
+				assert.Equal(t, "LIB-0001", input.Barcode)
+				assert.Equal(t, model.BookCopyStatusAvailable, input.Status)
+				return bookCopy, nil
+			})
+
+		err := httpHandler.CreateBookCopy(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+	})
+
+	t.Run("failed - unknown condition", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/v1/books/1/copies", strings.NewReader(`{"barcode":"LIB-0001","condition":"mint"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("1")
+
+		err := httpHandler.CreateBookCopy(ctx)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+	})
+
+	t.Run("failed - barcode is taken", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/v1/books/1/copies", strings.NewReader(`{"barcode":"LIB-0001","condition":"new"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("1")
+
+		mockBookCopyUsecase.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(nil, domainerr.Conflict("record already exists", nil))
+
+		err := httpHandler.CreateBookCopy(ctx)
+		assert.ErrorIs(t, err, domainerr.ErrConflict)
+	})
+}
+
+func TestBookCopyDeliveryHTTP_FetchBookCopies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBookCopyUsecase := mock.NewMockBookCopyUsecase(ctrl)
+	httpHandler := BookCopyHTTPHandler{BookCopyUsecase: mockBookCopyUsecase}
+	e := echo.New()
+	e.Validator = &Validator{}
+
+	bookCopies := []*model.BookCopy{{ID: 2, BookID: 1, Barcode: "LIB-0001", Condition: "new", Status: model.BookCopyStatusAvailable}}
+
+	t.Run("success", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/books/1/copies?status=available", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("1")
+
+		expectedParams := model.GetBookCopiesQueryParams{BookID: 1, PageQuery: model.PageQuery{Page: 1, Size: config.DefaultPaginationDefaultSize}, Status: model.BookCopyStatusAvailable}
+		mockBookCopyUsecase.EXPECT().FindAllByBookID(gomock.Any(), expectedParams).Times(1).Return(bookCopies, int64(1), nil)
+
+		err := httpHandler.FetchBookCopies(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("failed - unknown status", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/books/1/copies?status=lost", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("1")
+
+		err := httpHandler.FetchBookCopies(ctx)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+	})
+}
+
+func TestBookCopyDeliveryHTTP_UpdateBookCopy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBookCopyUsecase := mock.NewMockBookCopyUsecase(ctrl)
+	httpHandler := BookCopyHTTPHandler{BookCopyUsecase: mockBookCopyUsecase}
+	e := echo.New()
+	e.Validator = &Validator{}
+
+	bookCopy := &model.BookCopy{ID: 2, BookID: 1, Barcode: "LIB-0001", Condition: "fair", Status: model.BookCopyStatusOnLoan}
+
+	t.Run("success", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/v1/copies/2", strings.NewReader(`{"barcode":"LIB-0001","condition":"fair"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("2")
+
+		input := model.UpdateBookCopyInput{ID: 2, Barcode: "LIB-0001", Condition: "fair"}
+		mockBookCopyUsecase.EXPECT().Update(gomock.Any(), input).Times(1).Return(bookCopy, nil)
+
+		err := httpHandler.UpdateBookCopy(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+}
+
+func TestBookCopyDeliveryHTTP_DeleteBookCopyByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBookCopyUsecase := mock.NewMockBookCopyUsecase(ctrl)
+	httpHandler := BookCopyHTTPHandler{BookCopyUsecase: mockBookCopyUsecase}
+	e := echo.New()
+
+	t.Run("success", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, "/v1/copies/2", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("2")
+
+		mockBookCopyUsecase.EXPECT().DeleteByID(gomock.Any(), int64(2)).Times(1).Return(nil)
+
+		err := httpHandler.DeleteBookCopyByID(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+	})
+
+	t.Run("failed - copy is on loan", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, "/v1/copies/2", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("2")
+
+		mockBookCopyUsecase.EXPECT().DeleteByID(gomock.Any(), int64(2)).Times(1).Return(domainerr.Conflict("copy 2 is on_loan", nil))
+
+		err := httpHandler.DeleteBookCopyByID(ctx)
+		assert.ErrorIs(t, err, domainerr.ErrConflict)
+	})
+}
@@ -1,43 +1,76 @@
 package http
 
 import (
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/labstack/echo/v4"
 	"github.com/sirupsen/logrus"
+	"github.com/ssentinull/create-apis-using-golang/internal/config"
 	"github.com/ssentinull/create-apis-using-golang/internal/model"
-	"github.com/ssentinull/create-apis-using-golang/internal/utils"
+)
+
+var (
+	errInvalidIDParam     = echo.NewHTTPError(http.StatusBadRequest, "ID param is invalid")
+	errInvalidHardParam   = echo.NewHTTPError(http.StatusBadRequest, "hard param must be a boolean")
+	errInvalidEnrichParam = echo.NewHTTPError(http.StatusBadRequest, "enrich param must be a boolean")
 )
 
 type BookHTTPHandler struct {
 	BookUsecase model.BookUsecase
 }
 
-func NewBookHTTPHandler(e *echo.Echo, bu model.BookUsecase) {
+func NewBookHTTPHandler(e *echo.Echo, bu model.BookUsecase, cacheRepo model.CacheRepository) {
 	handler := BookHTTPHandler{BookUsecase: bu}
+	idempotent := IdempotencyMiddleware(cacheRepo, config.IdempotencyTTL(), config.IdempotencyLockTTL())
 
 	g := e.Group("/v1")
-	g.POST("/books", handler.CreateBook)
+	g.POST("/books", handler.CreateBook, idempotent)
+	g.POST("/books:action", handler.BatchBooks, idempotent)
 	g.GET("/books", handler.FetchBooks)
+	g.GET("/books/trash", handler.FetchTrashedBooks)
+	g.GET("/books/search", handler.SearchBooks)
+	g.GET("/books/suggest", handler.SuggestBooks)
+	g.GET("/books/isbn/:isbn", handler.FetchBookByISBN)
 	g.GET("/books/:ID", handler.FetchBookByID)
-	g.PUT("/books", handler.UpdateBook)
-	g.DELETE("/books/:ID", handler.DeleteBookByID)
+	g.PUT("/books/:ID", handler.UpdateBook, idempotent)
+	g.PATCH("/books/:ID", handler.PatchBook, idempotent)
+	g.DELETE("/books/:ID", handler.DeleteBookByID, idempotent)
+	g.POST("/books/:ID/restore", handler.RestoreBook, idempotent)
+	g.GET("/works/:ID/editions", handler.FetchWorkEditions)
 }
 
+// CreateBook creates a book from the body, with ?enrich=true the fields left
+// out are filled in from the metadata of its ISBN
 func (bh *BookHTTPHandler) CreateBook(c echo.Context) error {
 	input := new(model.CreateBookInput)
 	if err := c.Bind(input); err != nil {
 		logrus.Error(err)
-		return c.JSON(http.StatusBadRequest, err.Error())
+		return err
+	}
+
+	if enrichParam := c.QueryParam("enrich"); enrichParam != "" {
+		enrich, err := strconv.ParseBool(enrichParam)
+		if err != nil {
+			logrus.Error(err)
+			return errInvalidEnrichParam
+		}
+		input.Enrich = enrich
+	}
+
+	if err := c.Validate(input); err != nil {
+		return err
 	}
 
-	book, err := bh.BookUsecase.Create(c.Request().Context(), input.ToModel())
+	book, err := bh.BookUsecase.Create(c.Request().Context(), input.ToModel(), input.Enrich)
 	if err != nil {
 		logrus.Error(err)
-		return c.JSON(utils.ParseHTTPErrorStatusCode(err), err.Error())
+		return err
 	}
 
+	setETag(c, book.Version)
 	return c.JSON(http.StatusCreated, book)
 }
 
@@ -45,67 +78,379 @@ func (bh *BookHTTPHandler) DeleteBookByID(c echo.Context) error {
 	ID, err := strconv.ParseInt(c.Param("ID"), 10, 64)
 	if err != nil {
 		logrus.Error(err)
-		return c.JSON(http.StatusBadRequest, "ID param is invalid")
+		return errInvalidIDParam
+	}
+
+	version, err := parseIfMatch(c)
+	if err != nil {
+		return err
+	}
+
+	hard := false
+	if hardParam := c.QueryParam("hard"); hardParam != "" {
+		if hard, err = strconv.ParseBool(hardParam); err != nil {
+			logrus.Error(err)
+			return errInvalidHardParam
+		}
+	}
+
+	if hard {
+		err = bh.BookUsecase.HardDeleteByID(c.Request().Context(), ID, version)
+	} else {
+		err = bh.BookUsecase.DeleteByID(c.Request().Context(), ID, version)
 	}
 
-	err = bh.BookUsecase.DeleteByID(c.Request().Context(), ID)
 	if err != nil {
 		logrus.Error(err)
-		return c.JSON(utils.ParseHTTPErrorStatusCode(err), err.Error())
+		return err
 	}
 
 	return c.NoContent(http.StatusNoContent)
 }
 
+func (bh *BookHTTPHandler) RestoreBook(c echo.Context) error {
+	ID, err := strconv.ParseInt(c.Param("ID"), 10, 64)
+	if err != nil {
+		logrus.Error(err)
+		return errInvalidIDParam
+	}
+
+	book, err := bh.BookUsecase.Restore(c.Request().Context(), ID)
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	setETag(c, book.Version)
+	return c.JSON(http.StatusOK, book)
+}
+
 func (bh *BookHTTPHandler) FetchBooks(c echo.Context) error {
 	queryParams := new(model.GetBooksQueryParams)
 	if err := c.Bind(queryParams); err != nil {
 		logrus.Error(err)
-		return c.JSON(http.StatusBadRequest, err.Error())
+		return err
+	}
+
+	if err := c.Validate(queryParams); err != nil {
+		return err
+	}
+
+	queryParams.Normalize(config.PaginationDefaultSize(), config.PaginationMaxSize())
+	if queryParams.CursorMode() {
+		books, cursors, count, err := bh.BookUsecase.FindAllByCursor(c.Request().Context(), *queryParams)
+		if err != nil {
+			logrus.Error(err)
+			return err
+		}
+
+		res := model.NewCursorPaginationResponse(books, queryParams.Limit, count, cursors)
+		if res.Facets, err = bh.findFacets(c, *queryParams); err != nil {
+			return err
+		}
+
+		setCursorLinkHeader(c, res)
+		return c.JSON(http.StatusOK, res)
 	}
 
 	books, count, err := bh.BookUsecase.FindAll(c.Request().Context(), *queryParams)
 	if err != nil {
 		logrus.Error(err)
-		return c.JSON(utils.ParseHTTPErrorStatusCode(err), err.Error())
+		return err
+	}
+
+	res := model.NewPaginationResponse(books, queryParams.Page, queryParams.Size, count)
+	if res.Facets, err = bh.findFacets(c, *queryParams); err != nil {
+		return err
 	}
 
-	return c.JSON(http.StatusOK, model.NewPaginationResponse(
-		books,
-		queryParams.Page,
-		queryParams.Size,
-		count,
-	))
+	setPageLinkHeader(c, res)
+	return c.JSON(http.StatusOK, res)
+}
+
+// findFacets counts the books matching the filters by the requested facets, if any
+func (bh *BookHTTPHandler) findFacets(c echo.Context, queryParams model.GetBooksQueryParams) (map[string][]*model.FacetCount, error) {
+	if queryParams.Facets == "" {
+		return nil, nil
+	}
+
+	facets, err := bh.BookUsecase.FindFacets(c.Request().Context(), queryParams)
+	if err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+
+	return facets, nil
+}
+
+func (bh *BookHTTPHandler) FetchTrashedBooks(c echo.Context) error {
+	queryParams := new(model.GetBooksQueryParams)
+	if err := c.Bind(queryParams); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	if err := c.Validate(queryParams); err != nil {
+		return err
+	}
+
+	// the trash is only paginated by page
+	queryParams.Cursor, queryParams.Limit = "", 0
+	queryParams.Normalize(config.PaginationDefaultSize(), config.PaginationMaxSize())
+
+	books, count, err := bh.BookUsecase.FindAllTrashed(c.Request().Context(), *queryParams)
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	res := model.NewPaginationResponse(books, queryParams.Page, queryParams.Size, count)
+	setPageLinkHeader(c, res)
+	return c.JSON(http.StatusOK, res)
+}
+
+func (bh *BookHTTPHandler) SearchBooks(c echo.Context) error {
+	queryParams := new(model.SearchBooksQueryParams)
+	if err := c.Bind(queryParams); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	if err := c.Validate(queryParams); err != nil {
+		return err
+	}
+
+	queryParams.Normalize(config.PaginationDefaultSize(), config.PaginationMaxSize())
+	results, count, err := bh.BookUsecase.Search(c.Request().Context(), *queryParams)
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	res := model.NewPaginationResponse(results, queryParams.Page, queryParams.Size, count)
+	setPageLinkHeader(c, res)
+	return c.JSON(http.StatusOK, res)
+}
+
+func (bh *BookHTTPHandler) SuggestBooks(c echo.Context) error {
+	queryParams := new(model.SuggestBooksQueryParams)
+	if err := c.Bind(queryParams); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	if err := c.Validate(queryParams); err != nil {
+		return err
+	}
+
+	suggestions, err := bh.BookUsecase.Suggest(c.Request().Context(), *queryParams)
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	return c.JSON(http.StatusOK, suggestions)
 }
 
 func (bh *BookHTTPHandler) FetchBookByID(c echo.Context) error {
 	ID, err := strconv.ParseInt(c.Param("ID"), 10, 64)
 	if err != nil {
 		logrus.Error(err)
-		return c.JSON(http.StatusBadRequest, "ID param is invalid")
+		return errInvalidIDParam
+	}
+
+	// answer conditional requests from the version alone, skipping the book lookup
+	if ifNoneMatch := c.Request().Header.Get(HeaderIfNoneMatch); ifNoneMatch != "" {
+		version, err := bh.BookUsecase.FindVersionByID(c.Request().Context(), ID)
+		if err != nil {
+			logrus.Error(err)
+			return err
+		}
+
+		if etagMatches(ifNoneMatch, version) {
+			setETag(c, version)
+			return c.NoContent(http.StatusNotModified)
+		}
 	}
 
 	book, err := bh.BookUsecase.FindByID(c.Request().Context(), ID)
 	if err != nil {
 		logrus.Error(err)
-		return c.JSON(utils.ParseHTTPErrorStatusCode(err), err.Error())
+		return err
+	}
+
+	setETag(c, book.Version)
+	return c.JSON(http.StatusOK, book)
+}
+
+func (bh *BookHTTPHandler) FetchBookByISBN(c echo.Context) error {
+	book, err := bh.BookUsecase.FindByISBN(c.Request().Context(), c.Param("isbn"))
+	if err != nil {
+		logrus.Error(err)
+		return err
 	}
 
+	setETag(c, book.Version)
 	return c.JSON(http.StatusOK, book)
 }
 
+func (bh *BookHTTPHandler) FetchWorkEditions(c echo.Context) error {
+	ID, err := strconv.ParseInt(c.Param("ID"), 10, 64)
+	if err != nil {
+		logrus.Error(err)
+		return errInvalidIDParam
+	}
+
+	books, err := bh.BookUsecase.FindAllByWorkID(c.Request().Context(), ID)
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	return c.JSON(http.StatusOK, books)
+}
+
 func (bh *BookHTTPHandler) UpdateBook(c echo.Context) error {
+	ID, err := strconv.ParseInt(c.Param("ID"), 10, 64)
+	if err != nil {
+		logrus.Error(err)
+		return errInvalidIDParam
+	}
+
 	input := new(model.UpdateBookInput)
 	if err := c.Bind(input); err != nil {
 		logrus.Error(err)
-		return c.JSON(http.StatusBadRequest, err.Error())
+		return err
+	}
+
+	input.ID = ID
+	input.Version, err = parseIfMatch(c)
+	if err != nil {
+		return err
+	}
+
+	if err := c.Validate(input); err != nil {
+		return err
 	}
 
 	book, err := bh.BookUsecase.Update(c.Request().Context(), input.ToModel())
 	if err != nil {
 		logrus.Error(err)
-		return c.JSON(utils.ParseHTTPErrorStatusCode(err), err.Error())
+		return err
 	}
 
+	setETag(c, book.Version)
 	return c.JSON(http.StatusOK, book)
 }
+
+func (bh *BookHTTPHandler) PatchBook(c echo.Context) error {
+	ID, err := strconv.ParseInt(c.Param("ID"), 10, 64)
+	if err != nil {
+		logrus.Error(err)
+		return errInvalidIDParam
+	}
+
+	input := model.PatchBookInput{}
+	input.Version, err = parseIfMatch(c)
+	if err != nil {
+		return err
+	}
+
+	switch contentType := c.Request().Header.Get(echo.HeaderContentType); {
+	case strings.HasPrefix(contentType, model.MIMEApplicationMergePatchJSON):
+		input.Type = model.MergePatch
+	case strings.HasPrefix(contentType, model.MIMEApplicationJSONPatchJSON):
+		input.Type = model.JSONPatch
+	default:
+		return echo.ErrUnsupportedMediaType
+	}
+
+	input.Document, err = io.ReadAll(c.Request().Body)
+	if err != nil {
+		logrus.Error(err)
+		return echo.NewHTTPError(http.StatusBadRequest, "request body cannot be read")
+	}
+
+	book, err := bh.BookUsecase.Patch(c.Request().Context(), ID, input)
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	setETag(c, book.Version)
+	return c.JSON(http.StatusOK, book)
+}
+
+// BatchBooks dispatches the batch methods of the books collection, eg: POST /v1/books:batchCreate
+func (bh *BookHTTPHandler) BatchBooks(c echo.Context) error {
+	switch c.Param("action") {
+	case ":batchCreate":
+		return bh.BatchCreateBooks(c)
+	case ":batchUpdate":
+		return bh.BatchUpdateBooks(c)
+	case ":batchDelete":
+		return bh.BatchDeleteBooks(c)
+	default:
+		return echo.ErrNotFound
+	}
+}
+
+func (bh *BookHTTPHandler) BatchCreateBooks(c echo.Context) error {
+	input := new(model.BatchCreateBooksInput)
+	if err := c.Bind(input); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	if err := c.Validate(input); err != nil {
+		return err
+	}
+
+	results, err := bh.BookUsecase.BatchCreate(c.Request().Context(), *input)
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	return writeBatchResponse(c, results, http.StatusCreated)
+}
+
+func (bh *BookHTTPHandler) BatchUpdateBooks(c echo.Context) error {
+	input := new(model.BatchUpdateBooksInput)
+	if err := c.Bind(input); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	if err := c.Validate(input); err != nil {
+		return err
+	}
+
+	results, err := bh.BookUsecase.BatchUpdate(c.Request().Context(), *input)
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	return writeBatchResponse(c, results, http.StatusOK)
+}
+
+func (bh *BookHTTPHandler) BatchDeleteBooks(c echo.Context) error {
+	input := new(model.BatchDeleteBooksInput)
+	if err := c.Bind(input); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	if err := c.Validate(input); err != nil {
+		return err
+	}
+
+	results, err := bh.BookUsecase.BatchDelete(c.Request().Context(), *input)
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	return writeBatchResponse(c, results, http.StatusNoContent)
+}
@@ -1,6 +1,7 @@
 package http
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -15,6 +16,8 @@ import (
 	"github.com/agiledragon/gomonkey/v2"
 	"github.com/golang/mock/gomock"
 	"github.com/labstack/echo/v4"
+	"github.com/ssentinull/create-apis-using-golang/internal/config"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
 	"github.com/ssentinull/create-apis-using-golang/internal/model"
 	"github.com/ssentinull/create-apis-using-golang/internal/model/mock"
 	"github.com/ssentinull/create-apis-using-golang/internal/utils"
@@ -28,6 +31,7 @@ func TestBookDeliveryHTTP_CreateBook(t *testing.T) {
 	mockBookUsecase := mock.NewMockBookUsecase(ctrl)
 	httpHandler := BookHTTPHandler{BookUsecase: mockBookUsecase}
 	e := echo.New()
+	e.Validator = &Validator{}
 
 	ID := int64(10)
 	title := "Harry Potter"
@@ -70,7 +74,7 @@ func TestBookDeliveryHTTP_CreateBook(t *testing.T) {
 		rec := httptest.NewRecorder()
 		ctx := e.NewContext(req, rec)
 
-		mockBookUsecase.EXPECT().Create(gomock.Any(), &bookModel).Times(1).Return(&bookModel, nil)
+		mockBookUsecase.EXPECT().Create(gomock.Any(), &bookModel, false).Times(1).Return(&bookModel, nil)
 
 		err := httpHandler.CreateBook(ctx)
 		assert.NoError(t, err)
@@ -94,8 +98,107 @@ func TestBookDeliveryHTTP_CreateBook(t *testing.T) {
 		ctx := e.NewContext(req, rec)
 
 		err = httpHandler.CreateBook(ctx)
-		assert.NoError(t, err)
+		assert.Error(t, err)
+
+		HTTPErrorHandler(err, ctx)
 		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+	})
+
+	t.Run("failed - request body fails validation", func(t *testing.T) {
+		faultyInputJSON, err := json.Marshal(model.CreateBookInput{
+			Title:         " ",
+			PublishedDate: "20-01-2023",
+		})
+		assert.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/v1/books", strings.NewReader(string(faultyInputJSON)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+
+		err = httpHandler.CreateBook(ctx)
+		assert.Error(t, err)
+
+		HTTPErrorHandler(err, ctx)
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"title":"must not be blank"`)
+		assert.Contains(t, rec.Body.String(), `"published_date":"must be a valid date in YYYY-MM-DD format"`)
+	})
+
+	t.Run("success - hyphenated isbn10 is normalized", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/v1/books", strings.NewReader(`{"title":"Harry Potter","isbn10":"0-7475-3269-9"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+
+		mockBookUsecase.EXPECT().Create(gomock.Any(), gomock.Any(), false).Times(1).
+			DoAndReturn(func(_ interface{}, book *model.Book, _ bool) (*model.Book, error) {
+				assert.Equal(t, "9780747532699", *book.ISBN13)
+				assert.Equal(t, "0747532699", *book.ISBN10)
+				return book, nil
+			})
+
+		err := httpHandler.CreateBook(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+	})
+
+	t.Run("success - enrich allows the title to be left out", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/v1/books?enrich=true", strings.NewReader(`{"isbn13":"9780747532699"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+
+		mockBookUsecase.EXPECT().Create(gomock.Any(), gomock.Any(), true).Times(1).Return(&bookModel, nil)
+
+		err := httpHandler.CreateBook(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+	})
+
+	t.Run("failed - invalid enrich param", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/v1/books?enrich=maybe", strings.NewReader(`{"isbn13":"9780747532699"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+
+		err := httpHandler.CreateBook(ctx)
+		assert.Equal(t, errInvalidEnrichParam, err)
+	})
+
+	t.Run("failed - title is required without enrich", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/v1/books", strings.NewReader(`{"isbn13":"9780747532699"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+
+		err := httpHandler.CreateBook(ctx)
+		assert.Error(t, err)
+
+		HTTPErrorHandler(err, ctx)
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"title":"is required"`)
+	})
+
+	t.Run("failed - isbns are different books", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/v1/books", strings.NewReader(`{"title":"Harry Potter","isbn13":"978-0-7475-3269-9","isbn10":"080442957X"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+
+		err := httpHandler.CreateBook(ctx)
+		assert.Error(t, err)
+
+		HTTPErrorHandler(err, ctx)
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"isbn10":"must be the same book as isbn13"`)
 	})
 
 	t.Run("failed - create book return error", func(t *testing.T) {
@@ -105,11 +208,14 @@ func TestBookDeliveryHTTP_CreateBook(t *testing.T) {
 		rec := httptest.NewRecorder()
 		ctx := e.NewContext(req, rec)
 
-		mockBookUsecase.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(nil, errors.New("usecase error"))
+		mockBookUsecase.EXPECT().Create(gomock.Any(), gomock.Any(), false).Times(1).Return(nil, errors.New("usecase error"))
 
 		err := httpHandler.CreateBook(ctx)
-		assert.NoError(t, err)
+		assert.Error(t, err)
+
+		HTTPErrorHandler(err, ctx)
 		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Equal(t, MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
 	})
 }
 
@@ -120,6 +226,7 @@ func TestBookDeliveryHTTP_DeleteBookByID(t *testing.T) {
 	mockBookUsecase := mock.NewMockBookUsecase(ctrl)
 	httpHandler := BookHTTPHandler{BookUsecase: mockBookUsecase}
 	e := echo.New()
+	e.Validator = &Validator{}
 
 	ID := int64(1)
 
@@ -130,7 +237,7 @@ func TestBookDeliveryHTTP_DeleteBookByID(t *testing.T) {
 		ctx.SetParamNames("ID")
 		ctx.SetParamValues(strconv.FormatInt(ID, 10))
 
-		mockBookUsecase.EXPECT().DeleteByID(gomock.Any(), ID).Times(1).Return(nil)
+		mockBookUsecase.EXPECT().DeleteByID(gomock.Any(), ID, int64(0)).Times(1).Return(nil)
 
 		err := httpHandler.DeleteBookByID(ctx)
 		assert.NoError(t, err)
@@ -144,9 +251,75 @@ func TestBookDeliveryHTTP_DeleteBookByID(t *testing.T) {
 		ctx.SetParamNames("ID")
 		ctx.SetParamValues("invalid")
 
+		err := httpHandler.DeleteBookByID(ctx)
+		assert.Error(t, err)
+
+		HTTPErrorHandler(err, ctx)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+	})
+
+	t.Run("failed - version does not match", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, "/v1/books", nil)
+		req.Header.Set(HeaderIfMatch, `"2"`)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues(strconv.FormatInt(ID, 10))
+
+		mockBookUsecase.EXPECT().DeleteByID(gomock.Any(), ID, int64(2)).Times(1).
+			Return(domainerr.PreconditionFailed("book 1 has been modified", nil))
+
+		err := httpHandler.DeleteBookByID(ctx)
+		assert.Error(t, err)
+
+		HTTPErrorHandler(err, ctx)
+		assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+		assert.Equal(t, MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+	})
+
+	t.Run("failed - if match header is weak", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, "/v1/books", nil)
+		req.Header.Set(HeaderIfMatch, `W/"2"`)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues(strconv.FormatInt(ID, 10))
+
+		err := httpHandler.DeleteBookByID(ctx)
+		assert.Error(t, err)
+
+		HTTPErrorHandler(err, ctx)
+		assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+	})
+
+	t.Run("success - hard delete", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, "/v1/books/1?hard=true", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues(strconv.FormatInt(ID, 10))
+
+		mockBookUsecase.EXPECT().HardDeleteByID(gomock.Any(), ID, int64(0)).Times(1).Return(nil)
+
 		err := httpHandler.DeleteBookByID(ctx)
 		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+	})
+
+	t.Run("failed - hard param is invalid", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, "/v1/books/1?hard=maybe", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues(strconv.FormatInt(ID, 10))
+
+		err := httpHandler.DeleteBookByID(ctx)
+		assert.Error(t, err)
+
+		HTTPErrorHandler(err, ctx)
 		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "hard param must be a boolean")
 	})
 
 	t.Run("failed - find by id return error", func(t *testing.T) {
@@ -158,12 +331,15 @@ func TestBookDeliveryHTTP_DeleteBookByID(t *testing.T) {
 		ctx.SetParamNames("ID")
 		ctx.SetParamValues(strconv.FormatInt(ID, 10))
 
-		mockBookUsecase.EXPECT().DeleteByID(gomock.Any(), ID).Times(1).Return(usecaseErr)
+		mockBookUsecase.EXPECT().DeleteByID(gomock.Any(), ID, int64(0)).Times(1).Return(usecaseErr)
 
 		err := httpHandler.DeleteBookByID(ctx)
-		assert.NoError(t, err)
+		assert.Error(t, err)
+
+		HTTPErrorHandler(err, ctx)
 		assert.Equal(t, http.StatusInternalServerError, rec.Code)
-		assert.Contains(t, rec.Body.String(), usecaseErr.Error())
+		assert.Equal(t, MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+		assert.NotContains(t, rec.Body.String(), usecaseErr.Error())
 	})
 }
 
@@ -174,6 +350,7 @@ func TestBookDeliveryHTTP_FetchBooks(t *testing.T) {
 	mockBookUsecase := mock.NewMockBookUsecase(ctrl)
 	httpHandler := BookHTTPHandler{BookUsecase: mockBookUsecase}
 	e := echo.New()
+	e.Validator = &Validator{}
 
 	ID := int64(1)
 	getBooksQueryParams := model.GetBooksQueryParams{
@@ -211,6 +388,93 @@ func TestBookDeliveryHTTP_FetchBooks(t *testing.T) {
 		assert.Contains(t, rec.Body.String(), string(bookModelJSON))
 	})
 
+	t.Run("success - with facets", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/books?title=potter&facets=author,decade", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+
+		params := model.GetBooksQueryParams{Page: 1, Size: config.DefaultPaginationDefaultSize, Title: "potter", Facets: "author,decade"}
+		facets := map[string][]*model.FacetCount{
+			model.AuthorFacet: {{Value: "J. K. Rowling", Count: 1}},
+			model.DecadeFacet: {{Value: "1990", Count: 1}},
+		}
+		mockBookUsecase.EXPECT().FindAll(gomock.Any(), params).Times(1).Return(bookModels, int64(len(bookModels)), nil)
+		mockBookUsecase.EXPECT().FindFacets(gomock.Any(), params).Times(1).Return(facets, nil)
+
+		err := httpHandler.FetchBooks(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		res := model.PaginationResponse{}
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		assert.Equal(t, facets, res.Facets)
+	})
+
+	t.Run("failed - find facets return error", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/books?facets=author", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+
+		mockBookUsecase.EXPECT().FindAll(gomock.Any(), gomock.Any()).Times(1).Return(bookModels, int64(len(bookModels)), nil)
+		mockBookUsecase.EXPECT().FindFacets(gomock.Any(), gomock.Any()).Times(1).Return(nil, errors.New("usecase error"))
+
+		err := httpHandler.FetchBooks(ctx)
+		assert.Error(t, err)
+
+		HTTPErrorHandler(err, ctx)
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+
+	t.Run("success - default page size and link header", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/books?author=Rowling", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+
+		defaultParams := model.GetBooksQueryParams{Page: 1, Size: config.DefaultPaginationDefaultSize, Author: "Rowling"}
+		mockBookUsecase.EXPECT().FindAll(gomock.Any(), defaultParams).Times(1).Return(bookModels, int64(25), nil)
+
+		err := httpHandler.FetchBooks(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"total_items":25,"has_next":true,"has_prev":false`)
+		assert.Equal(t,
+			`</v1/books?author=Rowling&page=1&size=10>; rel="first", `+
+				`</v1/books?author=Rowling&page=2&size=10>; rel="next", `+
+				`</v1/books?author=Rowling&page=3&size=10>; rel="last"`,
+			rec.Header().Get(HeaderLink))
+	})
+
+	t.Run("success - page size is capped", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/books?page=2&size=100000", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+
+		cappedParams := model.GetBooksQueryParams{Page: 2, Size: config.DefaultPaginationMaxSize}
+		mockBookUsecase.EXPECT().FindAll(gomock.Any(), cappedParams).Times(1).Return(bookModels, int64(101), nil)
+
+		err := httpHandler.FetchBooks(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Header().Get(HeaderLink), `</v1/books?page=1&size=100>; rel="prev"`)
+	})
+
+	t.Run("success - cursor mode", func(t *testing.T) {
+		cursorParams := model.GetBooksQueryParams{Limit: 1}
+		cursors := model.CursorPagination{NextCursor: "next"}
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/books?limit=1", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+
+		mockBookUsecase.EXPECT().FindAllByCursor(gomock.Any(), cursorParams).Times(1).Return(bookModels, cursors, int64(2), nil)
+
+		err := httpHandler.FetchBooks(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"next_cursor":"next"`)
+		assert.NotContains(t, rec.Body.String(), `"prev_cursor"`)
+	})
+
 	t.Run("failed - query param is invalid", func(t *testing.T) {
 		type invalidQueryParams struct {
 			Page bool `query:"page"`
@@ -227,8 +491,11 @@ func TestBookDeliveryHTTP_FetchBooks(t *testing.T) {
 		ctx := e.NewContext(req, rec)
 
 		err := httpHandler.FetchBooks(ctx)
-		assert.NoError(t, err)
+		assert.Error(t, err)
+
+		HTTPErrorHandler(err, ctx)
 		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
 	})
 
 	t.Run("failed - find all return error", func(t *testing.T) {
@@ -241,153 +508,803 @@ func TestBookDeliveryHTTP_FetchBooks(t *testing.T) {
 		mockBookUsecase.EXPECT().FindAll(gomock.Any(), getBooksQueryParams).Times(1).Return(nil, int64(0), usecaseErr)
 
 		err := httpHandler.FetchBooks(ctx)
-		assert.NoError(t, err)
+		assert.Error(t, err)
+
+		HTTPErrorHandler(err, ctx)
 		assert.Equal(t, http.StatusInternalServerError, rec.Code)
-		assert.Contains(t, rec.Body.String(), usecaseErr.Error())
+		assert.Equal(t, MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+		assert.NotContains(t, rec.Body.String(), usecaseErr.Error())
 	})
 }
 
-func TestBookDeliveryHTTP_FetchBookByID(t *testing.T) {
+func TestBookDeliveryHTTP_FetchTrashedBooks(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockBookUsecase := mock.NewMockBookUsecase(ctrl)
 	httpHandler := BookHTTPHandler{BookUsecase: mockBookUsecase}
 	e := echo.New()
+	e.Validator = &Validator{}
 
-	ID := int64(1)
-	bookModel := model.Book{
-		ID:          ID,
-		Title:       "Harry Potter",
-		Author:      "J. K. Rowling",
-		Description: "A book about wizards",
-	}
-
-	bookModelJSON, err := json.Marshal(bookModel)
-	assert.NoError(t, err)
+	books := []*model.Book{{ID: 1, Title: "Harry Potter"}}
 
 	t.Run("success", func(t *testing.T) {
-		req := httptest.NewRequest(http.MethodGet, "/v1/books", nil)
+		req := httptest.NewRequest(http.MethodGet, "/v1/books/trash?page=2&size=1&cursor=abc", nil)
 		rec := httptest.NewRecorder()
 		ctx := e.NewContext(req, rec)
-		ctx.SetParamNames("ID")
-		ctx.SetParamValues(strconv.FormatInt(ID, 10))
 
-		mockBookUsecase.EXPECT().FindByID(gomock.Any(), ID).Times(1).Return(&bookModel, nil)
+		expectedParams := model.GetBooksQueryParams{Page: 2, Size: 1}
+		mockBookUsecase.EXPECT().FindAllTrashed(gomock.Any(), expectedParams).Times(1).Return(books, int64(3), nil)
 
-		err := httpHandler.FetchBookByID(ctx)
+		err := httpHandler.FetchTrashedBooks(ctx)
 		assert.NoError(t, err)
 		assert.Equal(t, http.StatusOK, rec.Code)
-		assert.Contains(t, rec.Body.String(), string(bookModelJSON))
-	})
-
-	t.Run("failed - id params is invalid", func(t *testing.T) {
-		req := httptest.NewRequest(http.MethodGet, "/v1/books", nil)
-		rec := httptest.NewRecorder()
-		ctx := e.NewContext(req, rec)
-		ctx.SetParamNames("ID")
-		ctx.SetParamValues("invalid")
 
-		err := httpHandler.FetchBookByID(ctx)
-		assert.NoError(t, err)
-		assert.Equal(t, http.StatusBadRequest, rec.Code)
-		assert.Contains(t, rec.Body.String(), "ID param is invalid")
+		res := model.PaginationResponse{}
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		assert.Equal(t, int64(3), res.TotalItems)
+		assert.True(t, res.HasNext)
+		assert.Contains(t, rec.Header().Get(HeaderLink), `rel="next"`)
 	})
 
-	t.Run("failed - find by id return error", func(t *testing.T) {
-		usecaseErr := errors.New("usecase error")
-
-		req := httptest.NewRequest(http.MethodGet, "/v1/books", nil)
+	t.Run("failed - find all trashed return error", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/books/trash", nil)
 		rec := httptest.NewRecorder()
 		ctx := e.NewContext(req, rec)
-		ctx.SetParamNames("ID")
-		ctx.SetParamValues(strconv.FormatInt(ID, 10))
 
-		mockBookUsecase.EXPECT().FindByID(gomock.Any(), ID).Times(1).Return(nil, usecaseErr)
+		mockBookUsecase.EXPECT().FindAllTrashed(gomock.Any(), gomock.Any()).Times(1).Return(nil, int64(0), errors.New("usecase error"))
 
-		err := httpHandler.FetchBookByID(ctx)
-		assert.NoError(t, err)
+		err := httpHandler.FetchTrashedBooks(ctx)
+		assert.Error(t, err)
+
+		HTTPErrorHandler(err, ctx)
 		assert.Equal(t, http.StatusInternalServerError, rec.Code)
-		assert.Contains(t, rec.Body.String(), usecaseErr.Error())
+		assert.Equal(t, MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
 	})
 }
 
-func TestBookDeliveryHTTP_UpdateBook(t *testing.T) {
+func TestBookDeliveryHTTP_SearchBooks(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockBookUsecase := mock.NewMockBookUsecase(ctrl)
 	httpHandler := BookHTTPHandler{BookUsecase: mockBookUsecase}
 	e := echo.New()
+	e.Validator = &Validator{}
 
-	ID := int64(100)
-	title := "Harry Potter"
-	author := "J. K. Rowling"
-	description := "A book about wizards"
-
-	bookInput := model.UpdateBookInput{
-		ID:          ID,
-		Title:       title,
-		Author:      author,
-		Description: description,
-	}
-
-	bookInputJSON, err := json.Marshal(bookInput)
-	assert.NoError(t, err)
-
-	bookModel := model.Book{
-		ID:          ID,
-		Title:       title,
-		Author:      author,
-		Description: description,
-	}
+	results := []*model.BookSearchResult{{
+		Book:       model.Book{ID: 1, Title: "Harry Potter"},
+		Rank:       0.6,
+		Highlights: model.BookHighlights{Description: "a young <mark>wizard</mark>"},
+	}}
 
 	t.Run("success", func(t *testing.T) {
-		req := httptest.NewRequest(http.MethodPut, "/v1/books", strings.NewReader(string(bookInputJSON)))
-		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
-
+		req := httptest.NewRequest(http.MethodGet, "/v1/books/search?q=wizard+-muggle&page=2&size=1", nil)
 		rec := httptest.NewRecorder()
 		ctx := e.NewContext(req, rec)
 
-		mockBookUsecase.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).Return(&bookModel, nil)
+		expectedParams := model.SearchBooksQueryParams{Q: "wizard -muggle", PageQuery: model.PageQuery{Page: 2, Size: 1}}
+		mockBookUsecase.EXPECT().Search(gomock.Any(), expectedParams).Times(1).Return(results, int64(3), nil)
 
-		err := httpHandler.UpdateBook(ctx)
+		err := httpHandler.SearchBooks(ctx)
 		assert.NoError(t, err)
 		assert.Equal(t, http.StatusOK, rec.Code)
-		assert.NotEmpty(t, rec.Body.String())
+		assert.Contains(t, rec.Body.String(), `"highlights":{"title":"","author":"","description":"a young \u003cmark\u003ewizard\u003c/mark\u003e"}`)
+
+		res := model.PaginationResponse{}
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		assert.Equal(t, int64(3), res.TotalItems)
+		assert.True(t, res.HasNext)
+		assert.Contains(t, rec.Header().Get(HeaderLink), `rel="next"`)
 	})
 
-	t.Run("failed - request body is invalid", func(t *testing.T) {
-		type invalidInput struct {
-			Title int64 `json:"title"`
-		}
+	t.Run("failed - q is missing", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/books/search?q=++", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
 
-		faultyInput := invalidInput{Title: 1}
-		faultyInputJSON, err := json.Marshal(faultyInput)
-		assert.NoError(t, err)
+		err := httpHandler.SearchBooks(ctx)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
 
-		req := httptest.NewRequest(http.MethodPut, "/v1/books", strings.NewReader(string(faultyInputJSON)))
-		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		HTTPErrorHandler(err, ctx)
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	})
 
+	t.Run("failed - search return error", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/books/search?q=wizard", nil)
 		rec := httptest.NewRecorder()
 		ctx := e.NewContext(req, rec)
 
-		err = httpHandler.UpdateBook(ctx)
-		assert.NoError(t, err)
-		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		mockBookUsecase.EXPECT().Search(gomock.Any(), gomock.Any()).Times(1).Return(nil, int64(0), errors.New("usecase error"))
+
+		err := httpHandler.SearchBooks(ctx)
+		assert.Error(t, err)
+
+		HTTPErrorHandler(err, ctx)
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Equal(t, MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
 	})
+}
 
-	t.Run("failed - update book return error", func(t *testing.T) {
-		req := httptest.NewRequest(http.MethodPut, "/v1/books", strings.NewReader(string(bookInputJSON)))
-		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+func TestBookDeliveryHTTP_SuggestBooks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBookUsecase := mock.NewMockBookUsecase(ctrl)
+	httpHandler := BookHTTPHandler{BookUsecase: mockBookUsecase}
+	e := echo.New()
+	e.Validator = &Validator{}
+
+	suggestions := []*model.BookSuggestion{{Field: "author", Value: "J. K. Rowling", Similarity: 0.5}}
 
+	t.Run("success", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/books/suggest?q=rowlnig&limit=3", nil)
 		rec := httptest.NewRecorder()
 		ctx := e.NewContext(req, rec)
 
-		mockBookUsecase.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).Return(nil, errors.New("usecase error"))
+		expectedParams := model.SuggestBooksQueryParams{Q: "rowlnig", Limit: 3}
+		mockBookUsecase.EXPECT().Suggest(gomock.Any(), expectedParams).Times(1).Return(suggestions, nil)
 
-		err := httpHandler.UpdateBook(ctx)
+		err := httpHandler.SuggestBooks(ctx)
 		assert.NoError(t, err)
-		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		res := []*model.BookSuggestion{}
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		assert.Equal(t, suggestions, res)
+	})
+
+	t.Run("failed - q is missing", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/books/suggest", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+
+		err := httpHandler.SuggestBooks(ctx)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+	})
+
+	t.Run("failed - suggest return error", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/books/suggest?q=rowlnig", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+
+		mockBookUsecase.EXPECT().Suggest(gomock.Any(), gomock.Any()).Times(1).Return(nil, errors.New("usecase error"))
+
+		err := httpHandler.SuggestBooks(ctx)
+		assert.Error(t, err)
+
+		HTTPErrorHandler(err, ctx)
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Equal(t, MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+	})
+}
+
+func TestBookDeliveryHTTP_RestoreBook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBookUsecase := mock.NewMockBookUsecase(ctrl)
+	httpHandler := BookHTTPHandler{BookUsecase: mockBookUsecase}
+	e := echo.New()
+	e.Validator = &Validator{}
+
+	ID := int64(1)
+	bookModel := model.Book{ID: ID, Title: "Harry Potter", Version: 3}
+
+	t.Run("success", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/v1/books/1/restore", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues(strconv.FormatInt(ID, 10))
+
+		mockBookUsecase.EXPECT().Restore(gomock.Any(), ID).Times(1).Return(&bookModel, nil)
+
+		err := httpHandler.RestoreBook(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, `"3"`, rec.Header().Get(HeaderETag))
+	})
+
+	t.Run("failed - id params is invalid", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/v1/books/invalid/restore", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("invalid")
+
+		err := httpHandler.RestoreBook(ctx)
+		assert.Error(t, err)
+
+		HTTPErrorHandler(err, ctx)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("failed - book is not in the trash", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/v1/books/1/restore", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues(strconv.FormatInt(ID, 10))
+
+		mockBookUsecase.EXPECT().Restore(gomock.Any(), ID).Times(1).Return(nil, domainerr.NotFound("deleted book 1 not found", nil))
+
+		err := httpHandler.RestoreBook(ctx)
+		assert.Error(t, err)
+
+		HTTPErrorHandler(err, ctx)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Equal(t, MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+	})
+}
+
+func TestBookDeliveryHTTP_FetchBookByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBookUsecase := mock.NewMockBookUsecase(ctrl)
+	httpHandler := BookHTTPHandler{BookUsecase: mockBookUsecase}
+	e := echo.New()
+	e.Validator = &Validator{}
+
+	ID := int64(1)
+	bookModel := model.Book{
+		ID:          ID,
+		Title:       "Harry Potter",
+		Author:      "J. K. Rowling",
+		Description: "A book about wizards",
+	}
+
+	bookModelJSON, err := json.Marshal(bookModel)
+	assert.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/books", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues(strconv.FormatInt(ID, 10))
+
+		mockBookUsecase.EXPECT().FindByID(gomock.Any(), ID).Times(1).Return(&bookModel, nil)
+
+		err := httpHandler.FetchBookByID(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), string(bookModelJSON))
+	})
+
+	t.Run("success - not modified", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/books", nil)
+		req.Header.Set(HeaderIfNoneMatch, `"3"`)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues(strconv.FormatInt(ID, 10))
+
+		mockBookUsecase.EXPECT().FindVersionByID(gomock.Any(), ID).Times(1).Return(int64(3), nil)
+
+		err := httpHandler.FetchBookByID(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotModified, rec.Code)
+		assert.Equal(t, `"3"`, rec.Header().Get(HeaderETag))
+		assert.Empty(t, rec.Body.String())
+	})
+
+	t.Run("success - etag is stale", func(t *testing.T) {
+		modifiedBook := bookModel
+		modifiedBook.Version = 4
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/books", nil)
+		req.Header.Set(HeaderIfNoneMatch, `W/"3"`)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues(strconv.FormatInt(ID, 10))
+
+		mockBookUsecase.EXPECT().FindVersionByID(gomock.Any(), ID).Times(1).Return(int64(4), nil)
+		mockBookUsecase.EXPECT().FindByID(gomock.Any(), ID).Times(1).Return(&modifiedBook, nil)
+
+		err := httpHandler.FetchBookByID(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, `"4"`, rec.Header().Get(HeaderETag))
+	})
+
+	t.Run("failed - id params is invalid", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/books", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("invalid")
+
+		err := httpHandler.FetchBookByID(ctx)
+		assert.Error(t, err)
+
+		HTTPErrorHandler(err, ctx)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+		assert.Contains(t, rec.Body.String(), "ID param is invalid")
+	})
+
+	t.Run("failed - find by id return error", func(t *testing.T) {
+		usecaseErr := errors.New("usecase error")
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/books", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues(strconv.FormatInt(ID, 10))
+
+		mockBookUsecase.EXPECT().FindByID(gomock.Any(), ID).Times(1).Return(nil, usecaseErr)
+
+		err := httpHandler.FetchBookByID(ctx)
+		assert.Error(t, err)
+
+		HTTPErrorHandler(err, ctx)
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Equal(t, MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+		assert.NotContains(t, rec.Body.String(), usecaseErr.Error())
+	})
+
+	t.Run("failed - book not found", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/books", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues(strconv.FormatInt(ID, 10))
+
+		mockBookUsecase.EXPECT().FindByID(gomock.Any(), ID).Times(1).Return(nil, domainerr.NotFound("book not found", nil))
+
+		err := httpHandler.FetchBookByID(ctx)
+		assert.Error(t, err)
+
+		HTTPErrorHandler(err, ctx)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Equal(t, MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+	})
+}
+
+func TestBookDeliveryHTTP_FetchBookByISBN(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBookUsecase := mock.NewMockBookUsecase(ctrl)
+	httpHandler := BookHTTPHandler{BookUsecase: mockBookUsecase}
+	e := echo.New()
+
+	isbn13 := "9780747532699"
+	book := &model.Book{ID: 1, Title: "Harry Potter", ISBN13: &isbn13, Version: 3}
+
+	t.Run("success", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/books/isbn/978-0-7475-3269-9", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("isbn")
+		ctx.SetParamValues("978-0-7475-3269-9")
+
+		mockBookUsecase.EXPECT().FindByISBN(gomock.Any(), "978-0-7475-3269-9").Times(1).Return(book, nil)
+
+		err := httpHandler.FetchBookByISBN(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, `"3"`, rec.Header().Get(HeaderETag))
+		assert.Contains(t, rec.Body.String(), `"isbn13":"9780747532699"`)
+	})
+
+	t.Run("failed - book not found", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/books/isbn/9780747532699", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("isbn")
+		ctx.SetParamValues("9780747532699")
+
+		mockBookUsecase.EXPECT().FindByISBN(gomock.Any(), "9780747532699").Times(1).Return(nil, domainerr.NotFound("record not found", nil))
+
+		err := httpHandler.FetchBookByISBN(ctx)
+		assert.Error(t, err)
+
+		HTTPErrorHandler(err, ctx)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestBookDeliveryHTTP_FetchWorkEditions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBookUsecase := mock.NewMockBookUsecase(ctrl)
+	httpHandler := BookHTTPHandler{BookUsecase: mockBookUsecase}
+	e := echo.New()
+
+	books := []*model.Book{
+		{ID: 1, Title: "Harry Potter", PublishedDate: "1997-06-26", WorkID: 1},
+		{ID: 2, Title: "Harry Potter", PublishedDate: "2014-09-01", WorkID: 1},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/works/1/editions", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("1")
+
+		mockBookUsecase.EXPECT().FindAllByWorkID(gomock.Any(), int64(1)).Times(1).Return(books, nil)
+
+		err := httpHandler.FetchWorkEditions(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		res := []*model.Book{}
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		assert.Len(t, res, 2)
+	})
+
+	t.Run("failed - invalid ID param", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/works/abc/editions", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("abc")
+
+		err := httpHandler.FetchWorkEditions(ctx)
+		assert.Equal(t, errInvalidIDParam, err)
+	})
+
+	t.Run("failed - work not found", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/works/1/editions", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("1")
+
+		mockBookUsecase.EXPECT().FindAllByWorkID(gomock.Any(), int64(1)).Times(1).Return(nil, domainerr.NotFound("work 1 not found", nil))
+
+		err := httpHandler.FetchWorkEditions(ctx)
+		assert.Error(t, err)
+
+		HTTPErrorHandler(err, ctx)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestBookDeliveryHTTP_UpdateBook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBookUsecase := mock.NewMockBookUsecase(ctrl)
+	httpHandler := BookHTTPHandler{BookUsecase: mockBookUsecase}
+	e := echo.New()
+	e.Validator = &Validator{}
+
+	ID := int64(100)
+	title := "Harry Potter"
+	author := "J. K. Rowling"
+	description := "A book about wizards"
+
+	bookInput := model.UpdateBookInput{
+		ID:          ID,
+		Title:       title,
+		Author:      author,
+		Description: description,
+	}
+
+	bookInputJSON, err := json.Marshal(bookInput)
+	assert.NoError(t, err)
+
+	bookModel := model.Book{
+		ID:          ID,
+		Title:       title,
+		Author:      author,
+		Description: description,
+	}
+
+	t.Run("success", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/v1/books/100", strings.NewReader(string(bookInputJSON)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues(strconv.FormatInt(ID, 10))
+
+		mockBookUsecase.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+			func(_ context.Context, book *model.Book) (*model.Book, error) {
+				assert.Equal(t, ID, book.ID)
+				return &bookModel, nil
+			})
+
+		err := httpHandler.UpdateBook(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.NotEmpty(t, rec.Body.String())
+	})
+
+	t.Run("success - if match header", func(t *testing.T) {
+		updatedBook := bookModel
+		updatedBook.Version = 3
+
+		req := httptest.NewRequest(http.MethodPut, "/v1/books/100", strings.NewReader(string(bookInputJSON)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set(HeaderIfMatch, `"2"`)
+
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues(strconv.FormatInt(ID, 10))
+
+		mockBookUsecase.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+			func(_ context.Context, book *model.Book) (*model.Book, error) {
+				assert.Equal(t, int64(2), book.Version)
+				return &updatedBook, nil
+			})
+
+		err := httpHandler.UpdateBook(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, `"3"`, rec.Header().Get(HeaderETag))
+	})
+
+	t.Run("failed - version does not match", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/v1/books/100", strings.NewReader(string(bookInputJSON)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set(HeaderIfMatch, `"1"`)
+
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues(strconv.FormatInt(ID, 10))
+
+		mockBookUsecase.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).
+			Return(nil, domainerr.PreconditionFailed("book 100 has been modified", nil))
+
+		err := httpHandler.UpdateBook(ctx)
+		assert.Error(t, err)
+
+		HTTPErrorHandler(err, ctx)
+		assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+		assert.Equal(t, MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+	})
+
+	t.Run("failed - id params is invalid", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/v1/books/invalid", strings.NewReader(string(bookInputJSON)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("invalid")
+
+		err := httpHandler.UpdateBook(ctx)
+		assert.Error(t, err)
+
+		HTTPErrorHandler(err, ctx)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("failed - request body is invalid", func(t *testing.T) {
+		type invalidInput struct {
+			Title int64 `json:"title"`
+		}
+
+		faultyInput := invalidInput{Title: 1}
+		faultyInputJSON, err := json.Marshal(faultyInput)
+		assert.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPut, "/v1/books/100", strings.NewReader(string(faultyInputJSON)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues(strconv.FormatInt(ID, 10))
+
+		err = httpHandler.UpdateBook(ctx)
+		assert.Error(t, err)
+
+		HTTPErrorHandler(err, ctx)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+	})
+
+	t.Run("failed - update book return error", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/v1/books/100", strings.NewReader(string(bookInputJSON)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues(strconv.FormatInt(ID, 10))
+
+		mockBookUsecase.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).Return(nil, errors.New("usecase error"))
+
+		err := httpHandler.UpdateBook(ctx)
+		assert.Error(t, err)
+
+		HTTPErrorHandler(err, ctx)
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Equal(t, MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+	})
+}
+
+func TestBookDeliveryHTTP_PatchBook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBookUsecase := mock.NewMockBookUsecase(ctrl)
+	httpHandler := BookHTTPHandler{BookUsecase: mockBookUsecase}
+	e := echo.New()
+	e.Validator = &Validator{}
+
+	ID := int64(100)
+	bookModel := model.Book{
+		ID:     ID,
+		Title:  "Harry Potter",
+		Author: "J. K. Rowling",
+	}
+
+	newContext := func(contentType, body string) (echo.Context, *httptest.ResponseRecorder) {
+		req := httptest.NewRequest(http.MethodPatch, "/v1/books/100", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, contentType)
+
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues(strconv.FormatInt(ID, 10))
+		return ctx, rec
+	}
+
+	t.Run("success - merge patch", func(t *testing.T) {
+		document := `{"description":null}`
+		ctx, rec := newContext(model.MIMEApplicationMergePatchJSON, document)
+
+		input := model.PatchBookInput{Type: model.MergePatch, Document: []byte(document)}
+		mockBookUsecase.EXPECT().Patch(gomock.Any(), ID, input).Times(1).Return(&bookModel, nil)
+
+		err := httpHandler.PatchBook(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("success - json patch", func(t *testing.T) {
+		document := `[{"op":"replace","path":"/title","value":"Harry Potter"}]`
+		ctx, rec := newContext(model.MIMEApplicationJSONPatchJSON, document)
+
+		input := model.PatchBookInput{Type: model.JSONPatch, Document: []byte(document)}
+		mockBookUsecase.EXPECT().Patch(gomock.Any(), ID, input).Times(1).Return(&bookModel, nil)
+
+		err := httpHandler.PatchBook(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("failed - content type is not supported", func(t *testing.T) {
+		ctx, rec := newContext(echo.MIMEApplicationJSON, `{"title":"Dune"}`)
+
+		err := httpHandler.PatchBook(ctx)
+		assert.Error(t, err)
+
+		HTTPErrorHandler(err, ctx)
+		assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+	})
+
+	t.Run("failed - patch book return error", func(t *testing.T) {
+		ctx, rec := newContext(model.MIMEApplicationMergePatchJSON, `{"title":null}`)
+
+		mockBookUsecase.EXPECT().Patch(gomock.Any(), ID, gomock.Any()).Times(1).
+			Return(nil, domainerr.ValidationFields("request contains invalid fields", map[string]string{"title": "is required"}))
+
+		err := httpHandler.PatchBook(ctx)
+		assert.Error(t, err)
+
+		HTTPErrorHandler(err, ctx)
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	})
+}
+
+func TestBookDeliveryHTTP_BatchBooks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBookUsecase := mock.NewMockBookUsecase(ctrl)
+	httpHandler := BookHTTPHandler{BookUsecase: mockBookUsecase}
+	e := echo.New()
+	e.Validator = &Validator{}
+
+	newContext := func(action, body string) (echo.Context, *httptest.ResponseRecorder) {
+		req := httptest.NewRequest(http.MethodPost, "/v1/books"+action, strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("action")
+		ctx.SetParamValues(action)
+		return ctx, rec
+	}
+
+	t.Run("success - batch create", func(t *testing.T) {
+		ctx, rec := newContext(":batchCreate", `{"books":[{"title":"Harry Potter"},{"title":"Dune"}]}`)
+
+		results := []model.BatchItemResult{
+			{Index: 0, ID: 1, Book: &model.Book{ID: 1, Title: "Harry Potter"}},
+			{Index: 1, ID: 2, Book: &model.Book{ID: 2, Title: "Dune"}},
+		}
+		mockBookUsecase.EXPECT().BatchCreate(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+			func(_ context.Context, input model.BatchCreateBooksInput) ([]model.BatchItemResult, error) {
+				assert.Len(t, input.Books, 2)
+				return results, nil
+			})
+
+		err := httpHandler.BatchBooks(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		res := BatchResponse{}
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		assert.Equal(t, 2, res.Succeeded)
+		assert.Equal(t, http.StatusCreated, res.Results[1].Status)
+	})
+
+	t.Run("success - best effort batch update with a failing book", func(t *testing.T) {
+		ctx, rec := newContext(":batchUpdate", `{"mode":"best_effort","books":[{"id":1,"title":"Emma"},{"id":2,"title":"Dune"}]}`)
+
+		results := []model.BatchItemResult{
+			{Index: 0, ID: 1, Book: &model.Book{ID: 1, Title: "Emma"}},
+			{Index: 1, ID: 2, Err: domainerr.NotFound("book 2 not found", nil)},
+		}
+		mockBookUsecase.EXPECT().BatchUpdate(gomock.Any(), gomock.Any()).Times(1).Return(results, nil)
+
+		err := httpHandler.BatchBooks(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusMultiStatus, rec.Code)
+
+		res := BatchResponse{}
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		assert.Equal(t, 1, res.Failed)
+		assert.Equal(t, http.StatusNotFound, res.Results[1].Status)
+		assert.Equal(t, "book 2 not found", res.Results[1].Error.Detail)
+	})
+
+	t.Run("success - batch delete", func(t *testing.T) {
+		ctx, rec := newContext(":batchDelete", `{"ids":[1,2]}`)
+
+		results := []model.BatchItemResult{{Index: 0, ID: 1}, {Index: 1, ID: 2}}
+		mockBookUsecase.EXPECT().BatchDelete(gomock.Any(), model.BatchDeleteBooksInput{IDs: []int64{1, 2}}).Times(1).Return(results, nil)
+
+		err := httpHandler.BatchBooks(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"status":204`)
+	})
+
+	t.Run("failed - transactional batch is rejected", func(t *testing.T) {
+		ctx, rec := newContext(":batchDelete", `{"ids":[1,2]}`)
+
+		mockBookUsecase.EXPECT().BatchDelete(gomock.Any(), gomock.Any()).Times(1).Return(nil, domainerr.NotFound("ids[1]: book 2 not found", nil))
+
+		err := httpHandler.BatchBooks(ctx)
+		assert.Error(t, err)
+
+		HTTPErrorHandler(err, ctx)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Equal(t, MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+	})
+
+	t.Run("failed - request body is invalid", func(t *testing.T) {
+		ctx, rec := newContext(":batchCreate", `{"books":{}}`)
+
+		err := httpHandler.BatchBooks(ctx)
+		assert.Error(t, err)
+
+		HTTPErrorHandler(err, ctx)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("failed - action is unknown", func(t *testing.T) {
+		ctx, rec := newContext(":batchPublish", `{}`)
+
+		err := httpHandler.BatchBooks(ctx)
+		assert.Error(t, err)
+
+		HTTPErrorHandler(err, ctx)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
 	})
 }
@@ -0,0 +1,139 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"github.com/sirupsen/logrus"
+	"github.com/ssentinull/create-apis-using-golang/internal/config"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+)
+
+const (
+	HeaderCacheControl = "Cache-Control"
+
+	// coverBodySlack leaves room for the multipart headers around a cover of the maximum size
+	coverBodySlack = 64 << 10
+
+	immutableCacheControl  = "public, max-age=31536000, immutable"
+	revalidateCacheControl = "public, no-cache"
+)
+
+var errMissingCover = echo.NewHTTPError(http.StatusBadRequest, "cover is required, as the request body or as the cover field of a multipart form")
+
+type CoverHTTPHandler struct {
+	CoverUsecase model.CoverUsecase
+}
+
+func NewCoverHTTPHandler(e *echo.Echo, cu model.CoverUsecase, cacheRepo model.CacheRepository) {
+	handler := CoverHTTPHandler{CoverUsecase: cu}
+	idempotent := IdempotencyMiddleware(cacheRepo, config.IdempotencyTTL(), config.IdempotencyLockTTL())
+	bodyLimit := middleware.BodyLimit(strconv.FormatInt(config.CoverMaxSize()+coverBodySlack, 10))
+
+	g := e.Group("/v1")
+	g.PUT("/books/:ID/cover", handler.UploadCover, bodyLimit, idempotent)
+	g.GET("/books/:ID/cover", handler.FetchCover)
+}
+
+// UploadCover replaces the cover of a book with the image sent as the raw
+// request body or as the cover field of a multipart form
+func (ch *CoverHTTPHandler) UploadCover(c echo.Context) error {
+	ID, err := strconv.ParseInt(c.Param("ID"), 10, 64)
+	if err != nil {
+		logrus.Error(err)
+		return errInvalidIDParam
+	}
+
+	cover, err := readCover(c)
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	book, err := ch.CoverUsecase.Upload(c.Request().Context(), ID, cover)
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	setETag(c, book.Version)
+	return c.JSON(http.StatusOK, book)
+}
+
+// FetchCover serves the cover of a book, the versioned covers linked from
+// cover_url are cached for good since a new upload changes the version. A
+// stale or made up version is served as the current cover and revalidated
+func (ch *CoverHTTPHandler) FetchCover(c echo.Context) error {
+	ID, err := strconv.ParseInt(c.Param("ID"), 10, 64)
+	if err != nil {
+		logrus.Error(err)
+		return errInvalidIDParam
+	}
+
+	queryParams := new(model.GetCoverQueryParams)
+	if err := c.Bind(queryParams); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	if err := c.Validate(queryParams); err != nil {
+		return err
+	}
+
+	cover, err := ch.CoverUsecase.FindByBookID(c.Request().Context(), ID, queryParams.Size)
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	cacheControl := revalidateCacheControl
+	if queryParams.Version != "" && queryParams.Version == cover.Version {
+		cacheControl = immutableCacheControl
+	}
+
+	c.Response().Header().Set(HeaderCacheControl, cacheControl)
+	c.Response().Header().Set(echo.HeaderXContentTypeOptions, "nosniff")
+	return c.Blob(http.StatusOK, cover.ContentType, cover.Body)
+}
+
+func readCover(c echo.Context) ([]byte, error) {
+	var reader io.Reader = c.Request().Body
+	if strings.HasPrefix(c.Request().Header.Get(echo.HeaderContentType), echo.MIMEMultipartForm) {
+		header, err := c.FormFile("cover")
+		if errors.Is(err, http.ErrMissingFile) {
+			return nil, errMissingCover
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		file, err := header.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		reader = file
+	}
+
+	cover, err := io.ReadAll(io.LimitReader(reader, config.CoverMaxSize()+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(cover)) > config.CoverMaxSize() {
+		return nil, echo.NewHTTPError(http.StatusRequestEntityTooLarge, fmt.Sprintf("cover must be at most %d bytes", config.CoverMaxSize()))
+	}
+
+	if len(cover) == 0 {
+		return nil, errMissingCover
+	}
+
+	return cover, nil
+}
@@ -0,0 +1,202 @@
+package http
+
+import (
+	"bytes"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/ssentinull/create-apis-using-golang/internal/config"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+	"github.com/ssentinull/create-apis-using-golang/internal/model/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCoverDeliveryHTTP_UploadCover(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCoverUsecase := mock.NewMockCoverUsecase(ctrl)
+	httpHandler := CoverHTTPHandler{CoverUsecase: mockCoverUsecase}
+	e := echo.New()
+
+	cover := []byte("\x89PNG\r\n\x1a\n0000")
+	book := &model.Book{ID: 1, Title: "Harry Potter", Version: 2}
+
+	t.Run("success - raw body", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/v1/books/1/cover", bytes.NewReader(cover))
+		req.Header.Set(echo.HeaderContentType, "image/png")
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("1")
+
+		mockCoverUsecase.EXPECT().Upload(gomock.Any(), int64(1), cover).Times(1).Return(book, nil)
+
+		err := httpHandler.UploadCover(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, `"2"`, rec.Header().Get(HeaderETag))
+	})
+
+	t.Run("success - multipart form", func(t *testing.T) {
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		part, err := writer.CreateFormFile("cover", "cover.png")
+		assert.NoError(t, err)
+		_, _ = part.Write(cover)
+		assert.NoError(t, writer.Close())
+
+		req := httptest.NewRequest(http.MethodPut, "/v1/books/1/cover", body)
+		req.Header.Set(echo.HeaderContentType, writer.FormDataContentType())
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("1")
+
+		mockCoverUsecase.EXPECT().Upload(gomock.Any(), int64(1), cover).Times(1).Return(book, nil)
+
+		err = httpHandler.UploadCover(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("failed - cover is too large", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/v1/books/1/cover", bytes.NewReader(make([]byte, config.CoverMaxSize()+1)))
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("1")
+
+		err := httpHandler.UploadCover(ctx)
+		httpErr := &echo.HTTPError{}
+		assert.True(t, errors.As(err, &httpErr))
+		assert.Equal(t, http.StatusRequestEntityTooLarge, httpErr.Code)
+	})
+
+	t.Run("failed - cover is missing", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/v1/books/1/cover", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("1")
+
+		err := httpHandler.UploadCover(ctx)
+		assert.Equal(t, errMissingCover, err)
+	})
+
+	t.Run("failed - usecase return error", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/v1/books/1/cover", bytes.NewReader(cover))
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("1")
+
+		mockCoverUsecase.EXPECT().Upload(gomock.Any(), int64(1), cover).Times(1).Return(nil, domainerr.Validation("cover must be a JPEG, PNG or WebP image", nil))
+
+		err := httpHandler.UploadCover(ctx)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+	})
+
+	t.Run("failed - invalid ID param", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/v1/books/abc/cover", bytes.NewReader(cover))
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("abc")
+
+		err := httpHandler.UploadCover(ctx)
+		assert.Equal(t, errInvalidIDParam, err)
+	})
+}
+
+func TestCoverDeliveryHTTP_FetchCover(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCoverUsecase := mock.NewMockCoverUsecase(ctrl)
+	httpHandler := CoverHTTPHandler{CoverUsecase: mockCoverUsecase}
+	e := echo.New()
+	e.Validator = &Validator{}
+
+	thumb := &model.Cover{
+		Blob:    model.Blob{ContentType: "image/jpeg", Body: []byte("thumb")},
+		Version: "1792332000000000",
+	}
+
+	t.Run("success - versioned cover is immutable", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/books/1/cover?size=thumb&v=1792332000000000", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("1")
+
+		mockCoverUsecase.EXPECT().FindByBookID(gomock.Any(), int64(1), model.CoverSizeThumb).Times(1).Return(thumb, nil)
+
+		err := httpHandler.FetchCover(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "image/jpeg", rec.Header().Get(echo.HeaderContentType))
+		assert.Equal(t, immutableCacheControl, rec.Header().Get(HeaderCacheControl))
+		assert.Equal(t, "thumb", rec.Body.String())
+	})
+
+	t.Run("success - stale version is revalidated", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/books/1/cover?v=1", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("1")
+
+		mockCoverUsecase.EXPECT().FindByBookID(gomock.Any(), int64(1), "").Times(1).Return(thumb, nil)
+
+		err := httpHandler.FetchCover(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, revalidateCacheControl, rec.Header().Get(HeaderCacheControl))
+		assert.Equal(t, "thumb", rec.Body.String())
+	})
+
+	t.Run("success - unversioned cover is revalidated", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/books/1/cover", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("1")
+
+		mockCoverUsecase.EXPECT().FindByBookID(gomock.Any(), int64(1), "").Times(1).Return(thumb, nil)
+
+		err := httpHandler.FetchCover(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, revalidateCacheControl, rec.Header().Get(HeaderCacheControl))
+	})
+
+	t.Run("failed - invalid size", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/books/1/cover?size=huge", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("1")
+
+		err := httpHandler.FetchCover(ctx)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+	})
+
+	t.Run("failed - cover not found", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/books/1/cover", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("1")
+
+		mockCoverUsecase.EXPECT().FindByBookID(gomock.Any(), int64(1), "").Times(1).Return(nil, domainerr.NotFound("book cover not found", nil))
+
+		err := httpHandler.FetchCover(ctx)
+		assert.ErrorIs(t, err, domainerr.ErrNotFound)
+	})
+}
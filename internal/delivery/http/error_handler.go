@@ -0,0 +1,85 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/ssentinull/create-apis-using-golang/internal/utils"
+)
+
+const MIMEApplicationProblemJSON = "application/problem+json"
+
+// Problem is an RFC 7807 problem details body
+type Problem struct {
+	Type      string            `json:"type"`
+	Title     string            `json:"title"`
+	Status    int               `json:"status"`
+	Detail    string            `json:"detail,omitempty"`
+	Instance  string            `json:"instance,omitempty"`
+	RequestID string            `json:"request_id,omitempty"`
+	Errors    map[string]string `json:"errors,omitempty"`
+}
+
+// HTTPErrorHandler renders every error returned by the handlers as
+// application/problem+json, register it as echo.Echo.HTTPErrorHandler
+func HTTPErrorHandler(err error, c echo.Context) {
+	if c.Response().Committed {
+		return
+	}
+
+	problem := newProblem(err, c)
+	if problem.Status >= http.StatusInternalServerError {
+		logrus.WithField("requestID", problem.RequestID).Error(err)
+	}
+
+	if c.Request().Method == http.MethodHead {
+		err = c.NoContent(problem.Status)
+	} else {
+		c.Response().Header().Set(echo.HeaderContentType, MIMEApplicationProblemJSON)
+		err = c.JSON(problem.Status, problem)
+	}
+
+	if err != nil {
+		logrus.Error(err)
+	}
+}
+
+func newProblem(err error, c echo.Context) Problem {
+	problem := Problem{
+		Type:      "about:blank",
+		Status:    utils.ParseHTTPErrorStatusCode(err),
+		Instance:  c.Request().URL.RequestURI(),
+		RequestID: requestID(c),
+	}
+
+	domainErr := &domainerr.Error{}
+	httpErr := &echo.HTTPError{}
+	switch {
+	case errors.As(err, &domainErr):
+		problem.Detail = domainErr.Error()
+		problem.Errors = domainErr.Fields
+	case errors.As(err, &httpErr):
+		problem.Status = httpErr.Code
+		problem.Detail = fmt.Sprint(httpErr.Message)
+	}
+
+	// never leak the underlying error of an unexpected failure
+	if problem.Status >= http.StatusInternalServerError && problem.Detail == "" {
+		problem.Detail = "an unexpected error occurred"
+	}
+
+	problem.Title = http.StatusText(problem.Status)
+	return problem
+}
+
+func requestID(c echo.Context) string {
+	if ID := c.Response().Header().Get(echo.HeaderXRequestID); ID != "" {
+		return ID
+	}
+
+	return c.Request().Header.Get(echo.HeaderXRequestID)
+}
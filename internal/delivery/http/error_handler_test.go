@@ -0,0 +1,72 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPErrorHandler(t *testing.T) {
+	e := echo.New()
+	requestID := "request-id"
+
+	newContext := func() (echo.Context, *httptest.ResponseRecorder) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/books/1?foo=bar", nil)
+		req.Header.Set(echo.HeaderXRequestID, requestID)
+		rec := httptest.NewRecorder()
+		return e.NewContext(req, rec), rec
+	}
+
+	t.Run("success - domain error", func(t *testing.T) {
+		ctx, rec := newContext()
+		HTTPErrorHandler(domainerr.NotFound("book not found", errors.New("record not found")), ctx)
+
+		problem := Problem{}
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Equal(t, MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+		assert.Equal(t, Problem{
+			Type:      "about:blank",
+			Title:     http.StatusText(http.StatusNotFound),
+			Status:    http.StatusNotFound,
+			Detail:    "book not found",
+			Instance:  "/v1/books/1?foo=bar",
+			RequestID: requestID,
+		}, problem)
+	})
+
+	t.Run("success - validation error with fields", func(t *testing.T) {
+		ctx, rec := newContext()
+		fields := map[string]string{"title": "title is required"}
+		HTTPErrorHandler(domainerr.ValidationFields("invalid book", fields), ctx)
+
+		problem := Problem{}
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Equal(t, fields, problem.Errors)
+	})
+
+	t.Run("success - echo http error", func(t *testing.T) {
+		ctx, rec := newContext()
+		HTTPErrorHandler(echo.NewHTTPError(http.StatusBadRequest, "ID param is invalid"), ctx)
+
+		problem := Problem{}
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, "ID param is invalid", problem.Detail)
+	})
+
+	t.Run("success - unexpected error is not leaked", func(t *testing.T) {
+		ctx, rec := newContext()
+		HTTPErrorHandler(errors.New("pq: relation books does not exist"), ctx)
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.NotContains(t, rec.Body.String(), "relation books")
+	})
+}
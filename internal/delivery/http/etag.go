@@ -0,0 +1,73 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+)
+
+const (
+	HeaderETag        = "ETag"
+	HeaderIfMatch     = "If-Match"
+	HeaderIfNoneMatch = "If-None-Match"
+)
+
+// setETag sets the ETag of a resource representation from its version
+func setETag(c echo.Context, version int64) {
+	c.Response().Header().Set(HeaderETag, fmt.Sprintf(`"%d"`, version))
+}
+
+// parseIfMatch returns the version required by the If-Match header, zero
+// when the header is absent or is a wildcard
+func parseIfMatch(c echo.Context) (int64, error) {
+	header := strings.TrimSpace(c.Request().Header.Get(HeaderIfMatch))
+	if header == "" || header == "*" {
+		return 0, nil
+	}
+
+	if strings.Contains(header, ",") {
+		return 0, echo.NewHTTPError(http.StatusBadRequest, "If-Match header must hold a single entity tag")
+	}
+
+	// If-Match uses the strong comparison, a weak tag never matches
+	version, ok := parseETag(header)
+	if !ok || strings.HasPrefix(header, "W/") {
+		return 0, domainerr.PreconditionFailed("If-Match header does not match the current version", nil)
+	}
+
+	return version, nil
+}
+
+// etagMatches reports whether any of the entity tags in the If-None-Match
+// header matches version, using the weak comparison
+func etagMatches(header string, version int64) bool {
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" {
+			return true
+		}
+
+		if tagVersion, ok := parseETag(strings.TrimPrefix(tag, "W/")); ok && tagVersion == version {
+			return true
+		}
+	}
+
+	return false
+}
+
+func parseETag(tag string) (int64, bool) {
+	tag = strings.TrimPrefix(tag, "W/")
+	if len(tag) < 2 || !strings.HasPrefix(tag, `"`) || !strings.HasSuffix(tag, `"`) {
+		return 0, false
+	}
+
+	version, err := strconv.ParseInt(tag[1:len(tag)-1], 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return version, true
+}
@@ -0,0 +1,91 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/ssentinull/create-apis-using-golang/internal/config"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+)
+
+type FineHTTPHandler struct {
+	FineUsecase model.FineUsecase
+}
+
+func NewFineHTTPHandler(e *echo.Echo, fu model.FineUsecase, cacheRepo model.CacheRepository) {
+	handler := FineHTTPHandler{FineUsecase: fu}
+	idempotent := IdempotencyMiddleware(cacheRepo, config.IdempotencyTTL(), config.IdempotencyLockTTL())
+
+	g := e.Group("/v1")
+	g.GET("/members/:ID/fines", handler.FetchMemberFines)
+	g.POST("/members/:ID/fines", handler.SettleFines, idempotent)
+}
+
+// FetchMemberFines lists the fines ledger of the member of the ID param
+// along with its balance
+func (fh *FineHTTPHandler) FetchMemberFines(c echo.Context) error {
+	memberID, err := strconv.ParseInt(c.Param("ID"), 10, 64)
+	if err != nil {
+		logrus.Error(err)
+		return errInvalidIDParam
+	}
+
+	queryParams := new(model.GetFinesQueryParams)
+	if err := c.Bind(queryParams); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	queryParams.MemberID = memberID
+	if err := c.Validate(queryParams); err != nil {
+		return err
+	}
+
+	queryParams.Normalize(config.PaginationDefaultSize(), config.PaginationMaxSize())
+	fines, count, err := fh.FineUsecase.FindAllByMemberID(c.Request().Context(), *queryParams)
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	balance, err := fh.FineUsecase.BalanceByMemberID(c.Request().Context(), memberID)
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	res := model.NewPaginationResponse(fines, queryParams.Page, queryParams.Size, count)
+	setPageLinkHeader(c, res)
+	return c.JSON(http.StatusOK, model.FinesResponse{PaginationResponse: res, BalanceCents: balance})
+}
+
+// SettleFines pays or waives an amount of the balance of the member of the
+// ID param
+func (fh *FineHTTPHandler) SettleFines(c echo.Context) error {
+	memberID, err := strconv.ParseInt(c.Param("ID"), 10, 64)
+	if err != nil {
+		logrus.Error(err)
+		return errInvalidIDParam
+	}
+
+	input := new(model.SettleFinesInput)
+	if err := c.Bind(input); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	input.MemberID = memberID
+	if err := c.Validate(input); err != nil {
+		return err
+	}
+
+	fine, err := fh.FineUsecase.Settle(c.Request().Context(), input.ToModel())
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, fine)
+}
@@ -0,0 +1,120 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/ssentinull/create-apis-using-golang/internal/config"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+	"github.com/ssentinull/create-apis-using-golang/internal/model/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFineDeliveryHTTP_FetchMemberFines(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFineUsecase := mock.NewMockFineUsecase(ctrl)
+	httpHandler := FineHTTPHandler{FineUsecase: mockFineUsecase}
+	e := echo.New()
+	e.Validator = &Validator{}
+
+	fines := []*model.Fine{{ID: 3, MemberID: 1, Kind: model.FineKindPayment, AmountCents: 50}}
+
+	t.Run("success", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/members/1/fines", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("1")
+
+		expectedParams := model.GetFinesQueryParams{MemberID: 1, PageQuery: model.PageQuery{Page: 1, Size: config.DefaultPaginationDefaultSize}}
+		mockFineUsecase.EXPECT().FindAllByMemberID(gomock.Any(), expectedParams).Times(1).Return(fines, int64(1), nil)
+		mockFineUsecase.EXPECT().BalanceByMemberID(gomock.Any(), int64(1)).Times(1).Return(int64(75), nil)
+
+		err := httpHandler.FetchMemberFines(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		res := map[string]interface{}{}
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		assert.Equal(t, float64(75), res["balance_cents"])
+		assert.Equal(t, float64(1), res["total_items"])
+	})
+
+	t.Run("failed - invalid ID", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/members/abc/fines", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("abc")
+
+		err := httpHandler.FetchMemberFines(ctx)
+		assert.Equal(t, errInvalidIDParam, err)
+	})
+}
+
+func TestFineDeliveryHTTP_SettleFines(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFineUsecase := mock.NewMockFineUsecase(ctrl)
+	httpHandler := FineHTTPHandler{FineUsecase: mockFineUsecase}
+	e := echo.New()
+	e.Validator = &Validator{}
+
+	fine := &model.Fine{ID: 3, MemberID: 1, Kind: model.FineKindWaiver, AmountCents: 50, Note: "first offence"}
+
+	t.Run("success", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/v1/members/1/fines", strings.NewReader(`{"kind":"waiver","amount_cents":50,"note":"first offence"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("1")
+
+		mockFineUsecase.EXPECT().Settle(gomock.Any(), gomock.Any()).Times(1).
+			DoAndReturn(func(_ interface{}, input *model.Fine) (*model.Fine, error) {
+				assert.Equal(t, int64(1), input.MemberID)
+				assert.Equal(t, model.FineKindWaiver, input.Kind)
+				assert.Equal(t, int64(50), input.AmountCents)
+				return fine, nil
+			})
+
+		err := httpHandler.SettleFines(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+	})
+
+	t.Run("failed - accrual", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/v1/members/1/fines", strings.NewReader(`{"kind":"accrual","amount_cents":50}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("1")
+
+		err := httpHandler.SettleFines(ctx)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+	})
+
+	t.Run("failed - amount exceeds the balance", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/v1/members/1/fines", strings.NewReader(`{"kind":"payment","amount_cents":5000}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("1")
+
+		mockFineUsecase.EXPECT().Settle(gomock.Any(), gomock.Any()).Times(1).Return(nil, domainerr.Conflict("amount exceeds the balance of 75 cents", nil))
+
+		err := httpHandler.SettleFines(ctx)
+		assert.ErrorIs(t, err, domainerr.ErrConflict)
+	})
+}
@@ -0,0 +1,115 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/ssentinull/create-apis-using-golang/internal/config"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+)
+
+type GenreHTTPHandler struct {
+	GenreUsecase model.GenreUsecase
+}
+
+func NewGenreHTTPHandler(e *echo.Echo, gu model.GenreUsecase, cacheRepo model.CacheRepository) {
+	handler := GenreHTTPHandler{GenreUsecase: gu}
+	idempotent := IdempotencyMiddleware(cacheRepo, config.IdempotencyTTL(), config.IdempotencyLockTTL())
+
+	g := e.Group("/v1")
+	g.POST("/genres", handler.CreateGenre, idempotent)
+	g.GET("/genres", handler.FetchGenreTree)
+	g.GET("/genres/:ID", handler.FetchGenreByID)
+	g.PUT("/genres/:ID", handler.UpdateGenre, idempotent)
+	g.DELETE("/genres/:ID", handler.DeleteGenreByID, idempotent)
+}
+
+func (gh *GenreHTTPHandler) CreateGenre(c echo.Context) error {
+	input := new(model.CreateGenreInput)
+	if err := c.Bind(input); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	if err := c.Validate(input); err != nil {
+		return err
+	}
+
+	genre, err := gh.GenreUsecase.Create(c.Request().Context(), input.ToModel())
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, genre)
+}
+
+func (gh *GenreHTTPHandler) DeleteGenreByID(c echo.Context) error {
+	ID, err := strconv.ParseInt(c.Param("ID"), 10, 64)
+	if err != nil {
+		logrus.Error(err)
+		return errInvalidIDParam
+	}
+
+	if err := gh.GenreUsecase.DeleteByID(c.Request().Context(), ID); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (gh *GenreHTTPHandler) FetchGenreTree(c echo.Context) error {
+	genres, err := gh.GenreUsecase.FindTree(c.Request().Context())
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	return c.JSON(http.StatusOK, genres)
+}
+
+func (gh *GenreHTTPHandler) FetchGenreByID(c echo.Context) error {
+	ID, err := strconv.ParseInt(c.Param("ID"), 10, 64)
+	if err != nil {
+		logrus.Error(err)
+		return errInvalidIDParam
+	}
+
+	genre, err := gh.GenreUsecase.FindByID(c.Request().Context(), ID)
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	return c.JSON(http.StatusOK, genre)
+}
+
+func (gh *GenreHTTPHandler) UpdateGenre(c echo.Context) error {
+	ID, err := strconv.ParseInt(c.Param("ID"), 10, 64)
+	if err != nil {
+		logrus.Error(err)
+		return errInvalidIDParam
+	}
+
+	input := new(model.UpdateGenreInput)
+	if err := c.Bind(input); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	input.ID = ID
+	if err := c.Validate(input); err != nil {
+		return err
+	}
+
+	genre, err := gh.GenreUsecase.Update(c.Request().Context(), input.ToModel())
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	return c.JSON(http.StatusOK, genre)
+}
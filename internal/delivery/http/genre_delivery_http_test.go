@@ -0,0 +1,243 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+	"github.com/ssentinull/create-apis-using-golang/internal/model/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenreDeliveryHTTP_CreateGenre(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGenreUsecase := mock.NewMockGenreUsecase(ctrl)
+	httpHandler := GenreHTTPHandler{GenreUsecase: mockGenreUsecase}
+	e := echo.New()
+	e.Validator = &Validator{}
+
+	parentID := int64(1)
+	genre := &model.Genre{ID: 2, Name: "Fantasy", ParentID: &parentID}
+
+	t.Run("success", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/v1/genres", strings.NewReader(`{"name":"Fantasy","parent_id":1}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+
+		mockGenreUsecase.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).
+			DoAndReturn(func(_ interface{}, input *model.Genre) (*model.Genre, error) {
+				assert.Equal(t, genre.Name, input.Name)
+				assert.Equal(t, genre.ParentID, input.ParentID)
+				assert.NotZero(t, input.ID)
+				return genre, nil
+			})
+
+		err := httpHandler.CreateGenre(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+	})
+
+	t.Run("failed - name is missing", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/v1/genres", strings.NewReader(`{"parent_id":1}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+
+		err := httpHandler.CreateGenre(ctx)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+	})
+}
+
+func TestGenreDeliveryHTTP_DeleteGenreByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGenreUsecase := mock.NewMockGenreUsecase(ctrl)
+	httpHandler := GenreHTTPHandler{GenreUsecase: mockGenreUsecase}
+	e := echo.New()
+
+	t.Run("success", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, "/v1/genres/1", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("1")
+
+		mockGenreUsecase.EXPECT().DeleteByID(gomock.Any(), int64(1)).Times(1).Return(nil)
+
+		err := httpHandler.DeleteGenreByID(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+	})
+
+	t.Run("failed - genre has subgenres", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, "/v1/genres/1", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("1")
+
+		mockGenreUsecase.EXPECT().DeleteByID(gomock.Any(), int64(1)).Times(1).Return(domainerr.Conflict("genre 1 has subgenres", nil))
+
+		err := httpHandler.DeleteGenreByID(ctx)
+		assert.Error(t, err)
+
+		HTTPErrorHandler(err, ctx)
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	})
+
+	t.Run("failed - invalid ID param", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, "/v1/genres/abc", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("abc")
+
+		err := httpHandler.DeleteGenreByID(ctx)
+		assert.Equal(t, errInvalidIDParam, err)
+	})
+}
+
+func TestGenreDeliveryHTTP_FetchGenreTree(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGenreUsecase := mock.NewMockGenreUsecase(ctrl)
+	httpHandler := GenreHTTPHandler{GenreUsecase: mockGenreUsecase}
+	e := echo.New()
+
+	tree := []*model.Genre{{ID: 1, Name: "Fiction", Children: []*model.Genre{{ID: 2, Name: "Fantasy"}}}}
+
+	t.Run("success", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/genres", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+
+		mockGenreUsecase.EXPECT().FindTree(gomock.Any()).Times(1).Return(tree, nil)
+
+		err := httpHandler.FetchGenreTree(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		res := []*model.Genre{}
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		assert.Len(t, res, 1)
+		assert.Len(t, res[0].Children, 1)
+	})
+
+	t.Run("failed - find tree return error", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/genres", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+
+		mockGenreUsecase.EXPECT().FindTree(gomock.Any()).Times(1).Return(nil, errors.New("usecase error"))
+
+		err := httpHandler.FetchGenreTree(ctx)
+		assert.Error(t, err)
+
+		HTTPErrorHandler(err, ctx)
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+}
+
+func TestGenreDeliveryHTTP_FetchGenreByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGenreUsecase := mock.NewMockGenreUsecase(ctrl)
+	httpHandler := GenreHTTPHandler{GenreUsecase: mockGenreUsecase}
+	e := echo.New()
+
+	genre := &model.Genre{ID: 1, Name: "Fiction"}
+
+	t.Run("success", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/genres/1", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("1")
+
+		mockGenreUsecase.EXPECT().FindByID(gomock.Any(), int64(1)).Times(1).Return(genre, nil)
+
+		err := httpHandler.FetchGenreByID(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("failed - genre not found", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/genres/1", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("1")
+
+		mockGenreUsecase.EXPECT().FindByID(gomock.Any(), int64(1)).Times(1).Return(nil, domainerr.NotFound("genre 1 not found", nil))
+
+		err := httpHandler.FetchGenreByID(ctx)
+		assert.Error(t, err)
+
+		HTTPErrorHandler(err, ctx)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestGenreDeliveryHTTP_UpdateGenre(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGenreUsecase := mock.NewMockGenreUsecase(ctrl)
+	httpHandler := GenreHTTPHandler{GenreUsecase: mockGenreUsecase}
+	e := echo.New()
+	e.Validator = &Validator{}
+
+	genre := &model.Genre{ID: 1, Name: "Literary Fiction"}
+
+	t.Run("success", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/v1/genres/1", strings.NewReader(`{"name":"Literary Fiction"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("1")
+
+		mockGenreUsecase.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).
+			DoAndReturn(func(_ interface{}, input *model.Genre) (*model.Genre, error) {
+				assert.Equal(t, genre.ID, input.ID)
+				assert.Equal(t, genre.Name, input.Name)
+				assert.Nil(t, input.ParentID)
+				return genre, nil
+			})
+
+		err := httpHandler.UpdateGenre(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("failed - parent is a subgenre", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/v1/genres/1", strings.NewReader(`{"name":"Fiction","parent_id":2}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("1")
+
+		mockGenreUsecase.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).
+			Return(nil, domainerr.Validation("genre cannot be nested under itself", nil))
+
+		err := httpHandler.UpdateGenre(ctx)
+		assert.Error(t, err)
+
+		HTTPErrorHandler(err, ctx)
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	})
+}
@@ -0,0 +1,114 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/ssentinull/create-apis-using-golang/internal/config"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+)
+
+type HoldHTTPHandler struct {
+	HoldUsecase model.HoldUsecase
+}
+
+func NewHoldHTTPHandler(e *echo.Echo, hu model.HoldUsecase, cacheRepo model.CacheRepository) {
+	handler := HoldHTTPHandler{HoldUsecase: hu}
+	idempotent := IdempotencyMiddleware(cacheRepo, config.IdempotencyTTL(), config.IdempotencyLockTTL())
+
+	g := e.Group("/v1")
+	g.POST("/books/:ID/holds", handler.PlaceHold, idempotent)
+	g.GET("/books/:ID/holds", handler.FetchHolds)
+	g.DELETE("/books/:ID/holds", handler.CancelHold, idempotent)
+}
+
+// PlaceHold queues the member of the body for the book of the ID param
+func (hh *HoldHTTPHandler) PlaceHold(c echo.Context) error {
+	bookID, err := strconv.ParseInt(c.Param("ID"), 10, 64)
+	if err != nil {
+		logrus.Error(err)
+		return errInvalidIDParam
+	}
+
+	input := new(model.PlaceHoldInput)
+	if err := c.Bind(input); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	input.BookID = bookID
+	if err := c.Validate(input); err != nil {
+		return err
+	}
+
+	hold, err := hh.HoldUsecase.Place(c.Request().Context(), input.ToModel())
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, hold)
+}
+
+// CancelHold takes the member of the member_id query param out of the
+// queue of the book of the ID param
+func (hh *HoldHTTPHandler) CancelHold(c echo.Context) error {
+	bookID, err := strconv.ParseInt(c.Param("ID"), 10, 64)
+	if err != nil {
+		logrus.Error(err)
+		return errInvalidIDParam
+	}
+
+	input := new(model.CancelHoldInput)
+	if err := c.Bind(input); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	input.BookID = bookID
+	if err := c.Validate(input); err != nil {
+		return err
+	}
+
+	hold, err := hh.HoldUsecase.Cancel(c.Request().Context(), *input)
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	return c.JSON(http.StatusOK, hold)
+}
+
+// FetchHolds lists the queue of the book of the ID param, first come first
+// served
+func (hh *HoldHTTPHandler) FetchHolds(c echo.Context) error {
+	bookID, err := strconv.ParseInt(c.Param("ID"), 10, 64)
+	if err != nil {
+		logrus.Error(err)
+		return errInvalidIDParam
+	}
+
+	queryParams := new(model.GetHoldsQueryParams)
+	if err := c.Bind(queryParams); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	queryParams.BookID = bookID
+	if err := c.Validate(queryParams); err != nil {
+		return err
+	}
+
+	queryParams.Normalize(config.PaginationDefaultSize(), config.PaginationMaxSize())
+	holds, count, err := hh.HoldUsecase.FindAllByBookID(c.Request().Context(), *queryParams)
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	res := model.NewPaginationResponse(holds, queryParams.Page, queryParams.Size, count)
+	setPageLinkHeader(c, res)
+	return c.JSON(http.StatusOK, res)
+}
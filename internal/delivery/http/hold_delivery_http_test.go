@@ -0,0 +1,164 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/ssentinull/create-apis-using-golang/internal/config"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+	"github.com/ssentinull/create-apis-using-golang/internal/model/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHoldDeliveryHTTP_PlaceHold(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHoldUsecase := mock.NewMockHoldUsecase(ctrl)
+	httpHandler := HoldHTTPHandler{HoldUsecase: mockHoldUsecase}
+	e := echo.New()
+	e.Validator = &Validator{}
+
+	hold := &model.Hold{ID: 3, BookID: 1, MemberID: 2, Status: model.HoldStatusWaiting}
+
+	t.Run("success", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/v1/books/1/holds", strings.NewReader(`{"member_id":2}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("1")
+
+		mockHoldUsecase.EXPECT().Place(gomock.Any(), gomock.Any()).Times(1).
+			DoAndReturn(func(_ interface{}, input *model.Hold) (*model.Hold, error) {
+				assert.Equal(t, int64(1), input.BookID)
+				assert.Equal(t, int64(2), input.MemberID)
+				assert.Equal(t, model.HoldStatusWaiting, input.Status)
+				return hold, nil
+			})
+
+		err := httpHandler.PlaceHold(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+	})
+
+	t.Run("failed - member is missing", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/v1/books/1/holds", strings.NewReader(`{}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("1")
+
+		err := httpHandler.PlaceHold(ctx)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+	})
+
+	t.Run("failed - book has available copies", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/v1/books/1/holds", strings.NewReader(`{"member_id":2}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("1")
+
+		mockHoldUsecase.EXPECT().Place(gomock.Any(), gomock.Any()).Times(1).Return(nil, domainerr.Conflict("book 1 has available copies", nil))
+
+		err := httpHandler.PlaceHold(ctx)
+		assert.ErrorIs(t, err, domainerr.ErrConflict)
+	})
+}
+
+func TestHoldDeliveryHTTP_CancelHold(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHoldUsecase := mock.NewMockHoldUsecase(ctrl)
+	httpHandler := HoldHTTPHandler{HoldUsecase: mockHoldUsecase}
+	e := echo.New()
+	e.Validator = &Validator{}
+
+	hold := &model.Hold{ID: 3, BookID: 1, MemberID: 2, Status: model.HoldStatusCancelled}
+
+	t.Run("success", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, "/v1/books/1/holds?member_id=2", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("1")
+
+		expectedInput := model.CancelHoldInput{BookID: 1, MemberID: 2}
+		mockHoldUsecase.EXPECT().Cancel(gomock.Any(), expectedInput).Times(1).Return(hold, nil)
+
+		err := httpHandler.CancelHold(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("failed - member is missing", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, "/v1/books/1/holds", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("1")
+
+		err := httpHandler.CancelHold(ctx)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+	})
+
+	t.Run("failed - invalid ID", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, "/v1/books/abc/holds?member_id=2", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("abc")
+
+		err := httpHandler.CancelHold(ctx)
+		assert.Equal(t, errInvalidIDParam, err)
+	})
+}
+
+func TestHoldDeliveryHTTP_FetchHolds(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHoldUsecase := mock.NewMockHoldUsecase(ctrl)
+	httpHandler := HoldHTTPHandler{HoldUsecase: mockHoldUsecase}
+	e := echo.New()
+	e.Validator = &Validator{}
+
+	holds := []*model.Hold{{ID: 3, BookID: 1, MemberID: 2, Status: model.HoldStatusWaiting}}
+
+	t.Run("success", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/books/1/holds", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("1")
+
+		expectedParams := model.GetHoldsQueryParams{BookID: 1, PageQuery: model.PageQuery{Page: 1, Size: config.DefaultPaginationDefaultSize}}
+		mockHoldUsecase.EXPECT().FindAllByBookID(gomock.Any(), expectedParams).Times(1).Return(holds, int64(1), nil)
+
+		err := httpHandler.FetchHolds(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/books/1/holds", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("1")
+
+		mockHoldUsecase.EXPECT().FindAllByBookID(gomock.Any(), gomock.Any()).Times(1).Return(nil, int64(0), domainerr.NotFound("book 1 not found", nil))
+
+		err := httpHandler.FetchHolds(ctx)
+		assert.ErrorIs(t, err, domainerr.ErrNotFound)
+	})
+}
@@ -0,0 +1,201 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+)
+
+const (
+	HeaderIdempotencyKey      = "Idempotency-Key"
+	HeaderIdempotentReplayed  = "Idempotent-Replayed"
+	maxIdempotencyKeyLength   = 255
+	idempotencyCacheKeyPrefix = "idempotency:"
+	idempotencyStoreTimeout   = 5 * time.Second
+)
+
+var (
+	errInvalidIdempotencyKey = echo.NewHTTPError(http.StatusBadRequest, "Idempotency-Key header must be at most 255 characters long")
+	errIdempotencyKeyReused  = domainerr.Validation("Idempotency-Key has already been used with a different request", nil)
+	errIdempotencyKeyInUse   = domainerr.Conflict("a request with the same Idempotency-Key is still being processed", nil)
+)
+
+// idempotencyRecord is what is stored under an idempotency key, Status is
+// zero while the first request is still being processed
+type idempotencyRecord struct {
+	Fingerprint string      `json:"fingerprint"`
+	Status      int         `json:"status,omitempty"`
+	Header      http.Header `json:"header,omitempty"`
+	Body        []byte      `json:"body,omitempty"`
+}
+
+// IdempotencyMiddleware makes the requests carrying an Idempotency-Key header
+// safe to retry, the response of the first request is stored for ttl and
+// replayed to every later request with the same key and the same body.
+// The key is locked for lockTTL while the first request is processed, so
+// that it can be retried if the response is never stored. Failed requests
+// aren't stored, so that they can be retried
+func IdempotencyMiddleware(cacheRepo model.CacheRepository, ttl, lockTTL time.Duration) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			key := c.Request().Header.Get(HeaderIdempotencyKey)
+			if key == "" {
+				return next(c)
+			}
+
+			if len(key) > maxIdempotencyKeyLength {
+				return errInvalidIdempotencyKey
+			}
+
+			fingerprint, err := requestFingerprint(c)
+			if err != nil {
+				logrus.Error(err)
+				return err
+			}
+
+			ctx := c.Request().Context()
+			cacheKey := idempotencyCacheKeyPrefix + key
+			pending, err := json.Marshal(idempotencyRecord{Fingerprint: fingerprint})
+			if err != nil {
+				logrus.Error(err)
+				return err
+			}
+
+			ok, err := cacheRepo.SetNX(ctx, cacheKey, string(pending), lockTTL)
+			if err != nil {
+				logrus.Error(err)
+				return err
+			}
+
+			if !ok {
+				return replayResponse(c, cacheRepo, cacheKey, fingerprint)
+			}
+
+			// release the key of a request whose response can't be replayed, so that it can be retried
+			release := func() {
+				ctx, cancel := storeContext()
+				defer cancel()
+				if err := cacheRepo.Delete(ctx, cacheKey); err != nil {
+					logrus.Error(err)
+				}
+			}
+
+			recorder := &responseRecorder{ResponseWriter: c.Response().Writer}
+			c.Response().Writer = recorder
+			defer func() {
+				c.Response().Writer = recorder.ResponseWriter
+			}()
+
+			if err := next(c); err != nil || c.Response().Status >= http.StatusInternalServerError {
+				release()
+				return err
+			}
+
+			header := c.Response().Header().Clone()
+			header.Del(echo.HeaderXRequestID)
+			record, err := json.Marshal(idempotencyRecord{
+				Fingerprint: fingerprint,
+				Status:      c.Response().Status,
+				Header:      header,
+				Body:        recorder.body.Bytes(),
+			})
+			if err != nil {
+				logrus.Error(err)
+				release()
+				return nil
+			}
+
+			storeCtx, cancel := storeContext()
+			defer cancel()
+			if err := cacheRepo.SetWithTTL(storeCtx, cacheKey, string(record), ttl); err != nil {
+				logrus.Error(err)
+				release()
+			}
+
+			return nil
+		}
+	}
+}
+
+// storeContext isn't tied to the request, so that the response is still
+// stored or released when the client disconnects after the handler ran
+func storeContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), idempotencyStoreTimeout)
+}
+
+// replayResponse writes the stored response of the idempotency key, as
+// long as it was stored for the same request
+func replayResponse(c echo.Context, cacheRepo model.CacheRepository, cacheKey, fingerprint string) error {
+	reply, err := cacheRepo.Get(c.Request().Context(), cacheKey)
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	// the key expired or its request failed in the meantime
+	if reply == "" {
+		return errIdempotencyKeyInUse
+	}
+
+	record := idempotencyRecord{}
+	if err := json.Unmarshal([]byte(reply), &record); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	switch {
+	case record.Fingerprint != fingerprint:
+		return errIdempotencyKeyReused
+	case record.Status == 0:
+		return errIdempotencyKeyInUse
+	}
+
+	for name, values := range record.Header {
+		c.Response().Header()[name] = values
+	}
+
+	c.Response().Header().Set(HeaderIdempotentReplayed, "true")
+	c.Response().WriteHeader(record.Status)
+	_, err = c.Response().Write(record.Body)
+	return err
+}
+
+// requestFingerprint hashes the method, the URI and the body of the request,
+// the body is buffered so that the handler can still read it
+func requestFingerprint(c echo.Context) (string, error) {
+	req := c.Request()
+	body := []byte{}
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return "", err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	hash := sha256.New()
+	hash.Write([]byte(req.Method + " " + req.URL.RequestURI() + "\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// responseRecorder keeps a copy of the response body written through it
+type responseRecorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
@@ -0,0 +1,177 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/ssentinull/create-apis-using-golang/internal/model/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdempotencyMiddleware(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCacheRepo := mock.NewMockCacheRepository(ctrl)
+	ttl, lockTTL := time.Hour, time.Minute
+	cacheKey := idempotencyCacheKeyPrefix + "key"
+	body := `{"title":"Harry Potter"}`
+
+	e := echo.New()
+	e.HTTPErrorHandler = HTTPErrorHandler
+
+	handlerCalls := 0
+	handlerErr := error(nil)
+	e.POST("/v1/books", func(c echo.Context) error {
+		handlerCalls++
+		if handlerErr != nil {
+			return handlerErr
+		}
+		c.Response().Header().Set(HeaderETag, `"1"`)
+		return c.JSON(http.StatusCreated, map[string]int64{"id": 1})
+	}, IdempotencyMiddleware(mockCacheRepo, ttl, lockTTL))
+
+	serve := func(key, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/v1/books", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		if key != "" {
+			req.Header.Set(HeaderIdempotencyKey, key)
+		}
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	storedRecord := ""
+	t.Run("success - first request is stored", func(t *testing.T) {
+		handlerCalls, handlerErr = 0, nil
+		mockCacheRepo.EXPECT().SetNX(gomock.Any(), cacheKey, gomock.Any(), lockTTL).Times(1).Return(true, nil)
+		mockCacheRepo.EXPECT().SetWithTTL(gomock.Any(), cacheKey, gomock.Any(), ttl).Times(1).DoAndReturn(
+			func(_ context.Context, _, val string, _ time.Duration) error {
+				storedRecord = val
+				return nil
+			})
+
+		rec := serve("key", body)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Equal(t, 1, handlerCalls)
+
+		record := idempotencyRecord{}
+		assert.NoError(t, json.Unmarshal([]byte(storedRecord), &record))
+		assert.Equal(t, http.StatusCreated, record.Status)
+		assert.JSONEq(t, `{"id":1}`, string(record.Body))
+	})
+
+	t.Run("success - response is stored after the request is cancelled", func(t *testing.T) {
+		handlerCalls, handlerErr = 0, nil
+		mockCacheRepo.EXPECT().SetNX(gomock.Any(), cacheKey, gomock.Any(), lockTTL).Times(1).Return(true, nil)
+		mockCacheRepo.EXPECT().SetWithTTL(gomock.Any(), cacheKey, gomock.Any(), ttl).Times(1).DoAndReturn(
+			func(ctx context.Context, _, val string, _ time.Duration) error {
+				assert.NoError(t, ctx.Err())
+				storedRecord = val
+				return nil
+			})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		req := httptest.NewRequest(http.MethodPost, "/v1/books", strings.NewReader(body)).WithContext(ctx)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set(HeaderIdempotencyKey, "key")
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Equal(t, 1, handlerCalls)
+	})
+
+	t.Run("success - retry is replayed", func(t *testing.T) {
+		handlerCalls, handlerErr = 0, nil
+		mockCacheRepo.EXPECT().SetNX(gomock.Any(), cacheKey, gomock.Any(), lockTTL).Times(1).Return(false, nil)
+		mockCacheRepo.EXPECT().Get(gomock.Any(), cacheKey).Times(1).Return(storedRecord, nil)
+
+		rec := serve("key", body)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Equal(t, 0, handlerCalls)
+		assert.JSONEq(t, `{"id":1}`, rec.Body.String())
+		assert.Equal(t, `"1"`, rec.Header().Get(HeaderETag))
+		assert.Equal(t, "true", rec.Header().Get(HeaderIdempotentReplayed))
+	})
+
+	t.Run("success - request without key is not stored", func(t *testing.T) {
+		handlerCalls, handlerErr = 0, nil
+
+		rec := serve("", body)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Equal(t, 1, handlerCalls)
+	})
+
+	t.Run("failed - key is reused with a different body", func(t *testing.T) {
+		handlerCalls, handlerErr = 0, nil
+		mockCacheRepo.EXPECT().SetNX(gomock.Any(), cacheKey, gomock.Any(), lockTTL).Times(1).Return(false, nil)
+		mockCacheRepo.EXPECT().Get(gomock.Any(), cacheKey).Times(1).Return(storedRecord, nil)
+
+		rec := serve("key", `{"title":"Dune"}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Equal(t, MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+		assert.Equal(t, 0, handlerCalls)
+	})
+
+	t.Run("failed - first request is still being processed", func(t *testing.T) {
+		handlerCalls, handlerErr = 0, nil
+		pending, err := json.Marshal(idempotencyRecord{Fingerprint: "pending"})
+		assert.NoError(t, err)
+
+		mockCacheRepo.EXPECT().SetNX(gomock.Any(), cacheKey, gomock.Any(), lockTTL).Times(1).DoAndReturn(
+			func(_ context.Context, _, val string, _ time.Duration) (bool, error) {
+				pending = []byte(val)
+				return false, nil
+			})
+		mockCacheRepo.EXPECT().Get(gomock.Any(), cacheKey).Times(1).DoAndReturn(
+			func(_ context.Context, _ string) (string, error) {
+				return string(pending), nil
+			})
+
+		rec := serve("key", body)
+		assert.Equal(t, http.StatusConflict, rec.Code)
+		assert.Equal(t, 0, handlerCalls)
+	})
+
+	t.Run("failed - failed request releases the key", func(t *testing.T) {
+		handlerCalls, handlerErr = 0, domainerr.Validation("book is invalid", nil)
+		mockCacheRepo.EXPECT().SetNX(gomock.Any(), cacheKey, gomock.Any(), lockTTL).Times(1).Return(true, nil)
+		mockCacheRepo.EXPECT().Delete(gomock.Any(), cacheKey).Times(1).Return(nil)
+
+		rec := serve("key", body)
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Equal(t, 1, handlerCalls)
+	})
+
+	t.Run("failed - cache is unavailable", func(t *testing.T) {
+		handlerCalls, handlerErr = 0, nil
+		mockCacheRepo.EXPECT().SetNX(gomock.Any(), cacheKey, gomock.Any(), lockTTL).Times(1).
+			Return(false, domainerr.Unavailable("cache is unavailable", errors.New("redis error")))
+
+		rec := serve("key", body)
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		assert.Equal(t, 0, handlerCalls)
+	})
+
+	t.Run("failed - key is too long", func(t *testing.T) {
+		handlerCalls, handlerErr = 0, nil
+
+		rec := serve(strings.Repeat("k", maxIdempotencyKeyLength+1), body)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, 0, handlerCalls)
+	})
+}
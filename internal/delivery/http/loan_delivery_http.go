@@ -0,0 +1,141 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/ssentinull/create-apis-using-golang/internal/config"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+)
+
+type LoanHTTPHandler struct {
+	LoanUsecase model.LoanUsecase
+}
+
+func NewLoanHTTPHandler(e *echo.Echo, lu model.LoanUsecase, cacheRepo model.CacheRepository) {
+	handler := LoanHTTPHandler{LoanUsecase: lu}
+	idempotent := IdempotencyMiddleware(cacheRepo, config.IdempotencyTTL(), config.IdempotencyLockTTL())
+
+	g := e.Group("/v1")
+	g.POST("/loans", handler.Checkout, idempotent)
+	g.GET("/loans/:ID", handler.FetchLoanByID)
+	g.PUT("/loans/:ID/due-date", handler.UpdateLoanDueDate, idempotent)
+	g.POST("/loans/:ID/return", handler.ReturnLoan, idempotent)
+	g.GET("/members/:ID/loans", handler.FetchMemberLoans)
+}
+
+// Checkout lends a copy, known by its ID or its barcode, to a member
+func (lh *LoanHTTPHandler) Checkout(c echo.Context) error {
+	input := new(model.CheckoutInput)
+	if err := c.Bind(input); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	if err := c.Validate(input); err != nil {
+		return err
+	}
+
+	loan, err := lh.LoanUsecase.Checkout(c.Request().Context(), *input)
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, loan)
+}
+
+func (lh *LoanHTTPHandler) FetchLoanByID(c echo.Context) error {
+	ID, err := strconv.ParseInt(c.Param("ID"), 10, 64)
+	if err != nil {
+		logrus.Error(err)
+		return errInvalidIDParam
+	}
+
+	loan, err := lh.LoanUsecase.FindByID(c.Request().Context(), ID)
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	return c.JSON(http.StatusOK, loan)
+}
+
+// FetchMemberLoans lists the loans of the member of the ID param, the
+// status query param narrows them down to the open or the returned ones
+func (lh *LoanHTTPHandler) FetchMemberLoans(c echo.Context) error {
+	memberID, err := strconv.ParseInt(c.Param("ID"), 10, 64)
+	if err != nil {
+		logrus.Error(err)
+		return errInvalidIDParam
+	}
+
+	queryParams := new(model.GetLoansQueryParams)
+	if err := c.Bind(queryParams); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	queryParams.MemberID = memberID
+	if err := c.Validate(queryParams); err != nil {
+		return err
+	}
+
+	queryParams.Normalize(config.PaginationDefaultSize(), config.PaginationMaxSize())
+	loans, count, err := lh.LoanUsecase.FindAllByMemberID(c.Request().Context(), *queryParams)
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	res := model.NewPaginationResponse(loans, queryParams.Page, queryParams.Size, count)
+	setPageLinkHeader(c, res)
+	return c.JSON(http.StatusOK, res)
+}
+
+// ReturnLoan closes the open loan of the ID param, its copy is available again
+func (lh *LoanHTTPHandler) ReturnLoan(c echo.Context) error {
+	ID, err := strconv.ParseInt(c.Param("ID"), 10, 64)
+	if err != nil {
+		logrus.Error(err)
+		return errInvalidIDParam
+	}
+
+	loan, err := lh.LoanUsecase.Return(c.Request().Context(), ID)
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	return c.JSON(http.StatusOK, loan)
+}
+
+// UpdateLoanDueDate moves the due date of the open loan of the ID param
+func (lh *LoanHTTPHandler) UpdateLoanDueDate(c echo.Context) error {
+	ID, err := strconv.ParseInt(c.Param("ID"), 10, 64)
+	if err != nil {
+		logrus.Error(err)
+		return errInvalidIDParam
+	}
+
+	input := new(model.UpdateLoanDueDateInput)
+	if err := c.Bind(input); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	input.ID = ID
+	if err := c.Validate(input); err != nil {
+		return err
+	}
+
+	loan, err := lh.LoanUsecase.UpdateDueDate(c.Request().Context(), *input)
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	return c.JSON(http.StatusOK, loan)
+}
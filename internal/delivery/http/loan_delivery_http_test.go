@@ -0,0 +1,181 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/ssentinull/create-apis-using-golang/internal/config"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+	"github.com/ssentinull/create-apis-using-golang/internal/model/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoanDeliveryHTTP_Checkout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLoanUsecase := mock.NewMockLoanUsecase(ctrl)
+	httpHandler := LoanHTTPHandler{LoanUsecase: mockLoanUsecase}
+	e := echo.New()
+	e.Validator = &Validator{}
+
+	loan := &model.Loan{ID: 3, CopyID: 2, BookID: 1, MemberID: 4}
+
+	t.Run("success", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/v1/loans", strings.NewReader(`{"barcode":"LIB-0001","member_id":4}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+
+		input := model.CheckoutInput{Barcode: "LIB-0001", MemberID: 4}
+		mockLoanUsecase.EXPECT().Checkout(gomock.Any(), input).Times(1).Return(loan, nil)
+
+		err := httpHandler.Checkout(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+	})
+
+	t.Run("failed - member is missing", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/v1/loans", strings.NewReader(`{"copy_id":2}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+
+		err := httpHandler.Checkout(ctx)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+	})
+
+	t.Run("failed - copy is on loan", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/v1/loans", strings.NewReader(`{"copy_id":2,"member_id":4}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+
+		mockLoanUsecase.EXPECT().Checkout(gomock.Any(), gomock.Any()).Times(1).Return(nil, domainerr.Conflict("copy 2 is on_loan", nil))
+
+		err := httpHandler.Checkout(ctx)
+		assert.ErrorIs(t, err, domainerr.ErrConflict)
+	})
+}
+
+func TestLoanDeliveryHTTP_ReturnLoan(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLoanUsecase := mock.NewMockLoanUsecase(ctrl)
+	httpHandler := LoanHTTPHandler{LoanUsecase: mockLoanUsecase}
+	e := echo.New()
+
+	returnedAt := time.Date(2026, 10, 8, 10, 0, 0, 0, time.UTC)
+	loan := &model.Loan{ID: 3, CopyID: 2, BookID: 1, MemberID: 4, ReturnedAt: &returnedAt}
+
+	t.Run("success", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/v1/loans/3/return", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("3")
+
+		mockLoanUsecase.EXPECT().Return(gomock.Any(), int64(3)).Times(1).Return(loan, nil)
+
+		err := httpHandler.ReturnLoan(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("failed - invalid ID param", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/v1/loans/abc/return", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("abc")
+
+		err := httpHandler.ReturnLoan(ctx)
+		assert.Equal(t, errInvalidIDParam, err)
+	})
+}
+
+func TestLoanDeliveryHTTP_UpdateLoanDueDate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLoanUsecase := mock.NewMockLoanUsecase(ctrl)
+	httpHandler := LoanHTTPHandler{LoanUsecase: mockLoanUsecase}
+	e := echo.New()
+	e.Validator = &Validator{}
+
+	dueAt := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	loan := &model.Loan{ID: 3, CopyID: 2, BookID: 1, MemberID: 4, DueAt: dueAt}
+
+	t.Run("success", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/v1/loans/3/due-date", strings.NewReader(`{"due_at":"2026-11-01T00:00:00Z"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("3")
+
+		input := model.UpdateLoanDueDateInput{ID: 3, DueAt: dueAt}
+		mockLoanUsecase.EXPECT().UpdateDueDate(gomock.Any(), input).Times(1).Return(loan, nil)
+
+		err := httpHandler.UpdateLoanDueDate(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("failed - due date is missing", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/v1/loans/3/due-date", strings.NewReader(`{}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("3")
+
+		err := httpHandler.UpdateLoanDueDate(ctx)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+	})
+}
+
+func TestLoanDeliveryHTTP_FetchMemberLoans(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLoanUsecase := mock.NewMockLoanUsecase(ctrl)
+	httpHandler := LoanHTTPHandler{LoanUsecase: mockLoanUsecase}
+	e := echo.New()
+	e.Validator = &Validator{}
+
+	loans := []*model.Loan{{ID: 3, CopyID: 2, BookID: 1, MemberID: 4}}
+
+	t.Run("success", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/members/4/loans?status=open", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("4")
+
+		expectedParams := model.GetLoansQueryParams{MemberID: 4, Page: 1, Size: config.DefaultPaginationDefaultSize, Status: model.LoanStatusOpen}
+		mockLoanUsecase.EXPECT().FindAllByMemberID(gomock.Any(), expectedParams).Times(1).Return(loans, int64(1), nil)
+
+		err := httpHandler.FetchMemberLoans(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("failed - unknown status", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/members/4/loans?status=lost", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("4")
+
+		err := httpHandler.FetchMemberLoans(ctx)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+	})
+}
@@ -0,0 +1,128 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/ssentinull/create-apis-using-golang/internal/config"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+)
+
+type MemberHTTPHandler struct {
+	MemberUsecase model.MemberUsecase
+}
+
+func NewMemberHTTPHandler(e *echo.Echo, mu model.MemberUsecase, cacheRepo model.CacheRepository) {
+	handler := MemberHTTPHandler{MemberUsecase: mu}
+	idempotent := IdempotencyMiddleware(cacheRepo, config.IdempotencyTTL(), config.IdempotencyLockTTL())
+
+	g := e.Group("/v1")
+	g.POST("/members", handler.CreateMember, idempotent)
+	g.GET("/members", handler.FetchMembers)
+	g.GET("/members/:ID", handler.FetchMemberByID)
+	g.PUT("/members/:ID", handler.UpdateMember, idempotent)
+	g.DELETE("/members/:ID", handler.DeleteMemberByID, idempotent)
+}
+
+func (mh *MemberHTTPHandler) CreateMember(c echo.Context) error {
+	input := new(model.CreateMemberInput)
+	if err := c.Bind(input); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	if err := c.Validate(input); err != nil {
+		return err
+	}
+
+	member, err := mh.MemberUsecase.Create(c.Request().Context(), input.ToModel())
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, member)
+}
+
+func (mh *MemberHTTPHandler) DeleteMemberByID(c echo.Context) error {
+	ID, err := strconv.ParseInt(c.Param("ID"), 10, 64)
+	if err != nil {
+		logrus.Error(err)
+		return errInvalidIDParam
+	}
+
+	if err := mh.MemberUsecase.DeleteByID(c.Request().Context(), ID); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (mh *MemberHTTPHandler) FetchMembers(c echo.Context) error {
+	queryParams := new(model.GetMembersQueryParams)
+	if err := c.Bind(queryParams); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	if err := c.Validate(queryParams); err != nil {
+		return err
+	}
+
+	queryParams.Normalize(config.PaginationDefaultSize(), config.PaginationMaxSize())
+	members, count, err := mh.MemberUsecase.FindAll(c.Request().Context(), *queryParams)
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	res := model.NewPaginationResponse(members, queryParams.Page, queryParams.Size, count)
+	setPageLinkHeader(c, res)
+	return c.JSON(http.StatusOK, res)
+}
+
+func (mh *MemberHTTPHandler) FetchMemberByID(c echo.Context) error {
+	ID, err := strconv.ParseInt(c.Param("ID"), 10, 64)
+	if err != nil {
+		logrus.Error(err)
+		return errInvalidIDParam
+	}
+
+	member, err := mh.MemberUsecase.FindByID(c.Request().Context(), ID)
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	return c.JSON(http.StatusOK, member)
+}
+
+func (mh *MemberHTTPHandler) UpdateMember(c echo.Context) error {
+	ID, err := strconv.ParseInt(c.Param("ID"), 10, 64)
+	if err != nil {
+		logrus.Error(err)
+		return errInvalidIDParam
+	}
+
+	input := new(model.UpdateMemberInput)
+	if err := c.Bind(input); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	input.ID = ID
+	if err := c.Validate(input); err != nil {
+		return err
+	}
+
+	member, err := mh.MemberUsecase.Update(c.Request().Context(), input.ToModel())
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	return c.JSON(http.StatusOK, member)
+}
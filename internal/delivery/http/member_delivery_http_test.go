@@ -0,0 +1,249 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/ssentinull/create-apis-using-golang/internal/config"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+	"github.com/ssentinull/create-apis-using-golang/internal/model/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemberDeliveryHTTP_CreateMember(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMemberUsecase := mock.NewMockMemberUsecase(ctrl)
+	httpHandler := MemberHTTPHandler{MemberUsecase: mockMemberUsecase}
+	e := echo.New()
+	e.Validator = &Validator{}
+
+	member := &model.Member{ID: 1, Name: "Hermione Granger", Email: "hermione@hogwarts.edu"}
+
+	t.Run("success", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/v1/members", strings.NewReader(`{"name":"Hermione Granger","email":"hermione@hogwarts.edu"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+
+		mockMemberUsecase.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).
+			DoAndReturn(func(_ interface{}, input *model.Member) (*model.Member, error) {
+				assert.Equal(t, member.Name, input.Name)
+				assert.NotZero(t, input.ID)
+				return member, nil
+			})
+
+		err := httpHandler.CreateMember(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+	})
+
+	t.Run("failed - name is missing", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/v1/members", strings.NewReader(`{}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+
+		err := httpHandler.CreateMember(ctx)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+	})
+
+	t.Run("failed - email is taken", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/v1/members", strings.NewReader(`{"name":"Hermione Granger","email":"hermione@hogwarts.edu"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+
+		mockMemberUsecase.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(nil, domainerr.Conflict("record already exists", nil))
+
+		err := httpHandler.CreateMember(ctx)
+		assert.Error(t, err)
+
+		HTTPErrorHandler(err, ctx)
+		assert.Equal(t, http.StatusConflict, rec.Code)
+		assert.Equal(t, MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+	})
+}
+
+func TestMemberDeliveryHTTP_DeleteMemberByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMemberUsecase := mock.NewMockMemberUsecase(ctrl)
+	httpHandler := MemberHTTPHandler{MemberUsecase: mockMemberUsecase}
+	e := echo.New()
+
+	t.Run("success", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, "/v1/members/1", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("1")
+
+		mockMemberUsecase.EXPECT().DeleteByID(gomock.Any(), int64(1)).Times(1).Return(nil)
+
+		err := httpHandler.DeleteMemberByID(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+	})
+
+	t.Run("failed - invalid ID param", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, "/v1/members/abc", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("abc")
+
+		err := httpHandler.DeleteMemberByID(ctx)
+		assert.Equal(t, errInvalidIDParam, err)
+	})
+
+	t.Run("failed - member not found", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, "/v1/members/1", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("1")
+
+		mockMemberUsecase.EXPECT().DeleteByID(gomock.Any(), int64(1)).Times(1).Return(domainerr.NotFound("member 1 not found", nil))
+
+		err := httpHandler.DeleteMemberByID(ctx)
+		assert.Error(t, err)
+
+		HTTPErrorHandler(err, ctx)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestMemberDeliveryHTTP_FetchMembers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMemberUsecase := mock.NewMockMemberUsecase(ctrl)
+	httpHandler := MemberHTTPHandler{MemberUsecase: mockMemberUsecase}
+	e := echo.New()
+	e.Validator = &Validator{}
+
+	members := []*model.Member{{ID: 1, Name: "Hermione Granger", Email: "hermione@hogwarts.edu"}}
+
+	t.Run("success", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/members?q=herm", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+
+		expectedParams := model.GetMembersQueryParams{PageQuery: model.PageQuery{Page: 1, Size: config.DefaultPaginationDefaultSize}, Q: "herm"}
+		mockMemberUsecase.EXPECT().FindAll(gomock.Any(), expectedParams).Times(1).Return(members, int64(11), nil)
+
+		err := httpHandler.FetchMembers(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		res := model.PaginationResponse{}
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		assert.Equal(t, int64(11), res.TotalItems)
+		assert.Contains(t, rec.Header().Get(HeaderLink), `rel="next"`)
+	})
+
+	t.Run("failed - find all return error", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/members", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+
+		mockMemberUsecase.EXPECT().FindAll(gomock.Any(), gomock.Any()).Times(1).Return(nil, int64(0), errors.New("usecase error"))
+
+		err := httpHandler.FetchMembers(ctx)
+		assert.Error(t, err)
+
+		HTTPErrorHandler(err, ctx)
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+}
+
+func TestMemberDeliveryHTTP_FetchMemberByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMemberUsecase := mock.NewMockMemberUsecase(ctrl)
+	httpHandler := MemberHTTPHandler{MemberUsecase: mockMemberUsecase}
+	e := echo.New()
+
+	member := &model.Member{ID: 1, Name: "Hermione Granger", Email: "hermione@hogwarts.edu"}
+
+	t.Run("success", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/members/1", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("1")
+
+		mockMemberUsecase.EXPECT().FindByID(gomock.Any(), int64(1)).Times(1).Return(member, nil)
+
+		err := httpHandler.FetchMemberByID(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"email":"hermione@hogwarts.edu"`)
+	})
+
+	t.Run("failed - invalid ID param", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/members/abc", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("abc")
+
+		err := httpHandler.FetchMemberByID(ctx)
+		assert.Equal(t, errInvalidIDParam, err)
+	})
+}
+
+func TestMemberDeliveryHTTP_UpdateMember(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMemberUsecase := mock.NewMockMemberUsecase(ctrl)
+	httpHandler := MemberHTTPHandler{MemberUsecase: mockMemberUsecase}
+	e := echo.New()
+	e.Validator = &Validator{}
+
+	member := &model.Member{ID: 1, Name: "Hermione Granger", Email: "hermione@hogwarts.edu"}
+
+	t.Run("success", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/v1/members/1", strings.NewReader(`{"name":"Hermione Granger","email":"hermione@hogwarts.edu"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("1")
+
+		mockMemberUsecase.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).
+			DoAndReturn(func(_ interface{}, input *model.Member) (*model.Member, error) {
+				assert.Equal(t, member.ID, input.ID)
+				assert.Equal(t, member.Name, input.Name)
+				return member, nil
+			})
+
+		err := httpHandler.UpdateMember(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("failed - name is blank", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/v1/members/1", strings.NewReader(`{"name":"  ","email":"hermione@hogwarts.edu"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("1")
+
+		err := httpHandler.UpdateMember(ctx)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+	})
+}
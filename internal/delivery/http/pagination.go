@@ -0,0 +1,73 @@
+package http
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+)
+
+const HeaderLink = "Link"
+
+// setPageLinkHeader sets the RFC 8288 first, prev, next & last links of a page paginated response
+func setPageLinkHeader(c echo.Context, res model.PaginationResponse) {
+	links := []string{}
+	addLink := func(rel string, page int64) {
+		links = append(links, paginationLink(c, rel, map[string]string{
+			"page": strconv.FormatInt(page, 10),
+			"size": strconv.FormatInt(res.Size, 10),
+		}))
+	}
+
+	addLink("first", 1)
+	if res.HasPrev {
+		addLink("prev", res.Page-1)
+	}
+
+	if res.HasNext {
+		addLink("next", res.Page+1)
+	}
+
+	if res.TotalPages > 0 {
+		addLink("last", res.TotalPages)
+	}
+
+	c.Response().Header().Set(HeaderLink, strings.Join(links, ", "))
+}
+
+// setCursorLinkHeader sets the RFC 8288 first, prev & next links of a cursor paginated response
+func setCursorLinkHeader(c echo.Context, res model.PaginationResponse) {
+	limit := strconv.FormatInt(res.Size, 10)
+	links := []string{
+		paginationLink(c, "first", map[string]string{"cursor": "", "limit": limit}),
+	}
+
+	if res.HasPrev {
+		links = append(links, paginationLink(c, "prev", map[string]string{"cursor": res.PrevCursor, "limit": limit}))
+	}
+
+	if res.HasNext {
+		links = append(links, paginationLink(c, "next", map[string]string{"cursor": res.NextCursor, "limit": limit}))
+	}
+
+	c.Response().Header().Set(HeaderLink, strings.Join(links, ", "))
+}
+
+// paginationLink returns a link to the current request URL with its query
+// params overridden by params, empty values remove the param
+func paginationLink(c echo.Context, rel string, params map[string]string) string {
+	u := *c.Request().URL
+	query := u.Query()
+	for key, val := range params {
+		if val == "" {
+			query.Del(key)
+			continue
+		}
+		query.Set(key, val)
+	}
+
+	u.RawQuery = query.Encode()
+	return fmt.Sprintf(`<%s>; rel="%s"`, u.RequestURI(), rel)
+}
@@ -0,0 +1,128 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/ssentinull/create-apis-using-golang/internal/config"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+)
+
+type PublisherHTTPHandler struct {
+	PublisherUsecase model.PublisherUsecase
+}
+
+func NewPublisherHTTPHandler(e *echo.Echo, pu model.PublisherUsecase, cacheRepo model.CacheRepository) {
+	handler := PublisherHTTPHandler{PublisherUsecase: pu}
+	idempotent := IdempotencyMiddleware(cacheRepo, config.IdempotencyTTL(), config.IdempotencyLockTTL())
+
+	g := e.Group("/v1")
+	g.POST("/publishers", handler.CreatePublisher, idempotent)
+	g.GET("/publishers", handler.FetchPublishers)
+	g.GET("/publishers/:ID", handler.FetchPublisherByID)
+	g.PUT("/publishers/:ID", handler.UpdatePublisher, idempotent)
+	g.DELETE("/publishers/:ID", handler.DeletePublisherByID, idempotent)
+}
+
+func (ph *PublisherHTTPHandler) CreatePublisher(c echo.Context) error {
+	input := new(model.CreatePublisherInput)
+	if err := c.Bind(input); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	if err := c.Validate(input); err != nil {
+		return err
+	}
+
+	publisher, err := ph.PublisherUsecase.Create(c.Request().Context(), input.ToModel())
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, publisher)
+}
+
+func (ph *PublisherHTTPHandler) DeletePublisherByID(c echo.Context) error {
+	ID, err := strconv.ParseInt(c.Param("ID"), 10, 64)
+	if err != nil {
+		logrus.Error(err)
+		return errInvalidIDParam
+	}
+
+	if err := ph.PublisherUsecase.DeleteByID(c.Request().Context(), ID); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (ph *PublisherHTTPHandler) FetchPublishers(c echo.Context) error {
+	queryParams := new(model.GetPublishersQueryParams)
+	if err := c.Bind(queryParams); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	if err := c.Validate(queryParams); err != nil {
+		return err
+	}
+
+	queryParams.Normalize(config.PaginationDefaultSize(), config.PaginationMaxSize())
+	publishers, count, err := ph.PublisherUsecase.FindAll(c.Request().Context(), *queryParams)
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	res := model.NewPaginationResponse(publishers, queryParams.Page, queryParams.Size, count)
+	setPageLinkHeader(c, res)
+	return c.JSON(http.StatusOK, res)
+}
+
+func (ph *PublisherHTTPHandler) FetchPublisherByID(c echo.Context) error {
+	ID, err := strconv.ParseInt(c.Param("ID"), 10, 64)
+	if err != nil {
+		logrus.Error(err)
+		return errInvalidIDParam
+	}
+
+	publisher, err := ph.PublisherUsecase.FindByID(c.Request().Context(), ID)
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	return c.JSON(http.StatusOK, publisher)
+}
+
+func (ph *PublisherHTTPHandler) UpdatePublisher(c echo.Context) error {
+	ID, err := strconv.ParseInt(c.Param("ID"), 10, 64)
+	if err != nil {
+		logrus.Error(err)
+		return errInvalidIDParam
+	}
+
+	input := new(model.UpdatePublisherInput)
+	if err := c.Bind(input); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	input.ID = ID
+	if err := c.Validate(input); err != nil {
+		return err
+	}
+
+	publisher, err := ph.PublisherUsecase.Update(c.Request().Context(), input.ToModel())
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	return c.JSON(http.StatusOK, publisher)
+}
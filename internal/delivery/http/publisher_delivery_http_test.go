@@ -0,0 +1,249 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/ssentinull/create-apis-using-golang/internal/config"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+	"github.com/ssentinull/create-apis-using-golang/internal/model/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublisherDeliveryHTTP_CreatePublisher(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPublisherUsecase := mock.NewMockPublisherUsecase(ctrl)
+	httpHandler := PublisherHTTPHandler{PublisherUsecase: mockPublisherUsecase}
+	e := echo.New()
+	e.Validator = &Validator{}
+
+	publisher := &model.Publisher{ID: 1, Name: "Bloomsbury"}
+
+	t.Run("success", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/v1/publishers", strings.NewReader(`{"name":"Bloomsbury"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+
+		mockPublisherUsecase.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).
+			DoAndReturn(func(_ interface{}, input *model.Publisher) (*model.Publisher, error) {
+				assert.Equal(t, publisher.Name, input.Name)
+				assert.NotZero(t, input.ID)
+				return publisher, nil
+			})
+
+		err := httpHandler.CreatePublisher(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+	})
+
+	t.Run("failed - name is missing", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/v1/publishers", strings.NewReader(`{}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+
+		err := httpHandler.CreatePublisher(ctx)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+	})
+
+	t.Run("failed - name is taken", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/v1/publishers", strings.NewReader(`{"name":"Bloomsbury"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+
+		mockPublisherUsecase.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(nil, domainerr.Conflict("record already exists", nil))
+
+		err := httpHandler.CreatePublisher(ctx)
+		assert.Error(t, err)
+
+		HTTPErrorHandler(err, ctx)
+		assert.Equal(t, http.StatusConflict, rec.Code)
+		assert.Equal(t, MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+	})
+}
+
+func TestPublisherDeliveryHTTP_DeletePublisherByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPublisherUsecase := mock.NewMockPublisherUsecase(ctrl)
+	httpHandler := PublisherHTTPHandler{PublisherUsecase: mockPublisherUsecase}
+	e := echo.New()
+
+	t.Run("success", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, "/v1/publishers/1", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("1")
+
+		mockPublisherUsecase.EXPECT().DeleteByID(gomock.Any(), int64(1)).Times(1).Return(nil)
+
+		err := httpHandler.DeletePublisherByID(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+	})
+
+	t.Run("failed - invalid ID param", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, "/v1/publishers/abc", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("abc")
+
+		err := httpHandler.DeletePublisherByID(ctx)
+		assert.Equal(t, errInvalidIDParam, err)
+	})
+
+	t.Run("failed - publisher not found", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, "/v1/publishers/1", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("1")
+
+		mockPublisherUsecase.EXPECT().DeleteByID(gomock.Any(), int64(1)).Times(1).Return(domainerr.NotFound("publisher 1 not found", nil))
+
+		err := httpHandler.DeletePublisherByID(ctx)
+		assert.Error(t, err)
+
+		HTTPErrorHandler(err, ctx)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestPublisherDeliveryHTTP_FetchPublishers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPublisherUsecase := mock.NewMockPublisherUsecase(ctrl)
+	httpHandler := PublisherHTTPHandler{PublisherUsecase: mockPublisherUsecase}
+	e := echo.New()
+	e.Validator = &Validator{}
+
+	publishers := []*model.Publisher{{ID: 1, Name: "Bloomsbury"}}
+
+	t.Run("success", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/publishers?name=bloom", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+
+		expectedParams := model.GetPublishersQueryParams{PageQuery: model.PageQuery{Page: 1, Size: config.DefaultPaginationDefaultSize}, Name: "bloom"}
+		mockPublisherUsecase.EXPECT().FindAll(gomock.Any(), expectedParams).Times(1).Return(publishers, int64(11), nil)
+
+		err := httpHandler.FetchPublishers(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		res := model.PaginationResponse{}
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		assert.Equal(t, int64(11), res.TotalItems)
+		assert.Contains(t, rec.Header().Get(HeaderLink), `rel="next"`)
+	})
+
+	t.Run("failed - find all return error", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/publishers", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+
+		mockPublisherUsecase.EXPECT().FindAll(gomock.Any(), gomock.Any()).Times(1).Return(nil, int64(0), errors.New("usecase error"))
+
+		err := httpHandler.FetchPublishers(ctx)
+		assert.Error(t, err)
+
+		HTTPErrorHandler(err, ctx)
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+}
+
+func TestPublisherDeliveryHTTP_FetchPublisherByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPublisherUsecase := mock.NewMockPublisherUsecase(ctrl)
+	httpHandler := PublisherHTTPHandler{PublisherUsecase: mockPublisherUsecase}
+	e := echo.New()
+
+	publisher := &model.Publisher{ID: 1, Name: "Bloomsbury"}
+
+	t.Run("success", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/publishers/1", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("1")
+
+		mockPublisherUsecase.EXPECT().FindByID(gomock.Any(), int64(1)).Times(1).Return(publisher, nil)
+
+		err := httpHandler.FetchPublisherByID(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"name":"Bloomsbury"`)
+	})
+
+	t.Run("failed - invalid ID param", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/publishers/abc", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("abc")
+
+		err := httpHandler.FetchPublisherByID(ctx)
+		assert.Equal(t, errInvalidIDParam, err)
+	})
+}
+
+func TestPublisherDeliveryHTTP_UpdatePublisher(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPublisherUsecase := mock.NewMockPublisherUsecase(ctrl)
+	httpHandler := PublisherHTTPHandler{PublisherUsecase: mockPublisherUsecase}
+	e := echo.New()
+	e.Validator = &Validator{}
+
+	publisher := &model.Publisher{ID: 1, Name: "Bloomsbury"}
+
+	t.Run("success", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/v1/publishers/1", strings.NewReader(`{"name":"Bloomsbury"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("1")
+
+		mockPublisherUsecase.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).
+			DoAndReturn(func(_ interface{}, input *model.Publisher) (*model.Publisher, error) {
+				assert.Equal(t, publisher.ID, input.ID)
+				assert.Equal(t, publisher.Name, input.Name)
+				return publisher, nil
+			})
+
+		err := httpHandler.UpdatePublisher(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("failed - name is blank", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/v1/publishers/1", strings.NewReader(`{"name":"  "}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("1")
+
+		err := httpHandler.UpdatePublisher(ctx)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+	})
+}
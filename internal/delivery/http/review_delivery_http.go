@@ -0,0 +1,174 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/ssentinull/create-apis-using-golang/internal/config"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+)
+
+type ReviewHTTPHandler struct {
+	ReviewUsecase model.ReviewUsecase
+}
+
+func NewReviewHTTPHandler(e *echo.Echo, ru model.ReviewUsecase, cacheRepo model.CacheRepository) {
+	handler := ReviewHTTPHandler{ReviewUsecase: ru}
+	idempotent := IdempotencyMiddleware(cacheRepo, config.IdempotencyTTL(), config.IdempotencyLockTTL())
+
+	g := e.Group("/v1")
+	g.POST("/books/:ID/reviews", handler.CreateReview, idempotent)
+	g.GET("/books/:ID/reviews", handler.FetchBookReviews)
+	g.GET("/reviews/:ID", handler.FetchReviewByID)
+	g.PUT("/reviews/:ID", handler.UpdateReview, idempotent)
+	g.PUT("/reviews/:ID/status", handler.ModerateReview, idempotent)
+	g.DELETE("/reviews/:ID", handler.DeleteReviewByID, idempotent)
+}
+
+// CreateReview reviews the book of the ID param, the review awaits moderation
+func (rh *ReviewHTTPHandler) CreateReview(c echo.Context) error {
+	bookID, err := strconv.ParseInt(c.Param("ID"), 10, 64)
+	if err != nil {
+		logrus.Error(err)
+		return errInvalidIDParam
+	}
+
+	input := new(model.CreateReviewInput)
+	if err := c.Bind(input); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	input.BookID = bookID
+	if err := c.Validate(input); err != nil {
+		return err
+	}
+
+	review, err := rh.ReviewUsecase.Create(c.Request().Context(), input.ToModel())
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, review)
+}
+
+func (rh *ReviewHTTPHandler) DeleteReviewByID(c echo.Context) error {
+	ID, err := strconv.ParseInt(c.Param("ID"), 10, 64)
+	if err != nil {
+		logrus.Error(err)
+		return errInvalidIDParam
+	}
+
+	if err := rh.ReviewUsecase.DeleteByID(c.Request().Context(), ID); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// FetchBookReviews lists the approved reviews of the book of the ID param,
+// the status query param lists the reviews in another status
+func (rh *ReviewHTTPHandler) FetchBookReviews(c echo.Context) error {
+	bookID, err := strconv.ParseInt(c.Param("ID"), 10, 64)
+	if err != nil {
+		logrus.Error(err)
+		return errInvalidIDParam
+	}
+
+	queryParams := new(model.GetReviewsQueryParams)
+	if err := c.Bind(queryParams); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	queryParams.BookID = bookID
+	if err := c.Validate(queryParams); err != nil {
+		return err
+	}
+
+	queryParams.Normalize(config.PaginationDefaultSize(), config.PaginationMaxSize())
+	reviews, count, err := rh.ReviewUsecase.FindAllByBookID(c.Request().Context(), *queryParams)
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	res := model.NewPaginationResponse(reviews, queryParams.Page, queryParams.Size, count)
+	setPageLinkHeader(c, res)
+	return c.JSON(http.StatusOK, res)
+}
+
+func (rh *ReviewHTTPHandler) FetchReviewByID(c echo.Context) error {
+	ID, err := strconv.ParseInt(c.Param("ID"), 10, 64)
+	if err != nil {
+		logrus.Error(err)
+		return errInvalidIDParam
+	}
+
+	review, err := rh.ReviewUsecase.FindByID(c.Request().Context(), ID)
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	return c.JSON(http.StatusOK, review)
+}
+
+func (rh *ReviewHTTPHandler) UpdateReview(c echo.Context) error {
+	ID, err := strconv.ParseInt(c.Param("ID"), 10, 64)
+	if err != nil {
+		logrus.Error(err)
+		return errInvalidIDParam
+	}
+
+	input := new(model.UpdateReviewInput)
+	if err := c.Bind(input); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	input.ID = ID
+	if err := c.Validate(input); err != nil {
+		return err
+	}
+
+	review, err := rh.ReviewUsecase.Update(c.Request().Context(), *input)
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	return c.JSON(http.StatusOK, review)
+}
+
+// ModerateReview approves or rejects a review, or sends it back to moderation
+func (rh *ReviewHTTPHandler) ModerateReview(c echo.Context) error {
+	ID, err := strconv.ParseInt(c.Param("ID"), 10, 64)
+	if err != nil {
+		logrus.Error(err)
+		return errInvalidIDParam
+	}
+
+	input := new(model.ModerateReviewInput)
+	if err := c.Bind(input); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	input.ID = ID
+	if err := c.Validate(input); err != nil {
+		return err
+	}
+
+	review, err := rh.ReviewUsecase.Moderate(c.Request().Context(), *input)
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	return c.JSON(http.StatusOK, review)
+}
@@ -0,0 +1,235 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/ssentinull/create-apis-using-golang/internal/config"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+	"github.com/ssentinull/create-apis-using-golang/internal/model/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReviewDeliveryHTTP_CreateReview(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReviewUsecase := mock.NewMockReviewUsecase(ctrl)
+	httpHandler := ReviewHTTPHandler{ReviewUsecase: mockReviewUsecase}
+	e := echo.New()
+	e.Validator = &Validator{}
+
+	review := &model.Review{ID: 2, BookID: 1, Rating: 4, Reviewer: "hermione", Status: model.ReviewStatusPending}
+
+	t.Run("success", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/v1/books/1/reviews", strings.NewReader(`{"rating":4,"body":"A magical read","reviewer":"hermione"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("1")
+
+		mockReviewUsecase.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).
+			DoAndReturn(func(_ interface{}, input *model.Review) (*model.Review, error) {
+				assert.Equal(t, int64(1), input.BookID)
+				assert.Equal(t, 4, input.Rating)
+				assert.Equal(t, model.ReviewStatusPending, input.Status)
+				assert.NotZero(t, input.ID)
+				return review, nil
+			})
+
+		err := httpHandler.CreateReview(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+	})
+
+	t.Run("failed - rating is out of range", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/v1/books/1/reviews", strings.NewReader(`{"rating":0,"reviewer":"hermione"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("1")
+
+		err := httpHandler.CreateReview(ctx)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+	})
+
+	t.Run("failed - invalid ID param", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/v1/books/abc/reviews", strings.NewReader(`{}`))
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("abc")
+
+		err := httpHandler.CreateReview(ctx)
+		assert.Equal(t, errInvalidIDParam, err)
+	})
+}
+
+func TestReviewDeliveryHTTP_FetchBookReviews(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReviewUsecase := mock.NewMockReviewUsecase(ctrl)
+	httpHandler := ReviewHTTPHandler{ReviewUsecase: mockReviewUsecase}
+	e := echo.New()
+	e.Validator = &Validator{}
+
+	reviews := []*model.Review{{ID: 2, BookID: 1, Rating: 4, Reviewer: "hermione", Status: model.ReviewStatusPending}}
+
+	t.Run("success", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/books/1/reviews?status=pending", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("1")
+
+		expectedParams := model.GetReviewsQueryParams{BookID: 1, PageQuery: model.PageQuery{Page: 1, Size: config.DefaultPaginationDefaultSize}, Status: model.ReviewStatusPending}
+		mockReviewUsecase.EXPECT().FindAllByBookID(gomock.Any(), expectedParams).Times(1).Return(reviews, int64(1), nil)
+
+		err := httpHandler.FetchBookReviews(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		res := model.PaginationResponse{}
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		assert.Equal(t, int64(1), res.TotalItems)
+	})
+
+	t.Run("failed - unknown status", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/books/1/reviews?status=spam", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("1")
+
+		err := httpHandler.FetchBookReviews(ctx)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+	})
+}
+
+func TestReviewDeliveryHTTP_UpdateReview(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReviewUsecase := mock.NewMockReviewUsecase(ctrl)
+	httpHandler := ReviewHTTPHandler{ReviewUsecase: mockReviewUsecase}
+	e := echo.New()
+	e.Validator = &Validator{}
+
+	review := &model.Review{ID: 2, BookID: 1, Rating: 5, Reviewer: "hermione", Status: model.ReviewStatusPending}
+
+	t.Run("success", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/v1/reviews/2", strings.NewReader(`{"rating":5,"body":"Even better"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("2")
+
+		input := model.UpdateReviewInput{ID: 2, Rating: 5, Body: "Even better"}
+		mockReviewUsecase.EXPECT().Update(gomock.Any(), input).Times(1).Return(review, nil)
+
+		err := httpHandler.UpdateReview(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("failed - review not found", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/v1/reviews/2", strings.NewReader(`{"rating":5}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("2")
+
+		mockReviewUsecase.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).Return(nil, domainerr.NotFound("record not found", nil))
+
+		err := httpHandler.UpdateReview(ctx)
+		assert.ErrorIs(t, err, domainerr.ErrNotFound)
+	})
+}
+
+func TestReviewDeliveryHTTP_ModerateReview(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReviewUsecase := mock.NewMockReviewUsecase(ctrl)
+	httpHandler := ReviewHTTPHandler{ReviewUsecase: mockReviewUsecase}
+	e := echo.New()
+	e.Validator = &Validator{}
+
+	review := &model.Review{ID: 2, BookID: 1, Rating: 4, Reviewer: "hermione", Status: model.ReviewStatusApproved}
+
+	t.Run("success", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/v1/reviews/2/status", strings.NewReader(`{"status":"approved"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("2")
+
+		input := model.ModerateReviewInput{ID: 2, Status: model.ReviewStatusApproved}
+		mockReviewUsecase.EXPECT().Moderate(gomock.Any(), input).Times(1).Return(review, nil)
+
+		err := httpHandler.ModerateReview(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("failed - unknown status", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/v1/reviews/2/status", strings.NewReader(`{"status":"spam"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("2")
+
+		err := httpHandler.ModerateReview(ctx)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+	})
+}
+
+func TestReviewDeliveryHTTP_DeleteReviewByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReviewUsecase := mock.NewMockReviewUsecase(ctrl)
+	httpHandler := ReviewHTTPHandler{ReviewUsecase: mockReviewUsecase}
+	e := echo.New()
+
+	t.Run("success", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, "/v1/reviews/2", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("2")
+
+		mockReviewUsecase.EXPECT().DeleteByID(gomock.Any(), int64(2)).Times(1).Return(nil)
+
+		err := httpHandler.DeleteReviewByID(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+	})
+
+	t.Run("failed - usecase return error", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, "/v1/reviews/2", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("2")
+
+		mockReviewUsecase.EXPECT().DeleteByID(gomock.Any(), int64(2)).Times(1).Return(errors.New("usecase error"))
+
+		err := httpHandler.DeleteReviewByID(ctx)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,145 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/ssentinull/create-apis-using-golang/internal/config"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+)
+
+type SeriesHTTPHandler struct {
+	SeriesUsecase model.SeriesUsecase
+}
+
+func NewSeriesHTTPHandler(e *echo.Echo, su model.SeriesUsecase, cacheRepo model.CacheRepository) {
+	handler := SeriesHTTPHandler{SeriesUsecase: su}
+	idempotent := IdempotencyMiddleware(cacheRepo, config.IdempotencyTTL(), config.IdempotencyLockTTL())
+
+	g := e.Group("/v1")
+	g.POST("/series", handler.CreateSeries, idempotent)
+	g.GET("/series", handler.FetchSeries)
+	g.GET("/series/:ID", handler.FetchSeriesByID)
+	g.PUT("/series/:ID", handler.UpdateSeries, idempotent)
+	g.DELETE("/series/:ID", handler.DeleteSeriesByID, idempotent)
+	g.GET("/series/:ID/books", handler.FetchSeriesBooks)
+}
+
+func (sh *SeriesHTTPHandler) CreateSeries(c echo.Context) error {
+	input := new(model.CreateSeriesInput)
+	if err := c.Bind(input); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	if err := c.Validate(input); err != nil {
+		return err
+	}
+
+	series, err := sh.SeriesUsecase.Create(c.Request().Context(), input.ToModel())
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, series)
+}
+
+func (sh *SeriesHTTPHandler) DeleteSeriesByID(c echo.Context) error {
+	ID, err := strconv.ParseInt(c.Param("ID"), 10, 64)
+	if err != nil {
+		logrus.Error(err)
+		return errInvalidIDParam
+	}
+
+	if err := sh.SeriesUsecase.DeleteByID(c.Request().Context(), ID); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (sh *SeriesHTTPHandler) FetchSeries(c echo.Context) error {
+	queryParams := new(model.GetSeriesQueryParams)
+	if err := c.Bind(queryParams); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	if err := c.Validate(queryParams); err != nil {
+		return err
+	}
+
+	queryParams.Normalize(config.PaginationDefaultSize(), config.PaginationMaxSize())
+	series, count, err := sh.SeriesUsecase.FindAll(c.Request().Context(), *queryParams)
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	res := model.NewPaginationResponse(series, queryParams.Page, queryParams.Size, count)
+	setPageLinkHeader(c, res)
+	return c.JSON(http.StatusOK, res)
+}
+
+func (sh *SeriesHTTPHandler) FetchSeriesByID(c echo.Context) error {
+	ID, err := strconv.ParseInt(c.Param("ID"), 10, 64)
+	if err != nil {
+		logrus.Error(err)
+		return errInvalidIDParam
+	}
+
+	series, err := sh.SeriesUsecase.FindByID(c.Request().Context(), ID)
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	return c.JSON(http.StatusOK, series)
+}
+
+func (sh *SeriesHTTPHandler) UpdateSeries(c echo.Context) error {
+	ID, err := strconv.ParseInt(c.Param("ID"), 10, 64)
+	if err != nil {
+		logrus.Error(err)
+		return errInvalidIDParam
+	}
+
+	input := new(model.UpdateSeriesInput)
+	if err := c.Bind(input); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	input.ID = ID
+	if err := c.Validate(input); err != nil {
+		return err
+	}
+
+	series, err := sh.SeriesUsecase.Update(c.Request().Context(), input.ToModel())
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	return c.JSON(http.StatusOK, series)
+}
+
+func (sh *SeriesHTTPHandler) FetchSeriesBooks(c echo.Context) error {
+	ID, err := strconv.ParseInt(c.Param("ID"), 10, 64)
+	if err != nil {
+		logrus.Error(err)
+		return errInvalidIDParam
+	}
+
+	books, err := sh.SeriesUsecase.FindBooks(c.Request().Context(), ID)
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	return c.JSON(http.StatusOK, books)
+}
@@ -0,0 +1,276 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/ssentinull/create-apis-using-golang/internal/config"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+	"github.com/ssentinull/create-apis-using-golang/internal/model/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSeriesDeliveryHTTP_CreateSeries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSeriesUsecase := mock.NewMockSeriesUsecase(ctrl)
+	httpHandler := SeriesHTTPHandler{SeriesUsecase: mockSeriesUsecase}
+	e := echo.New()
+	e.Validator = &Validator{}
+
+	series := &model.Series{ID: 1, Name: "Harry Potter"}
+
+	t.Run("success", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/v1/series", strings.NewReader(`{"name":"Harry Potter"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+
+		mockSeriesUsecase.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).
+			DoAndReturn(func(_ interface{}, input *model.Series) (*model.Series, error) {
+				assert.Equal(t, series.Name, input.Name)
+				assert.NotZero(t, input.ID)
+				return series, nil
+			})
+
+		err := httpHandler.CreateSeries(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+	})
+
+	t.Run("failed - name is missing", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/v1/series", strings.NewReader(`{"description":"A series about wizards"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+
+		err := httpHandler.CreateSeries(ctx)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+	})
+}
+
+func TestSeriesDeliveryHTTP_DeleteSeriesByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSeriesUsecase := mock.NewMockSeriesUsecase(ctrl)
+	httpHandler := SeriesHTTPHandler{SeriesUsecase: mockSeriesUsecase}
+	e := echo.New()
+
+	t.Run("success", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, "/v1/series/1", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("1")
+
+		mockSeriesUsecase.EXPECT().DeleteByID(gomock.Any(), int64(1)).Times(1).Return(nil)
+
+		err := httpHandler.DeleteSeriesByID(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+	})
+
+	t.Run("failed - invalid ID param", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, "/v1/series/abc", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("abc")
+
+		err := httpHandler.DeleteSeriesByID(ctx)
+		assert.Equal(t, errInvalidIDParam, err)
+	})
+
+	t.Run("failed - series not found", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, "/v1/series/1", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("1")
+
+		mockSeriesUsecase.EXPECT().DeleteByID(gomock.Any(), int64(1)).Times(1).Return(domainerr.NotFound("series 1 not found", nil))
+
+		err := httpHandler.DeleteSeriesByID(ctx)
+		assert.Error(t, err)
+
+		HTTPErrorHandler(err, ctx)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestSeriesDeliveryHTTP_FetchSeries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSeriesUsecase := mock.NewMockSeriesUsecase(ctrl)
+	httpHandler := SeriesHTTPHandler{SeriesUsecase: mockSeriesUsecase}
+	e := echo.New()
+	e.Validator = &Validator{}
+
+	seriesList := []*model.Series{{ID: 1, Name: "Harry Potter"}}
+
+	t.Run("success", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/series?name=potter", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+
+		expectedParams := model.GetSeriesQueryParams{PageQuery: model.PageQuery{Page: 1, Size: config.DefaultPaginationDefaultSize}, Name: "potter"}
+		mockSeriesUsecase.EXPECT().FindAll(gomock.Any(), expectedParams).Times(1).Return(seriesList, int64(11), nil)
+
+		err := httpHandler.FetchSeries(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		res := model.PaginationResponse{}
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		assert.Equal(t, int64(11), res.TotalItems)
+		assert.Contains(t, rec.Header().Get(HeaderLink), `rel="next"`)
+	})
+
+	t.Run("failed - find all return error", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/series", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+
+		mockSeriesUsecase.EXPECT().FindAll(gomock.Any(), gomock.Any()).Times(1).Return(nil, int64(0), errors.New("usecase error"))
+
+		err := httpHandler.FetchSeries(ctx)
+		assert.Error(t, err)
+
+		HTTPErrorHandler(err, ctx)
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+}
+
+func TestSeriesDeliveryHTTP_FetchSeriesByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSeriesUsecase := mock.NewMockSeriesUsecase(ctrl)
+	httpHandler := SeriesHTTPHandler{SeriesUsecase: mockSeriesUsecase}
+	e := echo.New()
+
+	series := &model.Series{ID: 1, Name: "Harry Potter"}
+
+	t.Run("success", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/series/1", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("1")
+
+		mockSeriesUsecase.EXPECT().FindByID(gomock.Any(), int64(1)).Times(1).Return(series, nil)
+
+		err := httpHandler.FetchSeriesByID(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"name":"Harry Potter"`)
+	})
+
+	t.Run("failed - invalid ID param", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/series/abc", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("abc")
+
+		err := httpHandler.FetchSeriesByID(ctx)
+		assert.Equal(t, errInvalidIDParam, err)
+	})
+}
+
+func TestSeriesDeliveryHTTP_UpdateSeries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSeriesUsecase := mock.NewMockSeriesUsecase(ctrl)
+	httpHandler := SeriesHTTPHandler{SeriesUsecase: mockSeriesUsecase}
+	e := echo.New()
+	e.Validator = &Validator{}
+
+	series := &model.Series{ID: 1, Name: "Harry Potter", Description: "A series about wizards"}
+
+	t.Run("success", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/v1/series/1", strings.NewReader(`{"name":"Harry Potter","description":"A series about wizards"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("1")
+
+		mockSeriesUsecase.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).
+			DoAndReturn(func(_ interface{}, input *model.Series) (*model.Series, error) {
+				assert.Equal(t, series.ID, input.ID)
+				assert.Equal(t, series.Description, input.Description)
+				return series, nil
+			})
+
+		err := httpHandler.UpdateSeries(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("failed - name is blank", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPut, "/v1/series/1", strings.NewReader(`{"name":"  "}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("1")
+
+		err := httpHandler.UpdateSeries(ctx)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+	})
+}
+
+func TestSeriesDeliveryHTTP_FetchSeriesBooks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSeriesUsecase := mock.NewMockSeriesUsecase(ctrl)
+	httpHandler := SeriesHTTPHandler{SeriesUsecase: mockSeriesUsecase}
+	e := echo.New()
+
+	seriesID, position := int64(1), 1
+	books := []*model.Book{{ID: 2, Title: "Harry Potter", SeriesID: &seriesID, SeriesPosition: &position}}
+
+	t.Run("success", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/series/1/books", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("1")
+
+		mockSeriesUsecase.EXPECT().FindBooks(gomock.Any(), seriesID).Times(1).Return(books, nil)
+
+		err := httpHandler.FetchSeriesBooks(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"series_position":1`)
+	})
+
+	t.Run("failed - series not found", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/series/1/books", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("1")
+
+		mockSeriesUsecase.EXPECT().FindBooks(gomock.Any(), seriesID).Times(1).Return(nil, domainerr.NotFound("record not found", nil))
+
+		err := httpHandler.FetchSeriesBooks(ctx)
+		assert.Error(t, err)
+
+		HTTPErrorHandler(err, ctx)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
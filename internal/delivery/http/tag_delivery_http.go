@@ -0,0 +1,123 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/ssentinull/create-apis-using-golang/internal/config"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+)
+
+type TagHTTPHandler struct {
+	TagUsecase model.TagUsecase
+}
+
+func NewTagHTTPHandler(e *echo.Echo, tu model.TagUsecase, cacheRepo model.CacheRepository) {
+	handler := TagHTTPHandler{TagUsecase: tu}
+	idempotent := IdempotencyMiddleware(cacheRepo, config.IdempotencyTTL(), config.IdempotencyLockTTL())
+
+	g := e.Group("/v1")
+	g.GET("/tags", handler.FetchTags)
+	g.GET("/books/:ID/tags", handler.FetchBookTags)
+	g.POST("/books/:ID/tags", handler.AddBookTags, idempotent)
+	g.DELETE("/books/:ID/tags", handler.RemoveBookTags, idempotent)
+}
+
+func (th *TagHTTPHandler) FetchTags(c echo.Context) error {
+	queryParams := new(model.GetTagsQueryParams)
+	if err := c.Bind(queryParams); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	if err := c.Validate(queryParams); err != nil {
+		return err
+	}
+
+	queryParams.Normalize(config.PaginationDefaultSize(), config.PaginationMaxSize())
+	tags, count, err := th.TagUsecase.FindAll(c.Request().Context(), *queryParams)
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	res := model.NewPaginationResponse(tags, queryParams.Page, queryParams.Size, count)
+	setPageLinkHeader(c, res)
+	return c.JSON(http.StatusOK, res)
+}
+
+func (th *TagHTTPHandler) FetchBookTags(c echo.Context) error {
+	ID, err := strconv.ParseInt(c.Param("ID"), 10, 64)
+	if err != nil {
+		logrus.Error(err)
+		return errInvalidIDParam
+	}
+
+	tags, err := th.TagUsecase.FindAllByBookID(c.Request().Context(), ID)
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	return c.JSON(http.StatusOK, tags)
+}
+
+func (th *TagHTTPHandler) AddBookTags(c echo.Context) error {
+	input, err := th.bindBookTags(c)
+	if err != nil {
+		return err
+	}
+
+	tags, err := th.TagUsecase.AddBookTags(c.Request().Context(), *input)
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	return c.JSON(http.StatusOK, tags)
+}
+
+// RemoveBookTags takes the tags either from the body, like AddBookTags, or
+// from the comma separated tags query param, eg: DELETE /v1/books/1/tags?tags=a,b
+func (th *TagHTTPHandler) RemoveBookTags(c echo.Context) error {
+	input, err := th.bindBookTags(c)
+	if err != nil {
+		return err
+	}
+
+	tags, err := th.TagUsecase.RemoveBookTags(c.Request().Context(), *input)
+	if err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	return c.JSON(http.StatusOK, tags)
+}
+
+func (th *TagHTTPHandler) bindBookTags(c echo.Context) (*model.BookTagsInput, error) {
+	ID, err := strconv.ParseInt(c.Param("ID"), 10, 64)
+	if err != nil {
+		logrus.Error(err)
+		return nil, errInvalidIDParam
+	}
+
+	input := new(model.BookTagsInput)
+	if err := c.Bind(input); err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+
+	input.BookID = ID
+	if tags := c.QueryParam("tags"); len(input.Tags) == 0 && tags != "" {
+		input.Tags = strings.Split(tags, ",")
+	}
+
+	if err := c.Validate(input); err != nil {
+		return nil, err
+	}
+
+	return input, nil
+}
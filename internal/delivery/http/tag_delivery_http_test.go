@@ -0,0 +1,160 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/ssentinull/create-apis-using-golang/internal/config"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+	"github.com/ssentinull/create-apis-using-golang/internal/model/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTagDeliveryHTTP_FetchTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTagUsecase := mock.NewMockTagUsecase(ctrl)
+	httpHandler := TagHTTPHandler{TagUsecase: mockTagUsecase}
+	e := echo.New()
+	e.Validator = &Validator{}
+
+	tags := []*model.Tag{{ID: 1, Name: "magic"}}
+
+	t.Run("success", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/tags?name=ma", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+
+		expectedParams := model.GetTagsQueryParams{PageQuery: model.PageQuery{Page: 1, Size: config.DefaultPaginationDefaultSize}, Name: "ma"}
+		mockTagUsecase.EXPECT().FindAll(gomock.Any(), expectedParams).Times(1).Return(tags, int64(1), nil)
+
+		err := httpHandler.FetchTags(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		res := model.PaginationResponse{}
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		assert.Equal(t, int64(1), res.TotalItems)
+	})
+}
+
+func TestTagDeliveryHTTP_FetchBookTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTagUsecase := mock.NewMockTagUsecase(ctrl)
+	httpHandler := TagHTTPHandler{TagUsecase: mockTagUsecase}
+	e := echo.New()
+
+	t.Run("success", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/books/1/tags", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("1")
+
+		mockTagUsecase.EXPECT().FindAllByBookID(gomock.Any(), int64(1)).Times(1).Return([]*model.Tag{{ID: 1, Name: "magic"}}, nil)
+
+		err := httpHandler.FetchBookTags(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("failed - invalid ID param", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/books/abc/tags", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("abc")
+
+		err := httpHandler.FetchBookTags(ctx)
+		assert.Equal(t, errInvalidIDParam, err)
+	})
+}
+
+func TestTagDeliveryHTTP_AddBookTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTagUsecase := mock.NewMockTagUsecase(ctrl)
+	httpHandler := TagHTTPHandler{TagUsecase: mockTagUsecase}
+	e := echo.New()
+	e.Validator = &Validator{}
+
+	t.Run("success", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/v1/books/1/tags", strings.NewReader(`{"tags":["magic","school"]}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("1")
+
+		expectedInput := model.BookTagsInput{BookID: 1, Tags: []string{"magic", "school"}}
+		mockTagUsecase.EXPECT().AddBookTags(gomock.Any(), expectedInput).Times(1).
+			Return([]*model.Tag{{ID: 1, Name: "magic"}, {ID: 2, Name: "school"}}, nil)
+
+		err := httpHandler.AddBookTags(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("failed - tags are missing", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/v1/books/1/tags", strings.NewReader(`{}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("1")
+
+		err := httpHandler.AddBookTags(ctx)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+	})
+}
+
+func TestTagDeliveryHTTP_RemoveBookTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTagUsecase := mock.NewMockTagUsecase(ctrl)
+	httpHandler := TagHTTPHandler{TagUsecase: mockTagUsecase}
+	e := echo.New()
+	e.Validator = &Validator{}
+
+	t.Run("success - tags from query param", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, "/v1/books/1/tags?tags=magic,school", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("1")
+
+		expectedInput := model.BookTagsInput{BookID: 1, Tags: []string{"magic", "school"}}
+		mockTagUsecase.EXPECT().RemoveBookTags(gomock.Any(), expectedInput).Times(1).Return([]*model.Tag{}, nil)
+
+		err := httpHandler.RemoveBookTags(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("failed - book not found", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, "/v1/books/1/tags?tags=magic", nil)
+		rec := httptest.NewRecorder()
+		ctx := e.NewContext(req, rec)
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues("1")
+
+		mockTagUsecase.EXPECT().RemoveBookTags(gomock.Any(), gomock.Any()).Times(1).Return(nil, domainerr.NotFound("book 1 not found", nil))
+
+		err := httpHandler.RemoveBookTags(ctx)
+		assert.Error(t, err)
+
+		HTTPErrorHandler(err, ctx)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
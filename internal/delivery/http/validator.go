@@ -0,0 +1,11 @@
+package http
+
+import "github.com/ssentinull/create-apis-using-golang/internal/utils"
+
+// Validator plugs utils.ValidateStruct into echo, register it as echo.Echo.Validator
+type Validator struct{}
+
+// Validate :nodoc:
+func (v *Validator) Validate(i interface{}) error {
+	return utils.ValidateStruct(i)
+}
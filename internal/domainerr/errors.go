@@ -0,0 +1,77 @@
+package domainerr
+
+import "errors"
+
+// sentinel errors used to classify failures across layers, check them with errors.Is
+var (
+	ErrNotFound           = errors.New("resource not found")
+	ErrConflict           = errors.New("resource conflict")
+	ErrValidation         = errors.New("validation failed")
+	ErrUnauthorized       = errors.New("unauthorized")
+	ErrUnavailable        = errors.New("service unavailable")
+	ErrPreconditionFailed = errors.New("precondition failed")
+)
+
+// Error wraps an underlying error with one of the sentinel kinds and
+// a message that is safe to show to API clients, Fields holds per-field
+// messages for validation failures
+type Error struct {
+	Kind    error
+	Message string
+	Fields  map[string]string
+	Err     error
+}
+
+// Error :nodoc:
+func (e *Error) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+
+	return e.Kind.Error()
+}
+
+// Unwrap :nodoc:
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is the kind of this error
+func (e *Error) Is(target error) bool {
+	return e.Kind == target
+}
+
+// NotFound :nodoc:
+func NotFound(message string, err error) error {
+	return &Error{Kind: ErrNotFound, Message: message, Err: err}
+}
+
+// Conflict :nodoc:
+func Conflict(message string, err error) error {
+	return &Error{Kind: ErrConflict, Message: message, Err: err}
+}
+
+// Validation :nodoc:
+func Validation(message string, err error) error {
+	return &Error{Kind: ErrValidation, Message: message, Err: err}
+}
+
+// Unauthorized :nodoc:
+func Unauthorized(message string, err error) error {
+	return &Error{Kind: ErrUnauthorized, Message: message, Err: err}
+}
+
+// Unavailable :nodoc:
+func Unavailable(message string, err error) error {
+	return &Error{Kind: ErrUnavailable, Message: message, Err: err}
+}
+
+// PreconditionFailed :nodoc:
+func PreconditionFailed(message string, err error) error {
+	return &Error{Kind: ErrPreconditionFailed, Message: message, Err: err}
+}
+
+// ValidationFields :nodoc:
+func ValidationFields(message string, fields map[string]string) error {
+	return &Error{Kind: ErrValidation, Message: message, Fields: fields}
+}
@@ -0,0 +1,91 @@
+package model
+
+import (
+	"context"
+	"time"
+
+	"github.com/ssentinull/create-apis-using-golang/internal/utils"
+	"gorm.io/gorm"
+)
+
+type Author struct {
+	ID        int64          `json:"id"`
+	Name      string         `json:"name" validate:"required,notblank,max=255"`
+	Bio       string         `json:"bio" validate:"max=5000"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"deleted_at"`
+}
+
+// BookAuthor links a book to one of its authors
+type BookAuthor struct {
+	BookID   int64
+	AuthorID int64
+}
+
+func (BookAuthor) TableName() string {
+	return "book_authors"
+}
+
+type CreateAuthorInput struct {
+	Name string `json:"name" validate:"required,notblank,max=255"`
+	Bio  string `json:"bio" validate:"max=5000"`
+}
+
+func (i CreateAuthorInput) ToModel() *Author {
+	return &Author{
+		ID:        utils.GenerateID(),
+		Name:      i.Name,
+		Bio:       i.Bio,
+		CreatedAt: time.Now(),
+	}
+}
+
+// UpdateAuthorInput replaces every editable field of an author
+type UpdateAuthorInput struct {
+	ID   int64  `json:"-" validate:"required,min=1"`
+	Name string `json:"name" validate:"required,notblank,max=255"`
+	Bio  string `json:"bio" validate:"max=5000"`
+}
+
+func (i UpdateAuthorInput) ToModel() *Author {
+	return &Author{
+		ID:        i.ID,
+		Name:      i.Name,
+		Bio:       i.Bio,
+		UpdatedAt: time.Now(),
+	}
+}
+
+// ReplaceBookAuthorsInput replaces the authors of a book, an empty list
+// unlinks all of them
+type ReplaceBookAuthorsInput struct {
+	BookID    int64   `json:"-" validate:"required,min=1"`
+	AuthorIDs []int64 `json:"author_ids" validate:"max=50,dive,min=1"`
+}
+
+type GetAuthorsQueryParams struct {
+	PageQuery
+	Name string `query:"name" validate:"max=255"`
+}
+
+type AuthorUsecase interface {
+	Create(ctx context.Context, input *Author) (author *Author, err error)
+	DeleteByID(ctx context.Context, ID int64) (err error)
+	FindByID(ctx context.Context, ID int64) (author *Author, err error)
+	FindAll(ctx context.Context, query GetAuthorsQueryParams) (authors []*Author, count int64, err error)
+	FindAllByBookID(ctx context.Context, bookID int64) (authors []*Author, err error)
+	Update(ctx context.Context, input *Author) (author *Author, err error)
+	ReplaceBookAuthors(ctx context.Context, input ReplaceBookAuthorsInput) (authors []*Author, err error)
+}
+
+type AuthorRepository interface {
+	Create(ctx context.Context, input *Author) (err error)
+	DeleteByID(ctx context.Context, ID int64) (err error)
+	FindByID(ctx context.Context, ID int64) (author *Author, err error)
+	FindAll(ctx context.Context, query GetAuthorsQueryParams) (authors []*Author, err error)
+	CountAll(ctx context.Context, query GetAuthorsQueryParams) (count int64, err error)
+	FindAllByBookID(ctx context.Context, bookID int64) (authors []*Author, err error)
+	Update(ctx context.Context, input *Author) (author *Author, err error)
+	ReplaceBookAuthors(ctx context.Context, bookID int64, authorIDs []int64) (err error)
+}
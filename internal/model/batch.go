@@ -0,0 +1,80 @@
+package model
+
+import "time"
+
+// BatchMode tells how a batch request handles the failure of one of its items
+type BatchMode string
+
+const (
+	// BatchTransactional applies every item or none of them
+	BatchTransactional BatchMode = "transactional"
+	// BatchBestEffort applies the valid items and reports the failure of the others
+	BatchBestEffort BatchMode = "best_effort"
+)
+
+// Atomic reports whether the batch must be applied all-or-nothing, which is the default
+func (m BatchMode) Atomic() bool {
+	return m != BatchBestEffort
+}
+
+type BatchCreateBooksInput struct {
+	Mode  BatchMode         `json:"mode" validate:"omitempty,oneof=transactional best_effort"`
+	Books []CreateBookInput `json:"books" validate:"required,min=1"`
+}
+
+// BatchUpdateBookInput is a full replace of a single book of a batch, a
+// non zero Version makes the update conditional on the current version
+type BatchUpdateBookInput struct {
+	ID             int64  `json:"id" validate:"required,min=1"`
+	Version        int64  `json:"version"`
+	Title          string `json:"title" validate:"required,notblank,max=255"`
+	Author         string `json:"author" validate:"max=255"`
+	Description    string `json:"description" validate:"max=5000"`
+	PublishedDate  string `json:"published_date" validate:"omitempty,date"`
+	PublisherID    *int64 `json:"publisher_id" validate:"omitempty,min=1"`
+	SeriesID       *int64 `json:"series_id" validate:"omitempty,min=1"`
+	SeriesPosition *int   `json:"series_position" validate:"omitempty,min=1,excluded_without=SeriesID"`
+	WorkID         int64  `json:"work_id" validate:"min=0"`
+	GenreID        *int64 `json:"genre_id" validate:"omitempty,min=1"`
+	ISBN13         string `json:"isbn13" validate:"omitempty,isbn"`
+	ISBN10         string `json:"isbn10" validate:"omitempty,isbn10,sameisbn=ISBN13"`
+}
+
+func (i BatchUpdateBookInput) ToModel() *Book {
+	isbn13, isbn10 := bookISBNs(i.ISBN13, i.ISBN10)
+	return &Book{
+		ID:             i.ID,
+		Title:          i.Title,
+		Author:         i.Author,
+		Description:    i.Description,
+		PublishedDate:  i.PublishedDate,
+		PublisherID:    i.PublisherID,
+		SeriesID:       i.SeriesID,
+		SeriesPosition: i.SeriesPosition,
+		WorkID:         i.WorkID,
+		GenreID:        i.GenreID,
+		ISBN13:         isbn13,
+		ISBN10:         isbn10,
+		Version:        i.Version,
+		UpdatedAt:      time.Now(),
+	}
+}
+
+type BatchUpdateBooksInput struct {
+	Mode  BatchMode              `json:"mode" validate:"omitempty,oneof=transactional best_effort"`
+	Books []BatchUpdateBookInput `json:"books" validate:"required,min=1"`
+}
+
+type BatchDeleteBooksInput struct {
+	Mode BatchMode `json:"mode" validate:"omitempty,oneof=transactional best_effort"`
+	IDs  []int64   `json:"ids" validate:"required,min=1"`
+}
+
+// BatchItemResult is the outcome of a single item of a batch, in the
+// order of the request, Err is set when the item failed
+type BatchItemResult struct {
+	Index int
+	ID    int64
+	Book  *Book
+	Err   error
+}
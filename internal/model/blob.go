@@ -0,0 +1,17 @@
+package model
+
+import "context"
+
+// Blob is a binary object of a blob store along with its content type
+type Blob struct {
+	ContentType string
+	Body        []byte
+}
+
+// BlobStore keeps binary objects by key, such as the book covers, Get
+// returns a domainerr not found error for a missing key
+type BlobStore interface {
+	Put(ctx context.Context, key string, blob *Blob) (err error)
+	Get(ctx context.Context, key string) (blob *Blob, err error)
+	Delete(ctx context.Context, keys ...string) (err error)
+}
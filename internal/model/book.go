@@ -2,78 +2,454 @@ package model
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
 	"github.com/ssentinull/create-apis-using-golang/internal/utils"
 	"gorm.io/gorm"
 )
 
 type Book struct {
-	ID            int64          `json:"id"`
-	Title         string         `json:"title"`
-	Author        string         `json:"author"`
-	Description   string         `json:"description"`
-	PublishedDate string         `json:"published_date"`
-	CreatedAt     time.Time      `json:"created_at"`
-	UpdatedAt     time.Time      `json:"updated_at"`
-	DeletedAt     gorm.DeletedAt `json:"deleted_at"`
+	ID                   int64          `json:"id"`
+	Title                string         `json:"title" validate:"required,notblank,max=255"`
+	Author               string         `json:"author" validate:"max=255"`
+	Description          string         `json:"description" validate:"max=5000"`
+	PublishedDate        string         `json:"published_date" validate:"omitempty,date"`
+	PublisherID          *int64         `json:"publisher_id" validate:"omitempty,min=1"`
+	SeriesID             *int64         `json:"series_id" validate:"omitempty,min=1"`
+	SeriesPosition       *int           `json:"series_position" validate:"omitempty,min=1,excluded_without=SeriesID"`
+	WorkID               int64          `json:"work_id" validate:"min=0"`
+	GenreID              *int64         `json:"genre_id" validate:"omitempty,min=1"`
+	ISBN13               *string        `json:"isbn13" validate:"omitempty,isbn13"`
+	ISBN10               *string        `json:"isbn10" validate:"omitempty,isbn10"`
+	CoverURL             *string        `json:"cover_url" gorm:"-"`
+	CoverUpdatedAt       *time.Time     `json:"-"`
+	AverageRating        float64        `json:"average_rating" gorm:"->"`
+	RatingsCount         int64          `json:"ratings_count" gorm:"->"`
+	CopiesCount          int64          `json:"copies_count" gorm:"->"`
+	AvailableCopiesCount int64          `json:"available_copies_count" gorm:"->"`
+	Version              int64          `json:"version"`
+	CreatedAt            time.Time      `json:"created_at"`
+	UpdatedAt            time.Time      `json:"updated_at"`
+	DeletedAt            gorm.DeletedAt `json:"deleted_at"`
 }
 
+// BeforeCreate starts the version of a new book at 1, a book without a work
+// is the first edition of a work of its own
+func (b *Book) BeforeCreate(tx *gorm.DB) error {
+	if b.Version == 0 {
+		b.Version = 1
+	}
+	if b.WorkID == 0 {
+		b.WorkID = b.ID
+	}
+	return nil
+}
+
+// AfterFind trims the published date, which the postgres driver scans as a
+// timestamp, and points the cover URL at the current cover
+func (b *Book) AfterFind(tx *gorm.DB) error {
+	if publishedDate, err := time.Parse(time.RFC3339, b.PublishedDate); err == nil {
+		b.PublishedDate = publishedDate.Format(utils.DateLayout)
+	}
+	if b.CoverUpdatedAt != nil {
+		coverURL := b.coverURL()
+		b.CoverURL = &coverURL
+	}
+	return nil
+}
+
+// coverURL is versioned by the upload time of the cover, so that the
+// cover can be cached for good
+func (b *Book) coverURL() string {
+	return fmt.Sprintf("/v1/books/%d/cover?v=%s", b.ID, b.CoverVersion())
+}
+
+// CoverVersion is the version of the current cover of the book, as put in
+// its cover URL
+func (b *Book) CoverVersion() string {
+	if b.CoverUpdatedAt == nil {
+		return ""
+	}
+	return FormatCoverVersion(*b.CoverUpdatedAt)
+}
+
+// CreateBookInput is a new book, with Enrich its blank fields are filled in
+// from the metadata of its ISBN so the title may be left out
 type CreateBookInput struct {
-	Title         string `json:"title"`
-	Author        string `json:"author"`
-	Description   string `json:"description"`
-	PublishedDate string `json:"published_date"`
+	Enrich         bool   `json:"-"`
+	Title          string `json:"title" validate:"required_without=Enrich,omitempty,notblank,max=255"`
+	Author         string `json:"author" validate:"max=255"`
+	Description    string `json:"description" validate:"max=5000"`
+	PublishedDate  string `json:"published_date" validate:"omitempty,date"`
+	PublisherID    *int64 `json:"publisher_id" validate:"omitempty,min=1"`
+	SeriesID       *int64 `json:"series_id" validate:"omitempty,min=1"`
+	SeriesPosition *int   `json:"series_position" validate:"omitempty,min=1,excluded_without=SeriesID"`
+	WorkID         int64  `json:"work_id" validate:"min=0"`
+	GenreID        *int64 `json:"genre_id" validate:"omitempty,min=1"`
+	ISBN13         string `json:"isbn13" validate:"omitempty,isbn"`
+	ISBN10         string `json:"isbn10" validate:"omitempty,isbn10,sameisbn=ISBN13"`
 }
 
 func (i CreateBookInput) ToModel() *Book {
+	isbn13, isbn10 := bookISBNs(i.ISBN13, i.ISBN10)
 	return &Book{
-		ID:            utils.GenerateID(),
-		Title:         i.Title,
-		Author:        i.Author,
-		Description:   i.Description,
-		PublishedDate: i.PublishedDate,
-		CreatedAt:     time.Now(),
+		ID:             utils.GenerateID(),
+		Title:          i.Title,
+		Author:         i.Author,
+		Description:    i.Description,
+		PublishedDate:  i.PublishedDate,
+		PublisherID:    i.PublisherID,
+		SeriesID:       i.SeriesID,
+		SeriesPosition: i.SeriesPosition,
+		WorkID:         i.WorkID,
+		GenreID:        i.GenreID,
+		ISBN13:         isbn13,
+		ISBN10:         isbn10,
+		CreatedAt:      time.Now(),
 	}
 }
 
+// UpdateBookInput replaces every editable field of a book, a non zero
+// Version makes the update conditional on the current version and a zero
+// WorkID makes the book the first edition of a work of its own
 type UpdateBookInput struct {
-	ID            int64  `json:"id"`
-	Title         string `json:"title"`
-	Author        string `json:"author"`
-	Description   string `json:"description"`
-	PublishedDate string `json:"published_date"`
+	ID             int64  `json:"-" validate:"required,min=1"`
+	Version        int64  `json:"-"`
+	Title          string `json:"title" validate:"required,notblank,max=255"`
+	Author         string `json:"author" validate:"max=255"`
+	Description    string `json:"description" validate:"max=5000"`
+	PublishedDate  string `json:"published_date" validate:"omitempty,date"`
+	PublisherID    *int64 `json:"publisher_id" validate:"omitempty,min=1"`
+	SeriesID       *int64 `json:"series_id" validate:"omitempty,min=1"`
+	SeriesPosition *int   `json:"series_position" validate:"omitempty,min=1,excluded_without=SeriesID"`
+	WorkID         int64  `json:"work_id" validate:"min=0"`
+	GenreID        *int64 `json:"genre_id" validate:"omitempty,min=1"`
+	ISBN13         string `json:"isbn13" validate:"omitempty,isbn"`
+	ISBN10         string `json:"isbn10" validate:"omitempty,isbn10,sameisbn=ISBN13"`
 }
 
 func (i UpdateBookInput) ToModel() *Book {
+	isbn13, isbn10 := bookISBNs(i.ISBN13, i.ISBN10)
 	return &Book{
-		ID:            i.ID,
-		Title:         i.Title,
-		Author:        i.Author,
-		Description:   i.Description,
-		PublishedDate: i.PublishedDate,
-		UpdatedAt:     time.Now(),
+		ID:             i.ID,
+		Title:          i.Title,
+		Author:         i.Author,
+		Description:    i.Description,
+		PublishedDate:  i.PublishedDate,
+		PublisherID:    i.PublisherID,
+		SeriesID:       i.SeriesID,
+		SeriesPosition: i.SeriesPosition,
+		WorkID:         i.WorkID,
+		GenreID:        i.GenreID,
+		ISBN13:         isbn13,
+		ISBN10:         isbn10,
+		Version:        i.Version,
+		UpdatedAt:      time.Now(),
+	}
+}
+
+// PatchBookInput is a partial update of a book, Document is either a merge
+// patch or a JSON patch, as told by Type, where a null clears the field.
+// A non zero Version makes the patch conditional on the current version
+type PatchBookInput struct {
+	Type     PatchType
+	Document []byte
+	Version  int64
+}
+
+// Apply patches the editable fields of book and returns them as a full replace
+// input, the ISBN-10 is left out as it is derived from the ISBN-13 anyway
+func (i PatchBookInput) Apply(book *Book) (*UpdateBookInput, error) {
+	doc, err := json.Marshal(UpdateBookInput{
+		Title:          book.Title,
+		Author:         book.Author,
+		Description:    book.Description,
+		PublishedDate:  book.PublishedDate,
+		PublisherID:    book.PublisherID,
+		SeriesID:       book.SeriesID,
+		SeriesPosition: book.SeriesPosition,
+		WorkID:         book.WorkID,
+		GenreID:        book.GenreID,
+		ISBN13:         stringValue(book.ISBN13),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	patched, err := ApplyPatch(doc, i.Type, i.Document)
+	if err != nil {
+		return nil, err
 	}
+
+	input := &UpdateBookInput{}
+	if err := json.Unmarshal(patched, input); err != nil {
+		return nil, domainerr.Validation("patched book is invalid", err)
+	}
+
+	input.ID = book.ID
+	input.Version = book.Version
+	return input, nil
+}
+
+// bookISBNs normalizes the ISBNs of a book input, an ISBN-10 given on its
+// own fills in the ISBN-13 and the stored ISBN-10 is derived from the ISBN-13
+func bookISBNs(isbn13, isbn10 string) (*string, *string) {
+	if isbn13 == "" {
+		isbn13 = isbn10
+	}
+	if isbn13 == "" {
+		return nil, nil
+	}
+
+	isbn13 = utils.NormalizeISBN(isbn13)
+	if isbn10 = utils.ISBN10(isbn13); isbn10 == "" {
+		return &isbn13, nil
+	}
+	return &isbn13, &isbn10
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
 }
 
 type GetBooksQueryParams struct {
-	Page int64 `query:"page"`
-	Size int64 `query:"size"`
+	Page          int64  `query:"page"`
+	Size          int64  `query:"size"`
+	Author        string `query:"author" validate:"max=255"`
+	AuthorPrefix  string `query:"author_prefix" validate:"max=255"`
+	Title         string `query:"title" validate:"max=255"`
+	PublishedFrom string `query:"published_from" validate:"omitempty,date"`
+	PublishedTo   string `query:"published_to" validate:"omitempty,date"`
+	Sort          string `query:"sort" validate:"max=255"`
+	Q             string `query:"q" validate:"max=255"`
+	Cursor        string `query:"cursor"`
+	Limit         int64  `query:"limit"`
+	Facets        string `query:"facets" validate:"max=255"`
+	AuthorID      int64  `query:"author_id" validate:"min=0"`
+	Genre         int64  `query:"genre" validate:"min=0"`
+	Tags          string `query:"tags" validate:"max=1000"`
+	TagsMatch     string `query:"tags_match" validate:"omitempty,oneof=any all"`
+}
+
+// Normalize fills in the pagination defaults and caps the page size, or
+// the limit in cursor mode, at maxSize
+func (q *GetBooksQueryParams) Normalize(defaultSize, maxSize int64) {
+	if q.CursorMode() {
+		q.Limit = NormalizePageSize(q.Limit, defaultSize, maxSize)
+		return
+	}
+
+	if q.Page < 1 {
+		q.Page = 1
+	}
+
+	q.Size = NormalizePageSize(q.Size, defaultSize, maxSize)
+}
+
+// CursorMode reports whether the books should be paginated by cursor instead of page
+func (q GetBooksQueryParams) CursorMode() bool {
+	return q.Cursor != "" || q.Limit > 0
 }
 
+// SortField is a single column of the sort query param, eg: -published_date
+type SortField struct {
+	Column string
+	Desc   bool
+}
+
+// sortableBookColumns whitelists the columns that books can be sorted by
+var sortableBookColumns = map[string]bool{
+	"id":             true,
+	"title":          true,
+	"author":         true,
+	"published_date": true,
+	"created_at":     true,
+	"updated_at":     true,
+	"average_rating": true,
+	"ratings_count":  true,
+}
+
+// SortFields parses the comma separated sort query param, a leading "-"
+// sorts the column in descending order
+func (q GetBooksQueryParams) SortFields() ([]SortField, error) {
+	fields := []SortField{}
+	if strings.TrimSpace(q.Sort) == "" {
+		return fields, nil
+	}
+
+	for _, param := range strings.Split(q.Sort, ",") {
+		param = strings.TrimSpace(param)
+		field := SortField{Column: strings.TrimPrefix(param, "-"), Desc: strings.HasPrefix(param, "-")}
+		if !sortableBookColumns[field.Column] {
+			return nil, domainerr.ValidationFields("request contains invalid fields", map[string]string{
+				"sort": fmt.Sprintf("cannot sort by %q", field.Column),
+			})
+		}
+
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+// KeysetFields returns the sort fields followed by the id tiebreaker,
+// which together uniquely identify the position of a book in the listing
+func (q GetBooksQueryParams) KeysetFields() ([]SortField, error) {
+	fields, err := q.SortFields()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, field := range fields {
+		if field.Column == "id" {
+			return fields, nil
+		}
+	}
+
+	return append(fields, SortField{Column: "id", Desc: true}), nil
+}
+
+// ParseCursor decodes the cursor query param and checks that it was
+// issued for the same sort as the current query, with a value of the
+// right type for every keyset column
+func (q GetBooksQueryParams) ParseCursor() (Cursor, error) {
+	cursor, err := DecodeCursor(q.Cursor)
+	if err != nil || q.Cursor == "" {
+		return cursor, err
+	}
+
+	fields, err := q.KeysetFields()
+	if err != nil {
+		return cursor, err
+	}
+
+	if cursor.Sort != q.Sort || len(cursor.Values) != len(fields) {
+		return cursor, domainerr.ValidationFields("request contains invalid fields", map[string]string{
+			"cursor": "does not match the sort",
+		})
+	}
+
+	for i, field := range fields {
+		if !validSortValue(field.Column, cursor.Values[i]) {
+			return cursor, domainerr.ValidationFields("request contains invalid fields", map[string]string{
+				"cursor": "is invalid",
+			})
+		}
+	}
+
+	return cursor, nil
+}
+
+// validSortValue reports whether value, taken from a cursor, parses as the
+// type of column, the values are compared against the column in the database
+func validSortValue(column, value string) bool {
+	var err error
+	switch column {
+	case "id", "ratings_count":
+		_, err = strconv.ParseInt(value, 10, 64)
+	case "average_rating":
+		_, err = strconv.ParseFloat(value, 64)
+	case "published_date":
+		_, err = time.Parse(utils.DateLayout, value)
+	case "created_at", "updated_at":
+		_, err = time.Parse(time.RFC3339Nano, value)
+	}
+
+	return err == nil
+}
+
+// SortValue returns the value of column in its cursor representation
+func (b *Book) SortValue(column string) string {
+	switch column {
+	case "title":
+		return b.Title
+	case "author":
+		return b.Author
+	case "published_date":
+		if b.PublishedDate == "" {
+			return NullPublishedDate
+		}
+		return b.PublishedDate
+	case "created_at":
+		return b.CreatedAt.Format(time.RFC3339Nano)
+	case "updated_at":
+		return b.UpdatedAt.Format(time.RFC3339Nano)
+	case "average_rating":
+		return strconv.FormatFloat(b.AverageRating, 'f', -1, 64)
+	case "ratings_count":
+		return strconv.FormatInt(b.RatingsCount, 10)
+	default:
+		return strconv.FormatInt(b.ID, 10)
+	}
+}
+
+// NewBookCursor creates the cursor pointing at book for the given keyset fields
+func NewBookCursor(book *Book, fields []SortField, sort string, backward bool) string {
+	values := make([]string, 0, len(fields))
+	for _, field := range fields {
+		values = append(values, book.SortValue(field.Column))
+	}
+
+	return Cursor{Sort: sort, Values: values, Backward: backward}.Encode()
+}
+
+// NullPublishedDate sorts books without a published date before any other book
+const NullPublishedDate = "0001-01-01"
+
 type BookUsecase interface {
-	Create(ctx context.Context, input *Book) (book *Book, err error)
-	DeleteByID(ctx context.Context, ID int64) (err error)
+	Create(ctx context.Context, input *Book, enrich bool) (book *Book, err error)
+	DeleteByID(ctx context.Context, ID, version int64) (err error)
+	HardDeleteByID(ctx context.Context, ID, version int64) (err error)
+	Restore(ctx context.Context, ID int64) (book *Book, err error)
+	PurgeTrash(ctx context.Context) (count int64, err error)
 	FindByID(ctx context.Context, ID int64) (book *Book, err error)
+	FindByISBN(ctx context.Context, isbn string) (book *Book, err error)
+	FindVersionByID(ctx context.Context, ID int64) (version int64, err error)
 	FindAll(ctx context.Context, query GetBooksQueryParams) (books []*Book, count int64, err error)
+	FindAllByCursor(ctx context.Context, query GetBooksQueryParams) (books []*Book, cursors CursorPagination, count int64, err error)
+	FindAllTrashed(ctx context.Context, query GetBooksQueryParams) (books []*Book, count int64, err error)
+	FindAllByWorkID(ctx context.Context, workID int64) (books []*Book, err error)
+	FindFacets(ctx context.Context, query GetBooksQueryParams) (facets map[string][]*FacetCount, err error)
+	Search(ctx context.Context, query SearchBooksQueryParams) (results []*BookSearchResult, count int64, err error)
+	Suggest(ctx context.Context, query SuggestBooksQueryParams) (suggestions []*BookSuggestion, err error)
 	Update(ctx context.Context, input *Book) (book *Book, err error)
+	Patch(ctx context.Context, ID int64, input PatchBookInput) (book *Book, err error)
+	BatchCreate(ctx context.Context, input BatchCreateBooksInput) (results []BatchItemResult, err error)
+	BatchUpdate(ctx context.Context, input BatchUpdateBooksInput) (results []BatchItemResult, err error)
+	BatchDelete(ctx context.Context, input BatchDeleteBooksInput) (results []BatchItemResult, err error)
 }
 
 type BookRepository interface {
 	Create(ctx context.Context, input *Book) (err error)
-	DeleteByID(ctx context.Context, ID int64) (err error)
+	DeleteByID(ctx context.Context, ID, version int64) (err error)
+	HardDeleteByID(ctx context.Context, ID, version int64) (book *Book, err error)
+	Restore(ctx context.Context, ID int64) (book *Book, err error)
+	PurgeTrashed(ctx context.Context, deletedBefore time.Time) (books []*Book, err error)
 	FindByID(ctx context.Context, ID int64) (book *Book, err error)
+	FindByISBN(ctx context.Context, isbn13 string) (book *Book, err error)
+	FindVersionByID(ctx context.Context, ID int64) (version int64, err error)
 	FindAll(ctx context.Context, query GetBooksQueryParams) (books []*Book, err error)
-	CountAll(ctx context.Context) (count int64, err error)
+	FindAllByCursor(ctx context.Context, query GetBooksQueryParams) (books []*Book, hasMore bool, err error)
+	FindAllTrashed(ctx context.Context, query GetBooksQueryParams) (books []*Book, err error)
+	FindAllByWorkID(ctx context.Context, workID int64) (books []*Book, err error)
+	FindAllBySeriesID(ctx context.Context, seriesID int64) (books []*Book, err error)
+	CountAll(ctx context.Context, query GetBooksQueryParams) (count int64, err error)
+	CountAllTrashed(ctx context.Context, query GetBooksQueryParams) (count int64, err error)
+	CountByFacet(ctx context.Context, query GetBooksQueryParams, facet string) (counts []*FacetCount, err error)
+	Search(ctx context.Context, query SearchBooksQueryParams) (results []*BookSearchResult, err error)
+	CountSearch(ctx context.Context, query SearchBooksQueryParams) (count int64, err error)
+	Suggest(ctx context.Context, query SuggestBooksQueryParams) (suggestions []*BookSuggestion, err error)
 	Update(ctx context.Context, input *Book) (book *Book, err error)
+	UpdateCover(ctx context.Context, ID int64, coverUpdatedAt time.Time) (book *Book, err error)
+	BatchCreate(ctx context.Context, books []*Book, atomic bool) (errs []error, err error)
+	BatchUpdate(ctx context.Context, books []*Book, atomic bool) (updated []*Book, errs []error, err error)
+	BatchDelete(ctx context.Context, IDs []int64, atomic bool) (errs []error, err error)
 }
@@ -0,0 +1,92 @@
+package model
+
+import (
+	"context"
+	"time"
+
+	"github.com/ssentinull/create-apis-using-golang/internal/utils"
+	"gorm.io/gorm"
+)
+
+const (
+	// BookCopyStatusAvailable :nodoc:
+	BookCopyStatusAvailable = "available"
+	// BookCopyStatusOnLoan :nodoc:
+	BookCopyStatusOnLoan = "on_loan"
+	// BookCopyStatusReserved is a copy waiting to be picked up by the
+	// member of a ready hold
+	BookCopyStatusReserved = "reserved"
+)
+
+// BookCopy is a physical copy of a book on the shelf, its status is only
+// changed by the loans and the holds of the copy
+type BookCopy struct {
+	ID        int64          `json:"id"`
+	BookID    int64          `json:"book_id" validate:"required,min=1"`
+	Barcode   string         `json:"barcode" validate:"required,notblank,max=64"`
+	Condition string         `json:"condition" validate:"required,oneof=new good fair poor damaged"`
+	Status    string         `json:"status" validate:"required,oneof=available on_loan reserved"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"deleted_at"`
+}
+
+// IsAvailable reports whether the copy can be checked out
+func (c *BookCopy) IsAvailable() bool {
+	return c.Status == BookCopyStatusAvailable
+}
+
+// CreateBookCopyInput is a new copy of a book, which is available unless
+// it goes to the first hold in the queue of the book
+type CreateBookCopyInput struct {
+	BookID    int64  `json:"-" validate:"required,min=1"`
+	Barcode   string `json:"barcode" validate:"required,notblank,max=64"`
+	Condition string `json:"condition" validate:"required,oneof=new good fair poor damaged"`
+}
+
+func (i CreateBookCopyInput) ToModel() *BookCopy {
+	return &BookCopy{
+		ID:        utils.GenerateID(),
+		BookID:    i.BookID,
+		Barcode:   i.Barcode,
+		Condition: i.Condition,
+		Status:    BookCopyStatusAvailable,
+		CreatedAt: time.Now(),
+	}
+}
+
+// UpdateBookCopyInput replaces every editable field of a copy
+type UpdateBookCopyInput struct {
+	ID        int64  `json:"-" validate:"required,min=1"`
+	Barcode   string `json:"barcode" validate:"required,notblank,max=64"`
+	Condition string `json:"condition" validate:"required,oneof=new good fair poor damaged"`
+}
+
+// GetBookCopiesQueryParams lists the copies of a book, Status narrows them
+// down to the copies in that status
+type GetBookCopiesQueryParams struct {
+	BookID int64 `query:"-" validate:"required,min=1"`
+	PageQuery
+	Status string `query:"status" validate:"omitempty,oneof=available on_loan reserved"`
+}
+
+type BookCopyUsecase interface {
+	Create(ctx context.Context, bookCopy *BookCopy) (created *BookCopy, err error)
+	DeleteByID(ctx context.Context, ID int64) (err error)
+	FindByID(ctx context.Context, ID int64) (bookCopy *BookCopy, err error)
+	FindAllByBookID(ctx context.Context, query GetBookCopiesQueryParams) (copies []*BookCopy, count int64, err error)
+	Update(ctx context.Context, input UpdateBookCopyInput) (bookCopy *BookCopy, err error)
+}
+
+// BookCopyRepository keeps the copy counts of the books in step with their
+// copies, in the same transaction as every copy write. A new copy of a
+// book with a queue is reserved for the first hold until pickupBy
+type BookCopyRepository interface {
+	Create(ctx context.Context, bookCopy *BookCopy, pickupBy time.Time) (err error)
+	DeleteByID(ctx context.Context, ID int64) (err error)
+	FindByID(ctx context.Context, ID int64) (bookCopy *BookCopy, err error)
+	FindByBarcode(ctx context.Context, barcode string) (bookCopy *BookCopy, err error)
+	FindAllByBookID(ctx context.Context, query GetBookCopiesQueryParams) (copies []*BookCopy, err error)
+	CountAllByBookID(ctx context.Context, query GetBookCopiesQueryParams) (count int64, err error)
+	Update(ctx context.Context, input UpdateBookCopyInput) (bookCopy *BookCopy, err error)
+}
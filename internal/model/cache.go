@@ -1,10 +1,15 @@
 package model
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type CacheRepository interface {
 	Get(ctx context.Context, key string) (reply string, err error)
 	Set(ctx context.Context, key, val string) (err error)
+	SetWithTTL(ctx context.Context, key, val string, ttl time.Duration) (err error)
+	SetNX(ctx context.Context, key, val string, ttl time.Duration) (ok bool, err error)
 	Delete(ctx context.Context, keys ...string) (err error)
 	HashGet(ctx context.Context, hash, key string) (reply string, err error)
 	HashSet(ctx context.Context, hash, key, val string) (err error)
@@ -0,0 +1,56 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+const (
+	// CoverSizeOriginal :nodoc:
+	CoverSizeOriginal = "original"
+	// CoverSizeThumb :nodoc:
+	CoverSizeThumb = "thumb"
+)
+
+// GetCoverQueryParams :nodoc:, Version is the cover version put in the
+// cover_url of a book, a versioned cover never changes
+type GetCoverQueryParams struct {
+	Size    string `query:"size" validate:"omitempty,oneof=original thumb"`
+	Version string `query:"v"`
+}
+
+// Cover is a cover of a book in a size, Version is the version the cover_url
+// of the book currently points at
+type Cover struct {
+	Blob
+	Version string
+}
+
+// CoverKey is the blob store key of a version of the cover of a book in
+// size, every upload is stored under a new version so that a versioned cover
+// URL never points at different bytes
+func CoverKey(bookID int64, version, size string) string {
+	if size == "" {
+		size = CoverSizeOriginal
+	}
+	return fmt.Sprintf("covers/%d/%s/%s", bookID, version, size)
+}
+
+// CoverKeys returns the blob store keys of every size of a version of the
+// cover of a book
+func CoverKeys(bookID int64, version string) []string {
+	return []string{CoverKey(bookID, version, CoverSizeOriginal), CoverKey(bookID, version, CoverSizeThumb)}
+}
+
+// FormatCoverVersion is the version of a cover uploaded at updatedAt, as
+// put in the cover URL of its book
+func FormatCoverVersion(updatedAt time.Time) string {
+	return strconv.FormatInt(updatedAt.UnixMicro(), 10)
+}
+
+type CoverUsecase interface {
+	Upload(ctx context.Context, bookID int64, image []byte) (book *Book, err error)
+	FindByBookID(ctx context.Context, bookID int64, size string) (cover *Cover, err error)
+}
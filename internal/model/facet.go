@@ -0,0 +1,52 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+)
+
+const (
+	// AuthorFacet counts the books per author
+	AuthorFacet = "author"
+	// DecadeFacet counts the books per decade of their published date, eg: 1990
+	DecadeFacet = "decade"
+)
+
+// bookFacets whitelists the facets that books can be counted by
+var bookFacets = map[string]bool{
+	AuthorFacet: true,
+	DecadeFacet: true,
+}
+
+// FacetCount is the number of books sharing the same value of a facet
+type FacetCount struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+// FacetFields parses the comma separated facets query param, duplicates are dropped
+func (q GetBooksQueryParams) FacetFields() ([]string, error) {
+	facets := []string{}
+	if strings.TrimSpace(q.Facets) == "" {
+		return facets, nil
+	}
+
+	seen := map[string]bool{}
+	for _, facet := range strings.Split(q.Facets, ",") {
+		facet = strings.TrimSpace(facet)
+		if !bookFacets[facet] {
+			return nil, domainerr.ValidationFields("request contains invalid fields", map[string]string{
+				"facets": fmt.Sprintf("cannot count by %q", facet),
+			})
+		}
+
+		if !seen[facet] {
+			seen[facet] = true
+			facets = append(facets, facet)
+		}
+	}
+
+	return facets, nil
+}
@@ -0,0 +1,122 @@
+package model
+
+import (
+	"context"
+	"time"
+
+	"github.com/ssentinull/create-apis-using-golang/internal/utils"
+)
+
+const (
+	// FineKindAccrual :nodoc:
+	FineKindAccrual = "accrual"
+	// FineKindPayment :nodoc:
+	FineKindPayment = "payment"
+	// FineKindWaiver :nodoc:
+	FineKindWaiver = "waiver"
+)
+
+// Fine is an entry in the fines ledger of a member. An accrual charges the
+// member for an overdue loan, a payment or a waiver settles the charges.
+// The balance of a member is its accruals minus its payments and waivers
+type Fine struct {
+	ID          int64     `json:"id"`
+	MemberID    int64     `json:"member_id" validate:"required,min=1"`
+	LoanID      *int64    `json:"loan_id"`
+	Kind        string    `json:"kind" validate:"required,oneof=accrual payment waiver"`
+	AmountCents int64     `json:"amount_cents" validate:"required,min=1"`
+	OverdueDays int64     `json:"overdue_days"`
+	Note        string    `json:"note" validate:"max=500"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// FeeSchedule prices the overdue days of a loan. The days past the grace
+// period cost PerDayCents each, a loan is fined CapCents at most unless it
+// is zero
+type FeeSchedule struct {
+	PerDayCents     int64
+	GracePeriodDays int64
+	CapCents        int64
+}
+
+// Fine returns the whole days loan is overdue by at now, or by its return
+// if it is returned, and the fine owed for them
+func (s FeeSchedule) Fine(loan *Loan, now time.Time) (overdueDays, amountCents int64) {
+	end := now
+	if loan.ReturnedAt != nil {
+		end = *loan.ReturnedAt
+	}
+
+	if !end.After(loan.DueAt) {
+		return 0, 0
+	}
+
+	overdueDays = int64(end.Sub(loan.DueAt) / (24 * time.Hour))
+	if overdueDays <= s.GracePeriodDays {
+		return overdueDays, 0
+	}
+
+	amountCents = (overdueDays - s.GracePeriodDays) * s.PerDayCents
+	if s.CapCents > 0 && amountCents > s.CapCents {
+		amountCents = s.CapCents
+	}
+
+	return overdueDays, amountCents
+}
+
+// SettleFinesInput pays or waives an amount of the balance of a member
+type SettleFinesInput struct {
+	MemberID    int64  `json:"-" validate:"required,min=1"`
+	Kind        string `json:"kind" validate:"required,oneof=payment waiver"`
+	AmountCents int64  `json:"amount_cents" validate:"required,min=1"`
+	Note        string `json:"note" validate:"max=500"`
+}
+
+func (i SettleFinesInput) ToModel() *Fine {
+	return &Fine{
+		ID:          utils.GenerateID(),
+		MemberID:    i.MemberID,
+		Kind:        i.Kind,
+		AmountCents: i.AmountCents,
+		Note:        i.Note,
+	}
+}
+
+// GetFinesQueryParams lists the fines ledger of a member, the latest first
+type GetFinesQueryParams struct {
+	MemberID int64 `query:"-" validate:"required,min=1"`
+	PageQuery
+}
+
+// FinesResponse is a page of the fines ledger of a member along with its
+// balance
+type FinesResponse struct {
+	PaginationResponse
+	BalanceCents int64 `json:"balance_cents"`
+}
+
+type FineUsecase interface {
+	Settle(ctx context.Context, fine *Fine) (settled *Fine, err error)
+	FindAllByMemberID(ctx context.Context, query GetFinesQueryParams) (fines []*Fine, count int64, err error)
+	BalanceByMemberID(ctx context.Context, memberID int64) (balanceCents int64, err error)
+	AccrueOverdue(ctx context.Context) (count int64, err error)
+}
+
+// FineRepository keeps the fines ledger. The accruals of a loan are written
+// while holding the lock of the loan and the payments and waivers of a
+// member while holding the lock of the member, so that neither is counted
+// twice
+type FineRepository interface {
+	// Create records a payment or a waiver, which can't exceed the balance
+	// of its member
+	Create(ctx context.Context, fine *Fine) (err error)
+	// Accrue records the part of the accrual fine, the whole fine owed for
+	// loan, not accrued yet. The fines of a returned loan are closed
+	Accrue(ctx context.Context, loan *Loan, fine *Fine) (accrued bool, err error)
+	// FindAllAccruingLoans returns the loans due before dueBefore, open or
+	// returned late, whose fines are not closed
+	FindAllAccruingLoans(ctx context.Context, dueBefore time.Time) (loans []*Loan, err error)
+	FindAllByMemberID(ctx context.Context, query GetFinesQueryParams) (fines []*Fine, err error)
+	CountAllByMemberID(ctx context.Context, query GetFinesQueryParams) (count int64, err error)
+	BalanceByMemberID(ctx context.Context, memberID int64) (balanceCents int64, err error)
+}
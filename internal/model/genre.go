@@ -0,0 +1,95 @@
+package model
+
+import (
+	"context"
+	"time"
+
+	"github.com/ssentinull/create-apis-using-golang/internal/utils"
+	"gorm.io/gorm"
+)
+
+// Genre is a node of the genre tree, eg: Fantasy under Fiction. Children is
+// only filled in when the genres are returned as a tree
+type Genre struct {
+	ID        int64          `json:"id"`
+	Name      string         `json:"name" validate:"required,notblank,max=255"`
+	ParentID  *int64         `json:"parent_id" validate:"omitempty,min=1"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"deleted_at"`
+	Children  []*Genre       `json:"children,omitempty" gorm:"-"`
+}
+
+type CreateGenreInput struct {
+	Name     string `json:"name" validate:"required,notblank,max=255"`
+	ParentID *int64 `json:"parent_id" validate:"omitempty,min=1"`
+}
+
+func (i CreateGenreInput) ToModel() *Genre {
+	return &Genre{
+		ID:        utils.GenerateID(),
+		Name:      i.Name,
+		ParentID:  i.ParentID,
+		CreatedAt: time.Now(),
+	}
+}
+
+// UpdateGenreInput replaces every editable field of a genre, changing the
+// parent moves the genre along with its descendants
+type UpdateGenreInput struct {
+	ID       int64  `json:"-" validate:"required,min=1"`
+	Name     string `json:"name" validate:"required,notblank,max=255"`
+	ParentID *int64 `json:"parent_id" validate:"omitempty,min=1"`
+}
+
+func (i UpdateGenreInput) ToModel() *Genre {
+	return &Genre{
+		ID:        i.ID,
+		Name:      i.Name,
+		ParentID:  i.ParentID,
+		UpdatedAt: time.Now(),
+	}
+}
+
+// NewGenreTree nests the genres under their parents and returns the roots,
+// the order of the genres is kept among siblings. A genre whose parent isn't
+// part of genres is a root
+func NewGenreTree(genres []*Genre) []*Genre {
+	byID := make(map[int64]*Genre, len(genres))
+	for _, genre := range genres {
+		node := *genre
+		node.Children = nil
+		byID[genre.ID] = &node
+	}
+
+	roots := []*Genre{}
+	for _, genre := range genres {
+		node := byID[genre.ID]
+		if genre.ParentID != nil {
+			if parent, ok := byID[*genre.ParentID]; ok {
+				parent.Children = append(parent.Children, node)
+				continue
+			}
+		}
+
+		roots = append(roots, node)
+	}
+
+	return roots
+}
+
+type GenreUsecase interface {
+	Create(ctx context.Context, input *Genre) (genre *Genre, err error)
+	DeleteByID(ctx context.Context, ID int64) (err error)
+	FindByID(ctx context.Context, ID int64) (genre *Genre, err error)
+	FindTree(ctx context.Context) (genres []*Genre, err error)
+	Update(ctx context.Context, input *Genre) (genre *Genre, err error)
+}
+
+type GenreRepository interface {
+	Create(ctx context.Context, input *Genre) (err error)
+	DeleteByID(ctx context.Context, ID int64) (err error)
+	FindByID(ctx context.Context, ID int64) (genre *Genre, err error)
+	FindAll(ctx context.Context) (genres []*Genre, err error)
+	Update(ctx context.Context, input *Genre) (genre *Genre, err error)
+}
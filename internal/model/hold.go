@@ -0,0 +1,88 @@
+package model
+
+import (
+	"context"
+	"time"
+
+	"github.com/ssentinull/create-apis-using-golang/internal/utils"
+)
+
+const (
+	// HoldStatusWaiting :nodoc:
+	HoldStatusWaiting = "waiting"
+	// HoldStatusReady :nodoc:
+	HoldStatusReady = "ready"
+	// HoldStatusFulfilled :nodoc:
+	HoldStatusFulfilled = "fulfilled"
+	// HoldStatusCancelled :nodoc:
+	HoldStatusCancelled = "cancelled"
+	// HoldStatusExpired :nodoc:
+	HoldStatusExpired = "expired"
+)
+
+// Hold is the place of a member in the queue of a book whose copies are
+// all out. The hold waits until a copy comes back, the copy is then
+// reserved for the member until ExpiresAt and the hold is ready
+type Hold struct {
+	ID        int64      `json:"id"`
+	BookID    int64      `json:"book_id" validate:"required,min=1"`
+	MemberID  int64      `json:"member_id" validate:"required,min=1"`
+	CopyID    *int64     `json:"copy_id"`
+	Status    string     `json:"status" validate:"required,oneof=waiting ready fulfilled cancelled expired"`
+	ReadyAt   *time.Time `json:"ready_at"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// IsActive reports whether the hold is still in the queue of its book
+func (h *Hold) IsActive() bool {
+	return h.Status == HoldStatusWaiting || h.Status == HoldStatusReady
+}
+
+// PlaceHoldInput queues a member for a book
+type PlaceHoldInput struct {
+	BookID   int64 `json:"-" validate:"required,min=1"`
+	MemberID int64 `json:"member_id" validate:"required,min=1"`
+}
+
+func (i PlaceHoldInput) ToModel() *Hold {
+	return &Hold{
+		ID:        utils.GenerateID(),
+		BookID:    i.BookID,
+		MemberID:  i.MemberID,
+		Status:    HoldStatusWaiting,
+		CreatedAt: time.Now(),
+	}
+}
+
+// CancelHoldInput takes a member out of the queue of a book
+type CancelHoldInput struct {
+	BookID   int64 `query:"-" validate:"required,min=1"`
+	MemberID int64 `query:"member_id" validate:"required,min=1"`
+}
+
+// GetHoldsQueryParams lists the queue of a book, first come first served
+type GetHoldsQueryParams struct {
+	BookID int64 `query:"-" validate:"required,min=1"`
+	PageQuery
+}
+
+type HoldUsecase interface {
+	Place(ctx context.Context, hold *Hold) (placed *Hold, err error)
+	Cancel(ctx context.Context, input CancelHoldInput) (hold *Hold, err error)
+	FindAllByBookID(ctx context.Context, query GetHoldsQueryParams) (holds []*Hold, count int64, err error)
+	ExpireReady(ctx context.Context) (count int64, err error)
+}
+
+// HoldRepository changes the queue of a book only while holding the lock
+// of the book, so that a returned copy is never missed by a new hold nor
+// reserved for two members. A copy freed by a ready hold goes to the next
+// hold in the queue, which is ready for pickup until pickupBy
+type HoldRepository interface {
+	Create(ctx context.Context, hold *Hold) (err error)
+	Cancel(ctx context.Context, bookID, memberID int64, now, pickupBy time.Time) (hold *Hold, err error)
+	FindAllByBookID(ctx context.Context, query GetHoldsQueryParams) (holds []*Hold, err error)
+	CountAllByBookID(ctx context.Context, query GetHoldsQueryParams) (count int64, err error)
+	ExpireReady(ctx context.Context, now, pickupBy time.Time) (count int64, err error)
+}
@@ -0,0 +1,92 @@
+package model
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	// LoanStatusOpen :nodoc:
+	LoanStatusOpen = "open"
+	// LoanStatusReturned :nodoc:
+	LoanStatusReturned = "returned"
+	// LoanStatusAll lists the loans of a member in any status
+	LoanStatusAll = "all"
+)
+
+// Loan is a copy of a book checked out by a member, the loan is open
+// until the copy is returned
+type Loan struct {
+	ID           int64      `json:"id"`
+	CopyID       int64      `json:"copy_id"`
+	BookID       int64      `json:"book_id"`
+	MemberID     int64      `json:"member_id"`
+	CheckedOutAt time.Time  `json:"checked_out_at"`
+	DueAt        time.Time  `json:"due_at"`
+	ReturnedAt   *time.Time `json:"returned_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// IsOpen reports whether the copy of the loan is still out
+func (l *Loan) IsOpen() bool {
+	return l.ReturnedAt == nil
+}
+
+// CheckoutInput lends a copy, known by its ID or its barcode, to a member,
+// the loan is due after the loan period unless DueAt says otherwise
+type CheckoutInput struct {
+	CopyID   int64      `json:"copy_id" validate:"required_without=Barcode,omitempty,min=1"`
+	Barcode  string     `json:"barcode" validate:"required_without=CopyID,omitempty,notblank,max=64"`
+	MemberID int64      `json:"member_id" validate:"required,min=1"`
+	DueAt    *time.Time `json:"due_at"`
+}
+
+// UpdateLoanDueDateInput moves the due date of an open loan
+type UpdateLoanDueDateInput struct {
+	ID    int64     `json:"-" validate:"required,min=1"`
+	DueAt time.Time `json:"due_at" validate:"required"`
+}
+
+// GetLoansQueryParams lists the loans of a member, the newest first
+type GetLoansQueryParams struct {
+	MemberID int64  `query:"-" validate:"required,min=1"`
+	Page     int64  `query:"page"`
+	Size     int64  `query:"size"`
+	Status   string `query:"status" validate:"omitempty,oneof=open returned all"`
+}
+
+// Normalize fills in the pagination and status defaults and caps the page size at maxSize
+func (q *GetLoansQueryParams) Normalize(defaultSize, maxSize int64) {
+	if q.Page < 1 {
+		q.Page = 1
+	}
+
+	if q.Status == "" {
+		q.Status = LoanStatusAll
+	}
+
+	q.Size = NormalizePageSize(q.Size, defaultSize, maxSize)
+}
+
+type LoanUsecase interface {
+	Checkout(ctx context.Context, input CheckoutInput) (loan *Loan, err error)
+	Return(ctx context.Context, ID int64) (loan *Loan, err error)
+	UpdateDueDate(ctx context.Context, input UpdateLoanDueDateInput) (loan *Loan, err error)
+	FindByID(ctx context.Context, ID int64) (loan *Loan, err error)
+	FindAllByMemberID(ctx context.Context, query GetLoansQueryParams) (loans []*Loan, count int64, err error)
+}
+
+// LoanRepository locks the copy of a loan before every checkout and return,
+// so that a copy is never lent twice, and keeps the copy counts of the
+// books in step with the loans. A reserved copy is only lent to the member
+// of its hold, a returned copy is reserved for the first hold in the queue
+// of its book until pickupBy
+type LoanRepository interface {
+	Create(ctx context.Context, loan *Loan) (err error)
+	Return(ctx context.Context, ID int64, returnedAt, pickupBy time.Time) (loan *Loan, err error)
+	UpdateDueDate(ctx context.Context, ID int64, dueAt time.Time) (loan *Loan, err error)
+	FindByID(ctx context.Context, ID int64) (loan *Loan, err error)
+	FindAllByMemberID(ctx context.Context, query GetLoansQueryParams) (loans []*Loan, err error)
+	CountAllByMemberID(ctx context.Context, query GetLoansQueryParams) (count int64, err error)
+}
@@ -0,0 +1,74 @@
+package model
+
+import (
+	"context"
+	"time"
+
+	"github.com/ssentinull/create-apis-using-golang/internal/utils"
+	"gorm.io/gorm"
+)
+
+// Member borrows the copies of the books, a member is known by the
+// email, which is unique among the members that are not deleted
+type Member struct {
+	ID        int64          `json:"id"`
+	Name      string         `json:"name" validate:"required,notblank,max=255"`
+	Email     string         `json:"email" validate:"required,email,max=255"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"deleted_at"`
+}
+
+type CreateMemberInput struct {
+	Name  string `json:"name" validate:"required,notblank,max=255"`
+	Email string `json:"email" validate:"required,email,max=255"`
+}
+
+func (i CreateMemberInput) ToModel() *Member {
+	return &Member{
+		ID:        utils.GenerateID(),
+		Name:      i.Name,
+		Email:     i.Email,
+		CreatedAt: time.Now(),
+	}
+}
+
+// UpdateMemberInput replaces every editable field of a member
+type UpdateMemberInput struct {
+	ID    int64  `json:"-" validate:"required,min=1"`
+	Name  string `json:"name" validate:"required,notblank,max=255"`
+	Email string `json:"email" validate:"required,email,max=255"`
+}
+
+func (i UpdateMemberInput) ToModel() *Member {
+	return &Member{
+		ID:        i.ID,
+		Name:      i.Name,
+		Email:     i.Email,
+		UpdatedAt: time.Now(),
+	}
+}
+
+// GetMembersQueryParams lists the members, Q matches their name or email
+type GetMembersQueryParams struct {
+	PageQuery
+	Q string `query:"q" validate:"max=255"`
+}
+
+type MemberUsecase interface {
+	Create(ctx context.Context, input *Member) (member *Member, err error)
+	DeleteByID(ctx context.Context, ID int64) (err error)
+	FindByID(ctx context.Context, ID int64) (member *Member, err error)
+	FindAll(ctx context.Context, query GetMembersQueryParams) (members []*Member, count int64, err error)
+	Update(ctx context.Context, input *Member) (member *Member, err error)
+}
+
+// MemberRepository refuses to delete a member with open loans
+type MemberRepository interface {
+	Create(ctx context.Context, input *Member) (err error)
+	DeleteByID(ctx context.Context, ID int64) (err error)
+	FindByID(ctx context.Context, ID int64) (member *Member, err error)
+	FindAll(ctx context.Context, query GetMembersQueryParams) (members []*Member, err error)
+	CountAll(ctx context.Context, query GetMembersQueryParams) (count int64, err error)
+	Update(ctx context.Context, input *Member) (member *Member, err error)
+}
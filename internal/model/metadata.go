@@ -0,0 +1,64 @@
+package model
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/ssentinull/create-apis-using-golang/internal/utils"
+)
+
+// the limits of the book fields, the metadata is cut down to them since the
+// client cannot fix the fields it did not send
+const (
+	maxBookTitleLength       = 255
+	maxBookAuthorLength      = 255
+	maxBookDescriptionLength = 5000
+)
+
+// BookMetadata is what a metadata provider knows about the edition of an
+// ISBN, PublishedDate is either empty or in the utils.DateLayout format
+type BookMetadata struct {
+	ISBN13        string `json:"isbn13"`
+	Title         string `json:"title"`
+	Author        string `json:"author"`
+	Description   string `json:"description"`
+	PublishedDate string `json:"published_date"`
+}
+
+// Apply fills in the blank fields of book, the fields given by the client win.
+// The text fields are truncated to the limits of a book and a published date
+// that isn't a valid date is skipped, so that the metadata never fails the
+// validation of the book
+func (m BookMetadata) Apply(book *Book) {
+	if book.Title == "" {
+		book.Title = truncateText(m.Title, maxBookTitleLength)
+	}
+	if book.Author == "" {
+		book.Author = truncateText(m.Author, maxBookAuthorLength)
+	}
+	if book.Description == "" {
+		book.Description = truncateText(m.Description, maxBookDescriptionLength)
+	}
+	if _, err := time.Parse(utils.DateLayout, m.PublishedDate); book.PublishedDate == "" && err == nil {
+		book.PublishedDate = m.PublishedDate
+	}
+}
+
+// truncateText trims text and cuts it down to at most maxLength characters
+func truncateText(text string, maxLength int) string {
+	runes := []rune(strings.TrimSpace(text))
+	if len(runes) <= maxLength {
+		return string(runes)
+	}
+
+	return strings.TrimRightFunc(string(runes[:maxLength]), unicode.IsSpace)
+}
+
+// MetadataProvider looks up the metadata of a book in an external catalog, it
+// returns a domainerr not found error for an unknown ISBN and an unavailable
+// error when the catalog cannot be reached in time
+type MetadataProvider interface {
+	FindByISBN(ctx context.Context, isbn13 string) (metadata *BookMetadata, err error)
+}
@@ -0,0 +1,281 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/model/author.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/ssentinull/create-apis-using-golang/internal/model"
+)
+
+// MockAuthorUsecase is a mock of AuthorUsecase interface.
+type MockAuthorUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthorUsecaseMockRecorder
+}
+
+// MockAuthorUsecaseMockRecorder is the mock recorder for MockAuthorUsecase.
+type MockAuthorUsecaseMockRecorder struct {
+	mock *MockAuthorUsecase
+}
+
+// NewMockAuthorUsecase creates a new mock instance.
+func NewMockAuthorUsecase(ctrl *gomock.Controller) *MockAuthorUsecase {
+	mock := &MockAuthorUsecase{ctrl: ctrl}
+	mock.recorder = &MockAuthorUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthorUsecase) EXPECT() *MockAuthorUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAuthorUsecase) Create(ctx context.Context, input *model.Author) (*model.Author, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, input)
+	ret0, _ := ret[0].(*model.Author)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAuthorUsecaseMockRecorder) Create(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAuthorUsecase)(nil).Create), ctx, input)
+}
+
+// DeleteByID mocks base method.
+func (m *MockAuthorUsecase) DeleteByID(ctx context.Context, ID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, ID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockAuthorUsecaseMockRecorder) DeleteByID(ctx, ID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockAuthorUsecase)(nil).DeleteByID), ctx, ID)
+}
+
+// FindAll mocks base method.
+func (m *MockAuthorUsecase) FindAll(ctx context.Context, query model.GetAuthorsQueryParams) ([]*model.Author, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, query)
+	ret0, _ := ret[0].([]*model.Author)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockAuthorUsecaseMockRecorder) FindAll(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockAuthorUsecase)(nil).FindAll), ctx, query)
+}
+
+// FindAllByBookID mocks base method.
+func (m *MockAuthorUsecase) FindAllByBookID(ctx context.Context, bookID int64) ([]*model.Author, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByBookID", ctx, bookID)
+	ret0, _ := ret[0].([]*model.Author)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByBookID indicates an expected call of FindAllByBookID.
+func (mr *MockAuthorUsecaseMockRecorder) FindAllByBookID(ctx, bookID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByBookID", reflect.TypeOf((*MockAuthorUsecase)(nil).FindAllByBookID), ctx, bookID)
+}
+
+// FindByID mocks base method.
+func (m *MockAuthorUsecase) FindByID(ctx context.Context, ID int64) (*model.Author, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, ID)
+	ret0, _ := ret[0].(*model.Author)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockAuthorUsecaseMockRecorder) FindByID(ctx, ID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockAuthorUsecase)(nil).FindByID), ctx, ID)
+}
+
+// ReplaceBookAuthors mocks base method.
+func (m *MockAuthorUsecase) ReplaceBookAuthors(ctx context.Context, input model.ReplaceBookAuthorsInput) ([]*model.Author, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceBookAuthors", ctx, input)
+	ret0, _ := ret[0].([]*model.Author)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplaceBookAuthors indicates an expected call of ReplaceBookAuthors.
+func (mr *MockAuthorUsecaseMockRecorder) ReplaceBookAuthors(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceBookAuthors", reflect.TypeOf((*MockAuthorUsecase)(nil).ReplaceBookAuthors), ctx, input)
+}
+
+// Update mocks base method.
+func (m *MockAuthorUsecase) Update(ctx context.Context, input *model.Author) (*model.Author, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, input)
+	ret0, _ := ret[0].(*model.Author)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockAuthorUsecaseMockRecorder) Update(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockAuthorUsecase)(nil).Update), ctx, input)
+}
+
+// MockAuthorRepository is a mock of AuthorRepository interface.
+type MockAuthorRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthorRepositoryMockRecorder
+}
+
+// MockAuthorRepositoryMockRecorder is the mock recorder for MockAuthorRepository.
+type MockAuthorRepositoryMockRecorder struct {
+	mock *MockAuthorRepository
+}
+
+// NewMockAuthorRepository creates a new mock instance.
+func NewMockAuthorRepository(ctrl *gomock.Controller) *MockAuthorRepository {
+	mock := &MockAuthorRepository{ctrl: ctrl}
+	mock.recorder = &MockAuthorRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthorRepository) EXPECT() *MockAuthorRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountAll mocks base method.
+func (m *MockAuthorRepository) CountAll(ctx context.Context, query model.GetAuthorsQueryParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAll", ctx, query)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAll indicates an expected call of CountAll.
+func (mr *MockAuthorRepositoryMockRecorder) CountAll(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAll", reflect.TypeOf((*MockAuthorRepository)(nil).CountAll), ctx, query)
+}
+
+// Create mocks base method.
+func (m *MockAuthorRepository) Create(ctx context.Context, input *model.Author) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAuthorRepositoryMockRecorder) Create(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAuthorRepository)(nil).Create), ctx, input)
+}
+
+// DeleteByID mocks base method.
+func (m *MockAuthorRepository) DeleteByID(ctx context.Context, ID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, ID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockAuthorRepositoryMockRecorder) DeleteByID(ctx, ID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockAuthorRepository)(nil).DeleteByID), ctx, ID)
+}
+
+// FindAll mocks base method.
+func (m *MockAuthorRepository) FindAll(ctx context.Context, query model.GetAuthorsQueryParams) ([]*model.Author, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, query)
+	ret0, _ := ret[0].([]*model.Author)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockAuthorRepositoryMockRecorder) FindAll(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockAuthorRepository)(nil).FindAll), ctx, query)
+}
+
+// FindAllByBookID mocks base method.
+func (m *MockAuthorRepository) FindAllByBookID(ctx context.Context, bookID int64) ([]*model.Author, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByBookID", ctx, bookID)
+	ret0, _ := ret[0].([]*model.Author)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByBookID indicates an expected call of FindAllByBookID.
+func (mr *MockAuthorRepositoryMockRecorder) FindAllByBookID(ctx, bookID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByBookID", reflect.TypeOf((*MockAuthorRepository)(nil).FindAllByBookID), ctx, bookID)
+}
+
+// FindByID mocks base method.
+func (m *MockAuthorRepository) FindByID(ctx context.Context, ID int64) (*model.Author, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, ID)
+	ret0, _ := ret[0].(*model.Author)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockAuthorRepositoryMockRecorder) FindByID(ctx, ID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockAuthorRepository)(nil).FindByID), ctx, ID)
+}
+
+// ReplaceBookAuthors mocks base method.
+func (m *MockAuthorRepository) ReplaceBookAuthors(ctx context.Context, bookID int64, authorIDs []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceBookAuthors", ctx, bookID, authorIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceBookAuthors indicates an expected call of ReplaceBookAuthors.
+func (mr *MockAuthorRepositoryMockRecorder) ReplaceBookAuthors(ctx, bookID, authorIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceBookAuthors", reflect.TypeOf((*MockAuthorRepository)(nil).ReplaceBookAuthors), ctx, bookID, authorIDs)
+}
+
+// Update mocks base method.
+func (m *MockAuthorRepository) Update(ctx context.Context, input *model.Author) (*model.Author, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, input)
+	ret0, _ := ret[0].(*model.Author)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockAuthorRepositoryMockRecorder) Update(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockAuthorRepository)(nil).Update), ctx, input)
+}
@@ -7,6 +7,7 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	model "github.com/ssentinull/create-apis-using-golang/internal/model"
@@ -35,33 +36,78 @@ func (m *MockBookUsecase) EXPECT() *MockBookUsecaseMockRecorder {
 	return m.recorder
 }
 
+// BatchCreate mocks base method.
+func (m *MockBookUsecase) BatchCreate(ctx context.Context, input model.BatchCreateBooksInput) ([]model.BatchItemResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchCreate", ctx, input)
+	ret0, _ := ret[0].([]model.BatchItemResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchCreate indicates an expected call of BatchCreate.
+func (mr *MockBookUsecaseMockRecorder) BatchCreate(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchCreate", reflect.TypeOf((*MockBookUsecase)(nil).BatchCreate), ctx, input)
+}
+
+// BatchDelete mocks base method.
+func (m *MockBookUsecase) BatchDelete(ctx context.Context, input model.BatchDeleteBooksInput) ([]model.BatchItemResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchDelete", ctx, input)
+	ret0, _ := ret[0].([]model.BatchItemResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchDelete indicates an expected call of BatchDelete.
+func (mr *MockBookUsecaseMockRecorder) BatchDelete(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchDelete", reflect.TypeOf((*MockBookUsecase)(nil).BatchDelete), ctx, input)
+}
+
+// BatchUpdate mocks base method.
+func (m *MockBookUsecase) BatchUpdate(ctx context.Context, input model.BatchUpdateBooksInput) ([]model.BatchItemResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchUpdate", ctx, input)
+	ret0, _ := ret[0].([]model.BatchItemResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchUpdate indicates an expected call of BatchUpdate.
+func (mr *MockBookUsecaseMockRecorder) BatchUpdate(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchUpdate", reflect.TypeOf((*MockBookUsecase)(nil).BatchUpdate), ctx, input)
+}
+
 // Create mocks base method.
-func (m *MockBookUsecase) Create(ctx context.Context, input *model.Book) (*model.Book, error) {
+func (m *MockBookUsecase) Create(ctx context.Context, input *model.Book, enrich bool) (*model.Book, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", ctx, input)
+	ret := m.ctrl.Call(m, "Create", ctx, input, enrich)
 	ret0, _ := ret[0].(*model.Book)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockBookUsecaseMockRecorder) Create(ctx, input interface{}) *gomock.Call {
+func (mr *MockBookUsecaseMockRecorder) Create(ctx, input, enrich interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockBookUsecase)(nil).Create), ctx, input)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockBookUsecase)(nil).Create), ctx, input, enrich)
 }
 
 // DeleteByID mocks base method.
-func (m *MockBookUsecase) DeleteByID(ctx context.Context, ID int64) error {
+func (m *MockBookUsecase) DeleteByID(ctx context.Context, ID, version int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteByID", ctx, ID)
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, ID, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteByID indicates an expected call of DeleteByID.
-func (mr *MockBookUsecaseMockRecorder) DeleteByID(ctx, ID interface{}) *gomock.Call {
+func (mr *MockBookUsecaseMockRecorder) DeleteByID(ctx, ID, version interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockBookUsecase)(nil).DeleteByID), ctx, ID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockBookUsecase)(nil).DeleteByID), ctx, ID, version)
 }
 
 // FindAll mocks base method.
@@ -80,6 +126,54 @@ func (mr *MockBookUsecaseMockRecorder) FindAll(ctx, query interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockBookUsecase)(nil).FindAll), ctx, query)
 }
 
+// FindAllByCursor mocks base method.
+func (m *MockBookUsecase) FindAllByCursor(ctx context.Context, query model.GetBooksQueryParams) ([]*model.Book, model.CursorPagination, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByCursor", ctx, query)
+	ret0, _ := ret[0].([]*model.Book)
+	ret1, _ := ret[1].(model.CursorPagination)
+	ret2, _ := ret[2].(int64)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// FindAllByCursor indicates an expected call of FindAllByCursor.
+func (mr *MockBookUsecaseMockRecorder) FindAllByCursor(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByCursor", reflect.TypeOf((*MockBookUsecase)(nil).FindAllByCursor), ctx, query)
+}
+
+// FindAllByWorkID mocks base method.
+func (m *MockBookUsecase) FindAllByWorkID(ctx context.Context, workID int64) ([]*model.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByWorkID", ctx, workID)
+	ret0, _ := ret[0].([]*model.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByWorkID indicates an expected call of FindAllByWorkID.
+func (mr *MockBookUsecaseMockRecorder) FindAllByWorkID(ctx, workID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByWorkID", reflect.TypeOf((*MockBookUsecase)(nil).FindAllByWorkID), ctx, workID)
+}
+
+// FindAllTrashed mocks base method.
+func (m *MockBookUsecase) FindAllTrashed(ctx context.Context, query model.GetBooksQueryParams) ([]*model.Book, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllTrashed", ctx, query)
+	ret0, _ := ret[0].([]*model.Book)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindAllTrashed indicates an expected call of FindAllTrashed.
+func (mr *MockBookUsecaseMockRecorder) FindAllTrashed(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllTrashed", reflect.TypeOf((*MockBookUsecase)(nil).FindAllTrashed), ctx, query)
+}
+
 // FindByID mocks base method.
 func (m *MockBookUsecase) FindByID(ctx context.Context, ID int64) (*model.Book, error) {
 	m.ctrl.T.Helper()
@@ -95,6 +189,141 @@ func (mr *MockBookUsecaseMockRecorder) FindByID(ctx, ID interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockBookUsecase)(nil).FindByID), ctx, ID)
 }
 
+// FindByISBN mocks base method.
+func (m *MockBookUsecase) FindByISBN(ctx context.Context, isbn string) (*model.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByISBN", ctx, isbn)
+	ret0, _ := ret[0].(*model.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByISBN indicates an expected call of FindByISBN.
+func (mr *MockBookUsecaseMockRecorder) FindByISBN(ctx, isbn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByISBN", reflect.TypeOf((*MockBookUsecase)(nil).FindByISBN), ctx, isbn)
+}
+
+// FindFacets mocks base method.
+func (m *MockBookUsecase) FindFacets(ctx context.Context, query model.GetBooksQueryParams) (map[string][]*model.FacetCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindFacets", ctx, query)
+	ret0, _ := ret[0].(map[string][]*model.FacetCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindFacets indicates an expected call of FindFacets.
+func (mr *MockBookUsecaseMockRecorder) FindFacets(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindFacets", reflect.TypeOf((*MockBookUsecase)(nil).FindFacets), ctx, query)
+}
+
+// FindVersionByID mocks base method.
+func (m *MockBookUsecase) FindVersionByID(ctx context.Context, ID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindVersionByID", ctx, ID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindVersionByID indicates an expected call of FindVersionByID.
+func (mr *MockBookUsecaseMockRecorder) FindVersionByID(ctx, ID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindVersionByID", reflect.TypeOf((*MockBookUsecase)(nil).FindVersionByID), ctx, ID)
+}
+
+// HardDeleteByID mocks base method.
+func (m *MockBookUsecase) HardDeleteByID(ctx context.Context, ID, version int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HardDeleteByID", ctx, ID, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HardDeleteByID indicates an expected call of HardDeleteByID.
+func (mr *MockBookUsecaseMockRecorder) HardDeleteByID(ctx, ID, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HardDeleteByID", reflect.TypeOf((*MockBookUsecase)(nil).HardDeleteByID), ctx, ID, version)
+}
+
+// Patch mocks base method.
+func (m *MockBookUsecase) Patch(ctx context.Context, ID int64, input model.PatchBookInput) (*model.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Patch", ctx, ID, input)
+	ret0, _ := ret[0].(*model.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Patch indicates an expected call of Patch.
+func (mr *MockBookUsecaseMockRecorder) Patch(ctx, ID, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Patch", reflect.TypeOf((*MockBookUsecase)(nil).Patch), ctx, ID, input)
+}
+
+// PurgeTrash mocks base method.
+func (m *MockBookUsecase) PurgeTrash(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeTrash", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeTrash indicates an expected call of PurgeTrash.
+func (mr *MockBookUsecaseMockRecorder) PurgeTrash(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeTrash", reflect.TypeOf((*MockBookUsecase)(nil).PurgeTrash), ctx)
+}
+
+// Restore mocks base method.
+func (m *MockBookUsecase) Restore(ctx context.Context, ID int64) (*model.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, ID)
+	ret0, _ := ret[0].(*model.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockBookUsecaseMockRecorder) Restore(ctx, ID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockBookUsecase)(nil).Restore), ctx, ID)
+}
+
+// Search mocks base method.
+func (m *MockBookUsecase) Search(ctx context.Context, query model.SearchBooksQueryParams) ([]*model.BookSearchResult, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, query)
+	ret0, _ := ret[0].([]*model.BookSearchResult)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockBookUsecaseMockRecorder) Search(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockBookUsecase)(nil).Search), ctx, query)
+}
+
+// Suggest mocks base method.
+func (m *MockBookUsecase) Suggest(ctx context.Context, query model.SuggestBooksQueryParams) ([]*model.BookSuggestion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Suggest", ctx, query)
+	ret0, _ := ret[0].([]*model.BookSuggestion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Suggest indicates an expected call of Suggest.
+func (mr *MockBookUsecaseMockRecorder) Suggest(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Suggest", reflect.TypeOf((*MockBookUsecase)(nil).Suggest), ctx, query)
+}
+
 // Update mocks base method.
 func (m *MockBookUsecase) Update(ctx context.Context, input *model.Book) (*model.Book, error) {
 	m.ctrl.T.Helper()
@@ -133,19 +362,110 @@ func (m *MockBookRepository) EXPECT() *MockBookRepositoryMockRecorder {
 	return m.recorder
 }
 
+// BatchCreate mocks base method.
+func (m *MockBookRepository) BatchCreate(ctx context.Context, books []*model.Book, atomic bool) ([]error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchCreate", ctx, books, atomic)
+	ret0, _ := ret[0].([]error)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchCreate indicates an expected call of BatchCreate.
+func (mr *MockBookRepositoryMockRecorder) BatchCreate(ctx, books, atomic interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchCreate", reflect.TypeOf((*MockBookRepository)(nil).BatchCreate), ctx, books, atomic)
+}
+
+// BatchDelete mocks base method.
+func (m *MockBookRepository) BatchDelete(ctx context.Context, IDs []int64, atomic bool) ([]error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchDelete", ctx, IDs, atomic)
+	ret0, _ := ret[0].([]error)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchDelete indicates an expected call of BatchDelete.
+func (mr *MockBookRepositoryMockRecorder) BatchDelete(ctx, IDs, atomic interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchDelete", reflect.TypeOf((*MockBookRepository)(nil).BatchDelete), ctx, IDs, atomic)
+}
+
+// BatchUpdate mocks base method.
+func (m *MockBookRepository) BatchUpdate(ctx context.Context, books []*model.Book, atomic bool) ([]*model.Book, []error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchUpdate", ctx, books, atomic)
+	ret0, _ := ret[0].([]*model.Book)
+	ret1, _ := ret[1].([]error)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// BatchUpdate indicates an expected call of BatchUpdate.
+func (mr *MockBookRepositoryMockRecorder) BatchUpdate(ctx, books, atomic interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchUpdate", reflect.TypeOf((*MockBookRepository)(nil).BatchUpdate), ctx, books, atomic)
+}
+
 // CountAll mocks base method.
-func (m *MockBookRepository) CountAll(ctx context.Context) (int64, error) {
+func (m *MockBookRepository) CountAll(ctx context.Context, query model.GetBooksQueryParams) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CountAll", ctx)
+	ret := m.ctrl.Call(m, "CountAll", ctx, query)
 	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CountAll indicates an expected call of CountAll.
-func (mr *MockBookRepositoryMockRecorder) CountAll(ctx interface{}) *gomock.Call {
+func (mr *MockBookRepositoryMockRecorder) CountAll(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAll", reflect.TypeOf((*MockBookRepository)(nil).CountAll), ctx, query)
+}
+
+// CountAllTrashed mocks base method.
+func (m *MockBookRepository) CountAllTrashed(ctx context.Context, query model.GetBooksQueryParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAllTrashed", ctx, query)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAllTrashed indicates an expected call of CountAllTrashed.
+func (mr *MockBookRepositoryMockRecorder) CountAllTrashed(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAllTrashed", reflect.TypeOf((*MockBookRepository)(nil).CountAllTrashed), ctx, query)
+}
+
+// CountByFacet mocks base method.
+func (m *MockBookRepository) CountByFacet(ctx context.Context, query model.GetBooksQueryParams, facet string) ([]*model.FacetCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByFacet", ctx, query, facet)
+	ret0, _ := ret[0].([]*model.FacetCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByFacet indicates an expected call of CountByFacet.
+func (mr *MockBookRepositoryMockRecorder) CountByFacet(ctx, query, facet interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByFacet", reflect.TypeOf((*MockBookRepository)(nil).CountByFacet), ctx, query, facet)
+}
+
+// CountSearch mocks base method.
+func (m *MockBookRepository) CountSearch(ctx context.Context, query model.SearchBooksQueryParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountSearch", ctx, query)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountSearch indicates an expected call of CountSearch.
+func (mr *MockBookRepositoryMockRecorder) CountSearch(ctx, query interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAll", reflect.TypeOf((*MockBookRepository)(nil).CountAll), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountSearch", reflect.TypeOf((*MockBookRepository)(nil).CountSearch), ctx, query)
 }
 
 // Create mocks base method.
@@ -163,17 +483,17 @@ func (mr *MockBookRepositoryMockRecorder) Create(ctx, input interface{}) *gomock
 }
 
 // DeleteByID mocks base method.
-func (m *MockBookRepository) DeleteByID(ctx context.Context, ID int64) error {
+func (m *MockBookRepository) DeleteByID(ctx context.Context, ID, version int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteByID", ctx, ID)
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, ID, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteByID indicates an expected call of DeleteByID.
-func (mr *MockBookRepositoryMockRecorder) DeleteByID(ctx, ID interface{}) *gomock.Call {
+func (mr *MockBookRepositoryMockRecorder) DeleteByID(ctx, ID, version interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockBookRepository)(nil).DeleteByID), ctx, ID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockBookRepository)(nil).DeleteByID), ctx, ID, version)
 }
 
 // FindAll mocks base method.
@@ -191,6 +511,67 @@ func (mr *MockBookRepositoryMockRecorder) FindAll(ctx, query interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockBookRepository)(nil).FindAll), ctx, query)
 }
 
+// FindAllByCursor mocks base method.
+func (m *MockBookRepository) FindAllByCursor(ctx context.Context, query model.GetBooksQueryParams) ([]*model.Book, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByCursor", ctx, query)
+	ret0, _ := ret[0].([]*model.Book)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindAllByCursor indicates an expected call of FindAllByCursor.
+func (mr *MockBookRepositoryMockRecorder) FindAllByCursor(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByCursor", reflect.TypeOf((*MockBookRepository)(nil).FindAllByCursor), ctx, query)
+}
+
+// FindAllBySeriesID mocks base method.
+func (m *MockBookRepository) FindAllBySeriesID(ctx context.Context, seriesID int64) ([]*model.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllBySeriesID", ctx, seriesID)
+	ret0, _ := ret[0].([]*model.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllBySeriesID indicates an expected call of FindAllBySeriesID.
+func (mr *MockBookRepositoryMockRecorder) FindAllBySeriesID(ctx, seriesID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllBySeriesID", reflect.TypeOf((*MockBookRepository)(nil).FindAllBySeriesID), ctx, seriesID)
+}
+
+// FindAllByWorkID mocks base method.
+func (m *MockBookRepository) FindAllByWorkID(ctx context.Context, workID int64) ([]*model.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByWorkID", ctx, workID)
+	ret0, _ := ret[0].([]*model.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByWorkID indicates an expected call of FindAllByWorkID.
+func (mr *MockBookRepositoryMockRecorder) FindAllByWorkID(ctx, workID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByWorkID", reflect.TypeOf((*MockBookRepository)(nil).FindAllByWorkID), ctx, workID)
+}
+
+// FindAllTrashed mocks base method.
+func (m *MockBookRepository) FindAllTrashed(ctx context.Context, query model.GetBooksQueryParams) ([]*model.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllTrashed", ctx, query)
+	ret0, _ := ret[0].([]*model.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllTrashed indicates an expected call of FindAllTrashed.
+func (mr *MockBookRepositoryMockRecorder) FindAllTrashed(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllTrashed", reflect.TypeOf((*MockBookRepository)(nil).FindAllTrashed), ctx, query)
+}
+
 // FindByID mocks base method.
 func (m *MockBookRepository) FindByID(ctx context.Context, ID int64) (*model.Book, error) {
 	m.ctrl.T.Helper()
@@ -206,6 +587,111 @@ func (mr *MockBookRepositoryMockRecorder) FindByID(ctx, ID interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockBookRepository)(nil).FindByID), ctx, ID)
 }
 
+// FindByISBN mocks base method.
+func (m *MockBookRepository) FindByISBN(ctx context.Context, isbn13 string) (*model.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByISBN", ctx, isbn13)
+	ret0, _ := ret[0].(*model.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByISBN indicates an expected call of FindByISBN.
+func (mr *MockBookRepositoryMockRecorder) FindByISBN(ctx, isbn13 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByISBN", reflect.TypeOf((*MockBookRepository)(nil).FindByISBN), ctx, isbn13)
+}
+
+// FindVersionByID mocks base method.
+func (m *MockBookRepository) FindVersionByID(ctx context.Context, ID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindVersionByID", ctx, ID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindVersionByID indicates an expected call of FindVersionByID.
+func (mr *MockBookRepositoryMockRecorder) FindVersionByID(ctx, ID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindVersionByID", reflect.TypeOf((*MockBookRepository)(nil).FindVersionByID), ctx, ID)
+}
+
+// HardDeleteByID mocks base method.
+func (m *MockBookRepository) HardDeleteByID(ctx context.Context, ID, version int64) (*model.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HardDeleteByID", ctx, ID, version)
+	ret0, _ := ret[0].(*model.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HardDeleteByID indicates an expected call of HardDeleteByID.
+func (mr *MockBookRepositoryMockRecorder) HardDeleteByID(ctx, ID, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HardDeleteByID", reflect.TypeOf((*MockBookRepository)(nil).HardDeleteByID), ctx, ID, version)
+}
+
+// PurgeTrashed mocks base method.
+func (m *MockBookRepository) PurgeTrashed(ctx context.Context, deletedBefore time.Time) ([]*model.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeTrashed", ctx, deletedBefore)
+	ret0, _ := ret[0].([]*model.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeTrashed indicates an expected call of PurgeTrashed.
+func (mr *MockBookRepositoryMockRecorder) PurgeTrashed(ctx, deletedBefore interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeTrashed", reflect.TypeOf((*MockBookRepository)(nil).PurgeTrashed), ctx, deletedBefore)
+}
+
+// Restore mocks base method.
+func (m *MockBookRepository) Restore(ctx context.Context, ID int64) (*model.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, ID)
+	ret0, _ := ret[0].(*model.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockBookRepositoryMockRecorder) Restore(ctx, ID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockBookRepository)(nil).Restore), ctx, ID)
+}
+
+// Search mocks base method.
+func (m *MockBookRepository) Search(ctx context.Context, query model.SearchBooksQueryParams) ([]*model.BookSearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, query)
+	ret0, _ := ret[0].([]*model.BookSearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockBookRepositoryMockRecorder) Search(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockBookRepository)(nil).Search), ctx, query)
+}
+
+// Suggest mocks base method.
+func (m *MockBookRepository) Suggest(ctx context.Context, query model.SuggestBooksQueryParams) ([]*model.BookSuggestion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Suggest", ctx, query)
+	ret0, _ := ret[0].([]*model.BookSuggestion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Suggest indicates an expected call of Suggest.
+func (mr *MockBookRepositoryMockRecorder) Suggest(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Suggest", reflect.TypeOf((*MockBookRepository)(nil).Suggest), ctx, query)
+}
+
 // Update mocks base method.
 func (m *MockBookRepository) Update(ctx context.Context, input *model.Book) (*model.Book, error) {
 	m.ctrl.T.Helper()
@@ -220,3 +706,18 @@ func (mr *MockBookRepositoryMockRecorder) Update(ctx, input interface{}) *gomock
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockBookRepository)(nil).Update), ctx, input)
 }
+
+// UpdateCover mocks base method.
+func (m *MockBookRepository) UpdateCover(ctx context.Context, ID int64, coverUpdatedAt time.Time) (*model.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCover", ctx, ID, coverUpdatedAt)
+	ret0, _ := ret[0].(*model.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCover indicates an expected call of UpdateCover.
+func (mr *MockBookRepositoryMockRecorder) UpdateCover(ctx, ID, coverUpdatedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCover", reflect.TypeOf((*MockBookRepository)(nil).UpdateCover), ctx, ID, coverUpdatedAt)
+}
@@ -0,0 +1,238 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/model/book_copy.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/ssentinull/create-apis-using-golang/internal/model"
+)
+
+// MockBookCopyUsecase is a mock of BookCopyUsecase interface.
+type MockBookCopyUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockBookCopyUsecaseMockRecorder
+}
+
+// MockBookCopyUsecaseMockRecorder is the mock recorder for MockBookCopyUsecase.
+type MockBookCopyUsecaseMockRecorder struct {
+	mock *MockBookCopyUsecase
+}
+
+// NewMockBookCopyUsecase creates a new mock instance.
+func NewMockBookCopyUsecase(ctrl *gomock.Controller) *MockBookCopyUsecase {
+	mock := &MockBookCopyUsecase{ctrl: ctrl}
+	mock.recorder = &MockBookCopyUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBookCopyUsecase) EXPECT() *MockBookCopyUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockBookCopyUsecase) Create(ctx context.Context, bookCopy *model.BookCopy) (*model.BookCopy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, bookCopy)
+	ret0, _ := ret[0].(*model.BookCopy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockBookCopyUsecaseMockRecorder) Create(ctx, bookCopy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockBookCopyUsecase)(nil).Create), ctx, bookCopy)
+}
+
+// DeleteByID mocks base method.
+func (m *MockBookCopyUsecase) DeleteByID(ctx context.Context, ID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, ID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockBookCopyUsecaseMockRecorder) DeleteByID(ctx, ID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockBookCopyUsecase)(nil).DeleteByID), ctx, ID)
+}
+
+// FindAllByBookID mocks base method.
+func (m *MockBookCopyUsecase) FindAllByBookID(ctx context.Context, query model.GetBookCopiesQueryParams) ([]*model.BookCopy, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByBookID", ctx, query)
+	ret0, _ := ret[0].([]*model.BookCopy)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindAllByBookID indicates an expected call of FindAllByBookID.
+func (mr *MockBookCopyUsecaseMockRecorder) FindAllByBookID(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByBookID", reflect.TypeOf((*MockBookCopyUsecase)(nil).FindAllByBookID), ctx, query)
+}
+
+// FindByID mocks base method.
+func (m *MockBookCopyUsecase) FindByID(ctx context.Context, ID int64) (*model.BookCopy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, ID)
+	ret0, _ := ret[0].(*model.BookCopy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockBookCopyUsecaseMockRecorder) FindByID(ctx, ID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockBookCopyUsecase)(nil).FindByID), ctx, ID)
+}
+
+// Update mocks base method.
+func (m *MockBookCopyUsecase) Update(ctx context.Context, input model.UpdateBookCopyInput) (*model.BookCopy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, input)
+	ret0, _ := ret[0].(*model.BookCopy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockBookCopyUsecaseMockRecorder) Update(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockBookCopyUsecase)(nil).Update), ctx, input)
+}
+
+// MockBookCopyRepository is a mock of BookCopyRepository interface.
+type MockBookCopyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockBookCopyRepositoryMockRecorder
+}
+
+// MockBookCopyRepositoryMockRecorder is the mock recorder for MockBookCopyRepository.
+type MockBookCopyRepositoryMockRecorder struct {
+	mock *MockBookCopyRepository
+}
+
+// NewMockBookCopyRepository creates a new mock instance.
+func NewMockBookCopyRepository(ctrl *gomock.Controller) *MockBookCopyRepository {
+	mock := &MockBookCopyRepository{ctrl: ctrl}
+	mock.recorder = &MockBookCopyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBookCopyRepository) EXPECT() *MockBookCopyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountAllByBookID mocks base method.
+func (m *MockBookCopyRepository) CountAllByBookID(ctx context.Context, query model.GetBookCopiesQueryParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAllByBookID", ctx, query)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAllByBookID indicates an expected call of CountAllByBookID.
+func (mr *MockBookCopyRepositoryMockRecorder) CountAllByBookID(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAllByBookID", reflect.TypeOf((*MockBookCopyRepository)(nil).CountAllByBookID), ctx, query)
+}
+
+// Create mocks base method.
+func (m *MockBookCopyRepository) Create(ctx context.Context, bookCopy *model.BookCopy, pickupBy time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, bookCopy, pickupBy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockBookCopyRepositoryMockRecorder) Create(ctx, bookCopy, pickupBy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockBookCopyRepository)(nil).Create), ctx, bookCopy, pickupBy)
+}
+
+// DeleteByID mocks base method.
+func (m *MockBookCopyRepository) DeleteByID(ctx context.Context, ID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, ID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockBookCopyRepositoryMockRecorder) DeleteByID(ctx, ID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockBookCopyRepository)(nil).DeleteByID), ctx, ID)
+}
+
+// FindAllByBookID mocks base method.
+func (m *MockBookCopyRepository) FindAllByBookID(ctx context.Context, query model.GetBookCopiesQueryParams) ([]*model.BookCopy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByBookID", ctx, query)
+	ret0, _ := ret[0].([]*model.BookCopy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByBookID indicates an expected call of FindAllByBookID.
+func (mr *MockBookCopyRepositoryMockRecorder) FindAllByBookID(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByBookID", reflect.TypeOf((*MockBookCopyRepository)(nil).FindAllByBookID), ctx, query)
+}
+
+// FindByBarcode mocks base method.
+func (m *MockBookCopyRepository) FindByBarcode(ctx context.Context, barcode string) (*model.BookCopy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByBarcode", ctx, barcode)
+	ret0, _ := ret[0].(*model.BookCopy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByBarcode indicates an expected call of FindByBarcode.
+func (mr *MockBookCopyRepositoryMockRecorder) FindByBarcode(ctx, barcode interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByBarcode", reflect.TypeOf((*MockBookCopyRepository)(nil).FindByBarcode), ctx, barcode)
+}
+
+// FindByID mocks base method.
+func (m *MockBookCopyRepository) FindByID(ctx context.Context, ID int64) (*model.BookCopy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, ID)
+	ret0, _ := ret[0].(*model.BookCopy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockBookCopyRepositoryMockRecorder) FindByID(ctx, ID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockBookCopyRepository)(nil).FindByID), ctx, ID)
+}
+
+// Update mocks base method.
+func (m *MockBookCopyRepository) Update(ctx context.Context, input model.UpdateBookCopyInput) (*model.BookCopy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, input)
+	ret0, _ := ret[0].(*model.BookCopy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockBookCopyRepositoryMockRecorder) Update(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockBookCopyRepository)(nil).Update), ctx, input)
+}
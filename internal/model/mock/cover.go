@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/model/cover.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/ssentinull/create-apis-using-golang/internal/model"
+)
+
+// MockCoverUsecase is a mock of CoverUsecase interface.
+type MockCoverUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockCoverUsecaseMockRecorder
+}
+
+// MockCoverUsecaseMockRecorder is the mock recorder for MockCoverUsecase.
+type MockCoverUsecaseMockRecorder struct {
+	mock *MockCoverUsecase
+}
+
+// NewMockCoverUsecase creates a new mock instance.
+func NewMockCoverUsecase(ctrl *gomock.Controller) *MockCoverUsecase {
+	mock := &MockCoverUsecase{ctrl: ctrl}
+	mock.recorder = &MockCoverUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCoverUsecase) EXPECT() *MockCoverUsecaseMockRecorder {
+	return m.recorder
+}
+
+// FindByBookID mocks base method.
+func (m *MockCoverUsecase) FindByBookID(ctx context.Context, bookID int64, size string) (*model.Cover, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByBookID", ctx, bookID, size)
+	ret0, _ := ret[0].(*model.Cover)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByBookID indicates an expected call of FindByBookID.
+func (mr *MockCoverUsecaseMockRecorder) FindByBookID(ctx, bookID, size interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByBookID", reflect.TypeOf((*MockCoverUsecase)(nil).FindByBookID), ctx, bookID, size)
+}
+
+// Upload mocks base method.
+func (m *MockCoverUsecase) Upload(ctx context.Context, bookID int64, image []byte) (*model.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upload", ctx, bookID, image)
+	ret0, _ := ret[0].(*model.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Upload indicates an expected call of Upload.
+func (mr *MockCoverUsecaseMockRecorder) Upload(ctx, bookID, image interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upload", reflect.TypeOf((*MockCoverUsecase)(nil).Upload), ctx, bookID, image)
+}
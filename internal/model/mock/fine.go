@@ -0,0 +1,210 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/model/fine.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/ssentinull/create-apis-using-golang/internal/model"
+)
+
+// MockFineUsecase is a mock of FineUsecase interface.
+type MockFineUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockFineUsecaseMockRecorder
+}
+
+// MockFineUsecaseMockRecorder is the mock recorder for MockFineUsecase.
+type MockFineUsecaseMockRecorder struct {
+	mock *MockFineUsecase
+}
+
+// NewMockFineUsecase creates a new mock instance.
+func NewMockFineUsecase(ctrl *gomock.Controller) *MockFineUsecase {
+	mock := &MockFineUsecase{ctrl: ctrl}
+	mock.recorder = &MockFineUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFineUsecase) EXPECT() *MockFineUsecaseMockRecorder {
+	return m.recorder
+}
+
+// AccrueOverdue mocks base method.
+func (m *MockFineUsecase) AccrueOverdue(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AccrueOverdue", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AccrueOverdue indicates an expected call of AccrueOverdue.
+func (mr *MockFineUsecaseMockRecorder) AccrueOverdue(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AccrueOverdue", reflect.TypeOf((*MockFineUsecase)(nil).AccrueOverdue), ctx)
+}
+
+// BalanceByMemberID mocks base method.
+func (m *MockFineUsecase) BalanceByMemberID(ctx context.Context, memberID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BalanceByMemberID", ctx, memberID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BalanceByMemberID indicates an expected call of BalanceByMemberID.
+func (mr *MockFineUsecaseMockRecorder) BalanceByMemberID(ctx, memberID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BalanceByMemberID", reflect.TypeOf((*MockFineUsecase)(nil).BalanceByMemberID), ctx, memberID)
+}
+
+// FindAllByMemberID mocks base method.
+func (m *MockFineUsecase) FindAllByMemberID(ctx context.Context, query model.GetFinesQueryParams) ([]*model.Fine, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByMemberID", ctx, query)
+	ret0, _ := ret[0].([]*model.Fine)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindAllByMemberID indicates an expected call of FindAllByMemberID.
+func (mr *MockFineUsecaseMockRecorder) FindAllByMemberID(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByMemberID", reflect.TypeOf((*MockFineUsecase)(nil).FindAllByMemberID), ctx, query)
+}
+
+// Settle mocks base method.
+func (m *MockFineUsecase) Settle(ctx context.Context, fine *model.Fine) (*model.Fine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Settle", ctx, fine)
+	ret0, _ := ret[0].(*model.Fine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Settle indicates an expected call of Settle.
+func (mr *MockFineUsecaseMockRecorder) Settle(ctx, fine interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Settle", reflect.TypeOf((*MockFineUsecase)(nil).Settle), ctx, fine)
+}
+
+// MockFineRepository is a mock of FineRepository interface.
+type MockFineRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockFineRepositoryMockRecorder
+}
+
+// MockFineRepositoryMockRecorder is the mock recorder for MockFineRepository.
+type MockFineRepositoryMockRecorder struct {
+	mock *MockFineRepository
+}
+
+// NewMockFineRepository creates a new mock instance.
+func NewMockFineRepository(ctrl *gomock.Controller) *MockFineRepository {
+	mock := &MockFineRepository{ctrl: ctrl}
+	mock.recorder = &MockFineRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFineRepository) EXPECT() *MockFineRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Accrue mocks base method.
+func (m *MockFineRepository) Accrue(ctx context.Context, loan *model.Loan, fine *model.Fine) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Accrue", ctx, loan, fine)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Accrue indicates an expected call of Accrue.
+func (mr *MockFineRepositoryMockRecorder) Accrue(ctx, loan, fine interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Accrue", reflect.TypeOf((*MockFineRepository)(nil).Accrue), ctx, loan, fine)
+}
+
+// BalanceByMemberID mocks base method.
+func (m *MockFineRepository) BalanceByMemberID(ctx context.Context, memberID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BalanceByMemberID", ctx, memberID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BalanceByMemberID indicates an expected call of BalanceByMemberID.
+func (mr *MockFineRepositoryMockRecorder) BalanceByMemberID(ctx, memberID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BalanceByMemberID", reflect.TypeOf((*MockFineRepository)(nil).BalanceByMemberID), ctx, memberID)
+}
+
+// CountAllByMemberID mocks base method.
+func (m *MockFineRepository) CountAllByMemberID(ctx context.Context, query model.GetFinesQueryParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAllByMemberID", ctx, query)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAllByMemberID indicates an expected call of CountAllByMemberID.
+func (mr *MockFineRepositoryMockRecorder) CountAllByMemberID(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAllByMemberID", reflect.TypeOf((*MockFineRepository)(nil).CountAllByMemberID), ctx, query)
+}
+
+// Create mocks base method.
+func (m *MockFineRepository) Create(ctx context.Context, fine *model.Fine) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, fine)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockFineRepositoryMockRecorder) Create(ctx, fine interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockFineRepository)(nil).Create), ctx, fine)
+}
+
+// FindAllAccruingLoans mocks base method.
+func (m *MockFineRepository) FindAllAccruingLoans(ctx context.Context, dueBefore time.Time) ([]*model.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllAccruingLoans", ctx, dueBefore)
+	ret0, _ := ret[0].([]*model.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllAccruingLoans indicates an expected call of FindAllAccruingLoans.
+func (mr *MockFineRepositoryMockRecorder) FindAllAccruingLoans(ctx, dueBefore interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllAccruingLoans", reflect.TypeOf((*MockFineRepository)(nil).FindAllAccruingLoans), ctx, dueBefore)
+}
+
+// FindAllByMemberID mocks base method.
+func (m *MockFineRepository) FindAllByMemberID(ctx context.Context, query model.GetFinesQueryParams) ([]*model.Fine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByMemberID", ctx, query)
+	ret0, _ := ret[0].([]*model.Fine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByMemberID indicates an expected call of FindAllByMemberID.
+func (mr *MockFineRepositoryMockRecorder) FindAllByMemberID(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByMemberID", reflect.TypeOf((*MockFineRepository)(nil).FindAllByMemberID), ctx, query)
+}
@@ -0,0 +1,206 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/model/genre.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/ssentinull/create-apis-using-golang/internal/model"
+)
+
+// MockGenreUsecase is a mock of GenreUsecase interface.
+type MockGenreUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockGenreUsecaseMockRecorder
+}
+
+// MockGenreUsecaseMockRecorder is the mock recorder for MockGenreUsecase.
+type MockGenreUsecaseMockRecorder struct {
+	mock *MockGenreUsecase
+}
+
+// NewMockGenreUsecase creates a new mock instance.
+func NewMockGenreUsecase(ctrl *gomock.Controller) *MockGenreUsecase {
+	mock := &MockGenreUsecase{ctrl: ctrl}
+	mock.recorder = &MockGenreUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGenreUsecase) EXPECT() *MockGenreUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockGenreUsecase) Create(ctx context.Context, input *model.Genre) (*model.Genre, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, input)
+	ret0, _ := ret[0].(*model.Genre)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockGenreUsecaseMockRecorder) Create(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockGenreUsecase)(nil).Create), ctx, input)
+}
+
+// DeleteByID mocks base method.
+func (m *MockGenreUsecase) DeleteByID(ctx context.Context, ID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, ID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockGenreUsecaseMockRecorder) DeleteByID(ctx, ID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockGenreUsecase)(nil).DeleteByID), ctx, ID)
+}
+
+// FindByID mocks base method.
+func (m *MockGenreUsecase) FindByID(ctx context.Context, ID int64) (*model.Genre, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, ID)
+	ret0, _ := ret[0].(*model.Genre)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockGenreUsecaseMockRecorder) FindByID(ctx, ID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockGenreUsecase)(nil).FindByID), ctx, ID)
+}
+
+// FindTree mocks base method.
+func (m *MockGenreUsecase) FindTree(ctx context.Context) ([]*model.Genre, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTree", ctx)
+	ret0, _ := ret[0].([]*model.Genre)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindTree indicates an expected call of FindTree.
+func (mr *MockGenreUsecaseMockRecorder) FindTree(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTree", reflect.TypeOf((*MockGenreUsecase)(nil).FindTree), ctx)
+}
+
+// Update mocks base method.
+func (m *MockGenreUsecase) Update(ctx context.Context, input *model.Genre) (*model.Genre, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, input)
+	ret0, _ := ret[0].(*model.Genre)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockGenreUsecaseMockRecorder) Update(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockGenreUsecase)(nil).Update), ctx, input)
+}
+
+// MockGenreRepository is a mock of GenreRepository interface.
+type MockGenreRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockGenreRepositoryMockRecorder
+}
+
+// MockGenreRepositoryMockRecorder is the mock recorder for MockGenreRepository.
+type MockGenreRepositoryMockRecorder struct {
+	mock *MockGenreRepository
+}
+
+// NewMockGenreRepository creates a new mock instance.
+func NewMockGenreRepository(ctrl *gomock.Controller) *MockGenreRepository {
+	mock := &MockGenreRepository{ctrl: ctrl}
+	mock.recorder = &MockGenreRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGenreRepository) EXPECT() *MockGenreRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockGenreRepository) Create(ctx context.Context, input *model.Genre) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockGenreRepositoryMockRecorder) Create(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockGenreRepository)(nil).Create), ctx, input)
+}
+
+// DeleteByID mocks base method.
+func (m *MockGenreRepository) DeleteByID(ctx context.Context, ID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, ID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockGenreRepositoryMockRecorder) DeleteByID(ctx, ID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockGenreRepository)(nil).DeleteByID), ctx, ID)
+}
+
+// FindAll mocks base method.
+func (m *MockGenreRepository) FindAll(ctx context.Context) ([]*model.Genre, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx)
+	ret0, _ := ret[0].([]*model.Genre)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockGenreRepositoryMockRecorder) FindAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockGenreRepository)(nil).FindAll), ctx)
+}
+
+// FindByID mocks base method.
+func (m *MockGenreRepository) FindByID(ctx context.Context, ID int64) (*model.Genre, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, ID)
+	ret0, _ := ret[0].(*model.Genre)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockGenreRepositoryMockRecorder) FindByID(ctx, ID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockGenreRepository)(nil).FindByID), ctx, ID)
+}
+
+// Update mocks base method.
+func (m *MockGenreRepository) Update(ctx context.Context, input *model.Genre) (*model.Genre, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, input)
+	ret0, _ := ret[0].(*model.Genre)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockGenreRepositoryMockRecorder) Update(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockGenreRepository)(nil).Update), ctx, input)
+}
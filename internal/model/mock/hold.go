@@ -0,0 +1,195 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/model/hold.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/ssentinull/create-apis-using-golang/internal/model"
+)
+
+// MockHoldUsecase is a mock of HoldUsecase interface.
+type MockHoldUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockHoldUsecaseMockRecorder
+}
+
+// MockHoldUsecaseMockRecorder is the mock recorder for MockHoldUsecase.
+type MockHoldUsecaseMockRecorder struct {
+	mock *MockHoldUsecase
+}
+
+// NewMockHoldUsecase creates a new mock instance.
+func NewMockHoldUsecase(ctrl *gomock.Controller) *MockHoldUsecase {
+	mock := &MockHoldUsecase{ctrl: ctrl}
+	mock.recorder = &MockHoldUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHoldUsecase) EXPECT() *MockHoldUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Cancel mocks base method.
+func (m *MockHoldUsecase) Cancel(ctx context.Context, input model.CancelHoldInput) (*model.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cancel", ctx, input)
+	ret0, _ := ret[0].(*model.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Cancel indicates an expected call of Cancel.
+func (mr *MockHoldUsecaseMockRecorder) Cancel(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockHoldUsecase)(nil).Cancel), ctx, input)
+}
+
+// ExpireReady mocks base method.
+func (m *MockHoldUsecase) ExpireReady(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireReady", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireReady indicates an expected call of ExpireReady.
+func (mr *MockHoldUsecaseMockRecorder) ExpireReady(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireReady", reflect.TypeOf((*MockHoldUsecase)(nil).ExpireReady), ctx)
+}
+
+// FindAllByBookID mocks base method.
+func (m *MockHoldUsecase) FindAllByBookID(ctx context.Context, query model.GetHoldsQueryParams) ([]*model.Hold, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByBookID", ctx, query)
+	ret0, _ := ret[0].([]*model.Hold)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindAllByBookID indicates an expected call of FindAllByBookID.
+func (mr *MockHoldUsecaseMockRecorder) FindAllByBookID(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByBookID", reflect.TypeOf((*MockHoldUsecase)(nil).FindAllByBookID), ctx, query)
+}
+
+// Place mocks base method.
+func (m *MockHoldUsecase) Place(ctx context.Context, hold *model.Hold) (*model.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Place", ctx, hold)
+	ret0, _ := ret[0].(*model.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Place indicates an expected call of Place.
+func (mr *MockHoldUsecaseMockRecorder) Place(ctx, hold interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Place", reflect.TypeOf((*MockHoldUsecase)(nil).Place), ctx, hold)
+}
+
+// MockHoldRepository is a mock of HoldRepository interface.
+type MockHoldRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockHoldRepositoryMockRecorder
+}
+
+// MockHoldRepositoryMockRecorder is the mock recorder for MockHoldRepository.
+type MockHoldRepositoryMockRecorder struct {
+	mock *MockHoldRepository
+}
+
+// NewMockHoldRepository creates a new mock instance.
+func NewMockHoldRepository(ctrl *gomock.Controller) *MockHoldRepository {
+	mock := &MockHoldRepository{ctrl: ctrl}
+	mock.recorder = &MockHoldRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHoldRepository) EXPECT() *MockHoldRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Cancel mocks base method.
+func (m *MockHoldRepository) Cancel(ctx context.Context, bookID, memberID int64, now, pickupBy time.Time) (*model.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cancel", ctx, bookID, memberID, now, pickupBy)
+	ret0, _ := ret[0].(*model.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Cancel indicates an expected call of Cancel.
+func (mr *MockHoldRepositoryMockRecorder) Cancel(ctx, bookID, memberID, now, pickupBy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cancel", reflect.TypeOf((*MockHoldRepository)(nil).Cancel), ctx, bookID, memberID, now, pickupBy)
+}
+
+// CountAllByBookID mocks base method.
+func (m *MockHoldRepository) CountAllByBookID(ctx context.Context, query model.GetHoldsQueryParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAllByBookID", ctx, query)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAllByBookID indicates an expected call of CountAllByBookID.
+func (mr *MockHoldRepositoryMockRecorder) CountAllByBookID(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAllByBookID", reflect.TypeOf((*MockHoldRepository)(nil).CountAllByBookID), ctx, query)
+}
+
+// Create mocks base method.
+func (m *MockHoldRepository) Create(ctx context.Context, hold *model.Hold) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, hold)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockHoldRepositoryMockRecorder) Create(ctx, hold interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockHoldRepository)(nil).Create), ctx, hold)
+}
+
+// ExpireReady mocks base method.
+func (m *MockHoldRepository) ExpireReady(ctx context.Context, now, pickupBy time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireReady", ctx, now, pickupBy)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireReady indicates an expected call of ExpireReady.
+func (mr *MockHoldRepositoryMockRecorder) ExpireReady(ctx, now, pickupBy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireReady", reflect.TypeOf((*MockHoldRepository)(nil).ExpireReady), ctx, now, pickupBy)
+}
+
+// FindAllByBookID mocks base method.
+func (m *MockHoldRepository) FindAllByBookID(ctx context.Context, query model.GetHoldsQueryParams) ([]*model.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByBookID", ctx, query)
+	ret0, _ := ret[0].([]*model.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByBookID indicates an expected call of FindAllByBookID.
+func (mr *MockHoldRepositoryMockRecorder) FindAllByBookID(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByBookID", reflect.TypeOf((*MockHoldRepository)(nil).FindAllByBookID), ctx, query)
+}
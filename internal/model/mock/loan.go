@@ -0,0 +1,225 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/model/loan.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/ssentinull/create-apis-using-golang/internal/model"
+)
+
+// MockLoanUsecase is a mock of LoanUsecase interface.
+type MockLoanUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoanUsecaseMockRecorder
+}
+
+// MockLoanUsecaseMockRecorder is the mock recorder for MockLoanUsecase.
+type MockLoanUsecaseMockRecorder struct {
+	mock *MockLoanUsecase
+}
+
+// NewMockLoanUsecase creates a new mock instance.
+func NewMockLoanUsecase(ctrl *gomock.Controller) *MockLoanUsecase {
+	mock := &MockLoanUsecase{ctrl: ctrl}
+	mock.recorder = &MockLoanUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLoanUsecase) EXPECT() *MockLoanUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Checkout mocks base method.
+func (m *MockLoanUsecase) Checkout(ctx context.Context, input model.CheckoutInput) (*model.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Checkout", ctx, input)
+	ret0, _ := ret[0].(*model.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Checkout indicates an expected call of Checkout.
+func (mr *MockLoanUsecaseMockRecorder) Checkout(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Checkout", reflect.TypeOf((*MockLoanUsecase)(nil).Checkout), ctx, input)
+}
+
+// FindAllByMemberID mocks base method.
+func (m *MockLoanUsecase) FindAllByMemberID(ctx context.Context, query model.GetLoansQueryParams) ([]*model.Loan, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByMemberID", ctx, query)
+	ret0, _ := ret[0].([]*model.Loan)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindAllByMemberID indicates an expected call of FindAllByMemberID.
+func (mr *MockLoanUsecaseMockRecorder) FindAllByMemberID(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByMemberID", reflect.TypeOf((*MockLoanUsecase)(nil).FindAllByMemberID), ctx, query)
+}
+
+// FindByID mocks base method.
+func (m *MockLoanUsecase) FindByID(ctx context.Context, ID int64) (*model.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, ID)
+	ret0, _ := ret[0].(*model.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockLoanUsecaseMockRecorder) FindByID(ctx, ID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockLoanUsecase)(nil).FindByID), ctx, ID)
+}
+
+// Return mocks base method.
+func (m *MockLoanUsecase) Return(ctx context.Context, ID int64) (*model.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Return", ctx, ID)
+	ret0, _ := ret[0].(*model.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Return indicates an expected call of Return.
+func (mr *MockLoanUsecaseMockRecorder) Return(ctx, ID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Return", reflect.TypeOf((*MockLoanUsecase)(nil).Return), ctx, ID)
+}
+
+// UpdateDueDate mocks base method.
+func (m *MockLoanUsecase) UpdateDueDate(ctx context.Context, input model.UpdateLoanDueDateInput) (*model.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDueDate", ctx, input)
+	ret0, _ := ret[0].(*model.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateDueDate indicates an expected call of UpdateDueDate.
+func (mr *MockLoanUsecaseMockRecorder) UpdateDueDate(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDueDate", reflect.TypeOf((*MockLoanUsecase)(nil).UpdateDueDate), ctx, input)
+}
+
+// MockLoanRepository is a mock of LoanRepository interface.
+type MockLoanRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoanRepositoryMockRecorder
+}
+
+// MockLoanRepositoryMockRecorder is the mock recorder for MockLoanRepository.
+type MockLoanRepositoryMockRecorder struct {
+	mock *MockLoanRepository
+}
+
+// NewMockLoanRepository creates a new mock instance.
+func NewMockLoanRepository(ctrl *gomock.Controller) *MockLoanRepository {
+	mock := &MockLoanRepository{ctrl: ctrl}
+	mock.recorder = &MockLoanRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLoanRepository) EXPECT() *MockLoanRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountAllByMemberID mocks base method.
+func (m *MockLoanRepository) CountAllByMemberID(ctx context.Context, query model.GetLoansQueryParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAllByMemberID", ctx, query)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAllByMemberID indicates an expected call of CountAllByMemberID.
+func (mr *MockLoanRepositoryMockRecorder) CountAllByMemberID(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAllByMemberID", reflect.TypeOf((*MockLoanRepository)(nil).CountAllByMemberID), ctx, query)
+}
+
+// Create mocks base method.
+func (m *MockLoanRepository) Create(ctx context.Context, loan *model.Loan) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, loan)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockLoanRepositoryMockRecorder) Create(ctx, loan interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockLoanRepository)(nil).Create), ctx, loan)
+}
+
+// FindAllByMemberID mocks base method.
+func (m *MockLoanRepository) FindAllByMemberID(ctx context.Context, query model.GetLoansQueryParams) ([]*model.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByMemberID", ctx, query)
+	ret0, _ := ret[0].([]*model.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByMemberID indicates an expected call of FindAllByMemberID.
+func (mr *MockLoanRepositoryMockRecorder) FindAllByMemberID(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByMemberID", reflect.TypeOf((*MockLoanRepository)(nil).FindAllByMemberID), ctx, query)
+}
+
+// FindByID mocks base method.
+func (m *MockLoanRepository) FindByID(ctx context.Context, ID int64) (*model.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, ID)
+	ret0, _ := ret[0].(*model.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockLoanRepositoryMockRecorder) FindByID(ctx, ID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockLoanRepository)(nil).FindByID), ctx, ID)
+}
+
+// Return mocks base method.
+func (m *MockLoanRepository) Return(ctx context.Context, ID int64, returnedAt, pickupBy time.Time) (*model.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Return", ctx, ID, returnedAt, pickupBy)
+	ret0, _ := ret[0].(*model.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Return indicates an expected call of Return.
+func (mr *MockLoanRepositoryMockRecorder) Return(ctx, ID, returnedAt, pickupBy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Return", reflect.TypeOf((*MockLoanRepository)(nil).Return), ctx, ID, returnedAt, pickupBy)
+}
+
+// UpdateDueDate mocks base method.
+func (m *MockLoanRepository) UpdateDueDate(ctx context.Context, ID int64, dueAt time.Time) (*model.Loan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDueDate", ctx, ID, dueAt)
+	ret0, _ := ret[0].(*model.Loan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateDueDate indicates an expected call of UpdateDueDate.
+func (mr *MockLoanRepositoryMockRecorder) UpdateDueDate(ctx, ID, dueAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDueDate", reflect.TypeOf((*MockLoanRepository)(nil).UpdateDueDate), ctx, ID, dueAt)
+}
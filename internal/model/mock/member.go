@@ -0,0 +1,222 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/model/member.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/ssentinull/create-apis-using-golang/internal/model"
+)
+
+// MockMemberUsecase is a mock of MemberUsecase interface.
+type MockMemberUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockMemberUsecaseMockRecorder
+}
+
+// MockMemberUsecaseMockRecorder is the mock recorder for MockMemberUsecase.
+type MockMemberUsecaseMockRecorder struct {
+	mock *MockMemberUsecase
+}
+
+// NewMockMemberUsecase creates a new mock instance.
+func NewMockMemberUsecase(ctrl *gomock.Controller) *MockMemberUsecase {
+	mock := &MockMemberUsecase{ctrl: ctrl}
+	mock.recorder = &MockMemberUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMemberUsecase) EXPECT() *MockMemberUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockMemberUsecase) Create(ctx context.Context, input *model.Member) (*model.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, input)
+	ret0, _ := ret[0].(*model.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockMemberUsecaseMockRecorder) Create(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockMemberUsecase)(nil).Create), ctx, input)
+}
+
+// DeleteByID mocks base method.
+func (m *MockMemberUsecase) DeleteByID(ctx context.Context, ID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, ID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockMemberUsecaseMockRecorder) DeleteByID(ctx, ID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockMemberUsecase)(nil).DeleteByID), ctx, ID)
+}
+
+// FindAll mocks base method.
+func (m *MockMemberUsecase) FindAll(ctx context.Context, query model.GetMembersQueryParams) ([]*model.Member, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, query)
+	ret0, _ := ret[0].([]*model.Member)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockMemberUsecaseMockRecorder) FindAll(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockMemberUsecase)(nil).FindAll), ctx, query)
+}
+
+// FindByID mocks base method.
+func (m *MockMemberUsecase) FindByID(ctx context.Context, ID int64) (*model.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, ID)
+	ret0, _ := ret[0].(*model.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockMemberUsecaseMockRecorder) FindByID(ctx, ID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockMemberUsecase)(nil).FindByID), ctx, ID)
+}
+
+// Update mocks base method.
+func (m *MockMemberUsecase) Update(ctx context.Context, input *model.Member) (*model.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, input)
+	ret0, _ := ret[0].(*model.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockMemberUsecaseMockRecorder) Update(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockMemberUsecase)(nil).Update), ctx, input)
+}
+
+// MockMemberRepository is a mock of MemberRepository interface.
+type MockMemberRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMemberRepositoryMockRecorder
+}
+
+// MockMemberRepositoryMockRecorder is the mock recorder for MockMemberRepository.
+type MockMemberRepositoryMockRecorder struct {
+	mock *MockMemberRepository
+}
+
+// NewMockMemberRepository creates a new mock instance.
+func NewMockMemberRepository(ctrl *gomock.Controller) *MockMemberRepository {
+	mock := &MockMemberRepository{ctrl: ctrl}
+	mock.recorder = &MockMemberRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMemberRepository) EXPECT() *MockMemberRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountAll mocks base method.
+func (m *MockMemberRepository) CountAll(ctx context.Context, query model.GetMembersQueryParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAll", ctx, query)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAll indicates an expected call of CountAll.
+func (mr *MockMemberRepositoryMockRecorder) CountAll(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAll", reflect.TypeOf((*MockMemberRepository)(nil).CountAll), ctx, query)
+}
+
+// Create mocks base method.
+func (m *MockMemberRepository) Create(ctx context.Context, input *model.Member) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockMemberRepositoryMockRecorder) Create(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockMemberRepository)(nil).Create), ctx, input)
+}
+
+// DeleteByID mocks base method.
+func (m *MockMemberRepository) DeleteByID(ctx context.Context, ID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, ID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockMemberRepositoryMockRecorder) DeleteByID(ctx, ID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockMemberRepository)(nil).DeleteByID), ctx, ID)
+}
+
+// FindAll mocks base method.
+func (m *MockMemberRepository) FindAll(ctx context.Context, query model.GetMembersQueryParams) ([]*model.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, query)
+	ret0, _ := ret[0].([]*model.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockMemberRepositoryMockRecorder) FindAll(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockMemberRepository)(nil).FindAll), ctx, query)
+}
+
+// FindByID mocks base method.
+func (m *MockMemberRepository) FindByID(ctx context.Context, ID int64) (*model.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, ID)
+	ret0, _ := ret[0].(*model.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockMemberRepositoryMockRecorder) FindByID(ctx, ID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockMemberRepository)(nil).FindByID), ctx, ID)
+}
+
+// Update mocks base method.
+func (m *MockMemberRepository) Update(ctx context.Context, input *model.Member) (*model.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, input)
+	ret0, _ := ret[0].(*model.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockMemberRepositoryMockRecorder) Update(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockMemberRepository)(nil).Update), ctx, input)
+}
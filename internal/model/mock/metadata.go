@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/model/metadata.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/ssentinull/create-apis-using-golang/internal/model"
+)
+
+// MockMetadataProvider is a mock of MetadataProvider interface.
+type MockMetadataProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockMetadataProviderMockRecorder
+}
+
+// MockMetadataProviderMockRecorder is the mock recorder for MockMetadataProvider.
+type MockMetadataProviderMockRecorder struct {
+	mock *MockMetadataProvider
+}
+
+// NewMockMetadataProvider creates a new mock instance.
+func NewMockMetadataProvider(ctrl *gomock.Controller) *MockMetadataProvider {
+	mock := &MockMetadataProvider{ctrl: ctrl}
+	mock.recorder = &MockMetadataProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMetadataProvider) EXPECT() *MockMetadataProviderMockRecorder {
+	return m.recorder
+}
+
+// FindByISBN mocks base method.
+func (m *MockMetadataProvider) FindByISBN(ctx context.Context, isbn13 string) (*model.BookMetadata, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByISBN", ctx, isbn13)
+	ret0, _ := ret[0].(*model.BookMetadata)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByISBN indicates an expected call of FindByISBN.
+func (mr *MockMetadataProviderMockRecorder) FindByISBN(ctx, isbn13 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByISBN", reflect.TypeOf((*MockMetadataProvider)(nil).FindByISBN), ctx, isbn13)
+}
@@ -0,0 +1,222 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/model/publisher.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/ssentinull/create-apis-using-golang/internal/model"
+)
+
+// MockPublisherUsecase is a mock of PublisherUsecase interface.
+type MockPublisherUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockPublisherUsecaseMockRecorder
+}
+
+// MockPublisherUsecaseMockRecorder is the mock recorder for MockPublisherUsecase.
+type MockPublisherUsecaseMockRecorder struct {
+	mock *MockPublisherUsecase
+}
+
+// NewMockPublisherUsecase creates a new mock instance.
+func NewMockPublisherUsecase(ctrl *gomock.Controller) *MockPublisherUsecase {
+	mock := &MockPublisherUsecase{ctrl: ctrl}
+	mock.recorder = &MockPublisherUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPublisherUsecase) EXPECT() *MockPublisherUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPublisherUsecase) Create(ctx context.Context, input *model.Publisher) (*model.Publisher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, input)
+	ret0, _ := ret[0].(*model.Publisher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPublisherUsecaseMockRecorder) Create(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPublisherUsecase)(nil).Create), ctx, input)
+}
+
+// DeleteByID mocks base method.
+func (m *MockPublisherUsecase) DeleteByID(ctx context.Context, ID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, ID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockPublisherUsecaseMockRecorder) DeleteByID(ctx, ID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockPublisherUsecase)(nil).DeleteByID), ctx, ID)
+}
+
+// FindAll mocks base method.
+func (m *MockPublisherUsecase) FindAll(ctx context.Context, query model.GetPublishersQueryParams) ([]*model.Publisher, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, query)
+	ret0, _ := ret[0].([]*model.Publisher)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockPublisherUsecaseMockRecorder) FindAll(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockPublisherUsecase)(nil).FindAll), ctx, query)
+}
+
+// FindByID mocks base method.
+func (m *MockPublisherUsecase) FindByID(ctx context.Context, ID int64) (*model.Publisher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, ID)
+	ret0, _ := ret[0].(*model.Publisher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockPublisherUsecaseMockRecorder) FindByID(ctx, ID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockPublisherUsecase)(nil).FindByID), ctx, ID)
+}
+
+// Update mocks base method.
+func (m *MockPublisherUsecase) Update(ctx context.Context, input *model.Publisher) (*model.Publisher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, input)
+	ret0, _ := ret[0].(*model.Publisher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockPublisherUsecaseMockRecorder) Update(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPublisherUsecase)(nil).Update), ctx, input)
+}
+
+// MockPublisherRepository is a mock of PublisherRepository interface.
+type MockPublisherRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPublisherRepositoryMockRecorder
+}
+
+// MockPublisherRepositoryMockRecorder is the mock recorder for MockPublisherRepository.
+type MockPublisherRepositoryMockRecorder struct {
+	mock *MockPublisherRepository
+}
+
+// NewMockPublisherRepository creates a new mock instance.
+func NewMockPublisherRepository(ctrl *gomock.Controller) *MockPublisherRepository {
+	mock := &MockPublisherRepository{ctrl: ctrl}
+	mock.recorder = &MockPublisherRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPublisherRepository) EXPECT() *MockPublisherRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountAll mocks base method.
+func (m *MockPublisherRepository) CountAll(ctx context.Context, query model.GetPublishersQueryParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAll", ctx, query)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAll indicates an expected call of CountAll.
+func (mr *MockPublisherRepositoryMockRecorder) CountAll(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAll", reflect.TypeOf((*MockPublisherRepository)(nil).CountAll), ctx, query)
+}
+
+// Create mocks base method.
+func (m *MockPublisherRepository) Create(ctx context.Context, input *model.Publisher) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPublisherRepositoryMockRecorder) Create(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPublisherRepository)(nil).Create), ctx, input)
+}
+
+// DeleteByID mocks base method.
+func (m *MockPublisherRepository) DeleteByID(ctx context.Context, ID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, ID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockPublisherRepositoryMockRecorder) DeleteByID(ctx, ID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockPublisherRepository)(nil).DeleteByID), ctx, ID)
+}
+
+// FindAll mocks base method.
+func (m *MockPublisherRepository) FindAll(ctx context.Context, query model.GetPublishersQueryParams) ([]*model.Publisher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, query)
+	ret0, _ := ret[0].([]*model.Publisher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockPublisherRepositoryMockRecorder) FindAll(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockPublisherRepository)(nil).FindAll), ctx, query)
+}
+
+// FindByID mocks base method.
+func (m *MockPublisherRepository) FindByID(ctx context.Context, ID int64) (*model.Publisher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, ID)
+	ret0, _ := ret[0].(*model.Publisher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockPublisherRepositoryMockRecorder) FindByID(ctx, ID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockPublisherRepository)(nil).FindByID), ctx, ID)
+}
+
+// Update mocks base method.
+func (m *MockPublisherRepository) Update(ctx context.Context, input *model.Publisher) (*model.Publisher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, input)
+	ret0, _ := ret[0].(*model.Publisher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockPublisherRepositoryMockRecorder) Update(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPublisherRepository)(nil).Update), ctx, input)
+}
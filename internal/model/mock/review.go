@@ -0,0 +1,252 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/model/review.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/ssentinull/create-apis-using-golang/internal/model"
+)
+
+// MockReviewUsecase is a mock of ReviewUsecase interface.
+type MockReviewUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockReviewUsecaseMockRecorder
+}
+
+// MockReviewUsecaseMockRecorder is the mock recorder for MockReviewUsecase.
+type MockReviewUsecaseMockRecorder struct {
+	mock *MockReviewUsecase
+}
+
+// NewMockReviewUsecase creates a new mock instance.
+func NewMockReviewUsecase(ctrl *gomock.Controller) *MockReviewUsecase {
+	mock := &MockReviewUsecase{ctrl: ctrl}
+	mock.recorder = &MockReviewUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReviewUsecase) EXPECT() *MockReviewUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockReviewUsecase) Create(ctx context.Context, review *model.Review) (*model.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, review)
+	ret0, _ := ret[0].(*model.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockReviewUsecaseMockRecorder) Create(ctx, review interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockReviewUsecase)(nil).Create), ctx, review)
+}
+
+// DeleteByID mocks base method.
+func (m *MockReviewUsecase) DeleteByID(ctx context.Context, ID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, ID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockReviewUsecaseMockRecorder) DeleteByID(ctx, ID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockReviewUsecase)(nil).DeleteByID), ctx, ID)
+}
+
+// FindAllByBookID mocks base method.
+func (m *MockReviewUsecase) FindAllByBookID(ctx context.Context, query model.GetReviewsQueryParams) ([]*model.Review, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByBookID", ctx, query)
+	ret0, _ := ret[0].([]*model.Review)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindAllByBookID indicates an expected call of FindAllByBookID.
+func (mr *MockReviewUsecaseMockRecorder) FindAllByBookID(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByBookID", reflect.TypeOf((*MockReviewUsecase)(nil).FindAllByBookID), ctx, query)
+}
+
+// FindByID mocks base method.
+func (m *MockReviewUsecase) FindByID(ctx context.Context, ID int64) (*model.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, ID)
+	ret0, _ := ret[0].(*model.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockReviewUsecaseMockRecorder) FindByID(ctx, ID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockReviewUsecase)(nil).FindByID), ctx, ID)
+}
+
+// Moderate mocks base method.
+func (m *MockReviewUsecase) Moderate(ctx context.Context, input model.ModerateReviewInput) (*model.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Moderate", ctx, input)
+	ret0, _ := ret[0].(*model.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Moderate indicates an expected call of Moderate.
+func (mr *MockReviewUsecaseMockRecorder) Moderate(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Moderate", reflect.TypeOf((*MockReviewUsecase)(nil).Moderate), ctx, input)
+}
+
+// Update mocks base method.
+func (m *MockReviewUsecase) Update(ctx context.Context, input model.UpdateReviewInput) (*model.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, input)
+	ret0, _ := ret[0].(*model.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockReviewUsecaseMockRecorder) Update(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockReviewUsecase)(nil).Update), ctx, input)
+}
+
+// MockReviewRepository is a mock of ReviewRepository interface.
+type MockReviewRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockReviewRepositoryMockRecorder
+}
+
+// MockReviewRepositoryMockRecorder is the mock recorder for MockReviewRepository.
+type MockReviewRepositoryMockRecorder struct {
+	mock *MockReviewRepository
+}
+
+// NewMockReviewRepository creates a new mock instance.
+func NewMockReviewRepository(ctrl *gomock.Controller) *MockReviewRepository {
+	mock := &MockReviewRepository{ctrl: ctrl}
+	mock.recorder = &MockReviewRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReviewRepository) EXPECT() *MockReviewRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountAllByBookID mocks base method.
+func (m *MockReviewRepository) CountAllByBookID(ctx context.Context, query model.GetReviewsQueryParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAllByBookID", ctx, query)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAllByBookID indicates an expected call of CountAllByBookID.
+func (mr *MockReviewRepositoryMockRecorder) CountAllByBookID(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAllByBookID", reflect.TypeOf((*MockReviewRepository)(nil).CountAllByBookID), ctx, query)
+}
+
+// Create mocks base method.
+func (m *MockReviewRepository) Create(ctx context.Context, review *model.Review) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, review)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockReviewRepositoryMockRecorder) Create(ctx, review interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockReviewRepository)(nil).Create), ctx, review)
+}
+
+// DeleteByID mocks base method.
+func (m *MockReviewRepository) DeleteByID(ctx context.Context, ID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, ID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockReviewRepositoryMockRecorder) DeleteByID(ctx, ID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockReviewRepository)(nil).DeleteByID), ctx, ID)
+}
+
+// FindAllByBookID mocks base method.
+func (m *MockReviewRepository) FindAllByBookID(ctx context.Context, query model.GetReviewsQueryParams) ([]*model.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByBookID", ctx, query)
+	ret0, _ := ret[0].([]*model.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByBookID indicates an expected call of FindAllByBookID.
+func (mr *MockReviewRepositoryMockRecorder) FindAllByBookID(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByBookID", reflect.TypeOf((*MockReviewRepository)(nil).FindAllByBookID), ctx, query)
+}
+
+// FindByID mocks base method.
+func (m *MockReviewRepository) FindByID(ctx context.Context, ID int64) (*model.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, ID)
+	ret0, _ := ret[0].(*model.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockReviewRepositoryMockRecorder) FindByID(ctx, ID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockReviewRepository)(nil).FindByID), ctx, ID)
+}
+
+// Update mocks base method.
+func (m *MockReviewRepository) Update(ctx context.Context, input model.UpdateReviewInput) (*model.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, input)
+	ret0, _ := ret[0].(*model.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockReviewRepositoryMockRecorder) Update(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockReviewRepository)(nil).Update), ctx, input)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockReviewRepository) UpdateStatus(ctx context.Context, ID int64, status string) (*model.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, ID, status)
+	ret0, _ := ret[0].(*model.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockReviewRepositoryMockRecorder) UpdateStatus(ctx, ID, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockReviewRepository)(nil).UpdateStatus), ctx, ID, status)
+}
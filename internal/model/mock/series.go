@@ -0,0 +1,237 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/model/series.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/ssentinull/create-apis-using-golang/internal/model"
+)
+
+// MockSeriesUsecase is a mock of SeriesUsecase interface.
+type MockSeriesUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockSeriesUsecaseMockRecorder
+}
+
+// MockSeriesUsecaseMockRecorder is the mock recorder for MockSeriesUsecase.
+type MockSeriesUsecaseMockRecorder struct {
+	mock *MockSeriesUsecase
+}
+
+// NewMockSeriesUsecase creates a new mock instance.
+func NewMockSeriesUsecase(ctrl *gomock.Controller) *MockSeriesUsecase {
+	mock := &MockSeriesUsecase{ctrl: ctrl}
+	mock.recorder = &MockSeriesUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSeriesUsecase) EXPECT() *MockSeriesUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockSeriesUsecase) Create(ctx context.Context, input *model.Series) (*model.Series, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, input)
+	ret0, _ := ret[0].(*model.Series)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockSeriesUsecaseMockRecorder) Create(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSeriesUsecase)(nil).Create), ctx, input)
+}
+
+// DeleteByID mocks base method.
+func (m *MockSeriesUsecase) DeleteByID(ctx context.Context, ID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, ID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockSeriesUsecaseMockRecorder) DeleteByID(ctx, ID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockSeriesUsecase)(nil).DeleteByID), ctx, ID)
+}
+
+// FindAll mocks base method.
+func (m *MockSeriesUsecase) FindAll(ctx context.Context, query model.GetSeriesQueryParams) ([]*model.Series, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, query)
+	ret0, _ := ret[0].([]*model.Series)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockSeriesUsecaseMockRecorder) FindAll(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockSeriesUsecase)(nil).FindAll), ctx, query)
+}
+
+// FindBooks mocks base method.
+func (m *MockSeriesUsecase) FindBooks(ctx context.Context, ID int64) ([]*model.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBooks", ctx, ID)
+	ret0, _ := ret[0].([]*model.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBooks indicates an expected call of FindBooks.
+func (mr *MockSeriesUsecaseMockRecorder) FindBooks(ctx, ID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBooks", reflect.TypeOf((*MockSeriesUsecase)(nil).FindBooks), ctx, ID)
+}
+
+// FindByID mocks base method.
+func (m *MockSeriesUsecase) FindByID(ctx context.Context, ID int64) (*model.Series, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, ID)
+	ret0, _ := ret[0].(*model.Series)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockSeriesUsecaseMockRecorder) FindByID(ctx, ID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockSeriesUsecase)(nil).FindByID), ctx, ID)
+}
+
+// Update mocks base method.
+func (m *MockSeriesUsecase) Update(ctx context.Context, input *model.Series) (*model.Series, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, input)
+	ret0, _ := ret[0].(*model.Series)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockSeriesUsecaseMockRecorder) Update(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockSeriesUsecase)(nil).Update), ctx, input)
+}
+
+// MockSeriesRepository is a mock of SeriesRepository interface.
+type MockSeriesRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSeriesRepositoryMockRecorder
+}
+
+// MockSeriesRepositoryMockRecorder is the mock recorder for MockSeriesRepository.
+type MockSeriesRepositoryMockRecorder struct {
+	mock *MockSeriesRepository
+}
+
+// NewMockSeriesRepository creates a new mock instance.
+func NewMockSeriesRepository(ctrl *gomock.Controller) *MockSeriesRepository {
+	mock := &MockSeriesRepository{ctrl: ctrl}
+	mock.recorder = &MockSeriesRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSeriesRepository) EXPECT() *MockSeriesRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountAll mocks base method.
+func (m *MockSeriesRepository) CountAll(ctx context.Context, query model.GetSeriesQueryParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAll", ctx, query)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAll indicates an expected call of CountAll.
+func (mr *MockSeriesRepositoryMockRecorder) CountAll(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAll", reflect.TypeOf((*MockSeriesRepository)(nil).CountAll), ctx, query)
+}
+
+// Create mocks base method.
+func (m *MockSeriesRepository) Create(ctx context.Context, input *model.Series) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockSeriesRepositoryMockRecorder) Create(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSeriesRepository)(nil).Create), ctx, input)
+}
+
+// DeleteByID mocks base method.
+func (m *MockSeriesRepository) DeleteByID(ctx context.Context, ID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByID", ctx, ID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByID indicates an expected call of DeleteByID.
+func (mr *MockSeriesRepositoryMockRecorder) DeleteByID(ctx, ID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockSeriesRepository)(nil).DeleteByID), ctx, ID)
+}
+
+// FindAll mocks base method.
+func (m *MockSeriesRepository) FindAll(ctx context.Context, query model.GetSeriesQueryParams) ([]*model.Series, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, query)
+	ret0, _ := ret[0].([]*model.Series)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockSeriesRepositoryMockRecorder) FindAll(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockSeriesRepository)(nil).FindAll), ctx, query)
+}
+
+// FindByID mocks base method.
+func (m *MockSeriesRepository) FindByID(ctx context.Context, ID int64) (*model.Series, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, ID)
+	ret0, _ := ret[0].(*model.Series)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockSeriesRepositoryMockRecorder) FindByID(ctx, ID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockSeriesRepository)(nil).FindByID), ctx, ID)
+}
+
+// Update mocks base method.
+func (m *MockSeriesRepository) Update(ctx context.Context, input *model.Series) (*model.Series, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, input)
+	ret0, _ := ret[0].(*model.Series)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockSeriesRepositoryMockRecorder) Update(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockSeriesRepository)(nil).Update), ctx, input)
+}
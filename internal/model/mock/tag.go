@@ -0,0 +1,193 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/model/tag.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/ssentinull/create-apis-using-golang/internal/model"
+)
+
+// MockTagUsecase is a mock of TagUsecase interface.
+type MockTagUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockTagUsecaseMockRecorder
+}
+
+// MockTagUsecaseMockRecorder is the mock recorder for MockTagUsecase.
+type MockTagUsecaseMockRecorder struct {
+	mock *MockTagUsecase
+}
+
+// NewMockTagUsecase creates a new mock instance.
+func NewMockTagUsecase(ctrl *gomock.Controller) *MockTagUsecase {
+	mock := &MockTagUsecase{ctrl: ctrl}
+	mock.recorder = &MockTagUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTagUsecase) EXPECT() *MockTagUsecaseMockRecorder {
+	return m.recorder
+}
+
+// AddBookTags mocks base method.
+func (m *MockTagUsecase) AddBookTags(ctx context.Context, input model.BookTagsInput) ([]*model.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddBookTags", ctx, input)
+	ret0, _ := ret[0].([]*model.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddBookTags indicates an expected call of AddBookTags.
+func (mr *MockTagUsecaseMockRecorder) AddBookTags(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddBookTags", reflect.TypeOf((*MockTagUsecase)(nil).AddBookTags), ctx, input)
+}
+
+// FindAll mocks base method.
+func (m *MockTagUsecase) FindAll(ctx context.Context, query model.GetTagsQueryParams) ([]*model.Tag, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, query)
+	ret0, _ := ret[0].([]*model.Tag)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockTagUsecaseMockRecorder) FindAll(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockTagUsecase)(nil).FindAll), ctx, query)
+}
+
+// FindAllByBookID mocks base method.
+func (m *MockTagUsecase) FindAllByBookID(ctx context.Context, bookID int64) ([]*model.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByBookID", ctx, bookID)
+	ret0, _ := ret[0].([]*model.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByBookID indicates an expected call of FindAllByBookID.
+func (mr *MockTagUsecaseMockRecorder) FindAllByBookID(ctx, bookID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByBookID", reflect.TypeOf((*MockTagUsecase)(nil).FindAllByBookID), ctx, bookID)
+}
+
+// RemoveBookTags mocks base method.
+func (m *MockTagUsecase) RemoveBookTags(ctx context.Context, input model.BookTagsInput) ([]*model.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveBookTags", ctx, input)
+	ret0, _ := ret[0].([]*model.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveBookTags indicates an expected call of RemoveBookTags.
+func (mr *MockTagUsecaseMockRecorder) RemoveBookTags(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveBookTags", reflect.TypeOf((*MockTagUsecase)(nil).RemoveBookTags), ctx, input)
+}
+
+// MockTagRepository is a mock of TagRepository interface.
+type MockTagRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTagRepositoryMockRecorder
+}
+
+// MockTagRepositoryMockRecorder is the mock recorder for MockTagRepository.
+type MockTagRepositoryMockRecorder struct {
+	mock *MockTagRepository
+}
+
+// NewMockTagRepository creates a new mock instance.
+func NewMockTagRepository(ctrl *gomock.Controller) *MockTagRepository {
+	mock := &MockTagRepository{ctrl: ctrl}
+	mock.recorder = &MockTagRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTagRepository) EXPECT() *MockTagRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddBookTags mocks base method.
+func (m *MockTagRepository) AddBookTags(ctx context.Context, bookID int64, names []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddBookTags", ctx, bookID, names)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddBookTags indicates an expected call of AddBookTags.
+func (mr *MockTagRepositoryMockRecorder) AddBookTags(ctx, bookID, names interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddBookTags", reflect.TypeOf((*MockTagRepository)(nil).AddBookTags), ctx, bookID, names)
+}
+
+// CountAll mocks base method.
+func (m *MockTagRepository) CountAll(ctx context.Context, query model.GetTagsQueryParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAll", ctx, query)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAll indicates an expected call of CountAll.
+func (mr *MockTagRepositoryMockRecorder) CountAll(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAll", reflect.TypeOf((*MockTagRepository)(nil).CountAll), ctx, query)
+}
+
+// FindAll mocks base method.
+func (m *MockTagRepository) FindAll(ctx context.Context, query model.GetTagsQueryParams) ([]*model.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, query)
+	ret0, _ := ret[0].([]*model.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockTagRepositoryMockRecorder) FindAll(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockTagRepository)(nil).FindAll), ctx, query)
+}
+
+// FindAllByBookID mocks base method.
+func (m *MockTagRepository) FindAllByBookID(ctx context.Context, bookID int64) ([]*model.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByBookID", ctx, bookID)
+	ret0, _ := ret[0].([]*model.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByBookID indicates an expected call of FindAllByBookID.
+func (mr *MockTagRepositoryMockRecorder) FindAllByBookID(ctx, bookID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByBookID", reflect.TypeOf((*MockTagRepository)(nil).FindAllByBookID), ctx, bookID)
+}
+
+// RemoveBookTags mocks base method.
+func (m *MockTagRepository) RemoveBookTags(ctx context.Context, bookID int64, names []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveBookTags", ctx, bookID, names)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveBookTags indicates an expected call of RemoveBookTags.
+func (mr *MockTagRepositoryMockRecorder) RemoveBookTags(ctx, bookID, names interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveBookTags", reflect.TypeOf((*MockTagRepository)(nil).RemoveBookTags), ctx, bookID, names)
+}
@@ -1,7 +1,11 @@
 package model
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"math"
+
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
 )
 
 type PaginationResponse struct {
@@ -9,15 +13,66 @@ type PaginationResponse struct {
 	Page       int64       `json:"page"`
 	Size       int64       `json:"size"`
 	TotalPages int64       `json:"total_pages"`
+	TotalItems int64       `json:"total_items"`
+	HasNext    bool        `json:"has_next"`
+	HasPrev    bool        `json:"has_prev"`
+	NextCursor string      `json:"next_cursor,omitempty"`
+	PrevCursor string      `json:"prev_cursor,omitempty"`
+	// Facets holds the facet counts of the filtered books, when requested
+	Facets map[string][]*FacetCount `json:"facets,omitempty"`
 }
 
 func NewPaginationResponse(data interface{}, page, size, dataCount int64) PaginationResponse {
-	totalPages := math.Ceil(float64(dataCount) / float64(size))
+	totalPages := float64(0)
+	if size > 0 {
+		totalPages = math.Ceil(float64(dataCount) / float64(size))
+	}
+
 	return PaginationResponse{
 		Data:       data,
 		Page:       page,
 		Size:       size,
 		TotalPages: int64(totalPages),
+		TotalItems: dataCount,
+		HasNext:    page < int64(totalPages),
+		HasPrev:    page > 1,
+	}
+}
+
+func NewCursorPaginationResponse(data interface{}, size, dataCount int64, cursors CursorPagination) PaginationResponse {
+	res := NewPaginationResponse(data, 0, size, dataCount)
+	res.NextCursor = cursors.NextCursor
+	res.PrevCursor = cursors.PrevCursor
+	res.HasNext = cursors.NextCursor != ""
+	res.HasPrev = cursors.PrevCursor != ""
+	return res
+}
+
+// PageQuery holds the page query params of the paginated listings, the
+// query params of a listing embed it
+type PageQuery struct {
+	Page int64 `query:"page"`
+	Size int64 `query:"size"`
+}
+
+// Normalize fills in the pagination defaults and caps the page size at maxSize
+func (q *PageQuery) Normalize(defaultSize, maxSize int64) {
+	if q.Page < 1 {
+		q.Page = 1
+	}
+
+	q.Size = NormalizePageSize(q.Size, defaultSize, maxSize)
+}
+
+// NormalizePageSize falls back to defaultSize when size is not set and caps it at maxSize
+func NormalizePageSize(size, defaultSize, maxSize int64) int64 {
+	switch {
+	case size <= 0:
+		return defaultSize
+	case size > maxSize:
+		return maxSize
+	default:
+		return size
 	}
 }
 
@@ -28,3 +83,45 @@ func Offset(page, size int64) int64 {
 	}
 	return offset
 }
+
+// CursorPagination holds the cursors of the pages around the current one,
+// an empty cursor means there is no page in that direction
+type CursorPagination struct {
+	NextCursor string
+	PrevCursor string
+}
+
+// Cursor is the decoded form of the opaque cursor query param, Values
+// holds the keyset of the last seen row, in the order of the sort columns
+type Cursor struct {
+	Sort     string   `json:"s,omitempty"`
+	Values   []string `json:"v"`
+	Backward bool     `json:"b,omitempty"`
+}
+
+// Encode :nodoc:
+func (c Cursor) Encode() string {
+	bytes, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(bytes)
+}
+
+// DecodeCursor decodes an opaque cursor, an empty string yields the zero cursor
+func DecodeCursor(s string) (Cursor, error) {
+	cursor := Cursor{}
+	if s == "" {
+		return cursor, nil
+	}
+
+	bytes, err := base64.RawURLEncoding.DecodeString(s)
+	if err == nil {
+		err = json.Unmarshal(bytes, &cursor)
+	}
+
+	if err != nil {
+		return cursor, domainerr.ValidationFields("request contains invalid fields", map[string]string{
+			"cursor": "is invalid",
+		})
+	}
+
+	return cursor, nil
+}
@@ -0,0 +1,48 @@
+package model
+
+import (
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+)
+
+const (
+	MIMEApplicationMergePatchJSON = "application/merge-patch+json"
+	MIMEApplicationJSONPatchJSON  = "application/json-patch+json"
+)
+
+// PatchType is the format of a partial update document
+type PatchType string
+
+const (
+	// MergePatch is an RFC 7396 JSON merge patch
+	MergePatch PatchType = "merge-patch"
+	// JSONPatch is an RFC 6902 JSON patch
+	JSONPatch PatchType = "json-patch"
+)
+
+// ApplyPatch applies the patch document of patchType to the JSON document doc
+func ApplyPatch(doc []byte, patchType PatchType, patch []byte) ([]byte, error) {
+	var (
+		patched []byte
+		err     error
+	)
+
+	switch patchType {
+	case MergePatch:
+		patched, err = jsonpatch.MergePatch(doc, patch)
+	case JSONPatch:
+		ops, decodeErr := jsonpatch.DecodePatch(patch)
+		if decodeErr != nil {
+			return nil, domainerr.Validation("patch document is invalid", decodeErr)
+		}
+		patched, err = ops.Apply(doc)
+	default:
+		return nil, domainerr.Validation("patch type is not supported", nil)
+	}
+
+	if err != nil {
+		return nil, domainerr.Validation("patch document cannot be applied", err)
+	}
+
+	return patched, nil
+}
@@ -0,0 +1,65 @@
+package model
+
+import (
+	"context"
+	"time"
+
+	"github.com/ssentinull/create-apis-using-golang/internal/utils"
+	"gorm.io/gorm"
+)
+
+type Publisher struct {
+	ID        int64          `json:"id"`
+	Name      string         `json:"name" validate:"required,notblank,max=255"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"deleted_at"`
+}
+
+type CreatePublisherInput struct {
+	Name string `json:"name" validate:"required,notblank,max=255"`
+}
+
+func (i CreatePublisherInput) ToModel() *Publisher {
+	return &Publisher{
+		ID:        utils.GenerateID(),
+		Name:      i.Name,
+		CreatedAt: time.Now(),
+	}
+}
+
+// UpdatePublisherInput replaces every editable field of a publisher
+type UpdatePublisherInput struct {
+	ID   int64  `json:"-" validate:"required,min=1"`
+	Name string `json:"name" validate:"required,notblank,max=255"`
+}
+
+func (i UpdatePublisherInput) ToModel() *Publisher {
+	return &Publisher{
+		ID:        i.ID,
+		Name:      i.Name,
+		UpdatedAt: time.Now(),
+	}
+}
+
+type GetPublishersQueryParams struct {
+	PageQuery
+	Name string `query:"name" validate:"max=255"`
+}
+
+type PublisherUsecase interface {
+	Create(ctx context.Context, input *Publisher) (publisher *Publisher, err error)
+	DeleteByID(ctx context.Context, ID int64) (err error)
+	FindByID(ctx context.Context, ID int64) (publisher *Publisher, err error)
+	FindAll(ctx context.Context, query GetPublishersQueryParams) (publishers []*Publisher, count int64, err error)
+	Update(ctx context.Context, input *Publisher) (publisher *Publisher, err error)
+}
+
+type PublisherRepository interface {
+	Create(ctx context.Context, input *Publisher) (err error)
+	DeleteByID(ctx context.Context, ID int64) (err error)
+	FindByID(ctx context.Context, ID int64) (publisher *Publisher, err error)
+	FindAll(ctx context.Context, query GetPublishersQueryParams) (publishers []*Publisher, err error)
+	CountAll(ctx context.Context, query GetPublishersQueryParams) (count int64, err error)
+	Update(ctx context.Context, input *Publisher) (publisher *Publisher, err error)
+}
@@ -0,0 +1,111 @@
+package model
+
+import (
+	"context"
+	"time"
+
+	"github.com/ssentinull/create-apis-using-golang/internal/utils"
+)
+
+const (
+	// ReviewStatusPending :nodoc:
+	ReviewStatusPending = "pending"
+	// ReviewStatusApproved :nodoc:
+	ReviewStatusApproved = "approved"
+	// ReviewStatusRejected :nodoc:
+	ReviewStatusRejected = "rejected"
+	// ReviewStatusAll lists the reviews of a book in any status
+	ReviewStatusAll = "all"
+)
+
+// Review is the rating of a book by a reader, only the approved reviews
+// count towards the rating aggregate of the book
+type Review struct {
+	ID          int64      `json:"id"`
+	BookID      int64      `json:"book_id" validate:"required,min=1"`
+	Rating      int        `json:"rating" validate:"required,min=1,max=5"`
+	Body        string     `json:"body" validate:"max=5000"`
+	Reviewer    string     `json:"reviewer" validate:"required,notblank,max=255"`
+	Status      string     `json:"status" validate:"required,oneof=pending approved rejected"`
+	ModeratedAt *time.Time `json:"moderated_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// CountsTowardsRating reports whether the review is part of the rating
+// aggregate of its book
+func (r *Review) CountsTowardsRating() bool {
+	return r.Status == ReviewStatusApproved
+}
+
+// CreateReviewInput is a new review, which awaits moderation
+type CreateReviewInput struct {
+	BookID   int64  `json:"-" validate:"required,min=1"`
+	Rating   int    `json:"rating" validate:"required,min=1,max=5"`
+	Body     string `json:"body" validate:"max=5000"`
+	Reviewer string `json:"reviewer" validate:"required,notblank,max=255"`
+}
+
+func (i CreateReviewInput) ToModel() *Review {
+	return &Review{
+		ID:        utils.GenerateID(),
+		BookID:    i.BookID,
+		Rating:    i.Rating,
+		Body:      i.Body,
+		Reviewer:  i.Reviewer,
+		Status:    ReviewStatusPending,
+		CreatedAt: time.Now(),
+	}
+}
+
+// UpdateReviewInput replaces the rating and the body of a review, which
+// then awaits moderation again
+type UpdateReviewInput struct {
+	ID     int64  `json:"-" validate:"required,min=1"`
+	Rating int    `json:"rating" validate:"required,min=1,max=5"`
+	Body   string `json:"body" validate:"max=5000"`
+}
+
+// ModerateReviewInput moves a review to another moderation status
+type ModerateReviewInput struct {
+	ID     int64  `json:"-" validate:"required,min=1"`
+	Status string `json:"status" validate:"required,oneof=pending approved rejected"`
+}
+
+// GetReviewsQueryParams lists the reviews of a book, the approved ones
+// unless Status says otherwise
+type GetReviewsQueryParams struct {
+	BookID int64 `query:"-" validate:"required,min=1"`
+	PageQuery
+	Status string `query:"status" validate:"omitempty,oneof=pending approved rejected all"`
+}
+
+// Normalize fills in the pagination and status defaults and caps the page size at maxSize
+func (q *GetReviewsQueryParams) Normalize(defaultSize, maxSize int64) {
+	q.PageQuery.Normalize(defaultSize, maxSize)
+
+	if q.Status == "" {
+		q.Status = ReviewStatusApproved
+	}
+}
+
+type ReviewUsecase interface {
+	Create(ctx context.Context, review *Review) (created *Review, err error)
+	DeleteByID(ctx context.Context, ID int64) (err error)
+	FindByID(ctx context.Context, ID int64) (review *Review, err error)
+	FindAllByBookID(ctx context.Context, query GetReviewsQueryParams) (reviews []*Review, count int64, err error)
+	Update(ctx context.Context, input UpdateReviewInput) (review *Review, err error)
+	Moderate(ctx context.Context, input ModerateReviewInput) (review *Review, err error)
+}
+
+// ReviewRepository keeps the rating aggregate of the books in step with
+// their approved reviews, in the same transaction as every review write
+type ReviewRepository interface {
+	Create(ctx context.Context, review *Review) (err error)
+	DeleteByID(ctx context.Context, ID int64) (err error)
+	FindByID(ctx context.Context, ID int64) (review *Review, err error)
+	FindAllByBookID(ctx context.Context, query GetReviewsQueryParams) (reviews []*Review, err error)
+	CountAllByBookID(ctx context.Context, query GetReviewsQueryParams) (count int64, err error)
+	Update(ctx context.Context, input UpdateReviewInput) (review *Review, err error)
+	UpdateStatus(ctx context.Context, ID int64, status string) (review *Review, err error)
+}
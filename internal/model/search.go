@@ -0,0 +1,41 @@
+package model
+
+// SearchBooksQueryParams is a full-text search over the title, the author and
+// the description of the books, Q is in the web search syntax of postgres,
+// eg: "clean code" -java or golang
+type SearchBooksQueryParams struct {
+	Q string `query:"q" validate:"required,notblank,max=255"`
+	PageQuery
+}
+
+// BookSearchResult is a book matching a search, ranked by relevance
+type BookSearchResult struct {
+	Book
+	Rank       float64        `json:"rank"`
+	Highlights BookHighlights `json:"highlights" gorm:"embedded;embeddedPrefix:highlight_"`
+}
+
+// BookHighlights holds the snippets of the fields of a book where the
+// matching words are wrapped in <mark> tags
+type BookHighlights struct {
+	Title       string `json:"title"`
+	Author      string `json:"author"`
+	Description string `json:"description"`
+}
+
+// SuggestBooksQueryParams is a typo tolerant lookup of the titles and the
+// authors of the books, for autocompletion
+type SuggestBooksQueryParams struct {
+	Q     string `query:"q" validate:"required,notblank,max=255"`
+	Limit int64  `query:"limit"`
+	// Threshold is the minimum trigram word similarity of a suggestion, between 0 and 1
+	Threshold float64 `query:"-"`
+}
+
+// BookSuggestion is a title or an author similar to the suggest query,
+// Field tells which one it is
+type BookSuggestion struct {
+	Field      string  `json:"field"`
+	Value      string  `json:"value"`
+	Similarity float64 `json:"similarity"`
+}
@@ -0,0 +1,77 @@
+package model
+
+import (
+	"context"
+	"time"
+
+	"github.com/ssentinull/create-apis-using-golang/internal/utils"
+	"gorm.io/gorm"
+)
+
+// Series is an ordered sequence of books, the position of a book within it
+// is kept on the book
+type Series struct {
+	ID          int64          `json:"id"`
+	Name        string         `json:"name" validate:"required,notblank,max=255"`
+	Description string         `json:"description" validate:"max=5000"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `json:"deleted_at"`
+}
+
+func (Series) TableName() string {
+	return "series"
+}
+
+type CreateSeriesInput struct {
+	Name        string `json:"name" validate:"required,notblank,max=255"`
+	Description string `json:"description" validate:"max=5000"`
+}
+
+func (i CreateSeriesInput) ToModel() *Series {
+	return &Series{
+		ID:          utils.GenerateID(),
+		Name:        i.Name,
+		Description: i.Description,
+		CreatedAt:   time.Now(),
+	}
+}
+
+// UpdateSeriesInput replaces every editable field of a series
+type UpdateSeriesInput struct {
+	ID          int64  `json:"-" validate:"required,min=1"`
+	Name        string `json:"name" validate:"required,notblank,max=255"`
+	Description string `json:"description" validate:"max=5000"`
+}
+
+func (i UpdateSeriesInput) ToModel() *Series {
+	return &Series{
+		ID:          i.ID,
+		Name:        i.Name,
+		Description: i.Description,
+		UpdatedAt:   time.Now(),
+	}
+}
+
+type GetSeriesQueryParams struct {
+	PageQuery
+	Name string `query:"name" validate:"max=255"`
+}
+
+type SeriesUsecase interface {
+	Create(ctx context.Context, input *Series) (series *Series, err error)
+	DeleteByID(ctx context.Context, ID int64) (err error)
+	FindByID(ctx context.Context, ID int64) (series *Series, err error)
+	FindAll(ctx context.Context, query GetSeriesQueryParams) (series []*Series, count int64, err error)
+	FindBooks(ctx context.Context, ID int64) (books []*Book, err error)
+	Update(ctx context.Context, input *Series) (series *Series, err error)
+}
+
+type SeriesRepository interface {
+	Create(ctx context.Context, input *Series) (err error)
+	DeleteByID(ctx context.Context, ID int64) (err error)
+	FindByID(ctx context.Context, ID int64) (series *Series, err error)
+	FindAll(ctx context.Context, query GetSeriesQueryParams) (series []*Series, err error)
+	CountAll(ctx context.Context, query GetSeriesQueryParams) (count int64, err error)
+	Update(ctx context.Context, input *Series) (series *Series, err error)
+}
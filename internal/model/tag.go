@@ -0,0 +1,98 @@
+package model
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+const (
+	// TagsMatchAny matches the books having any of the tags, which is the default
+	TagsMatchAny = "any"
+	// TagsMatchAll matches the books having all of the tags
+	TagsMatchAll = "all"
+)
+
+// Tag is a free-form label of books, its name is kept normalized by NormalizeTagName
+type Tag struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// BookTag links a book to one of its tags
+type BookTag struct {
+	BookID int64
+	TagID  int64
+}
+
+func (BookTag) TableName() string {
+	return "book_tags"
+}
+
+// BookTagsInput adds or removes tags of a book by name, the tags that don't
+// exist yet are created when added
+type BookTagsInput struct {
+	BookID int64    `json:"-" validate:"required,min=1"`
+	Tags   []string `json:"tags" query:"-" validate:"required,min=1,max=20,dive,notblank,max=50"`
+}
+
+// TagNames returns the normalized tag names of the input, duplicates are dropped
+func (i BookTagsInput) TagNames() []string {
+	return normalizeTagNames(i.Tags)
+}
+
+type GetTagsQueryParams struct {
+	PageQuery
+	Name string `query:"name" validate:"max=50"`
+}
+
+// TagNames parses the comma separated tags query param into normalized tag
+// names, duplicates and blanks are dropped
+func (q GetBooksQueryParams) TagNames() []string {
+	if strings.TrimSpace(q.Tags) == "" {
+		return []string{}
+	}
+
+	return normalizeTagNames(strings.Split(q.Tags, ","))
+}
+
+// MatchAllTags reports whether the books must have every tag of the tags query param
+func (q GetBooksQueryParams) MatchAllTags() bool {
+	return q.TagsMatch == TagsMatchAll
+}
+
+// NormalizeTagName trims and lowercases name, so that tags differing only
+// by case or surrounding spaces are the same tag
+func NormalizeTagName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+func normalizeTagNames(names []string) []string {
+	normalized := []string{}
+	seen := map[string]bool{}
+	for _, name := range names {
+		name = NormalizeTagName(name)
+		if name != "" && !seen[name] {
+			seen[name] = true
+			normalized = append(normalized, name)
+		}
+	}
+
+	return normalized
+}
+
+type TagUsecase interface {
+	FindAll(ctx context.Context, query GetTagsQueryParams) (tags []*Tag, count int64, err error)
+	FindAllByBookID(ctx context.Context, bookID int64) (tags []*Tag, err error)
+	AddBookTags(ctx context.Context, input BookTagsInput) (tags []*Tag, err error)
+	RemoveBookTags(ctx context.Context, input BookTagsInput) (tags []*Tag, err error)
+}
+
+type TagRepository interface {
+	FindAll(ctx context.Context, query GetTagsQueryParams) (tags []*Tag, err error)
+	CountAll(ctx context.Context, query GetTagsQueryParams) (count int64, err error)
+	FindAllByBookID(ctx context.Context, bookID int64) (tags []*Tag, err error)
+	AddBookTags(ctx context.Context, bookID int64, names []string) (err error)
+	RemoveBookTags(ctx context.Context, bookID int64, names []string) (err error)
+}
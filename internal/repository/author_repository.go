@@ -0,0 +1,432 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/sirupsen/logrus"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+	"github.com/ssentinull/create-apis-using-golang/internal/utils"
+	"gorm.io/gorm"
+)
+
+type authorRepo struct {
+	db        *gorm.DB
+	cacheRepo model.CacheRepository
+}
+
+func NewAuthorRepository(db *gorm.DB, cacheRepo model.CacheRepository) model.AuthorRepository {
+	return &authorRepo{
+		db:        db,
+		cacheRepo: cacheRepo,
+	}
+}
+
+func (ar *authorRepo) Create(ctx context.Context, author *model.Author) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":    utils.Dump(ctx),
+		"author": utils.Dump(author),
+	})
+
+	err := ar.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(author).Error; err != nil {
+			return parseDBError(err)
+		}
+		return nil
+	})
+
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if err := ar.cacheRepo.Delete(ctx, ar.cacheHash()); err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (ar *authorRepo) DeleteByID(ctx context.Context, ID int64) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx": utils.Dump(ctx),
+		"ID":  ID,
+	})
+
+	err := ar.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Delete(&model.Author{}, ID)
+		if err := res.Error; err != nil {
+			return parseDBError(err)
+		}
+
+		if res.RowsAffected == 0 {
+			return domainerr.NotFound(fmt.Sprintf("author %d not found", ID), nil)
+		}
+
+		// a deleted author no longer credits its books
+		if err := tx.Where("author_id = ?", ID).Delete(&model.BookAuthor{}).Error; err != nil {
+			return parseDBError(err)
+		}
+		return nil
+	})
+
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	// the books are filtered by their authors, so their listings are stale too
+	cacheKeys := []string{
+		ar.findByIDCacheKey(ID),
+		ar.cacheHash(),
+		bookCacheHash,
+	}
+
+	if err := ar.cacheRepo.Delete(ctx, cacheKeys...); err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (ar *authorRepo) FindByID(ctx context.Context, ID int64) (*model.Author, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx": utils.Dump(ctx),
+		"ID":  ID,
+	})
+
+	cacheKey := ar.findByIDCacheKey(ID)
+	reply, err := ar.cacheRepo.Get(ctx, cacheKey)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if reply != "" {
+		author := &model.Author{}
+		if err := json.Unmarshal([]byte(reply), &author); err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+		return author, nil
+	}
+
+	author := &model.Author{}
+	err = ar.db.WithContext(ctx).Where("id = ?", ID).Take(author).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, parseDBError(err)
+	}
+
+	bytes, err := json.Marshal(author)
+	if err != nil {
+		logger.Error(err)
+		return author, nil
+	}
+
+	if err := ar.cacheRepo.Set(ctx, cacheKey, string(bytes)); err != nil {
+		logger.Error(err)
+	}
+
+	return author, nil
+}
+
+func (ar *authorRepo) FindAll(ctx context.Context, query model.GetAuthorsQueryParams) ([]*model.Author, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   utils.Dump(ctx),
+		"query": utils.Dump(query),
+	})
+
+	cacheHash := ar.cacheHash()
+	cacheKey := ar.findAllCacheKey(query)
+	reply, err := ar.cacheRepo.HashGet(ctx, cacheHash, cacheKey)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if reply != "" {
+		authors := []*model.Author{}
+		if err := json.Unmarshal([]byte(reply), &authors); err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+		return authors, nil
+	}
+
+	authors := []*model.Author{}
+	err = ar.applyFilters(ar.db.WithContext(ctx), query).
+		Order("name ASC").
+		Order("id ASC").
+		Offset(int(model.Offset(query.Page, query.Size))).
+		Limit(int(query.Size)).
+		Find(&authors).
+		Error
+	if err != nil {
+		logger.Error(err)
+		return nil, parseDBError(err)
+	}
+
+	bytes, err := json.Marshal(authors)
+	if err != nil {
+		logger.Error(err)
+		return authors, nil
+	}
+
+	if err := ar.cacheRepo.HashSet(ctx, cacheHash, cacheKey, string(bytes)); err != nil {
+		logger.Error(err)
+	}
+
+	return authors, nil
+}
+
+func (ar *authorRepo) CountAll(ctx context.Context, query model.GetAuthorsQueryParams) (int64, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   utils.Dump(ctx),
+		"query": utils.Dump(query),
+	})
+
+	cacheHash := ar.cacheHash()
+	cacheKey := ar.countAllCacheKey(query)
+	reply, err := ar.cacheRepo.HashGet(ctx, cacheHash, cacheKey)
+	if err != nil {
+		logger.Error(err)
+		return 0, err
+	}
+
+	if reply != "" {
+		count := int64(0)
+		if err := json.Unmarshal([]byte(reply), &count); err != nil {
+			logger.Error(err)
+			return 0, err
+		}
+		return count, nil
+	}
+
+	count := int64(0)
+	err = ar.applyFilters(ar.db.WithContext(ctx), query).
+		Model(&model.Author{}).
+		Count(&count).
+		Error
+	if err != nil {
+		logger.Error(err)
+		return int64(0), parseDBError(err)
+	}
+
+	bytes, err := json.Marshal(count)
+	if err != nil {
+		logger.Error(err)
+		return 0, err
+	}
+
+	if err := ar.cacheRepo.HashSet(ctx, cacheHash, cacheKey, string(bytes)); err != nil {
+		logger.Error(err)
+	}
+
+	return count, nil
+}
+
+func (ar *authorRepo) FindAllByBookID(ctx context.Context, bookID int64) ([]*model.Author, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":    utils.Dump(ctx),
+		"bookID": bookID,
+	})
+
+	cacheHash := ar.cacheHash()
+	cacheKey := ar.findAllByBookIDCacheKey(bookID)
+	reply, err := ar.cacheRepo.HashGet(ctx, cacheHash, cacheKey)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if reply != "" {
+		authors := []*model.Author{}
+		if err := json.Unmarshal([]byte(reply), &authors); err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+		return authors, nil
+	}
+
+	authors := []*model.Author{}
+	err = ar.db.WithContext(ctx).
+		Joins("JOIN book_authors ON book_authors.author_id = authors.id").
+		Where("book_authors.book_id = ?", bookID).
+		Order("authors.name ASC").
+		Find(&authors).
+		Error
+	if err != nil {
+		logger.Error(err)
+		return nil, parseDBError(err)
+	}
+
+	bytes, err := json.Marshal(authors)
+	if err != nil {
+		logger.Error(err)
+		return authors, nil
+	}
+
+	if err := ar.cacheRepo.HashSet(ctx, cacheHash, cacheKey, string(bytes)); err != nil {
+		logger.Error(err)
+	}
+
+	return authors, nil
+}
+
+func (ar *authorRepo) Update(ctx context.Context, author *model.Author) (*model.Author, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":    utils.Dump(ctx),
+		"author": utils.Dump(author),
+	})
+
+	err := ar.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(author).Updates(map[string]interface{}{
+			"name": author.Name,
+			"bio":  author.Bio,
+		})
+		if err := res.Error; err != nil {
+			return parseDBError(err)
+		}
+
+		if res.RowsAffected == 0 {
+			return domainerr.NotFound(fmt.Sprintf("author %d not found", author.ID), nil)
+		}
+		return nil
+	})
+
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	cacheKeys := []string{
+		ar.cacheHash(),
+		ar.findByIDCacheKey(author.ID),
+	}
+
+	if err := ar.cacheRepo.Delete(ctx, cacheKeys...); err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return ar.FindByID(ctx, author.ID)
+}
+
+// ReplaceBookAuthors links the book to exactly the given authors, which
+// must all exist, along with the book
+func (ar *authorRepo) ReplaceBookAuthors(ctx context.Context, bookID int64, authorIDs []int64) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":       utils.Dump(ctx),
+		"bookID":    bookID,
+		"authorIDs": authorIDs,
+	})
+
+	err := ar.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		count := int64(0)
+		if err := tx.Model(&model.Book{}).Where("id = ?", bookID).Count(&count).Error; err != nil {
+			return parseDBError(err)
+		}
+
+		if count == 0 {
+			return domainerr.NotFound(fmt.Sprintf("book %d not found", bookID), nil)
+		}
+
+		links := []*model.BookAuthor{}
+		if len(authorIDs) > 0 {
+			foundIDs := []int64{}
+			if err := tx.Model(&model.Author{}).Where("id IN ?", authorIDs).Pluck("id", &foundIDs).Error; err != nil {
+				return parseDBError(err)
+			}
+
+			found := make(map[int64]bool, len(foundIDs))
+			for _, ID := range foundIDs {
+				found[ID] = true
+			}
+
+			linked := make(map[int64]bool, len(authorIDs))
+			for _, ID := range authorIDs {
+				if !found[ID] {
+					return domainerr.NotFound(fmt.Sprintf("author %d not found", ID), nil)
+				}
+
+				// a repeated author is linked only once
+				if !linked[ID] {
+					linked[ID] = true
+					links = append(links, &model.BookAuthor{BookID: bookID, AuthorID: ID})
+				}
+			}
+		}
+
+		if err := tx.Where("book_id = ?", bookID).Delete(&model.BookAuthor{}).Error; err != nil {
+			return parseDBError(err)
+		}
+
+		if len(links) == 0 {
+			return nil
+		}
+
+		if err := tx.Create(links).Error; err != nil {
+			return parseDBError(err)
+		}
+		return nil
+	})
+
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	// the books are filtered by their authors, so their listings are stale too
+	cacheKeys := []string{
+		ar.cacheHash(),
+		bookCacheHash,
+	}
+
+	if err := ar.cacheRepo.Delete(ctx, cacheKeys...); err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (ar *authorRepo) cacheHash() string {
+	return "author"
+}
+
+func (ar *authorRepo) findByIDCacheKey(ID int64) string {
+	return fmt.Sprintf("author:%d", ID)
+}
+
+func (ar *authorRepo) findAllCacheKey(query model.GetAuthorsQueryParams) string {
+	return fmt.Sprintf("author:page:%d:size:%d:%s", query.Page, query.Size, ar.filtersCacheKey(query))
+}
+
+func (ar *authorRepo) countAllCacheKey(query model.GetAuthorsQueryParams) string {
+	return fmt.Sprintf("author:count:%s", ar.filtersCacheKey(query))
+}
+
+func (ar *authorRepo) findAllByBookIDCacheKey(bookID int64) string {
+	return fmt.Sprintf("author:book:%d", bookID)
+}
+
+func (ar *authorRepo) filtersCacheKey(query model.GetAuthorsQueryParams) string {
+	filters := url.Values{}
+	filters.Set("name", query.Name)
+	return filters.Encode()
+}
+
+// applyFilters narrows db down to the authors matching the filters in query
+func (ar *authorRepo) applyFilters(db *gorm.DB, query model.GetAuthorsQueryParams) *gorm.DB {
+	if query.Name != "" {
+		db = db.Where("name ILIKE ?", "%"+escapeLike(query.Name)+"%")
+	}
+
+	return db
+}
@@ -0,0 +1,428 @@
+package repository
+
+import (
+	"encoding/json"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+var testAuthor = model.Author{
+	ID:   int64(1),
+	Name: "J. K. Rowling",
+	Bio:  "British author",
+}
+
+func TestAuthorRepository_Create(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := authorRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	author := testAuthor
+	query := `INSERT INTO "authors" ("name","bio","created_at","updated_at","deleted_at","id") VALUES ($1,$2,$3,$4,$5,$6) RETURNING "id"`
+
+	t.Run("success", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(author.ID))
+		mockedDependency.sql.ExpectCommit()
+		mockedDependency.cacheRepo.EXPECT().Delete(ctx, repo.cacheHash()).Times(1).Return(nil)
+
+		err := repo.Create(ctx, &author)
+		assert.NoError(t, err)
+	})
+
+	t.Run("failed - create author in db return error", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(errors.New("db error"))
+		mockedDependency.sql.ExpectRollback()
+
+		err := repo.Create(ctx, &author)
+		assert.Error(t, err)
+	})
+
+	t.Run("failed - delete cache return error", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(author.ID))
+		mockedDependency.sql.ExpectCommit()
+		mockedDependency.cacheRepo.EXPECT().Delete(ctx, repo.cacheHash()).Times(1).Return(errors.New("cache error"))
+
+		err := repo.Create(ctx, &author)
+		assert.Error(t, err)
+	})
+}
+
+func TestAuthorRepository_DeleteByID(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := authorRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	cacheKeys := []string{
+		repo.findByIDCacheKey(testAuthor.ID),
+		repo.cacheHash(),
+		bookCacheHash,
+	}
+
+	query := `UPDATE "authors" SET "deleted_at"=$1 WHERE "authors"."id" = $2 AND "authors"."deleted_at" IS NULL`
+	linksQuery := `DELETE FROM "book_authors" WHERE author_id = $1`
+
+	t.Run("success", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(query)).WithArgs(sqlmock.AnyArg(), testAuthor.ID).WillReturnResult(sqlmock.NewResult(1, 1))
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(linksQuery)).WithArgs(testAuthor.ID).WillReturnResult(sqlmock.NewResult(0, 2))
+		mockedDependency.sql.ExpectCommit()
+		mockedDependency.cacheRepo.EXPECT().Delete(ctx, cacheKeys).Times(1).Return(nil)
+
+		err := repo.DeleteByID(ctx, testAuthor.ID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("failed - author not found", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(query)).WillReturnResult(sqlmock.NewResult(0, 0))
+		mockedDependency.sql.ExpectRollback()
+
+		err := repo.DeleteByID(ctx, testAuthor.ID)
+		assert.ErrorIs(t, err, domainerr.ErrNotFound)
+	})
+
+	t.Run("failed - delete book links return error", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(query)).WillReturnResult(sqlmock.NewResult(1, 1))
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(linksQuery)).WillReturnError(errors.New("db error"))
+		mockedDependency.sql.ExpectRollback()
+
+		err := repo.DeleteByID(ctx, testAuthor.ID)
+		assert.Error(t, err)
+	})
+
+	t.Run("failed - delete cache return error", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(query)).WillReturnResult(sqlmock.NewResult(1, 1))
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(linksQuery)).WillReturnResult(sqlmock.NewResult(0, 0))
+		mockedDependency.sql.ExpectCommit()
+		mockedDependency.cacheRepo.EXPECT().Delete(ctx, cacheKeys).Times(1).Return(errors.New("cache error"))
+
+		err := repo.DeleteByID(ctx, testAuthor.ID)
+		assert.Error(t, err)
+	})
+}
+
+func TestAuthorRepository_FindByID(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := authorRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	query := `SELECT * FROM "authors" WHERE id = $1 AND "authors"."deleted_at" IS NULL LIMIT 1`
+
+	cacheKey := repo.findByIDCacheKey(testAuthor.ID)
+	bytes, err := json.Marshal(testAuthor)
+	assert.NoError(t, err)
+
+	t.Run("success - fetch from cache", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().Get(ctx, cacheKey).Times(1).Return(string(bytes), nil)
+		res, err := repo.FindByID(ctx, testAuthor.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, testAuthor.Name, res.Name)
+	})
+
+	t.Run("success - fetch from db", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "name", "bio"}).AddRow(testAuthor.ID, testAuthor.Name, testAuthor.Bio)
+
+		mockedDependency.cacheRepo.EXPECT().Get(ctx, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(testAuthor.ID).WillReturnRows(rows)
+		mockedDependency.cacheRepo.EXPECT().Set(ctx, cacheKey, gomock.Any()).Times(1).Return(nil)
+
+		res, err := repo.FindByID(ctx, testAuthor.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, testAuthor.Name, res.Name)
+	})
+
+	t.Run("failed - author not found", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().Get(ctx, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(gorm.ErrRecordNotFound)
+
+		res, err := repo.FindByID(ctx, testAuthor.ID)
+		assert.ErrorIs(t, err, domainerr.ErrNotFound)
+		assert.Nil(t, res)
+	})
+}
+
+func TestAuthorRepository_FindAll(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := authorRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	queryParams := model.GetAuthorsQueryParams{PageQuery: model.PageQuery{Page: 2, Size: 5}, Name: "rowling"}
+	query := `SELECT * FROM "authors" WHERE name ILIKE $1 AND "authors"."deleted_at" IS NULL ORDER BY name ASC,id ASC LIMIT 5 OFFSET 5`
+
+	cacheHash := repo.cacheHash()
+	cacheKey := repo.findAllCacheKey(queryParams)
+	bytes, err := json.Marshal([]*model.Author{&testAuthor})
+	assert.NoError(t, err)
+
+	t.Run("success - fetch from cache", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return(string(bytes), nil)
+		res, err := repo.FindAll(ctx, queryParams)
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+	})
+
+	t.Run("success - fetch from db", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "name", "bio"}).AddRow(testAuthor.ID, testAuthor.Name, testAuthor.Bio)
+
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WithArgs("%rowling%").WillReturnRows(rows)
+		mockedDependency.cacheRepo.EXPECT().HashSet(ctx, cacheHash, cacheKey, gomock.Any()).Times(1).Return(nil)
+
+		res, err := repo.FindAll(ctx, queryParams)
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+	})
+
+	t.Run("failed - fetch from db return error", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(errors.New("db error"))
+
+		res, err := repo.FindAll(ctx, queryParams)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestAuthorRepository_CountAll(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := authorRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	queryParams := model.GetAuthorsQueryParams{PageQuery: model.PageQuery{Page: 1, Size: 5}}
+	query := `SELECT count(*) FROM "authors" WHERE "authors"."deleted_at" IS NULL`
+
+	cacheHash := repo.cacheHash()
+	cacheKey := repo.countAllCacheKey(queryParams)
+
+	t.Run("success - fetch from cache", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("2", nil)
+		res, err := repo.CountAll(ctx, queryParams)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), res)
+	})
+
+	t.Run("success - fetch from db", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+		mockedDependency.cacheRepo.EXPECT().HashSet(ctx, cacheHash, cacheKey, "2").Times(1).Return(nil)
+
+		res, err := repo.CountAll(ctx, queryParams)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), res)
+	})
+
+	t.Run("failed - fetch from db return error", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(errors.New("db error"))
+
+		res, err := repo.CountAll(ctx, queryParams)
+		assert.Error(t, err)
+		assert.Zero(t, res)
+	})
+}
+
+func TestAuthorRepository_FindAllByBookID(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := authorRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	bookID := int64(10)
+	query := `SELECT "authors"."id","authors"."name","authors"."bio","authors"."created_at","authors"."updated_at","authors"."deleted_at" FROM "authors" ` +
+		`JOIN book_authors ON book_authors.author_id = authors.id WHERE book_authors.book_id = $1 AND "authors"."deleted_at" IS NULL ORDER BY authors.name ASC`
+
+	cacheHash := repo.cacheHash()
+	cacheKey := repo.findAllByBookIDCacheKey(bookID)
+	bytes, err := json.Marshal([]*model.Author{&testAuthor})
+	assert.NoError(t, err)
+
+	t.Run("success - fetch from cache", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return(string(bytes), nil)
+		res, err := repo.FindAllByBookID(ctx, bookID)
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+	})
+
+	t.Run("success - fetch from db", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "name", "bio"}).AddRow(testAuthor.ID, testAuthor.Name, testAuthor.Bio)
+
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(bookID).WillReturnRows(rows)
+		mockedDependency.cacheRepo.EXPECT().HashSet(ctx, cacheHash, cacheKey, gomock.Any()).Times(1).Return(nil)
+
+		res, err := repo.FindAllByBookID(ctx, bookID)
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+	})
+
+	t.Run("failed - fetch from db return error", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(errors.New("db error"))
+
+		res, err := repo.FindAllByBookID(ctx, bookID)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestAuthorRepository_Update(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := authorRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	author := testAuthor
+	cacheKeys := []string{
+		repo.cacheHash(),
+		repo.findByIDCacheKey(author.ID),
+	}
+
+	query := `UPDATE "authors" SET "bio"=$1,"name"=$2,"updated_at"=$3 WHERE "authors"."deleted_at" IS NULL AND "id" = $4`
+	bytes, err := json.Marshal(author)
+	assert.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(query)).WillReturnResult(sqlmock.NewResult(1, 1))
+		mockedDependency.sql.ExpectCommit()
+		mockedDependency.cacheRepo.EXPECT().Delete(ctx, cacheKeys).Times(1).Return(nil)
+		mockedDependency.cacheRepo.EXPECT().Get(ctx, repo.findByIDCacheKey(author.ID)).Times(1).Return(string(bytes), nil)
+
+		res, err := repo.Update(ctx, &author)
+		assert.NoError(t, err)
+		assert.Equal(t, author.Name, res.Name)
+	})
+
+	t.Run("failed - author not found", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(query)).WillReturnResult(sqlmock.NewResult(0, 0))
+		mockedDependency.sql.ExpectRollback()
+
+		res, err := repo.Update(ctx, &author)
+		assert.ErrorIs(t, err, domainerr.ErrNotFound)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - name is taken", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(query)).WillReturnError(&pgconn.PgError{Code: pgUniqueViolationCode})
+		mockedDependency.sql.ExpectRollback()
+
+		res, err := repo.Update(ctx, &author)
+		assert.ErrorIs(t, err, domainerr.ErrConflict)
+		assert.Nil(t, res)
+	})
+}
+
+func TestAuthorRepository_ReplaceBookAuthors(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := authorRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	bookID := int64(10)
+	cacheKeys := []string{
+		repo.cacheHash(),
+		bookCacheHash,
+	}
+
+	countQuery := `SELECT count(*) FROM "books" WHERE id = $1 AND "books"."deleted_at" IS NULL`
+	authorsQuery := `SELECT "id" FROM "authors" WHERE id IN`
+	deleteQuery := `DELETE FROM "book_authors" WHERE book_id = $1`
+	insertQuery := `INSERT INTO "book_authors" ("book_id","author_id") VALUES ($1,$2),($3,$4)`
+
+	t.Run("success", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(countQuery)).WithArgs(bookID).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(authorsQuery)).WithArgs(int64(1), int64(2), int64(1)).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(deleteQuery)).WithArgs(bookID).WillReturnResult(sqlmock.NewResult(0, 3))
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(insertQuery)).WithArgs(bookID, int64(1), bookID, int64(2)).WillReturnResult(sqlmock.NewResult(0, 2))
+		mockedDependency.sql.ExpectCommit()
+		mockedDependency.cacheRepo.EXPECT().Delete(ctx, cacheKeys).Times(1).Return(nil)
+
+		err := repo.ReplaceBookAuthors(ctx, bookID, []int64{1, 2, 1})
+		assert.NoError(t, err)
+	})
+
+	t.Run("success - unlink every author", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(countQuery)).WithArgs(bookID).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(deleteQuery)).WithArgs(bookID).WillReturnResult(sqlmock.NewResult(0, 3))
+		mockedDependency.sql.ExpectCommit()
+		mockedDependency.cacheRepo.EXPECT().Delete(ctx, cacheKeys).Times(1).Return(nil)
+
+		err := repo.ReplaceBookAuthors(ctx, bookID, nil)
+		assert.NoError(t, err)
+	})
+
+	t.Run("failed - book not found", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(countQuery)).WithArgs(bookID).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mockedDependency.sql.ExpectRollback()
+
+		err := repo.ReplaceBookAuthors(ctx, bookID, []int64{1})
+		assert.ErrorIs(t, err, domainerr.ErrNotFound)
+	})
+
+	t.Run("failed - author not found", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(countQuery)).WithArgs(bookID).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(authorsQuery)).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		mockedDependency.sql.ExpectRollback()
+
+		err := repo.ReplaceBookAuthors(ctx, bookID, []int64{1, 2})
+		assert.ErrorIs(t, err, domainerr.ErrNotFound)
+		assert.Contains(t, err.Error(), "author 2 not found")
+	})
+}
@@ -0,0 +1,377 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+	"github.com/ssentinull/create-apis-using-golang/internal/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// bookCopyCacheHash holds the cached copy listings
+const bookCopyCacheHash = "book_copy"
+
+type bookCopyRepo struct {
+	db        *gorm.DB
+	cacheRepo model.CacheRepository
+}
+
+func NewBookCopyRepository(db *gorm.DB, cacheRepo model.CacheRepository) model.BookCopyRepository {
+	return &bookCopyRepo{
+		db:        db,
+		cacheRepo: cacheRepo,
+	}
+}
+
+// Create adds a copy of a book, which must not be in the trash. The copy
+// is reserved for the first hold in the queue of the book if any
+func (cr *bookCopyRepo) Create(ctx context.Context, bookCopy *model.BookCopy, pickupBy time.Time) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":      utils.Dump(ctx),
+		"bookCopy": utils.Dump(bookCopy),
+		"pickupBy": pickupBy,
+	})
+
+	err := cr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		book := &model.Book{}
+		if err := lockBook(tx, bookCopy.BookID, book); err != nil {
+			return err
+		}
+
+		if book.DeletedAt.Valid {
+			return domainerr.NotFound(fmt.Sprintf("book %d not found", bookCopy.BookID), nil)
+		}
+
+		if err := tx.Create(bookCopy).Error; err != nil {
+			return parseDBError(err)
+		}
+
+		reserved, err := reserveForNextHold(tx, bookCopy.ID, bookCopy.BookID, bookCopy.CreatedAt, pickupBy)
+		if err != nil {
+			return err
+		}
+
+		if reserved {
+			bookCopy.Status = model.BookCopyStatusReserved
+			return addBookCopies(tx, bookCopy.BookID, 1, 0)
+		}
+		return addBookCopies(tx, bookCopy.BookID, 1, 1)
+	})
+
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return cr.deleteCache(ctx, logger, bookCopy.BookID)
+}
+
+// DeleteByID deletes a copy which is not on loan, the loans of the copy
+// are kept
+func (cr *bookCopyRepo) DeleteByID(ctx context.Context, ID int64) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx": utils.Dump(ctx),
+		"ID":  ID,
+	})
+
+	bookCopy := &model.BookCopy{}
+	err := cr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockBookCopy(tx, ID, bookCopy); err != nil {
+			return err
+		}
+
+		if !bookCopy.IsAvailable() {
+			return domainerr.Conflict(fmt.Sprintf("copy %d is %s", ID, bookCopy.Status), nil)
+		}
+
+		if err := tx.Delete(&model.BookCopy{}, ID).Error; err != nil {
+			return parseDBError(err)
+		}
+
+		return addBookCopies(tx, bookCopy.BookID, -1, -1)
+	})
+
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return cr.deleteCache(ctx, logger, bookCopy.BookID, ID)
+}
+
+func (cr *bookCopyRepo) FindByID(ctx context.Context, ID int64) (*model.BookCopy, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx": utils.Dump(ctx),
+		"ID":  ID,
+	})
+
+	cacheKey := cr.findByIDCacheKey(ID)
+	reply, err := cr.cacheRepo.Get(ctx, cacheKey)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if reply != "" {
+		bookCopy := &model.BookCopy{}
+		if err := json.Unmarshal([]byte(reply), &bookCopy); err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+		return bookCopy, nil
+	}
+
+	bookCopy := &model.BookCopy{}
+	err = cr.db.WithContext(ctx).Where("id = ?", ID).Take(bookCopy).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, parseDBError(err)
+	}
+
+	bytes, err := json.Marshal(bookCopy)
+	if err != nil {
+		logger.Error(err)
+		return bookCopy, nil
+	}
+
+	if err := cr.cacheRepo.Set(ctx, cacheKey, string(bytes)); err != nil {
+		logger.Error(err)
+	}
+
+	return bookCopy, nil
+}
+
+// FindByBarcode looks a copy up by the barcode on its label, the lookup
+// isn't cached as it only precedes a checkout
+func (cr *bookCopyRepo) FindByBarcode(ctx context.Context, barcode string) (*model.BookCopy, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":     utils.Dump(ctx),
+		"barcode": barcode,
+	})
+
+	bookCopy := &model.BookCopy{}
+	err := cr.db.WithContext(ctx).Where("barcode = ?", barcode).Take(bookCopy).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, parseDBError(err)
+	}
+
+	return bookCopy, nil
+}
+
+func (cr *bookCopyRepo) FindAllByBookID(ctx context.Context, query model.GetBookCopiesQueryParams) ([]*model.BookCopy, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   utils.Dump(ctx),
+		"query": utils.Dump(query),
+	})
+
+	cacheHash := cr.cacheHash()
+	cacheKey := cr.findAllByBookIDCacheKey(query)
+	reply, err := cr.cacheRepo.HashGet(ctx, cacheHash, cacheKey)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if reply != "" {
+		bookCopies := []*model.BookCopy{}
+		if err := json.Unmarshal([]byte(reply), &bookCopies); err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+		return bookCopies, nil
+	}
+
+	bookCopies := []*model.BookCopy{}
+	err = cr.applyFilters(cr.db.WithContext(ctx), query).
+		Order("barcode ASC").
+		Order("id ASC").
+		Offset(int(model.Offset(query.Page, query.Size))).
+		Limit(int(query.Size)).
+		Find(&bookCopies).
+		Error
+	if err != nil {
+		logger.Error(err)
+		return nil, parseDBError(err)
+	}
+
+	bytes, err := json.Marshal(bookCopies)
+	if err != nil {
+		logger.Error(err)
+		return bookCopies, nil
+	}
+
+	if err := cr.cacheRepo.HashSet(ctx, cacheHash, cacheKey, string(bytes)); err != nil {
+		logger.Error(err)
+	}
+
+	return bookCopies, nil
+}
+
+func (cr *bookCopyRepo) CountAllByBookID(ctx context.Context, query model.GetBookCopiesQueryParams) (int64, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   utils.Dump(ctx),
+		"query": utils.Dump(query),
+	})
+
+	cacheHash := cr.cacheHash()
+	cacheKey := cr.countAllByBookIDCacheKey(query)
+	reply, err := cr.cacheRepo.HashGet(ctx, cacheHash, cacheKey)
+	if err != nil {
+		logger.Error(err)
+		return 0, err
+	}
+
+	if reply != "" {
+		count := int64(0)
+		if err := json.Unmarshal([]byte(reply), &count); err != nil {
+			logger.Error(err)
+			return 0, err
+		}
+		return count, nil
+	}
+
+	count := int64(0)
+	err = cr.applyFilters(cr.db.WithContext(ctx), query).
+		Model(&model.BookCopy{}).
+		Count(&count).
+		Error
+	if err != nil {
+		logger.Error(err)
+		return int64(0), parseDBError(err)
+	}
+
+	bytes, err := json.Marshal(count)
+	if err != nil {
+		logger.Error(err)
+		return 0, err
+	}
+
+	if err := cr.cacheRepo.HashSet(ctx, cacheHash, cacheKey, string(bytes)); err != nil {
+		logger.Error(err)
+	}
+
+	return count, nil
+}
+
+// Update replaces the barcode and the condition of a copy, the status of
+// a copy is left to its loans
+func (cr *bookCopyRepo) Update(ctx context.Context, input model.UpdateBookCopyInput) (*model.BookCopy, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   utils.Dump(ctx),
+		"input": utils.Dump(input),
+	})
+
+	err := cr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&model.BookCopy{}).Where("id = ?", input.ID).Updates(map[string]interface{}{
+			"barcode":   input.Barcode,
+			"condition": input.Condition,
+		})
+		if err := res.Error; err != nil {
+			return parseDBError(err)
+		}
+
+		if res.RowsAffected == 0 {
+			return domainerr.NotFound(fmt.Sprintf("copy %d not found", input.ID), nil)
+		}
+		return nil
+	})
+
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	cacheKeys := []string{
+		cr.cacheHash(),
+		cr.findByIDCacheKey(input.ID),
+	}
+
+	if err := cr.cacheRepo.Delete(ctx, cacheKeys...); err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return cr.FindByID(ctx, input.ID)
+}
+
+// deleteCache invalidates the copies along with the queues and the book and
+// its version, whose copy counts are cached as part of it
+func (cr *bookCopyRepo) deleteCache(ctx context.Context, logger *logrus.Entry, bookID int64, IDs ...int64) error {
+	cacheKeys := append([]string{
+		cr.cacheHash(),
+		holdCacheHash,
+		bookCacheHash,
+	}, bookCacheKeys(bookID)...)
+
+	for _, ID := range IDs {
+		cacheKeys = append(cacheKeys, cr.findByIDCacheKey(ID))
+	}
+
+	if err := cr.cacheRepo.Delete(ctx, cacheKeys...); err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (cr *bookCopyRepo) cacheHash() string {
+	return bookCopyCacheHash
+}
+
+func (cr *bookCopyRepo) findByIDCacheKey(ID int64) string {
+	return fmt.Sprintf("book_copy:%d", ID)
+}
+
+func (cr *bookCopyRepo) findAllByBookIDCacheKey(query model.GetBookCopiesQueryParams) string {
+	return fmt.Sprintf("book_copy:book:%d:page:%d:size:%d:status:%s", query.BookID, query.Page, query.Size, query.Status)
+}
+
+func (cr *bookCopyRepo) countAllByBookIDCacheKey(query model.GetBookCopiesQueryParams) string {
+	return fmt.Sprintf("book_copy:book:%d:count:status:%s", query.BookID, query.Status)
+}
+
+// applyFilters narrows db down to the copies of the book, in the status of
+// query if it has one
+func (cr *bookCopyRepo) applyFilters(db *gorm.DB, query model.GetBookCopiesQueryParams) *gorm.DB {
+	db = db.Where("book_id = ?", query.BookID)
+	if query.Status != "" {
+		db = db.Where("status = ?", query.Status)
+	}
+
+	return db
+}
+
+// lockBookCopy reads the copy into dest and locks its row until the end of
+// tx, every write of the status of a copy goes through it so that a copy
+// is never lent twice
+func lockBookCopy(tx *gorm.DB, ID int64, dest *model.BookCopy) error {
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", ID).Take(dest).Error
+	if err != nil {
+		return parseDBError(err)
+	}
+	return nil
+}
+
+// updateBookCopyStatus writes the status of a copy locked by tx
+func updateBookCopyStatus(tx *gorm.DB, copyID int64, status string) error {
+	err := tx.Model(&model.BookCopy{}).Where("id = ?", copyID).Update("status", status).Error
+	return parseDBError(err)
+}
+
+// addBookCopies adds count copies, available of which can be checked out,
+// to the copy counts of a book and bumps its version, since the counts are
+// part of its representation
+func addBookCopies(tx *gorm.DB, bookID, count, available int64) error {
+	err := tx.Exec(`UPDATE "books" SET "copies_count" = "copies_count" + ?, "available_copies_count" = "available_copies_count" + ?, `+
+		`"version" = "version" + 1 WHERE "id" = ?`,
+		count, available, bookID).Error
+	return parseDBError(err)
+}
@@ -0,0 +1,374 @@
+package repository
+
+import (
+	"encoding/json"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+var testBookCopy = model.BookCopy{
+	ID:        int64(1),
+	BookID:    int64(10),
+	Barcode:   "LIB-0001",
+	Condition: "good",
+	Status:    model.BookCopyStatusAvailable,
+}
+
+const (
+	testLockBookCopyQuery  = `SELECT * FROM "book_copies" WHERE id = $1 AND "book_copies"."deleted_at" IS NULL LIMIT 1 FOR UPDATE`
+	testAddBookCopiesQuery = `UPDATE "books" SET "copies_count" = "copies_count" + $1, "available_copies_count" = "available_copies_count" + $2, ` +
+		`"version" = "version" + 1 WHERE "id" = $3`
+)
+
+func testBookCopyRows(bookCopy model.BookCopy) *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"id", "book_id", "barcode", "condition", "status"}).
+		AddRow(bookCopy.ID, bookCopy.BookID, bookCopy.Barcode, bookCopy.Condition, bookCopy.Status)
+}
+
+func TestBookCopyRepository_Create(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := bookCopyRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	cacheKeys := []string{
+		repo.cacheHash(),
+		holdCacheHash,
+		bookCacheHash,
+		"book:10",
+		"book:10:version",
+	}
+
+	pickupBy := time.Date(2026, 10, 21, 10, 0, 0, 0, time.UTC)
+	query := `INSERT INTO "book_copies" ("book_id","barcode","condition","status","created_at","updated_at","deleted_at","id") VALUES ($1,$2,$3,$4,$5,$6,$7,$8) RETURNING "id"`
+
+	t.Run("success", func(t *testing.T) {
+		bookCopy := testBookCopy
+
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testLockBookQuery)).WithArgs(bookCopy.BookID).WillReturnRows(testBookCountRows(bookCopy.BookID, 0, 0, false))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(bookCopy.ID))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testNextHoldQuery)).WithArgs(bookCopy.BookID, model.HoldStatusWaiting).WillReturnRows(sqlmock.NewRows(nil))
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(testAddBookCopiesQuery)).WithArgs(int64(1), int64(1), bookCopy.BookID).WillReturnResult(sqlmock.NewResult(0, 1))
+		mockedDependency.sql.ExpectCommit()
+		mockedDependency.cacheRepo.EXPECT().Delete(ctx, cacheKeys).Times(1).Return(nil)
+
+		err := repo.Create(ctx, &bookCopy, pickupBy)
+		assert.NoError(t, err)
+		assert.Equal(t, model.BookCopyStatusAvailable, bookCopy.Status)
+	})
+
+	t.Run("success - reserved for the next hold", func(t *testing.T) {
+		bookCopy := testBookCopy
+
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testLockBookQuery)).WithArgs(bookCopy.BookID).WillReturnRows(testBookCountRows(bookCopy.BookID, 1, 0, false))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(bookCopy.ID))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testNextHoldQuery)).WithArgs(bookCopy.BookID, model.HoldStatusWaiting).WillReturnRows(testHoldRows(testHold))
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(testReserveHoldQuery)).WithArgs(bookCopy.ID, pickupBy, sqlmock.AnyArg(), model.HoldStatusReady, sqlmock.AnyArg(), testHold.ID).WillReturnResult(sqlmock.NewResult(0, 1))
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(testUpdateBookCopyStatusQuery)).WithArgs(model.BookCopyStatusReserved, sqlmock.AnyArg(), bookCopy.ID).WillReturnResult(sqlmock.NewResult(0, 1))
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(testAddBookCopiesQuery)).WithArgs(int64(1), int64(0), bookCopy.BookID).WillReturnResult(sqlmock.NewResult(0, 1))
+		mockedDependency.sql.ExpectCommit()
+		mockedDependency.cacheRepo.EXPECT().Delete(ctx, cacheKeys).Times(1).Return(nil)
+
+		err := repo.Create(ctx, &bookCopy, pickupBy)
+		assert.NoError(t, err)
+		assert.Equal(t, model.BookCopyStatusReserved, bookCopy.Status)
+	})
+
+	t.Run("failed - book is in the trash", func(t *testing.T) {
+		bookCopy := testBookCopy
+
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testLockBookQuery)).WithArgs(bookCopy.BookID).WillReturnRows(testBookCountRows(bookCopy.BookID, 0, 0, true))
+		mockedDependency.sql.ExpectRollback()
+
+		err := repo.Create(ctx, &bookCopy, pickupBy)
+		assert.ErrorIs(t, err, domainerr.ErrNotFound)
+	})
+
+	t.Run("failed - book does not exist", func(t *testing.T) {
+		bookCopy := testBookCopy
+
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testLockBookQuery)).WithArgs(bookCopy.BookID).WillReturnError(gorm.ErrRecordNotFound)
+		mockedDependency.sql.ExpectRollback()
+
+		err := repo.Create(ctx, &bookCopy, pickupBy)
+		assert.ErrorIs(t, err, domainerr.ErrNotFound)
+	})
+
+	t.Run("failed - barcode is taken", func(t *testing.T) {
+		bookCopy := testBookCopy
+
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testLockBookQuery)).WithArgs(bookCopy.BookID).WillReturnRows(testBookCountRows(bookCopy.BookID, 0, 0, false))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(&pgconn.PgError{Code: pgUniqueViolationCode})
+		mockedDependency.sql.ExpectRollback()
+
+		err := repo.Create(ctx, &bookCopy, pickupBy)
+		assert.ErrorIs(t, err, domainerr.ErrConflict)
+	})
+}
+
+func TestBookCopyRepository_DeleteByID(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := bookCopyRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	cacheKeys := []string{
+		repo.cacheHash(),
+		holdCacheHash,
+		bookCacheHash,
+		"book:10",
+		"book:10:version",
+		repo.findByIDCacheKey(testBookCopy.ID),
+	}
+
+	query := `UPDATE "book_copies" SET "deleted_at"=$1 WHERE "book_copies"."id" = $2 AND "book_copies"."deleted_at" IS NULL`
+
+	t.Run("success", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testLockBookCopyQuery)).WithArgs(testBookCopy.ID).WillReturnRows(testBookCopyRows(testBookCopy))
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(query)).WithArgs(sqlmock.AnyArg(), testBookCopy.ID).WillReturnResult(sqlmock.NewResult(0, 1))
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(testAddBookCopiesQuery)).WithArgs(int64(-1), int64(-1), testBookCopy.BookID).WillReturnResult(sqlmock.NewResult(0, 1))
+		mockedDependency.sql.ExpectCommit()
+		mockedDependency.cacheRepo.EXPECT().Delete(ctx, cacheKeys).Times(1).Return(nil)
+
+		err := repo.DeleteByID(ctx, testBookCopy.ID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("failed - copy is on loan", func(t *testing.T) {
+		bookCopy := testBookCopy
+		bookCopy.Status = model.BookCopyStatusOnLoan
+
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testLockBookCopyQuery)).WithArgs(bookCopy.ID).WillReturnRows(testBookCopyRows(bookCopy))
+		mockedDependency.sql.ExpectRollback()
+
+		err := repo.DeleteByID(ctx, bookCopy.ID)
+		assert.ErrorIs(t, err, domainerr.ErrConflict)
+	})
+
+	t.Run("failed - copy not found", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testLockBookCopyQuery)).WillReturnError(gorm.ErrRecordNotFound)
+		mockedDependency.sql.ExpectRollback()
+
+		err := repo.DeleteByID(ctx, testBookCopy.ID)
+		assert.ErrorIs(t, err, domainerr.ErrNotFound)
+	})
+}
+
+func TestBookCopyRepository_FindByID(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := bookCopyRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	cacheKey := repo.findByIDCacheKey(testBookCopy.ID)
+	query := `SELECT * FROM "book_copies" WHERE id = $1 AND "book_copies"."deleted_at" IS NULL LIMIT 1`
+	bytes, err := json.Marshal(testBookCopy)
+	assert.NoError(t, err)
+
+	t.Run("success - fetch from cache", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().Get(ctx, cacheKey).Times(1).Return(string(bytes), nil)
+		res, err := repo.FindByID(ctx, testBookCopy.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, testBookCopy.Barcode, res.Barcode)
+	})
+
+	t.Run("success - fetch from db", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().Get(ctx, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(testBookCopy.ID).WillReturnRows(testBookCopyRows(testBookCopy))
+		mockedDependency.cacheRepo.EXPECT().Set(ctx, cacheKey, gomock.Any()).Times(1).Return(nil)
+
+		res, err := repo.FindByID(ctx, testBookCopy.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, testBookCopy.Barcode, res.Barcode)
+	})
+
+	t.Run("failed - copy not found", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().Get(ctx, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(gorm.ErrRecordNotFound)
+
+		res, err := repo.FindByID(ctx, testBookCopy.ID)
+		assert.ErrorIs(t, err, domainerr.ErrNotFound)
+		assert.Nil(t, res)
+	})
+}
+
+func TestBookCopyRepository_FindByBarcode(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := bookCopyRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	query := `SELECT * FROM "book_copies" WHERE barcode = $1 AND "book_copies"."deleted_at" IS NULL LIMIT 1`
+
+	t.Run("success", func(t *testing.T) {
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(testBookCopy.Barcode).WillReturnRows(testBookCopyRows(testBookCopy))
+
+		res, err := repo.FindByBarcode(ctx, testBookCopy.Barcode)
+		assert.NoError(t, err)
+		assert.Equal(t, testBookCopy.ID, res.ID)
+	})
+
+	t.Run("failed - copy not found", func(t *testing.T) {
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(gorm.ErrRecordNotFound)
+
+		res, err := repo.FindByBarcode(ctx, testBookCopy.Barcode)
+		assert.ErrorIs(t, err, domainerr.ErrNotFound)
+		assert.Nil(t, res)
+	})
+}
+
+func TestBookCopyRepository_FindAllByBookID(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := bookCopyRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	queryParams := model.GetBookCopiesQueryParams{BookID: 10, PageQuery: model.PageQuery{Page: 2, Size: 5}, Status: model.BookCopyStatusAvailable}
+	query := `SELECT * FROM "book_copies" WHERE book_id = $1 AND status = $2 AND "book_copies"."deleted_at" IS NULL ORDER BY barcode ASC,id ASC LIMIT 5 OFFSET 5`
+
+	cacheHash := repo.cacheHash()
+	cacheKey := repo.findAllByBookIDCacheKey(queryParams)
+	bytes, err := json.Marshal([]*model.BookCopy{&testBookCopy})
+	assert.NoError(t, err)
+
+	t.Run("success - fetch from cache", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return(string(bytes), nil)
+		res, err := repo.FindAllByBookID(ctx, queryParams)
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+	})
+
+	t.Run("success - fetch from db", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(int64(10), model.BookCopyStatusAvailable).WillReturnRows(testBookCopyRows(testBookCopy))
+		mockedDependency.cacheRepo.EXPECT().HashSet(ctx, cacheHash, cacheKey, gomock.Any()).Times(1).Return(nil)
+
+		res, err := repo.FindAllByBookID(ctx, queryParams)
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+	})
+
+	t.Run("failed - fetch from db return error", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(errors.New("db error"))
+
+		res, err := repo.FindAllByBookID(ctx, queryParams)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestBookCopyRepository_CountAllByBookID(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := bookCopyRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	queryParams := model.GetBookCopiesQueryParams{BookID: 10, PageQuery: model.PageQuery{Page: 1, Size: 5}}
+	query := `SELECT count(*) FROM "book_copies" WHERE book_id = $1 AND "book_copies"."deleted_at" IS NULL`
+
+	cacheHash := repo.cacheHash()
+	cacheKey := repo.countAllByBookIDCacheKey(queryParams)
+
+	t.Run("success - fetch from cache", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("3", nil)
+		res, err := repo.CountAllByBookID(ctx, queryParams)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), res)
+	})
+
+	t.Run("success - fetch from db", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(int64(10)).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+		mockedDependency.cacheRepo.EXPECT().HashSet(ctx, cacheHash, cacheKey, "3").Times(1).Return(nil)
+
+		res, err := repo.CountAllByBookID(ctx, queryParams)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), res)
+	})
+}
+
+func TestBookCopyRepository_Update(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := bookCopyRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	input := model.UpdateBookCopyInput{ID: testBookCopy.ID, Barcode: "LIB-0002", Condition: "fair"}
+	cacheKeys := []string{
+		repo.cacheHash(),
+		repo.findByIDCacheKey(input.ID),
+	}
+
+	query := `UPDATE "book_copies" SET "barcode"=$1,"condition"=$2,"updated_at"=$3 WHERE id = $4 AND "book_copies"."deleted_at" IS NULL`
+	bytes, err := json.Marshal(testBookCopy)
+	assert.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(query)).WithArgs(input.Barcode, input.Condition, sqlmock.AnyArg(), input.ID).WillReturnResult(sqlmock.NewResult(0, 1))
+		mockedDependency.sql.ExpectCommit()
+		mockedDependency.cacheRepo.EXPECT().Delete(ctx, cacheKeys).Times(1).Return(nil)
+		mockedDependency.cacheRepo.EXPECT().Get(ctx, repo.findByIDCacheKey(input.ID)).Times(1).Return(string(bytes), nil)
+
+		res, err := repo.Update(ctx, input)
+		assert.NoError(t, err)
+		assert.Equal(t, testBookCopy.ID, res.ID)
+	})
+
+	t.Run("failed - copy not found", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(query)).WillReturnResult(sqlmock.NewResult(0, 0))
+		mockedDependency.sql.ExpectRollback()
+
+		res, err := repo.Update(ctx, input)
+		assert.ErrorIs(t, err, domainerr.ErrNotFound)
+		assert.Nil(t, res)
+	})
+}
@@ -4,13 +4,61 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/sirupsen/logrus"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
 	"github.com/ssentinull/create-apis-using-golang/internal/model"
 	"github.com/ssentinull/create-apis-using-golang/internal/utils"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+const (
+	// createInBatchesSize caps the rows inserted by a single statement of BatchCreate
+	createInBatchesSize = 100
+	// suggestCacheTTL keeps suggestions short-lived, as they aren't invalidated on writes
+	suggestCacheTTL = 1 * time.Minute
+	// maxFacetCounts caps the values returned for a single facet
+	maxFacetCounts = 50
+)
+
+// bookCacheHash holds the cached book listings
+const bookCacheHash = "book"
+
+// deletedBookReturning returns what is left to clean up of the permanently
+// deleted books, the blobs of their covers
+var deletedBookReturning = clause.Returning{Columns: []clause.Column{{Name: "id"}, {Name: "cover_updated_at"}}}
+
+// bookCacheKeys returns the cache keys of a book and of its version, for the
+// repositories whose writes change the book
+func bookCacheKeys(ID int64) []string {
+	return []string{fmt.Sprintf("book:%d", ID), fmt.Sprintf("book:%d:version", ID)}
+}
+
+// bookFacet is how the books are grouped and ordered for a facet
+type bookFacet struct {
+	value string
+	where string
+	order string
+}
+
+var bookFacets = map[string]bookFacet{
+	model.AuthorFacet: {
+		value: "author",
+		where: "author <> ''",
+		order: "count DESC, value ASC",
+	},
+	model.DecadeFacet: {
+		value: "to_char(date_trunc('decade', published_date), 'YYYY')",
+		where: "published_date IS NOT NULL",
+		order: "value ASC",
+	},
+}
+
 type bookRepo struct {
 	db        *gorm.DB
 	cacheRepo model.CacheRepository
@@ -31,8 +79,7 @@ func (br *bookRepo) Create(ctx context.Context, book *model.Book) error {
 
 	err := br.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		if err := tx.Create(book).Error; err != nil {
-			return err
-
+			return parseDBError(err)
 		}
 		return nil
 	})
@@ -44,7 +91,6 @@ func (br *bookRepo) Create(ctx context.Context, book *model.Book) error {
 
 	cacheKeys := []string{
 		br.cacheHash(),
-		br.countAllCacheKey(),
 	}
 
 	if err := br.cacheRepo.Delete(ctx, cacheKeys...); err != nil {
@@ -55,15 +101,21 @@ func (br *bookRepo) Create(ctx context.Context, book *model.Book) error {
 	return nil
 }
 
-func (br *bookRepo) DeleteByID(ctx context.Context, ID int64) error {
+func (br *bookRepo) DeleteByID(ctx context.Context, ID, version int64) error {
 	logger := logrus.WithFields(logrus.Fields{
-		"ctx": utils.Dump(ctx),
-		"ID":  ID,
+		"ctx":     utils.Dump(ctx),
+		"ID":      ID,
+		"version": version,
 	})
 
 	err := br.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		if err := tx.Delete(&model.Book{}, ID).Error; err != nil {
-			return err
+		res := br.whereVersion(tx, version).Delete(&model.Book{}, ID)
+		if err := res.Error; err != nil {
+			return parseDBError(err)
+		}
+
+		if res.RowsAffected == 0 {
+			return br.versionMismatchError(tx, ID, version)
 		}
 		return nil
 	})
@@ -75,6 +127,7 @@ func (br *bookRepo) DeleteByID(ctx context.Context, ID int64) error {
 
 	cacheKeys := []string{
 		br.findByIDCacheKey(ID),
+		br.findVersionByIDCacheKey(ID),
 		br.cacheHash(),
 	}
 
@@ -86,6 +139,135 @@ func (br *bookRepo) DeleteByID(ctx context.Context, ID int64) error {
 	return nil
 }
 
+// HardDeleteByID permanently deletes a book, the deleted book only holds
+// its ID and the upload time of its cover
+func (br *bookRepo) HardDeleteByID(ctx context.Context, ID, version int64) (*model.Book, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":     utils.Dump(ctx),
+		"ID":      ID,
+		"version": version,
+	})
+
+	deleted := &model.Book{}
+	err := br.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		count := int64(0)
+		if err := tx.Model(&model.Loan{}).Where("book_id = ?", ID).Count(&count).Error; err != nil {
+			return parseDBError(err)
+		}
+
+		// the loans back the fines ledger of their members
+		if count > 0 {
+			return domainerr.Conflict(fmt.Sprintf("book %d has loans and can't be deleted permanently", ID), nil)
+		}
+
+		res := br.whereVersion(tx.Unscoped(), version).
+			Clauses(deletedBookReturning).
+			Delete(deleted, ID)
+		if err := res.Error; err != nil {
+			return parseDBError(err)
+		}
+
+		if res.RowsAffected == 0 {
+			return br.versionMismatchError(tx.Unscoped(), ID, version)
+		}
+		return nil
+	})
+
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	cacheKeys := []string{
+		br.findByIDCacheKey(ID),
+		br.findVersionByIDCacheKey(ID),
+		br.cacheHash(),
+	}
+
+	if err := br.cacheRepo.Delete(ctx, cacheKeys...); err != nil {
+		logger.Error(err)
+		return deleted, err
+	}
+
+	return deleted, nil
+}
+
+func (br *bookRepo) Restore(ctx context.Context, ID int64) (*model.Book, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx": utils.Dump(ctx),
+		"ID":  ID,
+	})
+
+	err := br.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// restoring changes the representation of the book, so it gets a new version
+		res := br.trashed(tx).Model(&model.Book{}).Where("id = ?", ID).Updates(map[string]interface{}{
+			"deleted_at": nil,
+			"version":    gorm.Expr("version + 1"),
+		})
+		if err := res.Error; err != nil {
+			return parseDBError(err)
+		}
+
+		if res.RowsAffected == 0 {
+			return domainerr.NotFound(fmt.Sprintf("deleted book %d not found", ID), nil)
+		}
+		return nil
+	})
+
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	cacheKeys := []string{
+		br.cacheHash(),
+		br.findByIDCacheKey(ID),
+		br.findVersionByIDCacheKey(ID),
+	}
+
+	if err := br.cacheRepo.Delete(ctx, cacheKeys...); err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return br.FindByID(ctx, ID)
+}
+
+// PurgeTrashed permanently deletes the books in the trash since before
+// deletedBefore, the purged books only hold their ID and the upload time of
+// their cover
+func (br *bookRepo) PurgeTrashed(ctx context.Context, deletedBefore time.Time) ([]*model.Book, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":           utils.Dump(ctx),
+		"deletedBefore": deletedBefore,
+	})
+
+	// the books with loans stay in the trash, the loans back the fines
+	// ledger of their members
+	purged := []*model.Book{}
+	err := br.trashed(br.db.WithContext(ctx)).
+		Clauses(deletedBookReturning).
+		Where("deleted_at < ?", deletedBefore).
+		Where(`NOT EXISTS (SELECT 1 FROM "loans" WHERE "loans"."book_id" = "books"."id")`).
+		Delete(&purged).
+		Error
+	if err != nil {
+		logger.Error(err)
+		return nil, parseDBError(err)
+	}
+
+	if len(purged) == 0 {
+		return purged, nil
+	}
+
+	if err := br.cacheRepo.Delete(ctx, br.cacheHash()); err != nil {
+		logger.Error(err)
+		return purged, err
+	}
+
+	return purged, nil
+}
+
 func (br *bookRepo) FindByID(ctx context.Context, ID int64) (*model.Book, error) {
 	logger := logrus.WithFields(logrus.Fields{
 		"ctx": utils.Dump(ctx),
@@ -112,7 +294,7 @@ func (br *bookRepo) FindByID(ctx context.Context, ID int64) (*model.Book, error)
 	err = br.db.WithContext(ctx).Where("id = ?", ID).Take(&book).Error
 	if err != nil {
 		logger.Error(err)
-		return nil, err
+		return nil, parseDBError(err)
 	}
 
 	bytes, err := json.Marshal(book)
@@ -128,6 +310,87 @@ func (br *bookRepo) FindByID(ctx context.Context, ID int64) (*model.Book, error)
 	return book, nil
 }
 
+// FindByISBN caches the book in the book hash rather than under a key of its
+// own, so that any write to the books, which may change the ISBN, evicts it
+func (br *bookRepo) FindByISBN(ctx context.Context, isbn13 string) (*model.Book, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":    utils.Dump(ctx),
+		"isbn13": isbn13,
+	})
+
+	cacheHash := br.cacheHash()
+	cacheKey := br.findByISBNCacheKey(isbn13)
+	reply, err := br.cacheRepo.HashGet(ctx, cacheHash, cacheKey)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if reply != "" {
+		book := &model.Book{}
+		if err := json.Unmarshal([]byte(reply), &book); err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+		return book, nil
+	}
+
+	book := &model.Book{}
+	err = br.db.WithContext(ctx).Where("isbn13 = ?", isbn13).Take(&book).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, parseDBError(err)
+	}
+
+	bytes, err := json.Marshal(book)
+	if err != nil {
+		logger.Error(err)
+		return book, nil
+	}
+
+	if err := br.cacheRepo.HashSet(ctx, cacheHash, cacheKey, string(bytes)); err != nil {
+		logger.Error(err)
+	}
+
+	return book, nil
+}
+
+func (br *bookRepo) FindVersionByID(ctx context.Context, ID int64) (int64, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx": utils.Dump(ctx),
+		"ID":  ID,
+	})
+
+	cacheKey := br.findVersionByIDCacheKey(ID)
+	reply, err := br.cacheRepo.Get(ctx, cacheKey)
+	if err != nil {
+		logger.Error(err)
+		return 0, err
+	}
+
+	if reply != "" {
+		version, err := strconv.ParseInt(reply, 10, 64)
+		if err != nil {
+			logger.Error(err)
+			return 0, err
+		}
+		return version, nil
+	}
+
+	book := &model.Book{}
+	err = br.db.WithContext(ctx).Select("version").Where("id = ?", ID).Take(book).Error
+	if err != nil {
+		logger.Error(err)
+		return 0, parseDBError(err)
+	}
+
+	if err := br.cacheRepo.Set(ctx, cacheKey, strconv.FormatInt(book.Version, 10)); err != nil {
+		logger.Error(err)
+	}
+
+	return book.Version, nil
+}
+
 func (br *bookRepo) FindAll(ctx context.Context, query model.GetBooksQueryParams) ([]*model.Book, error) {
 	logger := logrus.WithFields(logrus.Fields{
 		"ctx":   utils.Dump(ctx),
@@ -151,9 +414,19 @@ func (br *bookRepo) FindAll(ctx context.Context, query model.GetBooksQueryParams
 		return books, nil
 	}
 
+	sortFields, err := query.SortFields()
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
 	books := []*model.Book{}
-	err = br.db.WithContext(ctx).
-		Order("id DESC").
+	db := br.applyFilters(br.db.WithContext(ctx), query)
+	for _, field := range sortFields {
+		db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: field.Column}, Desc: field.Desc})
+	}
+
+	err = db.Order("id DESC").
 		Offset(int(model.Offset(query.Page, query.Size))).
 		Limit(int(query.Size)).
 		Find(&books).
@@ -176,11 +449,98 @@ func (br *bookRepo) FindAll(ctx context.Context, query model.GetBooksQueryParams
 	return books, nil
 }
 
-func (br *bookRepo) CountAll(ctx context.Context) (int64, error) {
-	logger := logrus.WithField("ctx", utils.Dump(ctx))
+func (br *bookRepo) FindAllByCursor(ctx context.Context, query model.GetBooksQueryParams) ([]*model.Book, bool, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   utils.Dump(ctx),
+		"query": utils.Dump(query),
+	})
+
+	cacheHash := br.cacheHash()
+	cacheKey := br.findAllByCursorCacheKey(query)
+	reply, err := br.cacheRepo.HashGet(ctx, cacheHash, cacheKey)
+	if err != nil {
+		logger.Error(err)
+		return nil, false, err
+	}
 
-	cacheKey := br.countAllCacheKey()
-	reply, err := br.cacheRepo.Get(ctx, cacheKey)
+	page := cursorPage{}
+	if reply != "" {
+		if err := json.Unmarshal([]byte(reply), &page); err != nil {
+			logger.Error(err)
+			return nil, false, err
+		}
+		return page.Books, page.HasMore, nil
+	}
+
+	cursor, err := query.ParseCursor()
+	if err != nil {
+		logger.Error(err)
+		return nil, false, err
+	}
+
+	fields, err := query.KeysetFields()
+	if err != nil {
+		logger.Error(err)
+		return nil, false, err
+	}
+
+	// walking backward reverses the order, the rows are flipped back below
+	if cursor.Backward {
+		for i := range fields {
+			fields[i].Desc = !fields[i].Desc
+		}
+	}
+
+	db := br.applyFilters(br.db.WithContext(ctx), query)
+	if len(cursor.Values) > 0 {
+		db = db.Where(keysetCondition(fields, cursor.Values))
+	}
+
+	for _, field := range fields {
+		db = db.Order(keysetColumn(field.Column) + sortDirection(field.Desc))
+	}
+
+	books := []*model.Book{}
+	err = db.Limit(int(query.Limit) + 1).Find(&books).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, false, err
+	}
+
+	page.HasMore = int64(len(books)) > query.Limit
+	if page.HasMore {
+		books = books[:query.Limit]
+	}
+
+	if cursor.Backward {
+		for i, j := 0, len(books)-1; i < j; i, j = i+1, j-1 {
+			books[i], books[j] = books[j], books[i]
+		}
+	}
+
+	page.Books = books
+	bytes, err := json.Marshal(page)
+	if err != nil {
+		logger.Error(err)
+		return page.Books, page.HasMore, nil
+	}
+
+	if err := br.cacheRepo.HashSet(ctx, cacheHash, cacheKey, string(bytes)); err != nil {
+		logger.Error(err)
+	}
+
+	return page.Books, page.HasMore, nil
+}
+
+func (br *bookRepo) CountAll(ctx context.Context, query model.GetBooksQueryParams) (int64, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   utils.Dump(ctx),
+		"query": utils.Dump(query),
+	})
+
+	cacheHash := br.cacheHash()
+	cacheKey := br.countAllCacheKey(query)
+	reply, err := br.cacheRepo.HashGet(ctx, cacheHash, cacheKey)
 	if err != nil {
 		logger.Error(err)
 		return 0, err
@@ -196,12 +556,12 @@ func (br *bookRepo) CountAll(ctx context.Context) (int64, error) {
 	}
 
 	count := int64(0)
-	err = br.db.WithContext(ctx).
+	err = br.applyFilters(br.db.WithContext(ctx), query).
 		Model(model.Book{}).
 		Count(&count).
 		Error
 	if err != nil {
-		logrus.WithField("ctx", utils.Dump(ctx)).Error(err)
+		logger.Error(err)
 		return int64(0), err
 	}
 
@@ -211,57 +571,892 @@ func (br *bookRepo) CountAll(ctx context.Context) (int64, error) {
 		return 0, err
 	}
 
-	if err := br.cacheRepo.Set(ctx, cacheKey, string(bytes)); err != nil {
+	if err := br.cacheRepo.HashSet(ctx, cacheHash, cacheKey, string(bytes)); err != nil {
 		logger.Error(err)
 	}
 
 	return count, nil
 }
 
-func (br *bookRepo) Update(ctx context.Context, book *model.Book) (*model.Book, error) {
+func (br *bookRepo) CountByFacet(ctx context.Context, query model.GetBooksQueryParams, facet string) ([]*model.FacetCount, error) {
 	logger := logrus.WithFields(logrus.Fields{
-		"ctx":  utils.Dump(ctx),
-		"book": utils.Dump(book),
-	})
-
-	err := br.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		if err := tx.Updates(book).Error; err != nil {
-			return err
-		}
-		return nil
+		"ctx":   utils.Dump(ctx),
+		"query": utils.Dump(query),
+		"facet": facet,
 	})
 
+	cacheHash := br.cacheHash()
+	cacheKey := br.countByFacetCacheKey(query, facet)
+	reply, err := br.cacheRepo.HashGet(ctx, cacheHash, cacheKey)
 	if err != nil {
 		logger.Error(err)
 		return nil, err
 	}
 
-	cacheKeys := []string{
-		br.cacheHash(),
-		br.countAllCacheKey(),
-		br.findByIDCacheKey(book.ID),
+	if reply != "" {
+		counts := []*model.FacetCount{}
+		if err := json.Unmarshal([]byte(reply), &counts); err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+		return counts, nil
 	}
 
-	if err := br.cacheRepo.Delete(ctx, cacheKeys...); err != nil {
+	bf, ok := bookFacets[facet]
+	if !ok {
+		err := domainerr.Validation(fmt.Sprintf("cannot count by %q", facet), nil)
 		logger.Error(err)
 		return nil, err
 	}
 
-	return br.FindByID(ctx, book.ID)
-}
-
-func (br *bookRepo) cacheHash() string {
-	return "book"
-}
+	counts := []*model.FacetCount{}
+	err = br.applyFilters(br.db.WithContext(ctx), query).
+		Model(&model.Book{}).
+		Select(bf.value + " AS value, count(*) AS count").
+		Where(bf.where).
+		Group("value").
+		Order(bf.order).
+		Limit(maxFacetCounts).
+		Scan(&counts).
+		Error
+	if err != nil {
+		logger.Error(err)
+		return nil, parseDBError(err)
+	}
 
-func (br *bookRepo) findByIDCacheKey(ID int64) string {
+	bytes, err := json.Marshal(counts)
+	if err != nil {
+		logger.Error(err)
+		return counts, nil
+	}
+
+	if err := br.cacheRepo.HashSet(ctx, cacheHash, cacheKey, string(bytes)); err != nil {
+		logger.Error(err)
+	}
+
+	return counts, nil
+}
+
+func (br *bookRepo) FindAllTrashed(ctx context.Context, query model.GetBooksQueryParams) ([]*model.Book, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   utils.Dump(ctx),
+		"query": utils.Dump(query),
+	})
+
+	cacheHash := br.cacheHash()
+	cacheKey := br.findAllTrashedCacheKey(query)
+	reply, err := br.cacheRepo.HashGet(ctx, cacheHash, cacheKey)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if reply != "" {
+		books := []*model.Book{}
+		if err := json.Unmarshal([]byte(reply), &books); err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+		return books, nil
+	}
+
+	sortFields, err := query.SortFields()
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	books := []*model.Book{}
+	db := br.applyFilters(br.trashed(br.db.WithContext(ctx)), query)
+	for _, field := range sortFields {
+		db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: field.Column}, Desc: field.Desc})
+	}
+
+	err = db.Order("deleted_at DESC").
+		Order("id DESC").
+		Offset(int(model.Offset(query.Page, query.Size))).
+		Limit(int(query.Size)).
+		Find(&books).
+		Error
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	bytes, err := json.Marshal(books)
+	if err != nil {
+		logger.Error(err)
+		return books, nil
+	}
+
+	if err := br.cacheRepo.HashSet(ctx, cacheHash, cacheKey, string(bytes)); err != nil {
+		logger.Error(err)
+	}
+
+	return books, nil
+}
+
+func (br *bookRepo) CountAllTrashed(ctx context.Context, query model.GetBooksQueryParams) (int64, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   utils.Dump(ctx),
+		"query": utils.Dump(query),
+	})
+
+	cacheHash := br.cacheHash()
+	cacheKey := br.countAllTrashedCacheKey(query)
+	reply, err := br.cacheRepo.HashGet(ctx, cacheHash, cacheKey)
+	if err != nil {
+		logger.Error(err)
+		return 0, err
+	}
+
+	if reply != "" {
+		count := int64(0)
+		if err := json.Unmarshal([]byte(reply), &count); err != nil {
+			logger.Error(err)
+			return 0, err
+		}
+		return count, nil
+	}
+
+	count := int64(0)
+	err = br.applyFilters(br.trashed(br.db.WithContext(ctx)), query).
+		Model(model.Book{}).
+		Count(&count).
+		Error
+	if err != nil {
+		logger.Error(err)
+		return int64(0), err
+	}
+
+	bytes, err := json.Marshal(count)
+	if err != nil {
+		logger.Error(err)
+		return 0, err
+	}
+
+	if err := br.cacheRepo.HashSet(ctx, cacheHash, cacheKey, string(bytes)); err != nil {
+		logger.Error(err)
+	}
+
+	return count, nil
+}
+
+// FindAllByWorkID returns the editions of a work, oldest first
+func (br *bookRepo) FindAllByWorkID(ctx context.Context, workID int64) ([]*model.Book, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":    utils.Dump(ctx),
+		"workID": workID,
+	})
+
+	cacheHash := br.cacheHash()
+	cacheKey := br.findAllByWorkIDCacheKey(workID)
+	reply, err := br.cacheRepo.HashGet(ctx, cacheHash, cacheKey)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if reply != "" {
+		books := []*model.Book{}
+		if err := json.Unmarshal([]byte(reply), &books); err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+		return books, nil
+	}
+
+	books := []*model.Book{}
+	err = br.db.WithContext(ctx).
+		Where("work_id = ?", workID).
+		Order("published_date ASC NULLS LAST").
+		Order("id ASC").
+		Find(&books).
+		Error
+	if err != nil {
+		logger.Error(err)
+		return nil, parseDBError(err)
+	}
+
+	bytes, err := json.Marshal(books)
+	if err != nil {
+		logger.Error(err)
+		return books, nil
+	}
+
+	if err := br.cacheRepo.HashSet(ctx, cacheHash, cacheKey, string(bytes)); err != nil {
+		logger.Error(err)
+	}
+
+	return books, nil
+}
+
+// FindAllBySeriesID returns the books of a series in their reading order,
+// the books without a position come last
+func (br *bookRepo) FindAllBySeriesID(ctx context.Context, seriesID int64) ([]*model.Book, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":      utils.Dump(ctx),
+		"seriesID": seriesID,
+	})
+
+	cacheHash := br.cacheHash()
+	cacheKey := br.findAllBySeriesIDCacheKey(seriesID)
+	reply, err := br.cacheRepo.HashGet(ctx, cacheHash, cacheKey)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if reply != "" {
+		books := []*model.Book{}
+		if err := json.Unmarshal([]byte(reply), &books); err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+		return books, nil
+	}
+
+	books := []*model.Book{}
+	err = br.db.WithContext(ctx).
+		Where("series_id = ?", seriesID).
+		Order("series_position ASC NULLS LAST").
+		Order("id ASC").
+		Find(&books).
+		Error
+	if err != nil {
+		logger.Error(err)
+		return nil, parseDBError(err)
+	}
+
+	bytes, err := json.Marshal(books)
+	if err != nil {
+		logger.Error(err)
+		return books, nil
+	}
+
+	if err := br.cacheRepo.HashSet(ctx, cacheHash, cacheKey, string(bytes)); err != nil {
+		logger.Error(err)
+	}
+
+	return books, nil
+}
+
+func (br *bookRepo) Search(ctx context.Context, query model.SearchBooksQueryParams) ([]*model.BookSearchResult, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   utils.Dump(ctx),
+		"query": utils.Dump(query),
+	})
+
+	cacheHash := br.cacheHash()
+	cacheKey := br.searchCacheKey(query)
+	reply, err := br.cacheRepo.HashGet(ctx, cacheHash, cacheKey)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if reply != "" {
+		results := []*model.BookSearchResult{}
+		if err := json.Unmarshal([]byte(reply), &results); err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+		return results, nil
+	}
+
+	tsQuery := webSearchQuery(query)
+	results := []*model.BookSearchResult{}
+	err = br.matchSearch(br.db.WithContext(ctx), query).
+		Select("books.*, ts_rank(search_vector, ?) AS rank, ? AS highlight_title, ? AS highlight_author, ? AS highlight_description",
+			tsQuery,
+			tsHeadline(query, "title", tsQuery),
+			tsHeadline(query, "author", tsQuery),
+			tsHeadline(query, "description", tsQuery),
+		).
+		Order("rank DESC").
+		Order("id DESC").
+		Offset(int(model.Offset(query.Page, query.Size))).
+		Limit(int(query.Size)).
+		Find(&results).
+		Error
+	if err != nil {
+		logger.Error(err)
+		return nil, parseDBError(err)
+	}
+
+	bytes, err := json.Marshal(results)
+	if err != nil {
+		logger.Error(err)
+		return results, nil
+	}
+
+	if err := br.cacheRepo.HashSet(ctx, cacheHash, cacheKey, string(bytes)); err != nil {
+		logger.Error(err)
+	}
+
+	return results, nil
+}
+
+func (br *bookRepo) CountSearch(ctx context.Context, query model.SearchBooksQueryParams) (int64, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   utils.Dump(ctx),
+		"query": utils.Dump(query),
+	})
+
+	cacheHash := br.cacheHash()
+	cacheKey := br.countSearchCacheKey(query)
+	reply, err := br.cacheRepo.HashGet(ctx, cacheHash, cacheKey)
+	if err != nil {
+		logger.Error(err)
+		return 0, err
+	}
+
+	if reply != "" {
+		count := int64(0)
+		if err := json.Unmarshal([]byte(reply), &count); err != nil {
+			logger.Error(err)
+			return 0, err
+		}
+		return count, nil
+	}
+
+	count := int64(0)
+	err = br.matchSearch(br.db.WithContext(ctx), query).
+		Count(&count).
+		Error
+	if err != nil {
+		logger.Error(err)
+		return int64(0), parseDBError(err)
+	}
+
+	bytes, err := json.Marshal(count)
+	if err != nil {
+		logger.Error(err)
+		return 0, err
+	}
+
+	if err := br.cacheRepo.HashSet(ctx, cacheHash, cacheKey, string(bytes)); err != nil {
+		logger.Error(err)
+	}
+
+	return count, nil
+}
+
+// Suggest looks up the titles and the authors whose words are similar to the
+// query. The <% operator is served by the trigram indexes and filters by the
+// word similarity threshold, which is set for the transaction only
+func (br *bookRepo) Suggest(ctx context.Context, query model.SuggestBooksQueryParams) ([]*model.BookSuggestion, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   utils.Dump(ctx),
+		"query": utils.Dump(query),
+	})
+
+	cacheKey := br.suggestCacheKey(query)
+	reply, err := br.cacheRepo.Get(ctx, cacheKey)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if reply != "" {
+		suggestions := []*model.BookSuggestion{}
+		if err := json.Unmarshal([]byte(reply), &suggestions); err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+		return suggestions, nil
+	}
+
+	suggestions := []*model.BookSuggestion{}
+	err = br.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec("SELECT set_config('pg_trgm.word_similarity_threshold', ?, true)", strconv.FormatFloat(query.Threshold, 'f', -1, 64)).Error
+		if err != nil {
+			return parseDBError(err)
+		}
+
+		err = tx.Raw(`SELECT field, value, MAX(similarity) AS similarity FROM (
+				SELECT 'title' AS field, title AS value, word_similarity(@q, title) AS similarity FROM books WHERE @q <% title AND deleted_at IS NULL
+				UNION ALL
+				SELECT 'author' AS field, author AS value, word_similarity(@q, author) AS similarity FROM books WHERE @q <% author AND deleted_at IS NULL
+			) AS suggestions GROUP BY field, value ORDER BY similarity DESC, value ASC LIMIT @limit`,
+			map[string]interface{}{"q": query.Q, "limit": query.Limit},
+		).Scan(&suggestions).Error
+		if err != nil {
+			return parseDBError(err)
+		}
+		return nil
+	})
+
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	bytes, err := json.Marshal(suggestions)
+	if err != nil {
+		logger.Error(err)
+		return suggestions, nil
+	}
+
+	if err := br.cacheRepo.SetWithTTL(ctx, cacheKey, string(bytes), suggestCacheTTL); err != nil {
+		logger.Error(err)
+	}
+
+	return suggestions, nil
+}
+
+func (br *bookRepo) Update(ctx context.Context, book *model.Book) (*model.Book, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":  utils.Dump(ctx),
+		"book": utils.Dump(book),
+	})
+
+	err := br.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return br.update(tx, book)
+	})
+
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	cacheKeys := []string{
+		br.cacheHash(),
+		br.findByIDCacheKey(book.ID),
+		br.findVersionByIDCacheKey(book.ID),
+	}
+
+	if err := br.cacheRepo.Delete(ctx, cacheKeys...); err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return br.FindByID(ctx, book.ID)
+}
+
+// UpdateCover records a new cover of the book, which changes its cover URL
+// and so its representation and version too
+func (br *bookRepo) UpdateCover(ctx context.Context, ID int64, coverUpdatedAt time.Time) (*model.Book, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":            utils.Dump(ctx),
+		"ID":             ID,
+		"coverUpdatedAt": coverUpdatedAt,
+	})
+
+	err := br.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&model.Book{}).Where("id = ?", ID).Updates(map[string]interface{}{
+			"cover_updated_at": coverUpdatedAt,
+			"version":          gorm.Expr("version + 1"),
+		})
+		if err := res.Error; err != nil {
+			return parseDBError(err)
+		}
+
+		if res.RowsAffected == 0 {
+			return domainerr.NotFound(fmt.Sprintf("book %d not found", ID), nil)
+		}
+		return nil
+	})
+
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	cacheKeys := []string{
+		br.cacheHash(),
+		br.findByIDCacheKey(ID),
+		br.findVersionByIDCacheKey(ID),
+	}
+
+	if err := br.cacheRepo.Delete(ctx, cacheKeys...); err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return br.FindByID(ctx, ID)
+}
+
+func (br *bookRepo) BatchCreate(ctx context.Context, books []*model.Book, atomic bool) ([]error, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":    utils.Dump(ctx),
+		"books":  utils.Dump(books),
+		"atomic": atomic,
+	})
+
+	// CreateInBatches runs every insert statement in a single transaction
+	errs := make([]error, len(books))
+	err := br.db.WithContext(ctx).CreateInBatches(books, createInBatchesSize).Error
+	if err != nil {
+		err = parseDBError(err)
+	}
+
+	// a failed insert rolls back the whole batch, so retry the books one
+	// at a time to find out which of them are failing
+	if err != nil && !atomic {
+		err = nil
+		for i, book := range books {
+			if createErr := br.db.WithContext(ctx).Create(book).Error; createErr != nil {
+				errs[i] = parseDBError(createErr)
+			}
+		}
+	}
+
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if countFailed(errs) == len(books) {
+		return errs, nil
+	}
+
+	if err := br.cacheRepo.Delete(ctx, br.cacheHash()); err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return errs, nil
+}
+
+func (br *bookRepo) BatchUpdate(ctx context.Context, books []*model.Book, atomic bool) ([]*model.Book, []error, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":    utils.Dump(ctx),
+		"books":  utils.Dump(books),
+		"atomic": atomic,
+	})
+
+	errs := make([]error, len(books))
+	if atomic {
+		err := br.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			for i, book := range books {
+				if err := br.update(tx, book); err != nil {
+					errs[i] = err
+					return err
+				}
+			}
+			return nil
+		})
+
+		if err != nil {
+			logger.Error(err)
+			if countFailed(errs) == 0 {
+				return nil, nil, err
+			}
+			return nil, errs, nil
+		}
+	} else {
+		for i, book := range books {
+			errs[i] = br.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+				return br.update(tx, book)
+			})
+		}
+	}
+
+	IDs := []int64{}
+	cacheKeys := []string{br.cacheHash()}
+	for i, book := range books {
+		if errs[i] == nil {
+			IDs = append(IDs, book.ID)
+			cacheKeys = append(cacheKeys, br.findByIDCacheKey(book.ID), br.findVersionByIDCacheKey(book.ID))
+		}
+	}
+
+	updated := make([]*model.Book, len(books))
+	if len(IDs) == 0 {
+		return updated, errs, nil
+	}
+
+	if err := br.cacheRepo.Delete(ctx, cacheKeys...); err != nil {
+		logger.Error(err)
+		return nil, nil, err
+	}
+
+	found := []*model.Book{}
+	if err := br.db.WithContext(ctx).Where("id IN ?", IDs).Find(&found).Error; err != nil {
+		logger.Error(err)
+		return nil, nil, parseDBError(err)
+	}
+
+	foundByID := make(map[int64]*model.Book, len(found))
+	for _, book := range found {
+		foundByID[book.ID] = book
+	}
+
+	for i, book := range books {
+		if errs[i] == nil {
+			updated[i] = foundByID[book.ID]
+		}
+	}
+
+	return updated, errs, nil
+}
+
+func (br *bookRepo) BatchDelete(ctx context.Context, IDs []int64, atomic bool) ([]error, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":    utils.Dump(ctx),
+		"IDs":    IDs,
+		"atomic": atomic,
+	})
+
+	errs := make([]error, len(IDs))
+	deletedIDs := []int64{}
+	err := br.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		foundIDs := []int64{}
+		err := tx.Model(&model.Book{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id IN ?", IDs).
+			Pluck("id", &foundIDs).
+			Error
+		if err != nil {
+			return parseDBError(err)
+		}
+
+		found := make(map[int64]bool, len(foundIDs))
+		for _, ID := range foundIDs {
+			found[ID] = true
+		}
+
+		for i, ID := range IDs {
+			if !found[ID] {
+				errs[i] = domainerr.NotFound(fmt.Sprintf("book %d not found", ID), nil)
+			}
+		}
+
+		if len(foundIDs) == 0 || (atomic && countFailed(errs) > 0) {
+			return nil
+		}
+
+		if err := tx.Delete(&model.Book{}, foundIDs).Error; err != nil {
+			return parseDBError(err)
+		}
+
+		deletedIDs = foundIDs
+		return nil
+	})
+
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if len(deletedIDs) == 0 {
+		return errs, nil
+	}
+
+	cacheKeys := []string{br.cacheHash()}
+	for _, ID := range deletedIDs {
+		cacheKeys = append(cacheKeys, br.findByIDCacheKey(ID), br.findVersionByIDCacheKey(ID))
+	}
+
+	if err := br.cacheRepo.Delete(ctx, cacheKeys...); err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return errs, nil
+}
+
+func (br *bookRepo) cacheHash() string {
+	return bookCacheHash
+}
+
+func (br *bookRepo) findByIDCacheKey(ID int64) string {
 	return fmt.Sprintf("book:%d", ID)
 }
 
+func (br *bookRepo) findByISBNCacheKey(isbn13 string) string {
+	return fmt.Sprintf("book:isbn:%s", isbn13)
+}
+
+// update replaces every editable column of book, so that emptied fields get
+// cleared too, and bumps its version
+func (br *bookRepo) update(tx *gorm.DB, book *model.Book) error {
+	res := br.whereVersion(tx.Model(book), book.Version).Updates(map[string]interface{}{
+		"title":           book.Title,
+		"author":          book.Author,
+		"description":     book.Description,
+		"published_date":  nullableDate(book.PublishedDate),
+		"publisher_id":    book.PublisherID,
+		"series_id":       book.SeriesID,
+		"series_position": book.SeriesPosition,
+		"work_id":         br.workID(book),
+		"genre_id":        book.GenreID,
+		"isbn13":          book.ISBN13,
+		"isbn10":          book.ISBN10,
+		"version":         gorm.Expr("version + 1"),
+	})
+	if err := res.Error; err != nil {
+		return parseDBError(err)
+	}
+
+	if res.RowsAffected == 0 {
+		return br.versionMismatchError(tx, book.ID, book.Version)
+	}
+	return nil
+}
+
+// workID returns the work of book, a book without a work goes back to
+// being the first edition of a work of its own
+func (br *bookRepo) workID(book *model.Book) interface{} {
+	if book.WorkID == 0 {
+		return gorm.Expr("id")
+	}
+	return book.WorkID
+}
+
+// whereVersion makes the statement conditional on the current version
+// of the book, a zero version matches any version
+func (br *bookRepo) whereVersion(db *gorm.DB, version int64) *gorm.DB {
+	if version <= 0 {
+		return db
+	}
+
+	return db.Where("version = ?", version)
+}
+
+// versionMismatchError tells why a statement guarded by whereVersion did not
+// affect any row, either the book doesn't exist or its version has changed
+func (br *bookRepo) versionMismatchError(tx *gorm.DB, ID, version int64) error {
+	notFoundErr := domainerr.NotFound(fmt.Sprintf("book %d not found", ID), nil)
+	if version <= 0 {
+		return notFoundErr
+	}
+
+	count := int64(0)
+	if err := tx.Model(&model.Book{}).Where("id = ?", ID).Count(&count).Error; err != nil {
+		return parseDBError(err)
+	}
+
+	if count == 0 {
+		return notFoundErr
+	}
+
+	return domainerr.PreconditionFailed(fmt.Sprintf("book %d has been modified", ID), nil)
+}
+
+// trashed narrows db down to the soft deleted books
+func (br *bookRepo) trashed(db *gorm.DB) *gorm.DB {
+	return db.Unscoped().Where("deleted_at IS NOT NULL")
+}
+
+// applyFilters narrows db down to the books matching the filters in query
+func (br *bookRepo) applyFilters(db *gorm.DB, query model.GetBooksQueryParams) *gorm.DB {
+	if query.Author != "" {
+		db = db.Where("author = ?", query.Author)
+	}
+
+	if query.AuthorPrefix != "" {
+		db = db.Where("author ILIKE ?", escapeLike(query.AuthorPrefix)+"%")
+	}
+
+	if query.Title != "" {
+		db = db.Where("title ILIKE ?", "%"+escapeLike(query.Title)+"%")
+	}
+
+	if query.PublishedFrom != "" {
+		db = db.Where("published_date >= ?", query.PublishedFrom)
+	}
+
+	if query.PublishedTo != "" {
+		db = db.Where("published_date <= ?", query.PublishedTo)
+	}
+
+	if query.AuthorID > 0 {
+		db = db.Where("id IN (SELECT book_id FROM book_authors WHERE author_id = ?)", query.AuthorID)
+	}
+
+	if query.Genre > 0 {
+		db = db.Where("genre_id IN (?)", gorm.Expr(genreSubtreeQuery, query.Genre))
+	}
+
+	if tags := query.TagNames(); len(tags) > 0 {
+		if query.MatchAllTags() {
+			db = db.Where(`id IN (SELECT book_tags.book_id FROM book_tags JOIN tags ON tags.id = book_tags.tag_id `+
+				`WHERE tags.name IN ? GROUP BY book_tags.book_id HAVING count(*) = ?)`, tags, len(tags))
+		} else {
+			db = db.Where("id IN (SELECT book_tags.book_id FROM book_tags JOIN tags ON tags.id = book_tags.tag_id WHERE tags.name IN ?)", tags)
+		}
+	}
+
+	if query.Q != "" {
+		q := "%" + escapeLike(query.Q) + "%"
+		db = db.Where("title ILIKE ? OR author ILIKE ? OR description ILIKE ?", q, q, q)
+	}
+
+	return db
+}
+
+// matchSearch narrows db down to the books matching the full-text search in query
+func (br *bookRepo) matchSearch(db *gorm.DB, query model.SearchBooksQueryParams) *gorm.DB {
+	return db.Model(&model.Book{}).Where("search_vector @@ ?", webSearchQuery(query))
+}
+
+func (br *bookRepo) findVersionByIDCacheKey(ID int64) string {
+	return fmt.Sprintf("book:%d:version", ID)
+}
+
 func (br *bookRepo) findAllByQueryParams(query model.GetBooksQueryParams) string {
-	return fmt.Sprintf("book:page:%d:size:%d", query.Page, query.Size)
+	return fmt.Sprintf("book:page:%d:size:%d:sort:%s:%s", query.Page, query.Size, query.Sort, br.filtersCacheKey(query))
+}
+
+func (br *bookRepo) findAllByCursorCacheKey(query model.GetBooksQueryParams) string {
+	return fmt.Sprintf("book:cursor:%s:limit:%d:sort:%s:%s", query.Cursor, query.Limit, query.Sort, br.filtersCacheKey(query))
+}
+
+func (br *bookRepo) countAllCacheKey(query model.GetBooksQueryParams) string {
+	return fmt.Sprintf("book:count:%s", br.filtersCacheKey(query))
+}
+
+func (br *bookRepo) countByFacetCacheKey(query model.GetBooksQueryParams, facet string) string {
+	return fmt.Sprintf("book:facet:%s:%s", facet, br.filtersCacheKey(query))
+}
+
+func (br *bookRepo) findAllTrashedCacheKey(query model.GetBooksQueryParams) string {
+	return fmt.Sprintf("book:trash:page:%d:size:%d:sort:%s:%s", query.Page, query.Size, query.Sort, br.filtersCacheKey(query))
+}
+
+func (br *bookRepo) countAllTrashedCacheKey(query model.GetBooksQueryParams) string {
+	return fmt.Sprintf("book:trash:count:%s", br.filtersCacheKey(query))
+}
+
+func (br *bookRepo) findAllByWorkIDCacheKey(workID int64) string {
+	return fmt.Sprintf("book:work:%d", workID)
+}
+
+func (br *bookRepo) findAllBySeriesIDCacheKey(seriesID int64) string {
+	return fmt.Sprintf("book:series:%d", seriesID)
+}
+
+func (br *bookRepo) searchCacheKey(query model.SearchBooksQueryParams) string {
+	return fmt.Sprintf("book:search:page:%d:size:%d:%s", query.Page, query.Size, br.searchFiltersCacheKey(query))
+}
+
+func (br *bookRepo) countSearchCacheKey(query model.SearchBooksQueryParams) string {
+	return fmt.Sprintf("book:search:count:%s", br.searchFiltersCacheKey(query))
+}
+
+func (br *bookRepo) suggestCacheKey(query model.SuggestBooksQueryParams) string {
+	return fmt.Sprintf("book:suggest:limit:%d:threshold:%g:q:%s", query.Limit, query.Threshold, url.QueryEscape(query.Q))
+}
+
+func (br *bookRepo) searchFiltersCacheKey(query model.SearchBooksQueryParams) string {
+	filters := url.Values{}
+	filters.Set("q", query.Q)
+	return filters.Encode()
 }
 
-func (br *bookRepo) countAllCacheKey() string {
-	return "book:count"
+func (br *bookRepo) filtersCacheKey(query model.GetBooksQueryParams) string {
+	filters := url.Values{}
+	filters.Set("author", query.Author)
+	filters.Set("author_prefix", query.AuthorPrefix)
+	filters.Set("title", query.Title)
+	filters.Set("published_from", query.PublishedFrom)
+	filters.Set("published_to", query.PublishedTo)
+	filters.Set("q", query.Q)
+	filters.Set("author_id", strconv.FormatInt(query.AuthorID, 10))
+	filters.Set("genre", strconv.FormatInt(query.Genre, 10))
+	filters.Set("tags", strings.Join(query.TagNames(), ","))
+	filters.Set("tags_match", strconv.FormatBool(query.MatchAllTags()))
+	return filters.Encode()
 }
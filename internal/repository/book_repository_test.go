@@ -9,8 +9,11 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
 	"github.com/ssentinull/create-apis-using-golang/internal/model"
 	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
 )
 
 func TestBookRepository_Create(t *testing.T) {
@@ -34,10 +37,9 @@ func TestBookRepository_Create(t *testing.T) {
 
 	cacheKeys := []string{
 		repo.cacheHash(),
-		repo.countAllCacheKey(),
 	}
 
-	query := `INSERT INTO "books" ("title","author","description","published_date","created_at","updated_at","deleted_at","id") VALUES ($1,$2,$3,$4,$5,$6,$7,$8) RETURNING "id"`
+	query := `INSERT INTO "books" ("title","author","description","published_date","publisher_id","series_id","series_position","work_id","genre_id","isbn13","isbn10","cover_updated_at","version","created_at","updated_at","deleted_at","id") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17) RETURNING "id"`
 
 	t.Run("success", func(t *testing.T) {
 		rows := sqlmock.NewRows([]string{"id", "author", "title", "description"}).
@@ -61,6 +63,24 @@ func TestBookRepository_Create(t *testing.T) {
 		assert.Error(t, err)
 	})
 
+	t.Run("failed - publisher does not exist", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(&pgconn.PgError{Code: pgForeignKeyViolationCode})
+		mockedDependency.sql.ExpectRollback()
+
+		err := repo.Create(ctx, &book)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+	})
+
+	t.Run("failed - isbn is taken", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(&pgconn.PgError{Code: pgUniqueViolationCode})
+		mockedDependency.sql.ExpectRollback()
+
+		err := repo.Create(ctx, &book)
+		assert.ErrorIs(t, err, domainerr.ErrConflict)
+	})
+
 	t.Run("failed - delete cache return error", func(t *testing.T) {
 		rows := sqlmock.NewRows([]string{"id", "author", "title", "description"}).
 			AddRow(book.ID, book.Author, book.Title, book.Description)
@@ -96,6 +116,7 @@ func TestBookRepository_Delete(t *testing.T) {
 
 	cacheKeys := []string{
 		repo.findByIDCacheKey(book.ID),
+		repo.findVersionByIDCacheKey(book.ID),
 		repo.cacheHash(),
 	}
 
@@ -107,7 +128,7 @@ func TestBookRepository_Delete(t *testing.T) {
 		mockedDependency.sql.ExpectCommit()
 		mockedDependency.cacheRepo.EXPECT().Delete(ctx, cacheKeys).Times(1).Return(nil)
 
-		err := repo.DeleteByID(ctx, book.ID)
+		err := repo.DeleteByID(ctx, book.ID, 0)
 		assert.NoError(t, err)
 	})
 
@@ -116,18 +137,288 @@ func TestBookRepository_Delete(t *testing.T) {
 		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(query)).WillReturnError(errors.New("db error"))
 		mockedDependency.sql.ExpectRollback()
 
-		err := repo.DeleteByID(ctx, book.ID)
+		err := repo.DeleteByID(ctx, book.ID, 0)
 		assert.Error(t, err)
 	})
 
+	t.Run("failed - book not found", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(query)).WillReturnResult(sqlmock.NewResult(0, 0))
+		mockedDependency.sql.ExpectRollback()
+
+		err := repo.DeleteByID(ctx, book.ID, 0)
+		assert.ErrorIs(t, err, domainerr.ErrNotFound)
+	})
+
+	t.Run("failed - version does not match", func(t *testing.T) {
+		versionQuery := `UPDATE "books" SET "deleted_at"=$1 WHERE version = $2 AND "books"."id" = $3 AND "books"."deleted_at" IS NULL`
+		countQuery := `SELECT count(*) FROM "books" WHERE id = $1 AND "books"."deleted_at" IS NULL`
+
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(versionQuery)).
+			WithArgs(sqlmock.AnyArg(), int64(2), book.ID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(countQuery)).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mockedDependency.sql.ExpectRollback()
+
+		err := repo.DeleteByID(ctx, book.ID, 2)
+		assert.ErrorIs(t, err, domainerr.ErrPreconditionFailed)
+	})
+
 	t.Run("failed - delete cache return error", func(t *testing.T) {
 		mockedDependency.sql.ExpectBegin()
 		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(query)).WillReturnResult(sqlmock.NewResult(1, 1))
 		mockedDependency.sql.ExpectCommit()
 		mockedDependency.cacheRepo.EXPECT().Delete(ctx, cacheKeys).Times(1).Return(errors.New("cache error"))
 
-		err := repo.DeleteByID(ctx, book.ID)
+		err := repo.DeleteByID(ctx, book.ID, 0)
+		assert.Error(t, err)
+	})
+}
+
+func TestBookRepository_HardDeleteByID(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := bookRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	ID := int64(1)
+	cacheKeys := []string{
+		repo.findByIDCacheKey(ID),
+		repo.findVersionByIDCacheKey(ID),
+		repo.cacheHash(),
+	}
+
+	query := `DELETE FROM "books" WHERE "books"."id" = $1 RETURNING "id","cover_updated_at"`
+	loansQuery := `SELECT count(*) FROM "loans" WHERE book_id = $1`
+	coverUpdatedAt := time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC)
+	deletedRows := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"id", "cover_updated_at"})
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(loansQuery)).WithArgs(ID).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(ID).WillReturnRows(deletedRows().AddRow(ID, coverUpdatedAt))
+		mockedDependency.sql.ExpectCommit()
+		mockedDependency.cacheRepo.EXPECT().Delete(ctx, cacheKeys).Times(1).Return(nil)
+
+		res, err := repo.HardDeleteByID(ctx, ID, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, ID, res.ID)
+		assert.Equal(t, coverUpdatedAt, *res.CoverUpdatedAt)
+	})
+
+	t.Run("failed - version does not match", func(t *testing.T) {
+		versionQuery := `DELETE FROM "books" WHERE version = $1 AND "books"."id" = $2 RETURNING "id","cover_updated_at"`
+		countQuery := `SELECT count(*) FROM "books" WHERE id = $1`
+
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(loansQuery)).WithArgs(ID).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(versionQuery)).WithArgs(int64(2), ID).WillReturnRows(deletedRows())
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(countQuery)).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mockedDependency.sql.ExpectRollback()
+
+		res, err := repo.HardDeleteByID(ctx, ID, 2)
+		assert.ErrorIs(t, err, domainerr.ErrPreconditionFailed)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - book not found", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(loansQuery)).WithArgs(ID).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnRows(deletedRows())
+		mockedDependency.sql.ExpectRollback()
+
+		res, err := repo.HardDeleteByID(ctx, ID, 0)
+		assert.ErrorIs(t, err, domainerr.ErrNotFound)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - book has loans", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(loansQuery)).WithArgs(ID).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+		mockedDependency.sql.ExpectRollback()
+
+		res, err := repo.HardDeleteByID(ctx, ID, 0)
+		assert.ErrorIs(t, err, domainerr.ErrConflict)
+		assert.Nil(t, res)
+	})
+}
+
+func TestBookRepository_Restore(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := bookRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	book := model.Book{
+		ID:      int64(1),
+		Title:   "Harry Potter",
+		Version: 2,
+	}
+
+	bytes, err := json.Marshal(book)
+	assert.NoError(t, err)
+
+	cacheKeys := []string{
+		repo.cacheHash(),
+		repo.findByIDCacheKey(book.ID),
+		repo.findVersionByIDCacheKey(book.ID),
+	}
+
+	query := `UPDATE "books" SET "deleted_at"=$1,"version"=version + 1,"updated_at"=$2 WHERE deleted_at IS NOT NULL AND id = $3`
+
+	t.Run("success", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(query)).WithArgs(nil, sqlmock.AnyArg(), book.ID).WillReturnResult(sqlmock.NewResult(1, 1))
+		mockedDependency.sql.ExpectCommit()
+		mockedDependency.cacheRepo.EXPECT().Delete(ctx, cacheKeys).Times(1).Return(nil)
+		mockedDependency.cacheRepo.EXPECT().Get(ctx, repo.findByIDCacheKey(book.ID)).Times(1).Return(string(bytes), nil)
+
+		res, err := repo.Restore(ctx, book.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, book.Version, res.Version)
+	})
+
+	t.Run("failed - book is not in the trash", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(query)).WillReturnResult(sqlmock.NewResult(0, 0))
+		mockedDependency.sql.ExpectRollback()
+
+		res, err := repo.Restore(ctx, book.ID)
+		assert.ErrorIs(t, err, domainerr.ErrNotFound)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - restore book in db return error", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(query)).WillReturnError(errors.New("db error"))
+		mockedDependency.sql.ExpectRollback()
+
+		res, err := repo.Restore(ctx, book.ID)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestBookRepository_UpdateCover(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := bookRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	coverUpdatedAt := time.Date(2026, 10, 18, 14, 0, 0, 0, time.UTC)
+	book := model.Book{
+		ID:             int64(1),
+		Title:          "Harry Potter",
+		CoverUpdatedAt: &coverUpdatedAt,
+		Version:        2,
+	}
+
+	bytes, err := json.Marshal(book)
+	assert.NoError(t, err)
+
+	cacheKeys := []string{
+		repo.cacheHash(),
+		repo.findByIDCacheKey(book.ID),
+		repo.findVersionByIDCacheKey(book.ID),
+	}
+
+	query := `UPDATE "books" SET "cover_updated_at"=$1,"version"=version + 1,"updated_at"=$2 WHERE id = $3 AND "books"."deleted_at" IS NULL`
+
+	t.Run("success", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(query)).WithArgs(coverUpdatedAt, sqlmock.AnyArg(), book.ID).WillReturnResult(sqlmock.NewResult(1, 1))
+		mockedDependency.sql.ExpectCommit()
+		mockedDependency.cacheRepo.EXPECT().Delete(ctx, cacheKeys).Times(1).Return(nil)
+		mockedDependency.cacheRepo.EXPECT().Get(ctx, repo.findByIDCacheKey(book.ID)).Times(1).Return(string(bytes), nil)
+
+		res, err := repo.UpdateCover(ctx, book.ID, coverUpdatedAt)
+		assert.NoError(t, err)
+		assert.Equal(t, book.Version, res.Version)
+	})
+
+	t.Run("failed - book does not exist", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(query)).WillReturnResult(sqlmock.NewResult(0, 0))
+		mockedDependency.sql.ExpectRollback()
+
+		res, err := repo.UpdateCover(ctx, book.ID, coverUpdatedAt)
+		assert.ErrorIs(t, err, domainerr.ErrNotFound)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - update cover in db return error", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(query)).WillReturnError(errors.New("db error"))
+		mockedDependency.sql.ExpectRollback()
+
+		res, err := repo.UpdateCover(ctx, book.ID, coverUpdatedAt)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestBookRepository_PurgeTrashed(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := bookRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	deletedBefore := time.Date(2026, 9, 18, 0, 0, 0, 0, time.UTC)
+	query := `DELETE FROM "books" WHERE deleted_at IS NOT NULL AND deleted_at < $1 AND NOT EXISTS (SELECT 1 FROM "loans" WHERE "loans"."book_id" = "books"."id") RETURNING "id","cover_updated_at"`
+	coverUpdatedAt := time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC)
+
+	t.Run("success", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(deletedBefore).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "cover_updated_at"}).AddRow(1, coverUpdatedAt).AddRow(2, nil).AddRow(3, nil))
+		mockedDependency.sql.ExpectCommit()
+		mockedDependency.cacheRepo.EXPECT().Delete(ctx, repo.cacheHash()).Times(1).Return(nil)
+
+		res, err := repo.PurgeTrashed(ctx, deletedBefore)
+		assert.NoError(t, err)
+		assert.Len(t, res, 3)
+		assert.Equal(t, int64(1), res[0].ID)
+		assert.Equal(t, coverUpdatedAt, *res[0].CoverUpdatedAt)
+		assert.Nil(t, res[1].CoverUpdatedAt)
+	})
+
+	t.Run("success - nothing to purge", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnRows(sqlmock.NewRows([]string{"id", "cover_updated_at"}))
+		mockedDependency.sql.ExpectCommit()
+
+		res, err := repo.PurgeTrashed(ctx, deletedBefore)
+		assert.NoError(t, err)
+		assert.Empty(t, res)
+	})
+
+	t.Run("failed - purge books in db return error", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(errors.New("db error"))
+		mockedDependency.sql.ExpectRollback()
+
+		res, err := repo.PurgeTrashed(ctx, deletedBefore)
 		assert.Error(t, err)
+		assert.Empty(t, res)
 	})
 }
 
@@ -191,9 +482,18 @@ func TestBookRepository_FindByID(t *testing.T) {
 		assert.Error(t, err)
 		assert.Nil(t, res)
 	})
+
+	t.Run("failed - book not found", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().Get(ctx, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(gorm.ErrRecordNotFound)
+
+		res, err := repo.FindByID(ctx, book.ID)
+		assert.ErrorIs(t, err, domainerr.ErrNotFound)
+		assert.Nil(t, res)
+	})
 }
 
-func TestBookRepository_FindAll(t *testing.T) {
+func TestBookRepository_FindByISBN(t *testing.T) {
 	mockedDependency := newMockedDependency(t)
 	defer mockedDependency.close()
 
@@ -203,67 +503,60 @@ func TestBookRepository_FindAll(t *testing.T) {
 		cacheRepo: mockedDependency.cacheRepo,
 	}
 
-	queryParams := model.GetBooksQueryParams{
-		Page: 1,
-		Size: 5,
-	}
-
+	isbn13, isbn10 := "9780747532699", "0747532699"
 	book := model.Book{
-		ID:          int64(1),
-		Title:       "Harry Potter",
-		Author:      "J. K. Rowling",
-		Description: "A series about wizards",
-		CreatedAt:   time.Time{},
-		UpdatedAt:   time.Time{},
+		ID:     int64(1),
+		Title:  "Harry Potter",
+		ISBN13: &isbn13,
+		ISBN10: &isbn10,
 	}
 
-	query := `SELECT * FROM "books" WHERE "books"."deleted_at" IS NULL ORDER BY id DESC LIMIT 5`
+	query := `SELECT * FROM "books" WHERE isbn13 = $1 AND "books"."deleted_at" IS NULL LIMIT 1`
 
 	cacheHash := repo.cacheHash()
-	cacheKey := repo.findAllByQueryParams(queryParams)
-
-	books := []*model.Book{&book}
-	bytes, err := json.Marshal(books)
+	cacheKey := repo.findByISBNCacheKey(isbn13)
+	bytes, err := json.Marshal(book)
 	assert.NoError(t, err)
 
 	t.Run("success - fetch from cache", func(t *testing.T) {
 		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return(string(bytes), nil)
-		res, err := repo.FindAll(ctx, queryParams)
+		res, err := repo.FindByISBN(ctx, isbn13)
 		assert.NoError(t, err)
-		assert.NotNil(t, res)
+		assert.Equal(t, isbn10, *res.ISBN10)
 	})
 
 	t.Run("success - fetch from db", func(t *testing.T) {
-		rows := sqlmock.NewRows([]string{"id", "author", "title", "description"}).
-			AddRow(book.ID, book.Author, book.Title, book.Description)
+		rows := sqlmock.NewRows([]string{"id", "title", "isbn13", "isbn10"}).
+			AddRow(book.ID, book.Title, isbn13, isbn10)
 
 		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("", nil)
-		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnRows(rows)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(isbn13).WillReturnRows(rows)
 		mockedDependency.cacheRepo.EXPECT().HashSet(ctx, cacheHash, cacheKey, gomock.Any()).Times(1).Return(nil)
 
-		res, err := repo.FindAll(ctx, queryParams)
+		res, err := repo.FindByISBN(ctx, isbn13)
 		assert.NoError(t, err)
-		assert.NotNil(t, res)
+		assert.Equal(t, book.ID, res.ID)
+		assert.Equal(t, isbn13, *res.ISBN13)
 	})
 
 	t.Run("failed - fetch from cache return error", func(t *testing.T) {
 		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("", errors.New("redis error"))
-		res, err := repo.FindAll(ctx, queryParams)
+		res, err := repo.FindByISBN(ctx, isbn13)
 		assert.Error(t, err)
 		assert.Nil(t, res)
 	})
 
-	t.Run("failed - fetch from db return error", func(t *testing.T) {
+	t.Run("failed - book not found", func(t *testing.T) {
 		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("", nil)
-		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(errors.New("db error"))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(gorm.ErrRecordNotFound)
 
-		res, err := repo.FindAll(ctx, queryParams)
-		assert.Error(t, err)
+		res, err := repo.FindByISBN(ctx, isbn13)
+		assert.ErrorIs(t, err, domainerr.ErrNotFound)
 		assert.Nil(t, res)
 	})
 }
 
-func TestBookRepository_CountAll(t *testing.T) {
+func TestBookRepository_FindVersionByID(t *testing.T) {
 	mockedDependency := newMockedDependency(t)
 	defer mockedDependency.close()
 
@@ -273,49 +566,45 @@ func TestBookRepository_CountAll(t *testing.T) {
 		cacheRepo: mockedDependency.cacheRepo,
 	}
 
-	query := `SELECT count(*) FROM "books" WHERE "books"."deleted_at" IS NULL`
-
-	cacheKey := repo.countAllCacheKey()
-	bytes, err := json.Marshal(1)
-	assert.NoError(t, err)
+	ID := int64(1)
+	query := `SELECT "version" FROM "books" WHERE id = $1 AND "books"."deleted_at" IS NULL LIMIT 1`
+	cacheKey := repo.findVersionByIDCacheKey(ID)
 
 	t.Run("success - fetch from cache", func(t *testing.T) {
-		mockedDependency.cacheRepo.EXPECT().Get(ctx, cacheKey).Times(1).Return(string(bytes), nil)
-		res, err := repo.CountAll(ctx)
+		mockedDependency.cacheRepo.EXPECT().Get(ctx, cacheKey).Times(1).Return("3", nil)
+		res, err := repo.FindVersionByID(ctx, ID)
 		assert.NoError(t, err)
-		assert.NotNil(t, res)
+		assert.Equal(t, int64(3), res)
 	})
 
 	t.Run("success - fetch from db", func(t *testing.T) {
-		rows := sqlmock.NewRows([]string{"count"}).AddRow(1)
-
 		mockedDependency.cacheRepo.EXPECT().Get(ctx, cacheKey).Times(1).Return("", nil)
-		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnRows(rows)
-		mockedDependency.cacheRepo.EXPECT().Set(ctx, cacheKey, gomock.Any()).Times(1).Return(nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(3))
+		mockedDependency.cacheRepo.EXPECT().Set(ctx, cacheKey, "3").Times(1).Return(nil)
 
-		res, err := repo.CountAll(ctx)
+		res, err := repo.FindVersionByID(ctx, ID)
 		assert.NoError(t, err)
-		assert.NotNil(t, res)
+		assert.Equal(t, int64(3), res)
 	})
 
 	t.Run("failed - fetch from cache return error", func(t *testing.T) {
 		mockedDependency.cacheRepo.EXPECT().Get(ctx, cacheKey).Times(1).Return("", errors.New("redis error"))
-		res, err := repo.CountAll(ctx)
+		res, err := repo.FindVersionByID(ctx, ID)
 		assert.Error(t, err)
 		assert.Zero(t, res)
 	})
 
-	t.Run("failed - fetch from db return error", func(t *testing.T) {
+	t.Run("failed - book not found", func(t *testing.T) {
 		mockedDependency.cacheRepo.EXPECT().Get(ctx, cacheKey).Times(1).Return("", nil)
-		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(errors.New("db error"))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(gorm.ErrRecordNotFound)
 
-		res, err := repo.CountAll(ctx)
-		assert.Error(t, err)
+		res, err := repo.FindVersionByID(ctx, ID)
+		assert.ErrorIs(t, err, domainerr.ErrNotFound)
 		assert.Zero(t, res)
 	})
 }
 
-func TestBookRepository_Update(t *testing.T) {
+func TestBookRepository_FindAll(t *testing.T) {
 	mockedDependency := newMockedDependency(t)
 	defer mockedDependency.close()
 
@@ -325,6 +614,11 @@ func TestBookRepository_Update(t *testing.T) {
 		cacheRepo: mockedDependency.cacheRepo,
 	}
 
+	queryParams := model.GetBooksQueryParams{
+		Page: 1,
+		Size: 5,
+	}
+
 	book := model.Book{
 		ID:          int64(1),
 		Title:       "Harry Potter",
@@ -334,21 +628,804 @@ func TestBookRepository_Update(t *testing.T) {
 		UpdatedAt:   time.Time{},
 	}
 
-	query := `UPDATE "books" SET "title"=$1,"author"=$2,"description"=$3,"updated_at"=$4 WHERE "books"."deleted_at" IS NULL AND "id" = $5`
+	query := `SELECT * FROM "books" WHERE "books"."deleted_at" IS NULL ORDER BY id DESC LIMIT 5`
 
-	cacheKey := repo.findByIDCacheKey(book.ID)
-	cacheKeys := []string{
-		repo.cacheHash(),
-		repo.countAllCacheKey(),
-		repo.findByIDCacheKey(book.ID),
-	}
+	cacheHash := repo.cacheHash()
+	cacheKey := repo.findAllByQueryParams(queryParams)
 
-	bytes, err := json.Marshal(book)
+	books := []*model.Book{&book}
+	bytes, err := json.Marshal(books)
 	assert.NoError(t, err)
 
-	t.Run("success", func(t *testing.T) {
-		mockedDependency.sql.ExpectBegin()
-		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(query)).WillReturnResult(sqlmock.NewResult(1, 1))
+	t.Run("success - fetch from cache", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return(string(bytes), nil)
+		res, err := repo.FindAll(ctx, queryParams)
+		assert.NoError(t, err)
+		assert.NotNil(t, res)
+	})
+
+	t.Run("success - fetch from db", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "author", "title", "description"}).
+			AddRow(book.ID, book.Author, book.Title, book.Description)
+
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnRows(rows)
+		mockedDependency.cacheRepo.EXPECT().HashSet(ctx, cacheHash, cacheKey, gomock.Any()).Times(1).Return(nil)
+
+		res, err := repo.FindAll(ctx, queryParams)
+		assert.NoError(t, err)
+		assert.NotNil(t, res)
+	})
+
+	t.Run("success - fetch from db with filters and sort", func(t *testing.T) {
+		filteredParams := model.GetBooksQueryParams{
+			Page:          2,
+			Size:          5,
+			AuthorPrefix:  "J. K.",
+			PublishedFrom: "1997-01-01",
+			Sort:          "title,-published_date",
+			Q:             "100%",
+		}
+		filteredCacheKey := repo.findAllByQueryParams(filteredParams)
+		filteredQuery := `SELECT * FROM "books" WHERE author ILIKE $1 AND published_date >= $2 AND ` +
+			`(title ILIKE $3 OR author ILIKE $4 OR description ILIKE $5) AND "books"."deleted_at" IS NULL ` +
+			`ORDER BY "title","published_date" DESC,id DESC LIMIT 5 OFFSET 5`
+
+		rows := sqlmock.NewRows([]string{"id", "author", "title", "description"}).
+			AddRow(book.ID, book.Author, book.Title, book.Description)
+
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, filteredCacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(filteredQuery)).
+			WithArgs("J. K.%", "1997-01-01", `%100\%%`, `%100\%%`, `%100\%%`).
+			WillReturnRows(rows)
+		mockedDependency.cacheRepo.EXPECT().HashSet(ctx, cacheHash, filteredCacheKey, gomock.Any()).Times(1).Return(nil)
+
+		res, err := repo.FindAll(ctx, filteredParams)
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+		assert.NotEqual(t, cacheKey, filteredCacheKey)
+	})
+
+	t.Run("success - fetch from db sorted by rating", func(t *testing.T) {
+		ratedParams := model.GetBooksQueryParams{Page: 1, Size: 5, Sort: "-average_rating,-ratings_count"}
+		ratedCacheKey := repo.findAllByQueryParams(ratedParams)
+		ratedQuery := `SELECT * FROM "books" WHERE "books"."deleted_at" IS NULL ` +
+			`ORDER BY "average_rating" DESC,"ratings_count" DESC,id DESC LIMIT 5`
+
+		rows := sqlmock.NewRows([]string{"id", "title", "average_rating", "ratings_count"}).
+			AddRow(book.ID, book.Title, 4.5, 2)
+
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, ratedCacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(ratedQuery)).WillReturnRows(rows)
+		mockedDependency.cacheRepo.EXPECT().HashSet(ctx, cacheHash, ratedCacheKey, gomock.Any()).Times(1).Return(nil)
+
+		res, err := repo.FindAll(ctx, ratedParams)
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+		assert.Equal(t, 4.5, res[0].AverageRating)
+		assert.Equal(t, int64(2), res[0].RatingsCount)
+	})
+
+	t.Run("failed - fetch from cache return error", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("", errors.New("redis error"))
+		res, err := repo.FindAll(ctx, queryParams)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - fetch from db return error", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(errors.New("db error"))
+
+		res, err := repo.FindAll(ctx, queryParams)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestBookRepository_FindAllByCursor(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := bookRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	queryParams := model.GetBooksQueryParams{
+		Limit: 1,
+	}
+
+	book := model.Book{
+		ID:          int64(2),
+		Title:       "Harry Potter",
+		Author:      "J. K. Rowling",
+		Description: "A series about wizards",
+		CreatedAt:   time.Time{},
+		UpdatedAt:   time.Time{},
+	}
+
+	query := `SELECT * FROM "books" WHERE "books"."deleted_at" IS NULL ORDER BY id DESC LIMIT 2`
+
+	cacheHash := repo.cacheHash()
+	cacheKey := repo.findAllByCursorCacheKey(queryParams)
+
+	bytes, err := json.Marshal(cursorPage{Books: []*model.Book{&book}, HasMore: true})
+	assert.NoError(t, err)
+
+	t.Run("success - fetch from cache", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return(string(bytes), nil)
+		res, hasMore, err := repo.FindAllByCursor(ctx, queryParams)
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+		assert.True(t, hasMore)
+	})
+
+	t.Run("success - fetch first page from db", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "author", "title", "description"}).
+			AddRow(book.ID, book.Author, book.Title, book.Description).
+			AddRow(int64(1), book.Author, book.Title, book.Description)
+
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnRows(rows)
+		mockedDependency.cacheRepo.EXPECT().HashSet(ctx, cacheHash, cacheKey, gomock.Any()).Times(1).Return(nil)
+
+		res, hasMore, err := repo.FindAllByCursor(ctx, queryParams)
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+		assert.Equal(t, book.ID, res[0].ID)
+		assert.True(t, hasMore)
+	})
+
+	t.Run("success - fetch previous page from db", func(t *testing.T) {
+		sortedParams := model.GetBooksQueryParams{
+			Sort:  "title",
+			Limit: 2,
+		}
+		fields, err := sortedParams.KeysetFields()
+		assert.NoError(t, err)
+
+		sortedParams.Cursor = model.NewBookCursor(&book, fields, sortedParams.Sort, true)
+		sortedCacheKey := repo.findAllByCursorCacheKey(sortedParams)
+		sortedQuery := `SELECT * FROM "books" WHERE (title < $1 OR (title = $2 AND id > $3)) AND "books"."deleted_at" IS NULL ` +
+			`ORDER BY title DESC,id ASC LIMIT 3`
+
+		rows := sqlmock.NewRows([]string{"id", "author", "title", "description"}).
+			AddRow(int64(3), book.Author, "Dune", book.Description).
+			AddRow(int64(4), book.Author, "Carrie", book.Description)
+
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, sortedCacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(sortedQuery)).
+			WithArgs(book.Title, book.Title, book.ID).
+			WillReturnRows(rows)
+		mockedDependency.cacheRepo.EXPECT().HashSet(ctx, cacheHash, sortedCacheKey, gomock.Any()).Times(1).Return(nil)
+
+		res, hasMore, err := repo.FindAllByCursor(ctx, sortedParams)
+		assert.NoError(t, err)
+		assert.Len(t, res, 2)
+		assert.Equal(t, "Carrie", res[0].Title)
+		assert.False(t, hasMore)
+	})
+
+	t.Run("failed - fetch from cache return error", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("", errors.New("redis error"))
+		res, _, err := repo.FindAllByCursor(ctx, queryParams)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - fetch from db return error", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(errors.New("db error"))
+
+		res, _, err := repo.FindAllByCursor(ctx, queryParams)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestBookRepository_CountAll(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := bookRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	queryParams := model.GetBooksQueryParams{
+		Page:   1,
+		Size:   5,
+		Author: "J. K. Rowling",
+	}
+
+	query := `SELECT count(*) FROM "books" WHERE author = $1 AND "books"."deleted_at" IS NULL`
+
+	cacheHash := repo.cacheHash()
+	cacheKey := repo.countAllCacheKey(queryParams)
+	bytes, err := json.Marshal(1)
+	assert.NoError(t, err)
+
+	t.Run("success - fetch from cache", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return(string(bytes), nil)
+		res, err := repo.CountAll(ctx, queryParams)
+		assert.NoError(t, err)
+		assert.NotNil(t, res)
+	})
+
+	t.Run("success - fetch from db", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"count"}).AddRow(1)
+
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnRows(rows)
+		mockedDependency.cacheRepo.EXPECT().HashSet(ctx, cacheHash, cacheKey, gomock.Any()).Times(1).Return(nil)
+
+		res, err := repo.CountAll(ctx, queryParams)
+		assert.NoError(t, err)
+		assert.NotNil(t, res)
+	})
+
+	t.Run("success - filter by author ID", func(t *testing.T) {
+		params := model.GetBooksQueryParams{Page: 1, Size: 5, AuthorID: 7}
+		authorQuery := `SELECT count(*) FROM "books" WHERE id IN (SELECT book_id FROM book_authors WHERE author_id = $1) AND "books"."deleted_at" IS NULL`
+
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, repo.countAllCacheKey(params)).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(authorQuery)).WithArgs(int64(7)).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+		mockedDependency.cacheRepo.EXPECT().HashSet(ctx, cacheHash, repo.countAllCacheKey(params), "2").Times(1).Return(nil)
+
+		res, err := repo.CountAll(ctx, params)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), res)
+		assert.NotEqual(t, cacheKey, repo.countAllCacheKey(params))
+	})
+
+	t.Run("success - filter by genre including its subgenres", func(t *testing.T) {
+		params := model.GetBooksQueryParams{Page: 1, Size: 5, Genre: 3}
+		genreQuery := `SELECT count(*) FROM "books" WHERE genre_id IN (WITH RECURSIVE subtree AS (`
+
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, repo.countAllCacheKey(params)).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(genreQuery)).WithArgs(int64(3)).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(4))
+		mockedDependency.cacheRepo.EXPECT().HashSet(ctx, cacheHash, repo.countAllCacheKey(params), "4").Times(1).Return(nil)
+
+		res, err := repo.CountAll(ctx, params)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(4), res)
+	})
+
+	t.Run("success - filter by any of the tags", func(t *testing.T) {
+		params := model.GetBooksQueryParams{Page: 1, Size: 5, Tags: "Magic, school,magic"}
+		tagsQuery := `SELECT count(*) FROM "books" WHERE id IN (SELECT book_tags.book_id FROM book_tags JOIN tags ON tags.id = book_tags.tag_id ` +
+			`WHERE tags.name IN ($1,$2)) AND "books"."deleted_at" IS NULL`
+
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, repo.countAllCacheKey(params)).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(tagsQuery)).WithArgs("magic", "school").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+		mockedDependency.cacheRepo.EXPECT().HashSet(ctx, cacheHash, repo.countAllCacheKey(params), "3").Times(1).Return(nil)
+
+		res, err := repo.CountAll(ctx, params)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), res)
+	})
+
+	t.Run("success - filter by all of the tags", func(t *testing.T) {
+		params := model.GetBooksQueryParams{Page: 1, Size: 5, Tags: "magic,school", TagsMatch: model.TagsMatchAll}
+		tagsQuery := `SELECT count(*) FROM "books" WHERE id IN (SELECT book_tags.book_id FROM book_tags JOIN tags ON tags.id = book_tags.tag_id ` +
+			`WHERE tags.name IN ($1,$2) GROUP BY book_tags.book_id HAVING count(*) = $3) AND "books"."deleted_at" IS NULL`
+
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, repo.countAllCacheKey(params)).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(tagsQuery)).WithArgs("magic", "school", 2).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mockedDependency.cacheRepo.EXPECT().HashSet(ctx, cacheHash, repo.countAllCacheKey(params), "1").Times(1).Return(nil)
+
+		res, err := repo.CountAll(ctx, params)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), res)
+		assert.NotEqual(t, repo.countAllCacheKey(model.GetBooksQueryParams{Page: 1, Size: 5, Tags: "magic,school"}), repo.countAllCacheKey(params))
+	})
+
+	t.Run("failed - fetch from cache return error", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("", errors.New("redis error"))
+		res, err := repo.CountAll(ctx, queryParams)
+		assert.Error(t, err)
+		assert.Zero(t, res)
+	})
+
+	t.Run("failed - fetch from db return error", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(errors.New("db error"))
+
+		res, err := repo.CountAll(ctx, queryParams)
+		assert.Error(t, err)
+		assert.Zero(t, res)
+	})
+}
+
+func TestBookRepository_CountByFacet(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := bookRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	queryParams := model.GetBooksQueryParams{Page: 1, Size: 5, Title: "potter", Facets: "author,decade"}
+	counts := []*model.FacetCount{{Value: "J. K. Rowling", Count: 7}}
+
+	authorQuery := `SELECT author AS value, count(*) AS count FROM "books" WHERE title ILIKE $1 AND author <> '' AND "books"."deleted_at" IS NULL GROUP BY "value" ORDER BY count DESC, value ASC LIMIT 50`
+	decadeQuery := `SELECT to_char(date_trunc('decade', published_date), 'YYYY') AS value, count(*) AS count FROM "books" WHERE title ILIKE $1 AND published_date IS NOT NULL AND "books"."deleted_at" IS NULL GROUP BY "value" ORDER BY value ASC LIMIT 50`
+
+	cacheHash := repo.cacheHash()
+	cacheKey := repo.countByFacetCacheKey(queryParams, model.AuthorFacet)
+
+	bytes, err := json.Marshal(counts)
+	assert.NoError(t, err)
+
+	t.Run("success - fetch from cache", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return(string(bytes), nil)
+		res, err := repo.CountByFacet(ctx, queryParams, model.AuthorFacet)
+		assert.NoError(t, err)
+		assert.Equal(t, counts, res)
+	})
+
+	t.Run("success - fetch from db", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"value", "count"}).AddRow("J. K. Rowling", 7)
+
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(authorQuery)).WithArgs("%potter%").WillReturnRows(rows)
+		mockedDependency.cacheRepo.EXPECT().HashSet(ctx, cacheHash, cacheKey, string(bytes)).Times(1).Return(nil)
+
+		res, err := repo.CountByFacet(ctx, queryParams, model.AuthorFacet)
+		assert.NoError(t, err)
+		assert.Equal(t, counts, res)
+	})
+
+	t.Run("success - fetch decades from db", func(t *testing.T) {
+		decadeCacheKey := repo.countByFacetCacheKey(queryParams, model.DecadeFacet)
+		rows := sqlmock.NewRows([]string{"value", "count"}).AddRow("1990", 3).AddRow("2000", 4)
+
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, decadeCacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(decadeQuery)).WithArgs("%potter%").WillReturnRows(rows)
+		mockedDependency.cacheRepo.EXPECT().HashSet(ctx, cacheHash, decadeCacheKey, gomock.Any()).Times(1).Return(nil)
+
+		res, err := repo.CountByFacet(ctx, queryParams, model.DecadeFacet)
+		assert.NoError(t, err)
+		assert.Equal(t, []*model.FacetCount{{Value: "1990", Count: 3}, {Value: "2000", Count: 4}}, res)
+	})
+
+	t.Run("failed - unknown facet", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, gomock.Any()).Times(1).Return("", nil)
+
+		res, err := repo.CountByFacet(ctx, queryParams, "password")
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - fetch from db return error", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(authorQuery)).WillReturnError(errors.New("db error"))
+
+		res, err := repo.CountByFacet(ctx, queryParams, model.AuthorFacet)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestBookRepository_FindAllTrashed(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := bookRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	queryParams := model.GetBooksQueryParams{
+		Page:   2,
+		Size:   5,
+		Author: "J. K. Rowling",
+	}
+
+	book := model.Book{
+		ID:     int64(1),
+		Title:  "Harry Potter",
+		Author: "J. K. Rowling",
+	}
+
+	query := `SELECT * FROM "books" WHERE deleted_at IS NOT NULL AND author = $1 ORDER BY deleted_at DESC,id DESC LIMIT 5 OFFSET 5`
+
+	cacheHash := repo.cacheHash()
+	cacheKey := repo.findAllTrashedCacheKey(queryParams)
+
+	bytes, err := json.Marshal([]*model.Book{&book})
+	assert.NoError(t, err)
+
+	t.Run("success - fetch from cache", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return(string(bytes), nil)
+		res, err := repo.FindAllTrashed(ctx, queryParams)
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+	})
+
+	t.Run("success - fetch from db", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "author", "title"}).AddRow(book.ID, book.Author, book.Title)
+
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(book.Author).WillReturnRows(rows)
+		mockedDependency.cacheRepo.EXPECT().HashSet(ctx, cacheHash, cacheKey, gomock.Any()).Times(1).Return(nil)
+
+		res, err := repo.FindAllTrashed(ctx, queryParams)
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+		assert.NotEqual(t, repo.findAllByQueryParams(queryParams), cacheKey)
+	})
+
+	t.Run("failed - fetch from db return error", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(errors.New("db error"))
+
+		res, err := repo.FindAllTrashed(ctx, queryParams)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestBookRepository_CountAllTrashed(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := bookRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	queryParams := model.GetBooksQueryParams{Page: 1, Size: 5}
+	query := `SELECT count(*) FROM "books" WHERE deleted_at IS NOT NULL`
+
+	cacheHash := repo.cacheHash()
+	cacheKey := repo.countAllTrashedCacheKey(queryParams)
+
+	t.Run("success - fetch from cache", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("2", nil)
+		res, err := repo.CountAllTrashed(ctx, queryParams)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), res)
+	})
+
+	t.Run("success - fetch from db", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+		mockedDependency.cacheRepo.EXPECT().HashSet(ctx, cacheHash, cacheKey, "2").Times(1).Return(nil)
+
+		res, err := repo.CountAllTrashed(ctx, queryParams)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), res)
+	})
+
+	t.Run("failed - fetch from db return error", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(errors.New("db error"))
+
+		res, err := repo.CountAllTrashed(ctx, queryParams)
+		assert.Error(t, err)
+		assert.Zero(t, res)
+	})
+}
+
+func TestBookRepository_FindAllByWorkID(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := bookRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	workID := int64(1)
+	book := &model.Book{ID: int64(2), Title: "Harry Potter", WorkID: int64(1)}
+	query := `SELECT * FROM "books" WHERE work_id = $1 AND "books"."deleted_at" IS NULL ORDER BY published_date ASC NULLS LAST,id ASC`
+
+	cacheHash := repo.cacheHash()
+	cacheKey := repo.findAllByWorkIDCacheKey(workID)
+	bytes, err := json.Marshal([]*model.Book{book})
+	assert.NoError(t, err)
+
+	t.Run("success - fetch from cache", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return(string(bytes), nil)
+		res, err := repo.FindAllByWorkID(ctx, workID)
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+	})
+
+	t.Run("success - fetch from db", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "title", "work_id"}).AddRow(book.ID, book.Title, book.WorkID)
+
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(workID).WillReturnRows(rows)
+		mockedDependency.cacheRepo.EXPECT().HashSet(ctx, cacheHash, cacheKey, gomock.Any()).Times(1).Return(nil)
+
+		res, err := repo.FindAllByWorkID(ctx, workID)
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+		assert.Equal(t, workID, res[0].WorkID)
+	})
+
+	t.Run("failed - fetch from db return error", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(errors.New("db error"))
+
+		res, err := repo.FindAllByWorkID(ctx, workID)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestBookRepository_FindAllBySeriesID(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := bookRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	seriesID, position := int64(1), 1
+	book := &model.Book{ID: int64(2), Title: "Harry Potter", SeriesID: &seriesID, SeriesPosition: &position}
+	query := `SELECT * FROM "books" WHERE series_id = $1 AND "books"."deleted_at" IS NULL ORDER BY series_position ASC NULLS LAST,id ASC`
+
+	cacheHash := repo.cacheHash()
+	cacheKey := repo.findAllBySeriesIDCacheKey(seriesID)
+	bytes, err := json.Marshal([]*model.Book{book})
+	assert.NoError(t, err)
+
+	t.Run("success - fetch from cache", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return(string(bytes), nil)
+		res, err := repo.FindAllBySeriesID(ctx, seriesID)
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+	})
+
+	t.Run("success - fetch from db", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "title", "series_id", "series_position"}).AddRow(book.ID, book.Title, seriesID, position)
+
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(seriesID).WillReturnRows(rows)
+		mockedDependency.cacheRepo.EXPECT().HashSet(ctx, cacheHash, cacheKey, gomock.Any()).Times(1).Return(nil)
+
+		res, err := repo.FindAllBySeriesID(ctx, seriesID)
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+		assert.Equal(t, position, *res[0].SeriesPosition)
+	})
+
+	t.Run("failed - fetch from db return error", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(errors.New("db error"))
+
+		res, err := repo.FindAllBySeriesID(ctx, seriesID)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestBookRepository_Search(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := bookRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	queryParams := model.SearchBooksQueryParams{
+		Q:         "wizard -muggle",
+		PageQuery: model.PageQuery{Page: 2, Size: 5},
+	}
+
+	result := model.BookSearchResult{
+		Book: model.Book{
+			ID:     int64(1),
+			Title:  "Harry Potter",
+			Author: "J. K. Rowling",
+		},
+		Rank:       0.6,
+		Highlights: model.BookHighlights{Title: "Harry Potter", Description: "a young <mark>wizard</mark>"},
+	}
+
+	query := `SELECT books.*, ts_rank(search_vector, websearch_to_tsquery($1::regconfig, $2)) AS rank, ` +
+		`ts_headline($3::regconfig, title, websearch_to_tsquery($4::regconfig, $5), $6) AS highlight_title, ` +
+		`ts_headline($7::regconfig, author, websearch_to_tsquery($8::regconfig, $9), $10) AS highlight_author, ` +
+		`ts_headline($11::regconfig, description, websearch_to_tsquery($12::regconfig, $13), $14) AS highlight_description ` +
+		`FROM "books" WHERE search_vector @@ websearch_to_tsquery($15::regconfig, $16) AND "books"."deleted_at" IS NULL ` +
+		`ORDER BY rank DESC,id DESC LIMIT 5 OFFSET 5`
+
+	cacheHash := repo.cacheHash()
+	cacheKey := repo.searchCacheKey(queryParams)
+
+	bytes, err := json.Marshal([]*model.BookSearchResult{&result})
+	assert.NoError(t, err)
+
+	t.Run("success - fetch from cache", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return(string(bytes), nil)
+		res, err := repo.Search(ctx, queryParams)
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+		assert.Equal(t, result.Highlights, res[0].Highlights)
+	})
+
+	t.Run("success - fetch from db", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "author", "title", "rank", "highlight_title", "highlight_author", "highlight_description"}).
+			AddRow(result.ID, result.Author, result.Title, result.Rank, result.Highlights.Title, result.Highlights.Author, result.Highlights.Description)
+
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs("english", queryParams.Q, "english", "english", queryParams.Q, tsHeadlineOptions, "english", "english", queryParams.Q, tsHeadlineOptions, "english", "english", queryParams.Q, tsHeadlineOptions, "english", queryParams.Q).
+			WillReturnRows(rows)
+		mockedDependency.cacheRepo.EXPECT().HashSet(ctx, cacheHash, cacheKey, gomock.Any()).Times(1).Return(nil)
+
+		res, err := repo.Search(ctx, queryParams)
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+		assert.Equal(t, result.Rank, res[0].Rank)
+		assert.Equal(t, result.Highlights, res[0].Highlights)
+	})
+
+	t.Run("failed - fetch from db return error", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(errors.New("db error"))
+
+		res, err := repo.Search(ctx, queryParams)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestBookRepository_CountSearch(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := bookRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	queryParams := model.SearchBooksQueryParams{Q: "wizard", PageQuery: model.PageQuery{Page: 1, Size: 5}}
+	query := `SELECT count(*) FROM "books" WHERE search_vector @@ websearch_to_tsquery($1::regconfig, $2) AND "books"."deleted_at" IS NULL`
+
+	cacheHash := repo.cacheHash()
+	cacheKey := repo.countSearchCacheKey(queryParams)
+
+	t.Run("success - fetch from cache", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("2", nil)
+		res, err := repo.CountSearch(ctx, queryParams)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), res)
+	})
+
+	t.Run("success - fetch from db", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs("english", queryParams.Q).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+		mockedDependency.cacheRepo.EXPECT().HashSet(ctx, cacheHash, cacheKey, "2").Times(1).Return(nil)
+
+		res, err := repo.CountSearch(ctx, queryParams)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), res)
+	})
+
+	t.Run("failed - fetch from db return error", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(errors.New("db error"))
+
+		res, err := repo.CountSearch(ctx, queryParams)
+		assert.Error(t, err)
+		assert.Zero(t, res)
+	})
+}
+
+func TestBookRepository_Suggest(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := bookRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	queryParams := model.SuggestBooksQueryParams{Q: "rowlnig", Limit: 5, Threshold: 0.3}
+	suggestion := model.BookSuggestion{Field: "author", Value: "J. K. Rowling", Similarity: 0.5}
+
+	setThreshold := `SELECT set_config('pg_trgm.word_similarity_threshold', $1, true)`
+	query := `SELECT field, value, MAX(similarity) AS similarity FROM ( ` +
+		`SELECT 'title' AS field, title AS value, word_similarity($1, title) AS similarity FROM books WHERE $2 <% title AND deleted_at IS NULL ` +
+		`UNION ALL ` +
+		`SELECT 'author' AS field, author AS value, word_similarity($3, author) AS similarity FROM books WHERE $4 <% author AND deleted_at IS NULL ` +
+		`) AS suggestions GROUP BY field, value ORDER BY similarity DESC, value ASC LIMIT $5`
+
+	cacheKey := repo.suggestCacheKey(queryParams)
+	bytes, err := json.Marshal([]*model.BookSuggestion{&suggestion})
+	assert.NoError(t, err)
+
+	t.Run("success - fetch from cache", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().Get(ctx, cacheKey).Times(1).Return(string(bytes), nil)
+		res, err := repo.Suggest(ctx, queryParams)
+		assert.NoError(t, err)
+		assert.Equal(t, []*model.BookSuggestion{&suggestion}, res)
+	})
+
+	t.Run("success - fetch from db", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"field", "value", "similarity"}).AddRow(suggestion.Field, suggestion.Value, suggestion.Similarity)
+
+		mockedDependency.cacheRepo.EXPECT().Get(ctx, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(setThreshold)).WithArgs("0.3").WillReturnResult(sqlmock.NewResult(0, 1))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(queryParams.Q, queryParams.Q, queryParams.Q, queryParams.Q, queryParams.Limit).WillReturnRows(rows)
+		mockedDependency.sql.ExpectCommit()
+		mockedDependency.cacheRepo.EXPECT().SetWithTTL(ctx, cacheKey, string(bytes), suggestCacheTTL).Times(1).Return(nil)
+
+		res, err := repo.Suggest(ctx, queryParams)
+		assert.NoError(t, err)
+		assert.Equal(t, []*model.BookSuggestion{&suggestion}, res)
+	})
+
+	t.Run("failed - fetch from db return error", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().Get(ctx, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(setThreshold)).WithArgs("0.3").WillReturnResult(sqlmock.NewResult(0, 1))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(errors.New("db error"))
+		mockedDependency.sql.ExpectRollback()
+
+		res, err := repo.Suggest(ctx, queryParams)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestBookRepository_Update(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := bookRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	book := model.Book{
+		ID:          int64(1),
+		Title:       "Harry Potter",
+		Author:      "J. K. Rowling",
+		Description: "A series about wizards",
+		CreatedAt:   time.Time{},
+		UpdatedAt:   time.Time{},
+	}
+
+	query := `UPDATE "books" SET "author"=$1,"description"=$2,"genre_id"=$3,"isbn10"=$4,"isbn13"=$5,"published_date"=$6,"publisher_id"=$7,"series_id"=$8,"series_position"=$9,"title"=$10,"version"=version + 1,"work_id"=id,"updated_at"=$11 WHERE "books"."deleted_at" IS NULL AND "id" = $12`
+
+	cacheKey := repo.findByIDCacheKey(book.ID)
+	cacheKeys := []string{
+		repo.cacheHash(),
+		repo.findByIDCacheKey(book.ID),
+		repo.findVersionByIDCacheKey(book.ID),
+	}
+
+	bytes, err := json.Marshal(book)
+	assert.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(book.Author, book.Description, nil, nil, nil, nil, nil, nil, nil, book.Title, sqlmock.AnyArg(), book.ID).
+			WillReturnResult(sqlmock.NewResult(1, 1))
 		mockedDependency.sql.ExpectCommit()
 		mockedDependency.cacheRepo.EXPECT().Delete(ctx, cacheKeys).Times(1).Return(nil)
 		mockedDependency.cacheRepo.EXPECT().Get(ctx, cacheKey).Times(1).Return(string(bytes), nil)
@@ -358,6 +1435,24 @@ func TestBookRepository_Update(t *testing.T) {
 		assert.NotNil(t, res)
 	})
 
+	t.Run("failed - version does not match", func(t *testing.T) {
+		versionedBook := book
+		versionedBook.Version = 2
+
+		versionQuery := `UPDATE "books" SET "author"=$1,"description"=$2,"genre_id"=$3,"isbn10"=$4,"isbn13"=$5,"published_date"=$6,"publisher_id"=$7,"series_id"=$8,"series_position"=$9,"title"=$10,"version"=version + 1,"work_id"=id,"updated_at"=$11 ` +
+			`WHERE version = $12 AND "books"."deleted_at" IS NULL AND "id" = $13`
+		countQuery := `SELECT count(*) FROM "books" WHERE id = $1 AND "books"."deleted_at" IS NULL`
+
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(versionQuery)).WillReturnResult(sqlmock.NewResult(0, 0))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(countQuery)).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mockedDependency.sql.ExpectRollback()
+
+		res, err := repo.Update(ctx, &versionedBook)
+		assert.ErrorIs(t, err, domainerr.ErrPreconditionFailed)
+		assert.Nil(t, res)
+	})
+
 	t.Run("failed - update book in db return error", func(t *testing.T) {
 		mockedDependency.sql.ExpectBegin()
 		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(query)).WillReturnError(errors.New("db error"))
@@ -379,3 +1474,199 @@ func TestBookRepository_Update(t *testing.T) {
 		assert.Nil(t, res)
 	})
 }
+
+func TestBookRepository_BatchCreate(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := bookRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	newBooks := func() []*model.Book {
+		return []*model.Book{
+			{ID: 1, Title: "Harry Potter"},
+			{ID: 2, Title: "Dune"},
+		}
+	}
+
+	batchQuery := `INSERT INTO "books" ("title","author","description","published_date","publisher_id","series_id","series_position","work_id","genre_id","isbn13","isbn10","cover_updated_at","version","created_at","updated_at","deleted_at","id") ` +
+		`VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17),($18,$19,$20,$21,$22,$23,$24,$25,$26,$27,$28,$29,$30,$31,$32,$33,$34) RETURNING "id"`
+	query := `INSERT INTO "books" ("title","author","description","published_date","publisher_id","series_id","series_position","work_id","genre_id","isbn13","isbn10","cover_updated_at","version","created_at","updated_at","deleted_at","id") ` +
+		`VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17) RETURNING "id"`
+
+	t.Run("success", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(batchQuery)).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+		mockedDependency.sql.ExpectCommit()
+		mockedDependency.cacheRepo.EXPECT().Delete(ctx, repo.cacheHash()).Times(1).Return(nil)
+
+		errs, err := repo.BatchCreate(ctx, newBooks(), true)
+		assert.NoError(t, err)
+		assert.Equal(t, []error{nil, nil}, errs)
+	})
+
+	t.Run("failed - transactional batch is rolled back", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(batchQuery)).WillReturnError(&pgconn.PgError{Code: "23505"})
+		mockedDependency.sql.ExpectRollback()
+
+		errs, err := repo.BatchCreate(ctx, newBooks(), true)
+		assert.ErrorIs(t, err, domainerr.ErrConflict)
+		assert.Nil(t, errs)
+	})
+
+	t.Run("success - best effort reports the failing books", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(batchQuery)).WillReturnError(&pgconn.PgError{Code: "23505"})
+		mockedDependency.sql.ExpectRollback()
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(&pgconn.PgError{Code: "23505"})
+		mockedDependency.sql.ExpectRollback()
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+		mockedDependency.sql.ExpectCommit()
+		mockedDependency.cacheRepo.EXPECT().Delete(ctx, repo.cacheHash()).Times(1).Return(nil)
+
+		errs, err := repo.BatchCreate(ctx, newBooks(), false)
+		assert.NoError(t, err)
+		assert.Len(t, errs, 2)
+		assert.ErrorIs(t, errs[0], domainerr.ErrConflict)
+		assert.NoError(t, errs[1])
+	})
+}
+
+func TestBookRepository_BatchUpdate(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := bookRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	newBooks := func() []*model.Book {
+		return []*model.Book{
+			{ID: 1, Title: "Harry Potter"},
+			{ID: 2, Title: "Dune"},
+		}
+	}
+
+	query := `UPDATE "books" SET "author"=$1,"description"=$2,"genre_id"=$3,"isbn10"=$4,"isbn13"=$5,"published_date"=$6,"publisher_id"=$7,"series_id"=$8,"series_position"=$9,"title"=$10,"version"=version + 1,"work_id"=id,"updated_at"=$11 WHERE "books"."deleted_at" IS NULL AND "id" = $12`
+	findQuery := `SELECT * FROM "books" WHERE id IN ($1,$2) AND "books"."deleted_at" IS NULL`
+
+	t.Run("success", func(t *testing.T) {
+		cacheKeys := []string{
+			repo.cacheHash(),
+			repo.findByIDCacheKey(1), repo.findVersionByIDCacheKey(1),
+			repo.findByIDCacheKey(2), repo.findVersionByIDCacheKey(2),
+		}
+
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(query)).WillReturnResult(sqlmock.NewResult(0, 1))
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(query)).WillReturnResult(sqlmock.NewResult(0, 1))
+		mockedDependency.sql.ExpectCommit()
+		mockedDependency.cacheRepo.EXPECT().Delete(ctx, cacheKeys).Times(1).Return(nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(findQuery)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "title", "version"}).AddRow(2, "Dune", 2).AddRow(1, "Harry Potter", 2))
+
+		updated, errs, err := repo.BatchUpdate(ctx, newBooks(), true)
+		assert.NoError(t, err)
+		assert.Equal(t, []error{nil, nil}, errs)
+		assert.Equal(t, int64(1), updated[0].ID)
+		assert.Equal(t, int64(2), updated[1].ID)
+	})
+
+	t.Run("failed - transactional batch is rolled back", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(query)).WillReturnResult(sqlmock.NewResult(0, 1))
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(query)).WillReturnResult(sqlmock.NewResult(0, 0))
+		mockedDependency.sql.ExpectRollback()
+
+		updated, errs, err := repo.BatchUpdate(ctx, newBooks(), true)
+		assert.NoError(t, err)
+		assert.Nil(t, updated)
+		assert.NoError(t, errs[0])
+		assert.ErrorIs(t, errs[1], domainerr.ErrNotFound)
+	})
+
+	t.Run("success - best effort reports the failing books", func(t *testing.T) {
+		cacheKeys := []string{
+			repo.cacheHash(),
+			repo.findByIDCacheKey(2), repo.findVersionByIDCacheKey(2),
+		}
+
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(query)).WillReturnResult(sqlmock.NewResult(0, 0))
+		mockedDependency.sql.ExpectRollback()
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(query)).WillReturnResult(sqlmock.NewResult(0, 1))
+		mockedDependency.sql.ExpectCommit()
+		mockedDependency.cacheRepo.EXPECT().Delete(ctx, cacheKeys).Times(1).Return(nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "books" WHERE id IN ($1) AND "books"."deleted_at" IS NULL`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "title", "version"}).AddRow(2, "Dune", 2))
+
+		updated, errs, err := repo.BatchUpdate(ctx, newBooks(), false)
+		assert.NoError(t, err)
+		assert.ErrorIs(t, errs[0], domainerr.ErrNotFound)
+		assert.Nil(t, updated[0])
+		assert.NoError(t, errs[1])
+		assert.Equal(t, int64(2), updated[1].ID)
+	})
+}
+
+func TestBookRepository_BatchDelete(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := bookRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	IDs := []int64{1, 2}
+	findQuery := `SELECT "id" FROM "books" WHERE id IN ($1,$2) AND "books"."deleted_at" IS NULL FOR UPDATE`
+	query := `UPDATE "books" SET "deleted_at"=$1 WHERE "books"."id" = $2 AND "books"."deleted_at" IS NULL`
+
+	t.Run("success - best effort reports the missing books", func(t *testing.T) {
+		cacheKeys := []string{
+			repo.cacheHash(),
+			repo.findByIDCacheKey(1), repo.findVersionByIDCacheKey(1),
+		}
+
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(findQuery)).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(query)).WithArgs(sqlmock.AnyArg(), int64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+		mockedDependency.sql.ExpectCommit()
+		mockedDependency.cacheRepo.EXPECT().Delete(ctx, cacheKeys).Times(1).Return(nil)
+
+		errs, err := repo.BatchDelete(ctx, IDs, false)
+		assert.NoError(t, err)
+		assert.NoError(t, errs[0])
+		assert.ErrorIs(t, errs[1], domainerr.ErrNotFound)
+	})
+
+	t.Run("failed - transactional batch deletes nothing", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(findQuery)).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		mockedDependency.sql.ExpectCommit()
+
+		errs, err := repo.BatchDelete(ctx, IDs, true)
+		assert.NoError(t, err)
+		assert.ErrorIs(t, errs[1], domainerr.ErrNotFound)
+	})
+
+	t.Run("failed - find books return error", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(findQuery)).WillReturnError(errors.New("db error"))
+		mockedDependency.sql.ExpectRollback()
+
+		errs, err := repo.BatchDelete(ctx, IDs, false)
+		assert.Error(t, err)
+		assert.Nil(t, errs)
+	})
+}
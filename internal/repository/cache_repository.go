@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/redis/go-redis/v9"
 	"github.com/ssentinull/create-apis-using-golang/internal/model"
@@ -18,27 +19,40 @@ func NewCacheRepository(client *redis.Client) model.CacheRepository {
 func (c *cacheRepo) Get(ctx context.Context, key string) (string, error) {
 	val, err := c.redisClient.Get(ctx, key).Result()
 	if err != nil && err != redis.Nil {
-		return "", err
+		return "", parseCacheError(err)
 	}
 	return val, nil
 }
 
 func (c *cacheRepo) Set(ctx context.Context, key, val string) error {
-	return c.redisClient.Set(ctx, key, val, 0).Err()
+	return parseCacheError(c.redisClient.Set(ctx, key, val, 0).Err())
+}
+
+func (c *cacheRepo) SetWithTTL(ctx context.Context, key, val string, ttl time.Duration) error {
+	return parseCacheError(c.redisClient.Set(ctx, key, val, ttl).Err())
+}
+
+// SetNX sets key only when it doesn't exist yet and reports whether it did
+func (c *cacheRepo) SetNX(ctx context.Context, key, val string, ttl time.Duration) (bool, error) {
+	ok, err := c.redisClient.SetNX(ctx, key, val, ttl).Result()
+	if err != nil {
+		return false, parseCacheError(err)
+	}
+	return ok, nil
 }
 
 func (c *cacheRepo) Delete(ctx context.Context, keys ...string) error {
-	return c.redisClient.Del(ctx, keys...).Err()
+	return parseCacheError(c.redisClient.Del(ctx, keys...).Err())
 }
 
 func (c *cacheRepo) HashGet(ctx context.Context, hash, key string) (string, error) {
 	val, err := c.redisClient.HGet(ctx, hash, key).Result()
 	if err != nil && err != redis.Nil {
-		return "", err
+		return "", parseCacheError(err)
 	}
 	return val, nil
 }
 
 func (c *cacheRepo) HashSet(ctx context.Context, hash, key, val string) error {
-	return c.redisClient.HSet(ctx, hash, key, val).Err()
+	return parseCacheError(c.redisClient.HSet(ctx, hash, key, val).Err())
 }
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/redis/go-redis/v9"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
 	"github.com/ssentinull/create-apis-using-golang/internal/model"
 	"github.com/stretchr/testify/assert"
 )
@@ -84,6 +85,61 @@ func TestCacheRepository_Set(t *testing.T) {
 	})
 }
 
+func TestCacheRepository_SetWithTTL(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	cacheRepo := cacheRepo{redisClient: mockedDependency.redis}
+	cacheKey := "idempotency:key"
+	cacheVal := `{"fingerprint":"abc"}`
+	ttl := time.Hour
+
+	t.Run("success", func(t *testing.T) {
+		mockedDependency.redisCmd.ExpectSet(cacheKey, cacheVal, ttl).SetVal("OK")
+		err := cacheRepo.SetWithTTL(ctx, cacheKey, cacheVal, ttl)
+		assert.NoError(t, err)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		mockedDependency.redisCmd.ExpectSet(cacheKey, cacheVal, ttl).SetErr(errors.New("redis error"))
+		err := cacheRepo.SetWithTTL(ctx, cacheKey, cacheVal, ttl)
+		assert.Error(t, err)
+	})
+}
+
+func TestCacheRepository_SetNX(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	cacheRepo := cacheRepo{redisClient: mockedDependency.redis}
+	cacheKey := "idempotency:key"
+	cacheVal := `{"fingerprint":"abc"}`
+	ttl := time.Hour
+
+	t.Run("success - key is set", func(t *testing.T) {
+		mockedDependency.redisCmd.ExpectSetNX(cacheKey, cacheVal, ttl).SetVal(true)
+		ok, err := cacheRepo.SetNX(ctx, cacheKey, cacheVal, ttl)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+	})
+
+	t.Run("success - key already exists", func(t *testing.T) {
+		mockedDependency.redisCmd.ExpectSetNX(cacheKey, cacheVal, ttl).SetVal(false)
+		ok, err := cacheRepo.SetNX(ctx, cacheKey, cacheVal, ttl)
+		assert.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		mockedDependency.redisCmd.ExpectSetNX(cacheKey, cacheVal, ttl).SetErr(errors.New("redis error"))
+		ok, err := cacheRepo.SetNX(ctx, cacheKey, cacheVal, ttl)
+		assert.ErrorIs(t, err, domainerr.ErrUnavailable)
+		assert.False(t, ok)
+	})
+}
+
 func TestCacheRepository_Delete(t *testing.T) {
 	mockedDependency := newMockedDependency(t)
 	defer mockedDependency.close()
@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"gorm.io/gorm"
+)
+
+const (
+	pgUniqueViolationCode     = "23505"
+	pgForeignKeyViolationCode = "23503"
+)
+
+// parseDBError translates gorm & postgres errors into domain errors
+func parseDBError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domainerr.NotFound("record not found", err)
+	}
+
+	pgErr := &pgconn.PgError{}
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case pgUniqueViolationCode:
+			return domainerr.Conflict("record already exists", err)
+		case pgForeignKeyViolationCode:
+			return domainerr.Validation("referenced record does not exist", err)
+		}
+	}
+
+	return err
+}
+
+// parseCacheError marks any cache failure as unavailable
+func parseCacheError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return domainerr.Unavailable("cache is unavailable", err)
+}
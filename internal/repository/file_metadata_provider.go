@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+	"github.com/ssentinull/create-apis-using-golang/internal/utils"
+)
+
+type fileMetadataProvider struct {
+	metadata map[string]model.BookMetadata
+}
+
+// NewFileMetadataProvider serves the metadata of the books listed in the JSON
+// array at path, it stands in for a real catalog in tests and offline setups
+func NewFileMetadataProvider(path string) (model.MetadataProvider, error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	list := []model.BookMetadata{}
+	if err := json.Unmarshal(bytes, &list); err != nil {
+		return nil, fmt.Errorf("invalid metadata file %s: %w", path, err)
+	}
+
+	metadata := make(map[string]model.BookMetadata, len(list))
+	for _, book := range list {
+		book.ISBN13 = utils.NormalizeISBN(book.ISBN13)
+		metadata[book.ISBN13] = book
+	}
+
+	return &fileMetadataProvider{metadata: metadata}, nil
+}
+
+func (fp *fileMetadataProvider) FindByISBN(ctx context.Context, isbn13 string) (*model.BookMetadata, error) {
+	metadata, ok := fp.metadata[isbn13]
+	if !ok {
+		return nil, domainerr.NotFound(fmt.Sprintf("metadata of %s not found", isbn13), nil)
+	}
+
+	return &metadata, nil
+}
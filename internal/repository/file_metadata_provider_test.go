@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileMetadataProvider_FindByISBN(t *testing.T) {
+	ctx := context.Background()
+	provider, err := NewFileMetadataProvider("testdata/metadata.json")
+	assert.NoError(t, err)
+
+	t.Run("success - hyphenated isbn in the file", func(t *testing.T) {
+		res, err := provider.FindByISBN(ctx, "9780747532699")
+		assert.NoError(t, err)
+		assert.Equal(t, "J. K. Rowling", res.Author)
+		assert.Equal(t, "1997-06-26", res.PublishedDate)
+	})
+
+	t.Run("failed - isbn not found", func(t *testing.T) {
+		res, err := provider.FindByISBN(ctx, "9780000000002")
+		assert.ErrorIs(t, err, domainerr.ErrNotFound)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - file does not exist", func(t *testing.T) {
+		res, err := NewFileMetadataProvider("testdata/missing.json")
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
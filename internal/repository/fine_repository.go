@@ -0,0 +1,332 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+	"github.com/ssentinull/create-apis-using-golang/internal/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type fineRepo struct {
+	db        *gorm.DB
+	cacheRepo model.CacheRepository
+}
+
+func NewFineRepository(db *gorm.DB, cacheRepo model.CacheRepository) model.FineRepository {
+	return &fineRepo{
+		db:        db,
+		cacheRepo: cacheRepo,
+	}
+}
+
+// Create records a payment or a waiver of the balance of a member. The
+// member is locked until the entry is stored, so that concurrent payments
+// can't settle the same balance twice
+func (fr *fineRepo) Create(ctx context.Context, fine *model.Fine) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":  utils.Dump(ctx),
+		"fine": utils.Dump(fine),
+	})
+
+	err := fr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "NO KEY UPDATE"}).Where("id = ?", fine.MemberID).Take(&model.Member{}).Error
+		if err != nil {
+			return parseDBError(err)
+		}
+
+		balance, err := fr.balance(tx, fine.MemberID)
+		if err != nil {
+			return err
+		}
+
+		if fine.AmountCents > balance {
+			return domainerr.Conflict(fmt.Sprintf("amount exceeds the balance of %d cents", balance), nil)
+		}
+
+		if err := tx.Create(fine).Error; err != nil {
+			return parseDBError(err)
+		}
+		return nil
+	})
+
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if err := fr.cacheRepo.Delete(ctx, fr.cacheHash()); err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// Accrue records the part of fine, the whole fine owed for loan, that
+// previous accruals of the loan left out. The loan must not have been
+// returned nor had its due date moved since it was read, or the fine would
+// be priced on stale dates
+func (fr *fineRepo) Accrue(ctx context.Context, loan *model.Loan, fine *model.Fine) (bool, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":  utils.Dump(ctx),
+		"loan": utils.Dump(loan),
+		"fine": utils.Dump(fine),
+	})
+
+	accrued := false
+	err := fr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		locked := &model.Loan{}
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", loan.ID).Take(locked).Error
+		if err != nil {
+			return parseDBError(err)
+		}
+
+		if locked.IsOpen() != loan.IsOpen() || !locked.DueAt.Equal(loan.DueAt) {
+			return domainerr.Conflict(fmt.Sprintf("loan %d changed while accruing its fines", loan.ID), nil)
+		}
+
+		total := int64(0)
+		err = tx.Model(&model.Fine{}).
+			Select("COALESCE(SUM(amount_cents), 0)").
+			Where("loan_id = ? AND kind = ?", loan.ID, model.FineKindAccrual).
+			Scan(&total).
+			Error
+		if err != nil {
+			return parseDBError(err)
+		}
+
+		if fine.AmountCents > total {
+			fine.AmountCents -= total
+			if err := tx.Create(fine).Error; err != nil {
+				return parseDBError(err)
+			}
+			accrued = true
+		}
+
+		if locked.IsOpen() {
+			return nil
+		}
+
+		err = tx.Model(&model.Loan{}).Where("id = ?", loan.ID).Update("fines_closed_at", fine.CreatedAt).Error
+		return parseDBError(err)
+	})
+
+	if err != nil {
+		logger.Error(err)
+		return false, err
+	}
+
+	if !accrued {
+		return false, nil
+	}
+
+	if err := fr.cacheRepo.Delete(ctx, fr.cacheHash()); err != nil {
+		logger.Error(err)
+		return true, err
+	}
+
+	return true, nil
+}
+
+// FindAllAccruingLoans returns the loans due before dueBefore that are
+// open or were returned late and whose fines are not closed, the longest
+// overdue first
+func (fr *fineRepo) FindAllAccruingLoans(ctx context.Context, dueBefore time.Time) ([]*model.Loan, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":       utils.Dump(ctx),
+		"dueBefore": dueBefore,
+	})
+
+	loans := []*model.Loan{}
+	err := fr.db.WithContext(ctx).
+		Where("fines_closed_at IS NULL AND due_at < ?", dueBefore).
+		Where("returned_at IS NULL OR returned_at > due_at").
+		Order("due_at ASC").
+		Find(&loans).
+		Error
+	if err != nil {
+		logger.Error(err)
+		return nil, parseDBError(err)
+	}
+
+	return loans, nil
+}
+
+// FindAllByMemberID returns the fines ledger of a member, the latest
+// entries first
+func (fr *fineRepo) FindAllByMemberID(ctx context.Context, query model.GetFinesQueryParams) ([]*model.Fine, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   utils.Dump(ctx),
+		"query": utils.Dump(query),
+	})
+
+	cacheHash := fr.cacheHash()
+	cacheKey := fr.findAllByMemberIDCacheKey(query)
+	reply, err := fr.cacheRepo.HashGet(ctx, cacheHash, cacheKey)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if reply != "" {
+		fines := []*model.Fine{}
+		if err := json.Unmarshal([]byte(reply), &fines); err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+		return fines, nil
+	}
+
+	fines := []*model.Fine{}
+	err = fr.db.WithContext(ctx).
+		Where("member_id = ?", query.MemberID).
+		Order("created_at DESC").
+		Order("id DESC").
+		Offset(int(model.Offset(query.Page, query.Size))).
+		Limit(int(query.Size)).
+		Find(&fines).
+		Error
+	if err != nil {
+		logger.Error(err)
+		return nil, parseDBError(err)
+	}
+
+	bytes, err := json.Marshal(fines)
+	if err != nil {
+		logger.Error(err)
+		return fines, nil
+	}
+
+	if err := fr.cacheRepo.HashSet(ctx, cacheHash, cacheKey, string(bytes)); err != nil {
+		logger.Error(err)
+	}
+
+	return fines, nil
+}
+
+func (fr *fineRepo) CountAllByMemberID(ctx context.Context, query model.GetFinesQueryParams) (int64, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   utils.Dump(ctx),
+		"query": utils.Dump(query),
+	})
+
+	cacheHash := fr.cacheHash()
+	cacheKey := fr.countAllByMemberIDCacheKey(query)
+	reply, err := fr.cacheRepo.HashGet(ctx, cacheHash, cacheKey)
+	if err != nil {
+		logger.Error(err)
+		return 0, err
+	}
+
+	if reply != "" {
+		count := int64(0)
+		if err := json.Unmarshal([]byte(reply), &count); err != nil {
+			logger.Error(err)
+			return 0, err
+		}
+		return count, nil
+	}
+
+	count := int64(0)
+	err = fr.db.WithContext(ctx).
+		Model(&model.Fine{}).
+		Where("member_id = ?", query.MemberID).
+		Count(&count).
+		Error
+	if err != nil {
+		logger.Error(err)
+		return int64(0), parseDBError(err)
+	}
+
+	bytes, err := json.Marshal(count)
+	if err != nil {
+		logger.Error(err)
+		return 0, err
+	}
+
+	if err := fr.cacheRepo.HashSet(ctx, cacheHash, cacheKey, string(bytes)); err != nil {
+		logger.Error(err)
+	}
+
+	return count, nil
+}
+
+func (fr *fineRepo) BalanceByMemberID(ctx context.Context, memberID int64) (int64, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":      utils.Dump(ctx),
+		"memberID": memberID,
+	})
+
+	cacheHash := fr.cacheHash()
+	cacheKey := fr.balanceByMemberIDCacheKey(memberID)
+	reply, err := fr.cacheRepo.HashGet(ctx, cacheHash, cacheKey)
+	if err != nil {
+		logger.Error(err)
+		return 0, err
+	}
+
+	if reply != "" {
+		balance := int64(0)
+		if err := json.Unmarshal([]byte(reply), &balance); err != nil {
+			logger.Error(err)
+			return 0, err
+		}
+		return balance, nil
+	}
+
+	balance, err := fr.balance(fr.db.WithContext(ctx), memberID)
+	if err != nil {
+		logger.Error(err)
+		return 0, err
+	}
+
+	bytes, err := json.Marshal(balance)
+	if err != nil {
+		logger.Error(err)
+		return 0, err
+	}
+
+	if err := fr.cacheRepo.HashSet(ctx, cacheHash, cacheKey, string(bytes)); err != nil {
+		logger.Error(err)
+	}
+
+	return balance, nil
+}
+
+// balance sums up the accruals of a member minus its payments and waivers
+func (fr *fineRepo) balance(db *gorm.DB, memberID int64) (int64, error) {
+	balance := int64(0)
+	err := db.Model(&model.Fine{}).
+		Select("COALESCE(SUM(CASE WHEN kind = ? THEN amount_cents ELSE -amount_cents END), 0)", model.FineKindAccrual).
+		Where("member_id = ?", memberID).
+		Scan(&balance).
+		Error
+	if err != nil {
+		return 0, parseDBError(err)
+	}
+
+	return balance, nil
+}
+
+func (fr *fineRepo) cacheHash() string {
+	return "fine"
+}
+
+func (fr *fineRepo) findAllByMemberIDCacheKey(query model.GetFinesQueryParams) string {
+	return fmt.Sprintf("fine:member:%d:page:%d:size:%d", query.MemberID, query.Page, query.Size)
+}
+
+func (fr *fineRepo) countAllByMemberIDCacheKey(query model.GetFinesQueryParams) string {
+	return fmt.Sprintf("fine:member:%d:count", query.MemberID)
+}
+
+func (fr *fineRepo) balanceByMemberIDCacheKey(memberID int64) string {
+	return fmt.Sprintf("fine:member:%d:balance", memberID)
+}
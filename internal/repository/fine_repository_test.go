@@ -0,0 +1,304 @@
+package repository
+
+import (
+	"encoding/json"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+var (
+	testFinedAt = time.Date(2026, 10, 20, 10, 0, 0, 0, time.UTC)
+	testFine    = model.Fine{
+		ID:          int64(1),
+		MemberID:    testMember.ID,
+		LoanID:      &testLoan.ID,
+		Kind:        model.FineKindAccrual,
+		AmountCents: int64(125),
+		OverdueDays: int64(5),
+		CreatedAt:   testFinedAt,
+	}
+)
+
+const testFineBalanceQuery = `SELECT COALESCE(SUM(CASE WHEN kind = $1 THEN amount_cents ELSE -amount_cents END), 0) FROM "fines" WHERE member_id = $2`
+
+func testFineRows(fine model.Fine) *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"id", "member_id", "loan_id", "kind", "amount_cents", "overdue_days", "created_at"}).
+		AddRow(fine.ID, fine.MemberID, fine.LoanID, fine.Kind, fine.AmountCents, fine.OverdueDays, fine.CreatedAt)
+}
+
+func TestFineRepository_Create(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := fineRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	payment := model.Fine{ID: int64(2), MemberID: testMember.ID, Kind: model.FineKindPayment, AmountCents: int64(100), CreatedAt: testFinedAt}
+	memberQuery := `SELECT * FROM "members" WHERE id = $1 AND "members"."deleted_at" IS NULL LIMIT 1 FOR NO KEY UPDATE`
+	query := `INSERT INTO "fines" ("member_id","loan_id","kind","amount_cents","overdue_days","note","created_at","id") VALUES ($1,$2,$3,$4,$5,$6,$7,$8) RETURNING "id"`
+
+	t.Run("success", func(t *testing.T) {
+		fine := payment
+
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(memberQuery)).WithArgs(fine.MemberID).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(fine.MemberID))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testFineBalanceQuery)).WithArgs(model.FineKindAccrual, fine.MemberID).WillReturnRows(sqlmock.NewRows([]string{"balance"}).AddRow(125))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(fine.ID))
+		mockedDependency.sql.ExpectCommit()
+		mockedDependency.cacheRepo.EXPECT().Delete(ctx, []string{repo.cacheHash()}).Times(1).Return(nil)
+
+		err := repo.Create(ctx, &fine)
+		assert.NoError(t, err)
+	})
+
+	t.Run("failed - amount exceeds the balance", func(t *testing.T) {
+		fine := payment
+
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(memberQuery)).WithArgs(fine.MemberID).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(fine.MemberID))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testFineBalanceQuery)).WithArgs(model.FineKindAccrual, fine.MemberID).WillReturnRows(sqlmock.NewRows([]string{"balance"}).AddRow(50))
+		mockedDependency.sql.ExpectRollback()
+
+		err := repo.Create(ctx, &fine)
+		assert.ErrorIs(t, err, domainerr.ErrConflict)
+	})
+
+	t.Run("failed - member not found", func(t *testing.T) {
+		fine := payment
+
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(memberQuery)).WithArgs(fine.MemberID).WillReturnError(gorm.ErrRecordNotFound)
+		mockedDependency.sql.ExpectRollback()
+
+		err := repo.Create(ctx, &fine)
+		assert.ErrorIs(t, err, domainerr.ErrNotFound)
+	})
+}
+
+func TestFineRepository_Accrue(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := fineRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	accruedQuery := `SELECT COALESCE(SUM(amount_cents), 0) FROM "fines" WHERE loan_id = $1 AND kind = $2`
+	query := `INSERT INTO "fines" ("member_id","loan_id","kind","amount_cents","overdue_days","note","created_at","id") VALUES ($1,$2,$3,$4,$5,$6,$7,$8) RETURNING "id"`
+	closeQuery := `UPDATE "loans" SET "fines_closed_at"=$1,"updated_at"=$2 WHERE id = $3`
+
+	returnedAt := testLoan.DueAt.AddDate(0, 0, 5)
+	returned := testLoan
+	returned.ReturnedAt = &returnedAt
+
+	t.Run("success - accrues the part not accrued yet", func(t *testing.T) {
+		loan := testLoan
+		fine := testFine
+
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testLockLoanQuery)).WithArgs(loan.ID).WillReturnRows(testLoanRows(loan))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(accruedQuery)).WithArgs(loan.ID, model.FineKindAccrual).WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow(100))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(fine.MemberID, loan.ID, model.FineKindAccrual, int64(25), fine.OverdueDays, "", fine.CreatedAt, fine.ID).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(fine.ID))
+		mockedDependency.sql.ExpectCommit()
+		mockedDependency.cacheRepo.EXPECT().Delete(ctx, []string{repo.cacheHash()}).Times(1).Return(nil)
+
+		accrued, err := repo.Accrue(ctx, &loan, &fine)
+		assert.NoError(t, err)
+		assert.True(t, accrued)
+		assert.Equal(t, int64(25), fine.AmountCents)
+	})
+
+	t.Run("success - returned loan is closed", func(t *testing.T) {
+		loan := returned
+		fine := testFine
+
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testLockLoanQuery)).WithArgs(loan.ID).WillReturnRows(testLoanRows(loan))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(accruedQuery)).WithArgs(loan.ID, model.FineKindAccrual).WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow(125))
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(closeQuery)).WithArgs(fine.CreatedAt, sqlmock.AnyArg(), loan.ID).WillReturnResult(sqlmock.NewResult(0, 1))
+		mockedDependency.sql.ExpectCommit()
+
+		accrued, err := repo.Accrue(ctx, &loan, &fine)
+		assert.NoError(t, err)
+		assert.False(t, accrued)
+	})
+
+	t.Run("failed - loan returned meanwhile", func(t *testing.T) {
+		loan := testLoan
+		fine := testFine
+
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testLockLoanQuery)).WithArgs(loan.ID).WillReturnRows(testLoanRows(returned))
+		mockedDependency.sql.ExpectRollback()
+
+		accrued, err := repo.Accrue(ctx, &loan, &fine)
+		assert.ErrorIs(t, err, domainerr.ErrConflict)
+		assert.False(t, accrued)
+	})
+}
+
+func TestFineRepository_FindAllAccruingLoans(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := fineRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	query := `SELECT * FROM "loans" WHERE (fines_closed_at IS NULL AND due_at < $1) AND (returned_at IS NULL OR returned_at > due_at) ORDER BY due_at ASC`
+
+	t.Run("success", func(t *testing.T) {
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(testFinedAt).WillReturnRows(testLoanRows(testLoan))
+
+		res, err := repo.FindAllAccruingLoans(ctx, testFinedAt)
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(errors.New("db error"))
+
+		res, err := repo.FindAllAccruingLoans(ctx, testFinedAt)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestFineRepository_FindAllByMemberID(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := fineRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	queryParams := model.GetFinesQueryParams{MemberID: testMember.ID, PageQuery: model.PageQuery{Page: 2, Size: 5}}
+	query := `SELECT * FROM "fines" WHERE member_id = $1 ORDER BY created_at DESC,id DESC LIMIT 5 OFFSET 5`
+
+	cacheHash := repo.cacheHash()
+	cacheKey := repo.findAllByMemberIDCacheKey(queryParams)
+	bytes, err := json.Marshal([]*model.Fine{&testFine})
+	assert.NoError(t, err)
+
+	t.Run("success - fetch from cache", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return(string(bytes), nil)
+		res, err := repo.FindAllByMemberID(ctx, queryParams)
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+	})
+
+	t.Run("success - fetch from db", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(testMember.ID).WillReturnRows(testFineRows(testFine))
+		mockedDependency.cacheRepo.EXPECT().HashSet(ctx, cacheHash, cacheKey, gomock.Any()).Times(1).Return(nil)
+
+		res, err := repo.FindAllByMemberID(ctx, queryParams)
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+	})
+
+	t.Run("failed - fetch from db return error", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(errors.New("db error"))
+
+		res, err := repo.FindAllByMemberID(ctx, queryParams)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestFineRepository_CountAllByMemberID(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := fineRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	queryParams := model.GetFinesQueryParams{MemberID: testMember.ID, PageQuery: model.PageQuery{Page: 1, Size: 5}}
+	query := `SELECT count(*) FROM "fines" WHERE member_id = $1`
+
+	cacheHash := repo.cacheHash()
+	cacheKey := repo.countAllByMemberIDCacheKey(queryParams)
+
+	t.Run("success - fetch from cache", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("3", nil)
+		res, err := repo.CountAllByMemberID(ctx, queryParams)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), res)
+	})
+
+	t.Run("success - fetch from db", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(testMember.ID).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+		mockedDependency.cacheRepo.EXPECT().HashSet(ctx, cacheHash, cacheKey, "3").Times(1).Return(nil)
+
+		res, err := repo.CountAllByMemberID(ctx, queryParams)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), res)
+	})
+}
+
+func TestFineRepository_BalanceByMemberID(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := fineRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	cacheHash := repo.cacheHash()
+	cacheKey := repo.balanceByMemberIDCacheKey(testMember.ID)
+
+	t.Run("success - fetch from cache", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("75", nil)
+		res, err := repo.BalanceByMemberID(ctx, testMember.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(75), res)
+	})
+
+	t.Run("success - fetch from db", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testFineBalanceQuery)).
+			WithArgs(model.FineKindAccrual, testMember.ID).
+			WillReturnRows(sqlmock.NewRows([]string{"balance"}).AddRow(75))
+		mockedDependency.cacheRepo.EXPECT().HashSet(ctx, cacheHash, cacheKey, "75").Times(1).Return(nil)
+
+		res, err := repo.BalanceByMemberID(ctx, testMember.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(75), res)
+	})
+
+	t.Run("failed - db error", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testFineBalanceQuery)).WillReturnError(errors.New("db error"))
+
+		res, err := repo.BalanceByMemberID(ctx, testMember.ID)
+		assert.Error(t, err)
+		assert.Zero(t, res)
+	})
+}
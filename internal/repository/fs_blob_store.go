@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+)
+
+type fsBlobStore struct {
+	dir string
+}
+
+// NewFSBlobStore keeps the blobs as files under dir, the content type of a
+// blob is sniffed again when it is read
+func NewFSBlobStore(dir string) model.BlobStore {
+	return &fsBlobStore{dir: dir}
+}
+
+// Put writes the blob to a temporary file first, so that a reader never
+// sees a partially written blob
+func (fb *fsBlobStore) Put(ctx context.Context, key string, blob *model.Blob) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"key":         key,
+		"contentType": blob.ContentType,
+	})
+
+	path, err := fb.path(key)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".blob-*")
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(blob.Body); err != nil {
+		tmp.Close()
+		logger.Error(err)
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (fb *fsBlobStore) Get(ctx context.Context, key string) (*model.Blob, error) {
+	path, err := fb.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, domainerr.NotFound(fmt.Sprintf("blob %s not found", key), nil)
+	}
+
+	if err != nil {
+		logrus.WithField("key", key).Error(err)
+		return nil, err
+	}
+
+	return &model.Blob{ContentType: http.DetectContentType(body), Body: body}, nil
+}
+
+func (fb *fsBlobStore) Delete(ctx context.Context, keys ...string) error {
+	for _, key := range keys {
+		path, err := fb.path(key)
+		if err != nil {
+			return err
+		}
+
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			logrus.WithField("key", key).Error(err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// path maps key to a file under dir and rejects the keys escaping it
+func (fb *fsBlobStore) path(key string) (string, error) {
+	path := filepath.Join(fb.dir, filepath.FromSlash(key))
+	rel, err := filepath.Rel(fb.dir, path)
+	if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return "", fmt.Errorf("invalid blob key %q", key)
+	}
+
+	return path, nil
+}
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFSBlobStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewFSBlobStore(t.TempDir())
+	png := []byte("\x89PNG\r\n\x1a\n0000")
+
+	t.Run("success - put then get", func(t *testing.T) {
+		err := store.Put(ctx, "covers/1/original", &model.Blob{ContentType: "image/png", Body: png})
+		assert.NoError(t, err)
+
+		res, err := store.Get(ctx, "covers/1/original")
+		assert.NoError(t, err)
+		assert.Equal(t, "image/png", res.ContentType)
+		assert.Equal(t, png, res.Body)
+	})
+
+	t.Run("success - put overwrites the blob", func(t *testing.T) {
+		err := store.Put(ctx, "covers/1/original", &model.Blob{ContentType: "text/plain", Body: []byte("cover")})
+		assert.NoError(t, err)
+
+		res, err := store.Get(ctx, "covers/1/original")
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("cover"), res.Body)
+	})
+
+	t.Run("success - delete ignores the missing blobs", func(t *testing.T) {
+		err := store.Delete(ctx, "covers/1/original", "covers/2/original")
+		assert.NoError(t, err)
+
+		res, err := store.Get(ctx, "covers/1/original")
+		assert.ErrorIs(t, err, domainerr.ErrNotFound)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - key escapes the directory", func(t *testing.T) {
+		err := store.Put(ctx, "../cover", &model.Blob{Body: png})
+		assert.Error(t, err)
+	})
+}
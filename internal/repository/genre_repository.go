@@ -0,0 +1,292 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+	"github.com/ssentinull/create-apis-using-golang/internal/utils"
+	"gorm.io/gorm"
+)
+
+// genreSubtreeQuery selects the ID of a genre along with the IDs of all its descendants
+const genreSubtreeQuery = `WITH RECURSIVE subtree AS (
+	SELECT id FROM genres WHERE id = ?
+	UNION
+	SELECT genres.id FROM genres JOIN subtree ON genres.parent_id = subtree.id
+) SELECT id FROM subtree`
+
+type genreRepo struct {
+	db        *gorm.DB
+	cacheRepo model.CacheRepository
+}
+
+func NewGenreRepository(db *gorm.DB, cacheRepo model.CacheRepository) model.GenreRepository {
+	return &genreRepo{
+		db:        db,
+		cacheRepo: cacheRepo,
+	}
+}
+
+func (gr *genreRepo) Create(ctx context.Context, genre *model.Genre) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   utils.Dump(ctx),
+		"genre": utils.Dump(genre),
+	})
+
+	err := gr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := gr.checkParent(tx, genre); err != nil {
+			return err
+		}
+
+		if err := tx.Create(genre).Error; err != nil {
+			return parseDBError(err)
+		}
+		return nil
+	})
+
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if err := gr.cacheRepo.Delete(ctx, gr.cacheHash()); err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// DeleteByID deletes a genre without subgenres, the books of the genre keep
+// pointing at it
+func (gr *genreRepo) DeleteByID(ctx context.Context, ID int64) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx": utils.Dump(ctx),
+		"ID":  ID,
+	})
+
+	err := gr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		count := int64(0)
+		if err := tx.Model(&model.Genre{}).Where("parent_id = ?", ID).Count(&count).Error; err != nil {
+			return parseDBError(err)
+		}
+
+		if count > 0 {
+			return domainerr.Conflict(fmt.Sprintf("genre %d has subgenres", ID), nil)
+		}
+
+		res := tx.Delete(&model.Genre{}, ID)
+		if err := res.Error; err != nil {
+			return parseDBError(err)
+		}
+
+		if res.RowsAffected == 0 {
+			return domainerr.NotFound(fmt.Sprintf("genre %d not found", ID), nil)
+		}
+		return nil
+	})
+
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	// the books are filtered by genre subtrees, so their listings are stale too
+	cacheKeys := []string{
+		gr.findByIDCacheKey(ID),
+		gr.cacheHash(),
+		bookCacheHash,
+	}
+
+	if err := gr.cacheRepo.Delete(ctx, cacheKeys...); err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (gr *genreRepo) FindByID(ctx context.Context, ID int64) (*model.Genre, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx": utils.Dump(ctx),
+		"ID":  ID,
+	})
+
+	cacheKey := gr.findByIDCacheKey(ID)
+	reply, err := gr.cacheRepo.Get(ctx, cacheKey)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if reply != "" {
+		genre := &model.Genre{}
+		if err := json.Unmarshal([]byte(reply), &genre); err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+		return genre, nil
+	}
+
+	genre := &model.Genre{}
+	err = gr.db.WithContext(ctx).Where("id = ?", ID).Take(genre).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, parseDBError(err)
+	}
+
+	bytes, err := json.Marshal(genre)
+	if err != nil {
+		logger.Error(err)
+		return genre, nil
+	}
+
+	if err := gr.cacheRepo.Set(ctx, cacheKey, string(bytes)); err != nil {
+		logger.Error(err)
+	}
+
+	return genre, nil
+}
+
+// FindAll returns every genre ordered by name, the vocabulary is small
+// enough to be returned whole
+func (gr *genreRepo) FindAll(ctx context.Context) ([]*model.Genre, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx": utils.Dump(ctx),
+	})
+
+	cacheHash := gr.cacheHash()
+	cacheKey := gr.findAllCacheKey()
+	reply, err := gr.cacheRepo.HashGet(ctx, cacheHash, cacheKey)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if reply != "" {
+		genres := []*model.Genre{}
+		if err := json.Unmarshal([]byte(reply), &genres); err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+		return genres, nil
+	}
+
+	genres := []*model.Genre{}
+	err = gr.db.WithContext(ctx).
+		Order("name ASC").
+		Order("id ASC").
+		Find(&genres).
+		Error
+	if err != nil {
+		logger.Error(err)
+		return nil, parseDBError(err)
+	}
+
+	bytes, err := json.Marshal(genres)
+	if err != nil {
+		logger.Error(err)
+		return genres, nil
+	}
+
+	if err := gr.cacheRepo.HashSet(ctx, cacheHash, cacheKey, string(bytes)); err != nil {
+		logger.Error(err)
+	}
+
+	return genres, nil
+}
+
+func (gr *genreRepo) Update(ctx context.Context, genre *model.Genre) (*model.Genre, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   utils.Dump(ctx),
+		"genre": utils.Dump(genre),
+	})
+
+	err := gr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := gr.checkParent(tx, genre); err != nil {
+			return err
+		}
+
+		res := tx.Model(genre).Updates(map[string]interface{}{
+			"name":      genre.Name,
+			"parent_id": genre.ParentID,
+		})
+		if err := res.Error; err != nil {
+			return parseDBError(err)
+		}
+
+		if res.RowsAffected == 0 {
+			return domainerr.NotFound(fmt.Sprintf("genre %d not found", genre.ID), nil)
+		}
+		return nil
+	})
+
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	// moving a genre changes the subtrees the books are filtered by
+	cacheKeys := []string{
+		gr.cacheHash(),
+		gr.findByIDCacheKey(genre.ID),
+		bookCacheHash,
+	}
+
+	if err := gr.cacheRepo.Delete(ctx, cacheKeys...); err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return gr.FindByID(ctx, genre.ID)
+}
+
+// checkParent checks that the parent of genre exists and isn't the genre
+// itself or one of its descendants, which would make a cycle
+func (gr *genreRepo) checkParent(tx *gorm.DB, genre *model.Genre) error {
+	if genre.ParentID == nil {
+		return nil
+	}
+
+	parentID := *genre.ParentID
+	count := int64(0)
+	if err := tx.Model(&model.Genre{}).Where("id = ?", parentID).Count(&count).Error; err != nil {
+		return parseDBError(err)
+	}
+
+	if count == 0 {
+		return domainerr.NotFound(fmt.Sprintf("genre %d not found", parentID), nil)
+	}
+
+	cycles := int64(0)
+	err := tx.Raw(fmt.Sprintf("SELECT count(*) FROM (%s) AS subtree WHERE id = ?", genreSubtreeQuery), genre.ID, parentID).
+		Scan(&cycles).
+		Error
+	if err != nil {
+		return parseDBError(err)
+	}
+
+	if cycles > 0 {
+		return domainerr.ValidationFields("request contains invalid fields", map[string]string{
+			"parent_id": "must not be the genre itself or one of its subgenres",
+		})
+	}
+
+	return nil
+}
+
+func (gr *genreRepo) cacheHash() string {
+	return "genre"
+}
+
+func (gr *genreRepo) findByIDCacheKey(ID int64) string {
+	return fmt.Sprintf("genre:%d", ID)
+}
+
+func (gr *genreRepo) findAllCacheKey() string {
+	return "genre:all"
+}
@@ -0,0 +1,289 @@
+package repository
+
+import (
+	"encoding/json"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+var (
+	testGenreParentID = int64(1)
+	testGenre         = model.Genre{
+		ID:       int64(2),
+		Name:     "Fantasy",
+		ParentID: &testGenreParentID,
+	}
+)
+
+func TestGenreRepository_Create(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := genreRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	genre := testGenre
+	parentQuery := `SELECT count(*) FROM "genres" WHERE id = $1 AND "genres"."deleted_at" IS NULL`
+	cycleQuery := `SELECT count(*) FROM (WITH RECURSIVE subtree AS (`
+	query := `INSERT INTO "genres" ("name","parent_id","created_at","updated_at","deleted_at","id") VALUES ($1,$2,$3,$4,$5,$6) RETURNING "id"`
+
+	t.Run("success", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(parentQuery)).WithArgs(testGenreParentID).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(cycleQuery)).WithArgs(genre.ID, testGenreParentID).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(genre.ID))
+		mockedDependency.sql.ExpectCommit()
+		mockedDependency.cacheRepo.EXPECT().Delete(ctx, repo.cacheHash()).Times(1).Return(nil)
+
+		err := repo.Create(ctx, &genre)
+		assert.NoError(t, err)
+	})
+
+	t.Run("success - root genre", func(t *testing.T) {
+		root := model.Genre{ID: testGenreParentID, Name: "Fiction"}
+
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(root.ID))
+		mockedDependency.sql.ExpectCommit()
+		mockedDependency.cacheRepo.EXPECT().Delete(ctx, repo.cacheHash()).Times(1).Return(nil)
+
+		err := repo.Create(ctx, &root)
+		assert.NoError(t, err)
+	})
+
+	t.Run("failed - parent not found", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(parentQuery)).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mockedDependency.sql.ExpectRollback()
+
+		err := repo.Create(ctx, &genre)
+		assert.ErrorIs(t, err, domainerr.ErrNotFound)
+	})
+
+	t.Run("failed - create genre in db return error", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(parentQuery)).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(cycleQuery)).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(errors.New("db error"))
+		mockedDependency.sql.ExpectRollback()
+
+		err := repo.Create(ctx, &genre)
+		assert.Error(t, err)
+	})
+}
+
+func TestGenreRepository_DeleteByID(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := genreRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	cacheKeys := []string{
+		repo.findByIDCacheKey(testGenre.ID),
+		repo.cacheHash(),
+		bookCacheHash,
+	}
+
+	childrenQuery := `SELECT count(*) FROM "genres" WHERE parent_id = $1 AND "genres"."deleted_at" IS NULL`
+	query := `UPDATE "genres" SET "deleted_at"=$1 WHERE "genres"."id" = $2 AND "genres"."deleted_at" IS NULL`
+
+	t.Run("success", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(childrenQuery)).WithArgs(testGenre.ID).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(query)).WithArgs(sqlmock.AnyArg(), testGenre.ID).WillReturnResult(sqlmock.NewResult(1, 1))
+		mockedDependency.sql.ExpectCommit()
+		mockedDependency.cacheRepo.EXPECT().Delete(ctx, cacheKeys).Times(1).Return(nil)
+
+		err := repo.DeleteByID(ctx, testGenre.ID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("failed - genre has subgenres", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(childrenQuery)).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+		mockedDependency.sql.ExpectRollback()
+
+		err := repo.DeleteByID(ctx, testGenre.ID)
+		assert.ErrorIs(t, err, domainerr.ErrConflict)
+	})
+
+	t.Run("failed - genre not found", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(childrenQuery)).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(query)).WillReturnResult(sqlmock.NewResult(0, 0))
+		mockedDependency.sql.ExpectRollback()
+
+		err := repo.DeleteByID(ctx, testGenre.ID)
+		assert.ErrorIs(t, err, domainerr.ErrNotFound)
+	})
+}
+
+func TestGenreRepository_FindByID(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := genreRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	query := `SELECT * FROM "genres" WHERE id = $1 AND "genres"."deleted_at" IS NULL LIMIT 1`
+
+	cacheKey := repo.findByIDCacheKey(testGenre.ID)
+	bytes, err := json.Marshal(testGenre)
+	assert.NoError(t, err)
+
+	t.Run("success - fetch from cache", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().Get(ctx, cacheKey).Times(1).Return(string(bytes), nil)
+		res, err := repo.FindByID(ctx, testGenre.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, testGenre.Name, res.Name)
+	})
+
+	t.Run("success - fetch from db", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "name", "parent_id"}).AddRow(testGenre.ID, testGenre.Name, testGenreParentID)
+
+		mockedDependency.cacheRepo.EXPECT().Get(ctx, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(testGenre.ID).WillReturnRows(rows)
+		mockedDependency.cacheRepo.EXPECT().Set(ctx, cacheKey, gomock.Any()).Times(1).Return(nil)
+
+		res, err := repo.FindByID(ctx, testGenre.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, testGenreParentID, *res.ParentID)
+	})
+
+	t.Run("failed - genre not found", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().Get(ctx, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(gorm.ErrRecordNotFound)
+
+		res, err := repo.FindByID(ctx, testGenre.ID)
+		assert.ErrorIs(t, err, domainerr.ErrNotFound)
+		assert.Nil(t, res)
+	})
+}
+
+func TestGenreRepository_FindAll(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := genreRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	query := `SELECT * FROM "genres" WHERE "genres"."deleted_at" IS NULL ORDER BY name ASC,id ASC`
+
+	cacheHash := repo.cacheHash()
+	cacheKey := repo.findAllCacheKey()
+	bytes, err := json.Marshal([]*model.Genre{&testGenre})
+	assert.NoError(t, err)
+
+	t.Run("success - fetch from cache", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return(string(bytes), nil)
+		res, err := repo.FindAll(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+	})
+
+	t.Run("success - fetch from db", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "name", "parent_id"}).
+			AddRow(testGenre.ID, testGenre.Name, testGenreParentID).
+			AddRow(testGenreParentID, "Fiction", nil)
+
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnRows(rows)
+		mockedDependency.cacheRepo.EXPECT().HashSet(ctx, cacheHash, cacheKey, gomock.Any()).Times(1).Return(nil)
+
+		res, err := repo.FindAll(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, res, 2)
+		assert.Nil(t, res[1].ParentID)
+	})
+
+	t.Run("failed - fetch from db return error", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(errors.New("db error"))
+
+		res, err := repo.FindAll(ctx)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestGenreRepository_Update(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := genreRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	genre := testGenre
+	cacheKeys := []string{
+		repo.cacheHash(),
+		repo.findByIDCacheKey(genre.ID),
+		bookCacheHash,
+	}
+
+	parentQuery := `SELECT count(*) FROM "genres" WHERE id = $1 AND "genres"."deleted_at" IS NULL`
+	cycleQuery := `SELECT count(*) FROM (WITH RECURSIVE subtree AS (`
+	query := `UPDATE "genres" SET "name"=$1,"parent_id"=$2,"updated_at"=$3 WHERE "genres"."deleted_at" IS NULL AND "id" = $4`
+	bytes, err := json.Marshal(genre)
+	assert.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(parentQuery)).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(cycleQuery)).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(query)).WillReturnResult(sqlmock.NewResult(1, 1))
+		mockedDependency.sql.ExpectCommit()
+		mockedDependency.cacheRepo.EXPECT().Delete(ctx, cacheKeys).Times(1).Return(nil)
+		mockedDependency.cacheRepo.EXPECT().Get(ctx, repo.findByIDCacheKey(genre.ID)).Times(1).Return(string(bytes), nil)
+
+		res, err := repo.Update(ctx, &genre)
+		assert.NoError(t, err)
+		assert.Equal(t, genre.Name, res.Name)
+	})
+
+	t.Run("failed - parent is a subgenre", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(parentQuery)).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(cycleQuery)).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mockedDependency.sql.ExpectRollback()
+
+		res, err := repo.Update(ctx, &genre)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - genre not found", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(parentQuery)).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(cycleQuery)).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(query)).WillReturnResult(sqlmock.NewResult(0, 0))
+		mockedDependency.sql.ExpectRollback()
+
+		res, err := repo.Update(ctx, &genre)
+		assert.ErrorIs(t, err, domainerr.ErrNotFound)
+		assert.Nil(t, res)
+	})
+}
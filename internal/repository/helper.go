@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// searchConfig is the postgres text search configuration of the search_vector
+// column of the books table, the search queries have to be parsed with the
+// same one to stem their words the same way
+const searchConfig = "english"
+
+// tsHeadlineOptions wraps the matching words of a highlight in <mark> tags
+// and keeps up to two short fragments of long fields
+const tsHeadlineOptions = "StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=35, MinWords=15"
+
+var likeReplacer = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// escapeLike escapes the LIKE wildcards in s so it is matched literally
+func escapeLike(s string) string {
+	return likeReplacer.Replace(s)
+}
+
+// nullableDate maps an empty date to NULL, as postgres rejects empty strings as dates
+func nullableDate(date string) interface{} {
+	if date == "" {
+		return nil
+	}
+
+	return date
+}
+
+// webSearchQuery parses the search of query with websearch_to_tsquery
+func webSearchQuery(query model.SearchBooksQueryParams) clause.Expr {
+	return gorm.Expr("websearch_to_tsquery(?::regconfig, ?)", searchConfig, query.Q)
+}
+
+// tsHeadline highlights the words of column matching tsQuery
+func tsHeadline(query model.SearchBooksQueryParams, column string, tsQuery clause.Expr) clause.Expr {
+	return gorm.Expr(fmt.Sprintf("ts_headline(?::regconfig, %s, ?, ?)", column), searchConfig, tsQuery, tsHeadlineOptions)
+}
+
+// cursorPage is the cached result of a keyset paginated query
+type cursorPage struct {
+	Books   []*model.Book `json:"books"`
+	HasMore bool          `json:"has_more"`
+}
+
+// keysetColumn returns the expression a sortable column is compared and
+// ordered by in keyset pagination, nullable columns are coalesced so that
+// every row has a comparable position
+func keysetColumn(column string) string {
+	if column == "published_date" {
+		return fmt.Sprintf("COALESCE(published_date, DATE '%s')", model.NullPublishedDate)
+	}
+
+	return column
+}
+
+func sortDirection(desc bool) string {
+	if desc {
+		return " DESC"
+	}
+
+	return " ASC"
+}
+
+// keysetCondition builds the condition matching the rows after values in
+// the order of fields, eg: for (a ASC, id DESC) it yields
+// (a > ?) OR (a = ? AND id < ?)
+func keysetCondition(fields []model.SortField, values []string) clause.Expression {
+	ors := make([]clause.Expression, 0, len(fields))
+	for i, field := range fields {
+		ands := make([]clause.Expression, 0, i+1)
+		for j := 0; j < i; j++ {
+			ands = append(ands, clause.Expr{SQL: keysetColumn(fields[j].Column) + " = ?", Vars: []interface{}{keysetValue(fields[j].Column, values[j])}})
+		}
+
+		operator := " > ?"
+		if field.Desc {
+			operator = " < ?"
+		}
+
+		ands = append(ands, clause.Expr{SQL: keysetColumn(field.Column) + operator, Vars: []interface{}{keysetValue(field.Column, values[i])}})
+		ors = append(ors, clause.And(ands...))
+	}
+
+	return clause.Or(ors...)
+}
+
+func keysetValue(column, value string) interface{} {
+	if column != "id" {
+		return value
+	}
+
+	// the cursor has been checked by GetBooksQueryParams.ParseCursor
+	ID, _ := strconv.ParseInt(value, 10, 64)
+	return ID
+}
+
+// countFailed counts the items of a batch that failed
+func countFailed(errs []error) int {
+	count := 0
+	for _, err := range errs {
+		if err != nil {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,406 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+	"github.com/ssentinull/create-apis-using-golang/internal/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// holdCacheHash holds the cached queues of the books
+const holdCacheHash = "hold"
+
+var activeHoldStatuses = []string{model.HoldStatusWaiting, model.HoldStatusReady}
+
+type holdRepo struct {
+	db        *gorm.DB
+	cacheRepo model.CacheRepository
+}
+
+func NewHoldRepository(db *gorm.DB, cacheRepo model.CacheRepository) model.HoldRepository {
+	return &holdRepo{
+		db:        db,
+		cacheRepo: cacheRepo,
+	}
+}
+
+// Create queues a member for a book which has copies but none of them on
+// the shelf, a book with an available copy is checked out instead
+func (hr *holdRepo) Create(ctx context.Context, hold *model.Hold) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":  utils.Dump(ctx),
+		"hold": utils.Dump(hold),
+	})
+
+	err := hr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		book := &model.Book{}
+		if err := lockBook(tx, hold.BookID, book); err != nil {
+			return err
+		}
+
+		switch {
+		case book.DeletedAt.Valid:
+			return domainerr.NotFound(fmt.Sprintf("book %d not found", hold.BookID), nil)
+		case book.CopiesCount == 0:
+			return domainerr.Validation(fmt.Sprintf("book %d has no copies", hold.BookID), nil)
+		case book.AvailableCopiesCount > 0:
+			return domainerr.Conflict(fmt.Sprintf("book %d has available copies", hold.BookID), nil)
+		}
+
+		err := tx.Clauses(clause.Locking{Strength: "SHARE"}).Where("id = ?", hold.MemberID).Take(&model.Member{}).Error
+		if err != nil {
+			return parseDBError(err)
+		}
+
+		if err := tx.Create(hold).Error; err != nil {
+			return parseDBError(err)
+		}
+		return nil
+	})
+
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if err := hr.cacheRepo.Delete(ctx, hr.cacheHash()); err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// Cancel takes a member out of the queue of a book at now, the copy
+// reserved for a ready hold goes to the next hold in the queue
+func (hr *holdRepo) Cancel(ctx context.Context, bookID, memberID int64, now, pickupBy time.Time) (*model.Hold, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":      utils.Dump(ctx),
+		"bookID":   bookID,
+		"memberID": memberID,
+		"now":      now,
+		"pickupBy": pickupBy,
+	})
+
+	hold := &model.Hold{}
+	err := hr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("book_id = ? AND member_id = ? AND status IN ?", bookID, memberID, activeHoldStatuses).Take(hold).Error
+		if err != nil {
+			return parseDBError(err)
+		}
+
+		return hr.close(tx, hold, model.HoldStatusCancelled, now, pickupBy)
+	})
+
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if err := hr.deleteCache(ctx, logger, hold); err != nil {
+		return nil, err
+	}
+
+	hold.Status = model.HoldStatusCancelled
+	return hold, nil
+}
+
+// FindAllByBookID returns the queue of a book, first come first served
+func (hr *holdRepo) FindAllByBookID(ctx context.Context, query model.GetHoldsQueryParams) ([]*model.Hold, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   utils.Dump(ctx),
+		"query": utils.Dump(query),
+	})
+
+	cacheHash := hr.cacheHash()
+	cacheKey := hr.findAllByBookIDCacheKey(query)
+	reply, err := hr.cacheRepo.HashGet(ctx, cacheHash, cacheKey)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if reply != "" {
+		holds := []*model.Hold{}
+		if err := json.Unmarshal([]byte(reply), &holds); err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+		return holds, nil
+	}
+
+	holds := []*model.Hold{}
+	err = hr.applyFilters(hr.db.WithContext(ctx), query).
+		Order("created_at ASC").
+		Order("id ASC").
+		Offset(int(model.Offset(query.Page, query.Size))).
+		Limit(int(query.Size)).
+		Find(&holds).
+		Error
+	if err != nil {
+		logger.Error(err)
+		return nil, parseDBError(err)
+	}
+
+	bytes, err := json.Marshal(holds)
+	if err != nil {
+		logger.Error(err)
+		return holds, nil
+	}
+
+	if err := hr.cacheRepo.HashSet(ctx, cacheHash, cacheKey, string(bytes)); err != nil {
+		logger.Error(err)
+	}
+
+	return holds, nil
+}
+
+func (hr *holdRepo) CountAllByBookID(ctx context.Context, query model.GetHoldsQueryParams) (int64, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   utils.Dump(ctx),
+		"query": utils.Dump(query),
+	})
+
+	cacheHash := hr.cacheHash()
+	cacheKey := hr.countAllByBookIDCacheKey(query)
+	reply, err := hr.cacheRepo.HashGet(ctx, cacheHash, cacheKey)
+	if err != nil {
+		logger.Error(err)
+		return 0, err
+	}
+
+	if reply != "" {
+		count := int64(0)
+		if err := json.Unmarshal([]byte(reply), &count); err != nil {
+			logger.Error(err)
+			return 0, err
+		}
+		return count, nil
+	}
+
+	count := int64(0)
+	err = hr.applyFilters(hr.db.WithContext(ctx), query).
+		Model(&model.Hold{}).
+		Count(&count).
+		Error
+	if err != nil {
+		logger.Error(err)
+		return int64(0), parseDBError(err)
+	}
+
+	bytes, err := json.Marshal(count)
+	if err != nil {
+		logger.Error(err)
+		return 0, err
+	}
+
+	if err := hr.cacheRepo.HashSet(ctx, cacheHash, cacheKey, string(bytes)); err != nil {
+		logger.Error(err)
+	}
+
+	return count, nil
+}
+
+// ExpireReady expires the ready holds whose pickup window closed by now,
+// every hold in a transaction of its own. The copy of an expired hold
+// goes to the next hold in the queue
+func (hr *holdRepo) ExpireReady(ctx context.Context, now, pickupBy time.Time) (int64, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":      utils.Dump(ctx),
+		"now":      now,
+		"pickupBy": pickupBy,
+	})
+
+	IDs := []int64{}
+	err := hr.db.WithContext(ctx).
+		Model(&model.Hold{}).
+		Where("status = ? AND expires_at <= ?", model.HoldStatusReady, now).
+		Order("expires_at ASC").
+		Pluck("id", &IDs).
+		Error
+	if err != nil {
+		logger.Error(err)
+		return 0, parseDBError(err)
+	}
+
+	count := int64(0)
+	for _, ID := range IDs {
+		hold := &model.Hold{}
+		err := hr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := tx.Where("id = ?", ID).Take(hold).Error; err != nil {
+				return parseDBError(err)
+			}
+
+			return hr.close(tx, hold, model.HoldStatusExpired, now, pickupBy)
+		})
+
+		// a hold picked up or cancelled meanwhile is left alone
+		if errors.Is(err, domainerr.ErrConflict) {
+			continue
+		}
+
+		if err != nil {
+			logger.Error(err)
+			return count, err
+		}
+
+		if err := hr.deleteCache(ctx, logger, hold); err != nil {
+			return count, err
+		}
+		count++
+	}
+
+	return count, nil
+}
+
+// close takes an active hold out of the queue of its book with status, a
+// ready hold has to be expired by now. The copy of a ready hold, the book
+// and the hold are locked in the order of the other writers of the queue,
+// the hold is read again under the locks and must not have changed
+func (hr *holdRepo) close(tx *gorm.DB, hold *model.Hold, status string, now, pickupBy time.Time) error {
+	read := *hold
+	if read.Status == model.HoldStatusReady {
+		if err := lockBookCopy(tx, *read.CopyID, &model.BookCopy{}); err != nil {
+			return err
+		}
+	}
+
+	if err := lockBook(tx, read.BookID, &model.Book{}); err != nil {
+		return err
+	}
+
+	locked := &model.Hold{}
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", read.ID).Take(locked).Error
+	if err != nil {
+		return parseDBError(err)
+	}
+
+	*hold = *locked
+	if hold.Status != read.Status {
+		return domainerr.Conflict(fmt.Sprintf("hold %d is %s", hold.ID, hold.Status), nil)
+	}
+
+	if status == model.HoldStatusExpired && hold.ExpiresAt.After(now) {
+		return domainerr.Conflict(fmt.Sprintf("hold %d is ready until %s", hold.ID, hold.ExpiresAt.Format(time.RFC3339)), nil)
+	}
+
+	err = tx.Model(&model.Hold{}).Where("id = ?", hold.ID).Update("status", status).Error
+	if err != nil {
+		return parseDBError(err)
+	}
+
+	if read.Status == model.HoldStatusReady {
+		return releaseBookCopy(tx, *read.CopyID, read.BookID, now, pickupBy)
+	}
+	return nil
+}
+
+// deleteCache invalidates the queues along with the copy of hold and its
+// book, whose availability changes when the copy goes back on the shelf
+func (hr *holdRepo) deleteCache(ctx context.Context, logger *logrus.Entry, hold *model.Hold) error {
+	cacheKeys := append([]string{
+		hr.cacheHash(),
+		bookCopyCacheHash,
+		bookCacheHash,
+	}, bookCacheKeys(hold.BookID)...)
+
+	if hold.CopyID != nil {
+		cacheKeys = append(cacheKeys, fmt.Sprintf("book_copy:%d", *hold.CopyID))
+	}
+
+	if err := hr.cacheRepo.Delete(ctx, cacheKeys...); err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (hr *holdRepo) cacheHash() string {
+	return holdCacheHash
+}
+
+func (hr *holdRepo) findAllByBookIDCacheKey(query model.GetHoldsQueryParams) string {
+	return fmt.Sprintf("hold:book:%d:page:%d:size:%d", query.BookID, query.Page, query.Size)
+}
+
+func (hr *holdRepo) countAllByBookIDCacheKey(query model.GetHoldsQueryParams) string {
+	return fmt.Sprintf("hold:book:%d:count", query.BookID)
+}
+
+// applyFilters narrows db down to the queue of the book
+func (hr *holdRepo) applyFilters(db *gorm.DB, query model.GetHoldsQueryParams) *gorm.DB {
+	return db.Where("book_id = ? AND status IN ?", query.BookID, activeHoldStatuses)
+}
+
+// lockBook reads the copy counts of a book into dest, the book may be in
+// the trash, and locks its row until the end of tx. Every change of the
+// queue of a book happens under this lock, after the lock of the copy it
+// involves if any
+func lockBook(tx *gorm.DB, bookID int64, dest *model.Book) error {
+	err := tx.Unscoped().
+		Clauses(clause.Locking{Strength: "NO KEY UPDATE"}).
+		Select("id", "copies_count", "available_copies_count", "deleted_at").
+		Where("id = ?", bookID).
+		Take(dest).
+		Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domainerr.NotFound(fmt.Sprintf("book %d not found", bookID), err)
+	}
+	return parseDBError(err)
+}
+
+// releaseBookCopy puts a copy locked by tx back on the shelf, unless it is
+// reserved for the next hold in the queue of its book
+func releaseBookCopy(tx *gorm.DB, copyID, bookID int64, now, pickupBy time.Time) error {
+	reserved, err := reserveForNextHold(tx, copyID, bookID, now, pickupBy)
+	if err != nil || reserved {
+		return err
+	}
+
+	if err := updateBookCopyStatus(tx, copyID, model.BookCopyStatusAvailable); err != nil {
+		return err
+	}
+
+	return addBookCopies(tx, bookID, 0, 1)
+}
+
+// reserveForNextHold reserves a copy locked by tx for the first waiting
+// hold in the queue of its book until pickupBy, it reports whether the
+// queue had a waiting hold
+func reserveForNextHold(tx *gorm.DB, copyID, bookID int64, now, pickupBy time.Time) (bool, error) {
+	hold := &model.Hold{}
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("book_id = ? AND status = ?", bookID, model.HoldStatusWaiting).
+		Order("created_at ASC").
+		Order("id ASC").
+		Take(hold).
+		Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, parseDBError(err)
+	}
+
+	err = tx.Model(&model.Hold{}).Where("id = ?", hold.ID).Updates(map[string]interface{}{
+		"status":     model.HoldStatusReady,
+		"copy_id":    copyID,
+		"ready_at":   now,
+		"expires_at": pickupBy,
+	}).Error
+	if err != nil {
+		return false, parseDBError(err)
+	}
+
+	return true, updateBookCopyStatus(tx, copyID, model.BookCopyStatusReserved)
+}
@@ -0,0 +1,364 @@
+package repository
+
+import (
+	"encoding/json"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+var (
+	testHoldExpiresAt = time.Date(2026, 10, 4, 10, 0, 0, 0, time.UTC)
+	testHold          = model.Hold{
+		ID:       int64(1),
+		BookID:   testBookCopy.BookID,
+		MemberID: testMember.ID,
+		Status:   model.HoldStatusWaiting,
+	}
+	testReadyHold = model.Hold{
+		ID:        int64(2),
+		BookID:    testBookCopy.BookID,
+		MemberID:  testMember.ID,
+		CopyID:    &testBookCopy.ID,
+		Status:    model.HoldStatusReady,
+		ExpiresAt: &testHoldExpiresAt,
+	}
+)
+
+const (
+	testLockBookQuery         = `SELECT "id","copies_count","available_copies_count","deleted_at" FROM "books" WHERE id = $1 LIMIT 1 FOR NO KEY UPDATE`
+	testLockHoldQuery         = `SELECT * FROM "holds" WHERE id = $1 LIMIT 1 FOR UPDATE`
+	testNextHoldQuery         = `SELECT * FROM "holds" WHERE book_id = $1 AND status = $2 ORDER BY created_at ASC,id ASC LIMIT 1 FOR UPDATE`
+	testReserveHoldQuery      = `UPDATE "holds" SET "copy_id"=$1,"expires_at"=$2,"ready_at"=$3,"status"=$4,"updated_at"=$5 WHERE id = $6`
+	testUpdateHoldStatusQuery = `UPDATE "holds" SET "status"=$1,"updated_at"=$2 WHERE id = $3`
+)
+
+func testBookCountRows(bookID, copiesCount, availableCopiesCount int64, deleted bool) *sqlmock.Rows {
+	var deletedAt *time.Time
+	if deleted {
+		now := time.Now()
+		deletedAt = &now
+	}
+
+	return sqlmock.NewRows([]string{"id", "copies_count", "available_copies_count", "deleted_at"}).
+		AddRow(bookID, copiesCount, availableCopiesCount, deletedAt)
+}
+
+func testHoldRows(hold model.Hold) *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"id", "book_id", "member_id", "copy_id", "status", "expires_at"}).
+		AddRow(hold.ID, hold.BookID, hold.MemberID, hold.CopyID, hold.Status, hold.ExpiresAt)
+}
+
+func TestHoldRepository_Create(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := holdRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	memberQuery := `SELECT * FROM "members" WHERE id = $1 AND "members"."deleted_at" IS NULL LIMIT 1 FOR SHARE`
+	query := `INSERT INTO "holds" ("book_id","member_id","copy_id","status","ready_at","expires_at","created_at","updated_at","id") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9) RETURNING "id"`
+
+	t.Run("success", func(t *testing.T) {
+		hold := testHold
+
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testLockBookQuery)).WithArgs(hold.BookID).WillReturnRows(testBookCountRows(hold.BookID, 1, 0, false))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(memberQuery)).WithArgs(hold.MemberID).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(hold.MemberID))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(hold.ID))
+		mockedDependency.sql.ExpectCommit()
+		mockedDependency.cacheRepo.EXPECT().Delete(ctx, []string{repo.cacheHash()}).Times(1).Return(nil)
+
+		err := repo.Create(ctx, &hold)
+		assert.NoError(t, err)
+	})
+
+	t.Run("failed - book has available copies", func(t *testing.T) {
+		hold := testHold
+
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testLockBookQuery)).WithArgs(hold.BookID).WillReturnRows(testBookCountRows(hold.BookID, 2, 1, false))
+		mockedDependency.sql.ExpectRollback()
+
+		err := repo.Create(ctx, &hold)
+		assert.ErrorIs(t, err, domainerr.ErrConflict)
+	})
+
+	t.Run("failed - book has no copies", func(t *testing.T) {
+		hold := testHold
+
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testLockBookQuery)).WithArgs(hold.BookID).WillReturnRows(testBookCountRows(hold.BookID, 0, 0, false))
+		mockedDependency.sql.ExpectRollback()
+
+		err := repo.Create(ctx, &hold)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+	})
+
+	t.Run("failed - book is in the trash", func(t *testing.T) {
+		hold := testHold
+
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testLockBookQuery)).WithArgs(hold.BookID).WillReturnRows(testBookCountRows(hold.BookID, 1, 0, true))
+		mockedDependency.sql.ExpectRollback()
+
+		err := repo.Create(ctx, &hold)
+		assert.ErrorIs(t, err, domainerr.ErrNotFound)
+	})
+
+	t.Run("failed - member already holds the book", func(t *testing.T) {
+		hold := testHold
+
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testLockBookQuery)).WithArgs(hold.BookID).WillReturnRows(testBookCountRows(hold.BookID, 1, 0, false))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(memberQuery)).WithArgs(hold.MemberID).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(hold.MemberID))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(&pgconn.PgError{Code: pgUniqueViolationCode})
+		mockedDependency.sql.ExpectRollback()
+
+		err := repo.Create(ctx, &hold)
+		assert.ErrorIs(t, err, domainerr.ErrConflict)
+	})
+}
+
+func TestHoldRepository_Cancel(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := holdRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	now := time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC)
+	pickupBy := now.Add(72 * time.Hour)
+	query := `SELECT * FROM "holds" WHERE book_id = $1 AND member_id = $2 AND status IN ($3,$4) LIMIT 1`
+
+	t.Run("success - waiting hold", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(testHold.BookID, testHold.MemberID, model.HoldStatusWaiting, model.HoldStatusReady).
+			WillReturnRows(testHoldRows(testHold))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testLockBookQuery)).WithArgs(testHold.BookID).WillReturnRows(testBookCountRows(testHold.BookID, 1, 0, false))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testLockHoldQuery)).WithArgs(testHold.ID).WillReturnRows(testHoldRows(testHold))
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(testUpdateHoldStatusQuery)).WithArgs(model.HoldStatusCancelled, sqlmock.AnyArg(), testHold.ID).WillReturnResult(sqlmock.NewResult(0, 1))
+		mockedDependency.sql.ExpectCommit()
+		mockedDependency.cacheRepo.EXPECT().Delete(ctx, []string{repo.cacheHash(), bookCopyCacheHash, bookCacheHash, "book:10", "book:10:version"}).Times(1).Return(nil)
+
+		res, err := repo.Cancel(ctx, testHold.BookID, testHold.MemberID, now, pickupBy)
+		assert.NoError(t, err)
+		assert.Equal(t, model.HoldStatusCancelled, res.Status)
+	})
+
+	t.Run("success - ready hold passes the copy on", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(testReadyHold.BookID, testReadyHold.MemberID, model.HoldStatusWaiting, model.HoldStatusReady).
+			WillReturnRows(testHoldRows(testReadyHold))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testLockBookCopyQuery)).WithArgs(testBookCopy.ID).WillReturnRows(testBookCopyRows(testBookCopy))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testLockBookQuery)).WithArgs(testReadyHold.BookID).WillReturnRows(testBookCountRows(testReadyHold.BookID, 1, 0, false))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testLockHoldQuery)).WithArgs(testReadyHold.ID).WillReturnRows(testHoldRows(testReadyHold))
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(testUpdateHoldStatusQuery)).WithArgs(model.HoldStatusCancelled, sqlmock.AnyArg(), testReadyHold.ID).WillReturnResult(sqlmock.NewResult(0, 1))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testNextHoldQuery)).WithArgs(testReadyHold.BookID, model.HoldStatusWaiting).WillReturnRows(testHoldRows(testHold))
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(testReserveHoldQuery)).
+			WithArgs(testBookCopy.ID, pickupBy, sqlmock.AnyArg(), model.HoldStatusReady, sqlmock.AnyArg(), testHold.ID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(testUpdateBookCopyStatusQuery)).
+			WithArgs(model.BookCopyStatusReserved, sqlmock.AnyArg(), testBookCopy.ID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockedDependency.sql.ExpectCommit()
+		mockedDependency.cacheRepo.EXPECT().Delete(ctx, []string{repo.cacheHash(), bookCopyCacheHash, bookCacheHash, "book:10", "book:10:version", "book_copy:1"}).Times(1).Return(nil)
+
+		res, err := repo.Cancel(ctx, testReadyHold.BookID, testReadyHold.MemberID, now, pickupBy)
+		assert.NoError(t, err)
+		assert.Equal(t, model.HoldStatusCancelled, res.Status)
+	})
+
+	t.Run("failed - hold changed meanwhile", func(t *testing.T) {
+		ready := testReadyHold
+		ready.ID = testHold.ID
+
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(testHold.BookID, testHold.MemberID, model.HoldStatusWaiting, model.HoldStatusReady).
+			WillReturnRows(testHoldRows(testHold))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testLockBookQuery)).WithArgs(testHold.BookID).WillReturnRows(testBookCountRows(testHold.BookID, 1, 0, false))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testLockHoldQuery)).WithArgs(testHold.ID).WillReturnRows(testHoldRows(ready))
+		mockedDependency.sql.ExpectRollback()
+
+		res, err := repo.Cancel(ctx, testHold.BookID, testHold.MemberID, now, pickupBy)
+		assert.ErrorIs(t, err, domainerr.ErrConflict)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - hold not found", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(gorm.ErrRecordNotFound)
+		mockedDependency.sql.ExpectRollback()
+
+		res, err := repo.Cancel(ctx, testHold.BookID, testHold.MemberID, now, pickupBy)
+		assert.ErrorIs(t, err, domainerr.ErrNotFound)
+		assert.Nil(t, res)
+	})
+}
+
+func TestHoldRepository_FindAllByBookID(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := holdRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	queryParams := model.GetHoldsQueryParams{BookID: 10, PageQuery: model.PageQuery{Page: 2, Size: 5}}
+	query := `SELECT * FROM "holds" WHERE book_id = $1 AND status IN ($2,$3) ORDER BY created_at ASC,id ASC LIMIT 5 OFFSET 5`
+
+	cacheHash := repo.cacheHash()
+	cacheKey := repo.findAllByBookIDCacheKey(queryParams)
+	bytes, err := json.Marshal([]*model.Hold{&testHold})
+	assert.NoError(t, err)
+
+	t.Run("success - fetch from cache", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return(string(bytes), nil)
+		res, err := repo.FindAllByBookID(ctx, queryParams)
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+	})
+
+	t.Run("success - fetch from db", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(int64(10), model.HoldStatusWaiting, model.HoldStatusReady).
+			WillReturnRows(testHoldRows(testHold))
+		mockedDependency.cacheRepo.EXPECT().HashSet(ctx, cacheHash, cacheKey, gomock.Any()).Times(1).Return(nil)
+
+		res, err := repo.FindAllByBookID(ctx, queryParams)
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+	})
+
+	t.Run("failed - fetch from db return error", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(errors.New("db error"))
+
+		res, err := repo.FindAllByBookID(ctx, queryParams)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestHoldRepository_CountAllByBookID(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := holdRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	queryParams := model.GetHoldsQueryParams{BookID: 10, PageQuery: model.PageQuery{Page: 1, Size: 5}}
+	query := `SELECT count(*) FROM "holds" WHERE book_id = $1 AND status IN ($2,$3)`
+
+	cacheHash := repo.cacheHash()
+	cacheKey := repo.countAllByBookIDCacheKey(queryParams)
+
+	t.Run("success - fetch from cache", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("3", nil)
+		res, err := repo.CountAllByBookID(ctx, queryParams)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), res)
+	})
+
+	t.Run("success - fetch from db", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(int64(10), model.HoldStatusWaiting, model.HoldStatusReady).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+		mockedDependency.cacheRepo.EXPECT().HashSet(ctx, cacheHash, cacheKey, "3").Times(1).Return(nil)
+
+		res, err := repo.CountAllByBookID(ctx, queryParams)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), res)
+	})
+}
+
+func TestHoldRepository_ExpireReady(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := holdRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	now := testHoldExpiresAt.Add(time.Minute)
+	pickupBy := now.Add(72 * time.Hour)
+	query := `SELECT "id" FROM "holds" WHERE status = $1 AND expires_at <= $2 ORDER BY expires_at ASC`
+	holdQuery := `SELECT * FROM "holds" WHERE id = $1 LIMIT 1`
+
+	t.Run("success", func(t *testing.T) {
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(model.HoldStatusReady, now).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(testReadyHold.ID))
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(holdQuery)).WithArgs(testReadyHold.ID).WillReturnRows(testHoldRows(testReadyHold))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testLockBookCopyQuery)).WithArgs(testBookCopy.ID).WillReturnRows(testBookCopyRows(testBookCopy))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testLockBookQuery)).WithArgs(testReadyHold.BookID).WillReturnRows(testBookCountRows(testReadyHold.BookID, 1, 0, false))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testLockHoldQuery)).WithArgs(testReadyHold.ID).WillReturnRows(testHoldRows(testReadyHold))
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(testUpdateHoldStatusQuery)).WithArgs(model.HoldStatusExpired, sqlmock.AnyArg(), testReadyHold.ID).WillReturnResult(sqlmock.NewResult(0, 1))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testNextHoldQuery)).WithArgs(testReadyHold.BookID, model.HoldStatusWaiting).WillReturnRows(sqlmock.NewRows(nil))
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(testUpdateBookCopyStatusQuery)).
+			WithArgs(model.BookCopyStatusAvailable, sqlmock.AnyArg(), testBookCopy.ID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(testAddBookCopiesQuery)).WithArgs(int64(0), int64(1), testReadyHold.BookID).WillReturnResult(sqlmock.NewResult(0, 1))
+		mockedDependency.sql.ExpectCommit()
+		mockedDependency.cacheRepo.EXPECT().Delete(ctx, []string{repo.cacheHash(), bookCopyCacheHash, bookCacheHash, "book:10", "book:10:version", "book_copy:1"}).Times(1).Return(nil)
+
+		count, err := repo.ExpireReady(ctx, now, pickupBy)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("success - hold picked up meanwhile is skipped", func(t *testing.T) {
+		fulfilled := testReadyHold
+		fulfilled.Status = model.HoldStatusFulfilled
+
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(model.HoldStatusReady, now).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(testReadyHold.ID))
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(holdQuery)).WithArgs(testReadyHold.ID).WillReturnRows(testHoldRows(testReadyHold))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testLockBookCopyQuery)).WithArgs(testBookCopy.ID).WillReturnRows(testBookCopyRows(testBookCopy))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testLockBookQuery)).WithArgs(testReadyHold.BookID).WillReturnRows(testBookCountRows(testReadyHold.BookID, 1, 0, false))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testLockHoldQuery)).WithArgs(testReadyHold.ID).WillReturnRows(testHoldRows(fulfilled))
+		mockedDependency.sql.ExpectRollback()
+
+		count, err := repo.ExpireReady(ctx, now, pickupBy)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), count)
+	})
+
+	t.Run("failed - db error", func(t *testing.T) {
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(errors.New("db error"))
+
+		count, err := repo.ExpireReady(ctx, now, pickupBy)
+		assert.Error(t, err)
+		assert.Equal(t, int64(0), count)
+	})
+}
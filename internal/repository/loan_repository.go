@@ -0,0 +1,394 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+	"github.com/ssentinull/create-apis-using-golang/internal/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type loanRepo struct {
+	db        *gorm.DB
+	cacheRepo model.CacheRepository
+}
+
+func NewLoanRepository(db *gorm.DB, cacheRepo model.CacheRepository) model.LoanRepository {
+	return &loanRepo{
+		db:        db,
+		cacheRepo: cacheRepo,
+	}
+}
+
+// Create checks the copy of the loan out to its member. The copy is locked
+// until the loan is stored, so that concurrent checkouts of the copy wait
+// for each other and all but the first one find the copy on loan. A copy
+// reserved for a hold is only checked out to the member of the hold
+func (lr *loanRepo) Create(ctx context.Context, loan *model.Loan) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":  utils.Dump(ctx),
+		"loan": utils.Dump(loan),
+	})
+
+	err := lr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		bookCopy := &model.BookCopy{}
+		if err := lockBookCopy(tx, loan.CopyID, bookCopy); err != nil {
+			return err
+		}
+
+		reserved := bookCopy.Status == model.BookCopyStatusReserved
+		if !bookCopy.IsAvailable() && !reserved {
+			return domainerr.Conflict(fmt.Sprintf("copy %d is %s", loan.CopyID, bookCopy.Status), nil)
+		}
+
+		// the member can't be deleted until the loan is stored
+		err := tx.Clauses(clause.Locking{Strength: "SHARE"}).Where("id = ?", loan.MemberID).Take(&model.Member{}).Error
+		if err != nil {
+			return parseDBError(err)
+		}
+
+		loan.BookID = bookCopy.BookID
+		if reserved {
+			if err := lr.fulfillHold(tx, loan); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Create(loan).Error; err != nil {
+			return parseDBError(err)
+		}
+
+		if err := updateBookCopyStatus(tx, loan.CopyID, model.BookCopyStatusOnLoan); err != nil {
+			return err
+		}
+
+		// a reserved copy is already off the shelf
+		if reserved {
+			return nil
+		}
+		return addBookCopies(tx, loan.BookID, 0, -1)
+	})
+
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return lr.deleteCache(ctx, logger, loan)
+}
+
+// Return closes an open loan and puts its copy back on the shelf, or
+// reserves it until pickupBy for the first hold in the queue of its book
+func (lr *loanRepo) Return(ctx context.Context, ID int64, returnedAt, pickupBy time.Time) (*model.Loan, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":        utils.Dump(ctx),
+		"ID":         ID,
+		"returnedAt": returnedAt,
+		"pickupBy":   pickupBy,
+	})
+
+	loan := &model.Loan{}
+	err := lr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lr.lockOpen(tx, ID, loan); err != nil {
+			return err
+		}
+
+		if err := lockBookCopy(tx, loan.CopyID, &model.BookCopy{}); err != nil {
+			return err
+		}
+
+		err := tx.Model(&model.Loan{}).Where("id = ?", ID).Update("returned_at", returnedAt).Error
+		if err != nil {
+			return parseDBError(err)
+		}
+
+		if err := lockBook(tx, loan.BookID, &model.Book{}); err != nil {
+			return err
+		}
+
+		return releaseBookCopy(tx, loan.CopyID, loan.BookID, returnedAt, pickupBy)
+	})
+
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if err := lr.deleteCache(ctx, logger, loan); err != nil {
+		return nil, err
+	}
+
+	return lr.FindByID(ctx, ID)
+}
+
+// UpdateDueDate moves the due date of an open loan, which can't fall
+// before its checkout
+func (lr *loanRepo) UpdateDueDate(ctx context.Context, ID int64, dueAt time.Time) (*model.Loan, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   utils.Dump(ctx),
+		"ID":    ID,
+		"dueAt": dueAt,
+	})
+
+	loan := &model.Loan{}
+	err := lr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lr.lockOpen(tx, ID, loan); err != nil {
+			return err
+		}
+
+		if !dueAt.After(loan.CheckedOutAt) {
+			return domainerr.Validation("due date must be after the checkout", nil)
+		}
+
+		err := tx.Model(&model.Loan{}).Where("id = ?", ID).Update("due_at", dueAt).Error
+		return parseDBError(err)
+	})
+
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	cacheKeys := []string{
+		lr.cacheHash(),
+		lr.findByIDCacheKey(ID),
+	}
+
+	if err := lr.cacheRepo.Delete(ctx, cacheKeys...); err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return lr.FindByID(ctx, ID)
+}
+
+func (lr *loanRepo) FindByID(ctx context.Context, ID int64) (*model.Loan, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx": utils.Dump(ctx),
+		"ID":  ID,
+	})
+
+	cacheKey := lr.findByIDCacheKey(ID)
+	reply, err := lr.cacheRepo.Get(ctx, cacheKey)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if reply != "" {
+		loan := &model.Loan{}
+		if err := json.Unmarshal([]byte(reply), &loan); err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+		return loan, nil
+	}
+
+	loan := &model.Loan{}
+	err = lr.db.WithContext(ctx).Where("id = ?", ID).Take(loan).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, parseDBError(err)
+	}
+
+	bytes, err := json.Marshal(loan)
+	if err != nil {
+		logger.Error(err)
+		return loan, nil
+	}
+
+	if err := lr.cacheRepo.Set(ctx, cacheKey, string(bytes)); err != nil {
+		logger.Error(err)
+	}
+
+	return loan, nil
+}
+
+// FindAllByMemberID returns the latest loans of a member first
+func (lr *loanRepo) FindAllByMemberID(ctx context.Context, query model.GetLoansQueryParams) ([]*model.Loan, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   utils.Dump(ctx),
+		"query": utils.Dump(query),
+	})
+
+	cacheHash := lr.cacheHash()
+	cacheKey := lr.findAllByMemberIDCacheKey(query)
+	reply, err := lr.cacheRepo.HashGet(ctx, cacheHash, cacheKey)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if reply != "" {
+		loans := []*model.Loan{}
+		if err := json.Unmarshal([]byte(reply), &loans); err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+		return loans, nil
+	}
+
+	loans := []*model.Loan{}
+	err = lr.applyFilters(lr.db.WithContext(ctx), query).
+		Order("checked_out_at DESC").
+		Order("id DESC").
+		Offset(int(model.Offset(query.Page, query.Size))).
+		Limit(int(query.Size)).
+		Find(&loans).
+		Error
+	if err != nil {
+		logger.Error(err)
+		return nil, parseDBError(err)
+	}
+
+	bytes, err := json.Marshal(loans)
+	if err != nil {
+		logger.Error(err)
+		return loans, nil
+	}
+
+	if err := lr.cacheRepo.HashSet(ctx, cacheHash, cacheKey, string(bytes)); err != nil {
+		logger.Error(err)
+	}
+
+	return loans, nil
+}
+
+func (lr *loanRepo) CountAllByMemberID(ctx context.Context, query model.GetLoansQueryParams) (int64, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   utils.Dump(ctx),
+		"query": utils.Dump(query),
+	})
+
+	cacheHash := lr.cacheHash()
+	cacheKey := lr.countAllByMemberIDCacheKey(query)
+	reply, err := lr.cacheRepo.HashGet(ctx, cacheHash, cacheKey)
+	if err != nil {
+		logger.Error(err)
+		return 0, err
+	}
+
+	if reply != "" {
+		count := int64(0)
+		if err := json.Unmarshal([]byte(reply), &count); err != nil {
+			logger.Error(err)
+			return 0, err
+		}
+		return count, nil
+	}
+
+	count := int64(0)
+	err = lr.applyFilters(lr.db.WithContext(ctx), query).
+		Model(&model.Loan{}).
+		Count(&count).
+		Error
+	if err != nil {
+		logger.Error(err)
+		return int64(0), parseDBError(err)
+	}
+
+	bytes, err := json.Marshal(count)
+	if err != nil {
+		logger.Error(err)
+		return 0, err
+	}
+
+	if err := lr.cacheRepo.HashSet(ctx, cacheHash, cacheKey, string(bytes)); err != nil {
+		logger.Error(err)
+	}
+
+	return count, nil
+}
+
+// lockOpen reads the loan into dest and locks its row until the end of tx,
+// a returned loan can't be changed anymore
+func (lr *loanRepo) lockOpen(tx *gorm.DB, ID int64, dest *model.Loan) error {
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", ID).Take(dest).Error
+	if err != nil {
+		return parseDBError(err)
+	}
+
+	if !dest.IsOpen() {
+		return domainerr.Conflict(fmt.Sprintf("loan %d is already returned", ID), nil)
+	}
+	return nil
+}
+
+// fulfillHold closes the ready hold the copy of loan is reserved for, which
+// must be a hold of the member of loan
+func (lr *loanRepo) fulfillHold(tx *gorm.DB, loan *model.Loan) error {
+	if err := lockBook(tx, loan.BookID, &model.Book{}); err != nil {
+		return err
+	}
+
+	hold := &model.Hold{}
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("copy_id = ? AND status = ?", loan.CopyID, model.HoldStatusReady).
+		Take(hold).
+		Error
+	if err != nil {
+		return parseDBError(err)
+	}
+
+	if hold.MemberID != loan.MemberID {
+		return domainerr.Conflict(fmt.Sprintf("copy %d is reserved for another member", loan.CopyID), nil)
+	}
+
+	err = tx.Model(&model.Hold{}).Where("id = ?", hold.ID).Update("status", model.HoldStatusFulfilled).Error
+	return parseDBError(err)
+}
+
+// deleteCache invalidates the loans along with the queues, the copy and the
+// book of loan, whose status and copy counts it changed
+func (lr *loanRepo) deleteCache(ctx context.Context, logger *logrus.Entry, loan *model.Loan) error {
+	cacheKeys := append([]string{
+		lr.cacheHash(),
+		lr.findByIDCacheKey(loan.ID),
+		holdCacheHash,
+		bookCopyCacheHash,
+		fmt.Sprintf("book_copy:%d", loan.CopyID),
+		bookCacheHash,
+	}, bookCacheKeys(loan.BookID)...)
+
+	if err := lr.cacheRepo.Delete(ctx, cacheKeys...); err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (lr *loanRepo) cacheHash() string {
+	return "loan"
+}
+
+func (lr *loanRepo) findByIDCacheKey(ID int64) string {
+	return fmt.Sprintf("loan:%d", ID)
+}
+
+func (lr *loanRepo) findAllByMemberIDCacheKey(query model.GetLoansQueryParams) string {
+	return fmt.Sprintf("loan:member:%d:page:%d:size:%d:status:%s", query.MemberID, query.Page, query.Size, query.Status)
+}
+
+func (lr *loanRepo) countAllByMemberIDCacheKey(query model.GetLoansQueryParams) string {
+	return fmt.Sprintf("loan:member:%d:count:status:%s", query.MemberID, query.Status)
+}
+
+// applyFilters narrows db down to the loans of the member in the status of query
+func (lr *loanRepo) applyFilters(db *gorm.DB, query model.GetLoansQueryParams) *gorm.DB {
+	db = db.Where("member_id = ?", query.MemberID)
+	switch query.Status {
+	case model.LoanStatusOpen:
+		db = db.Where("returned_at IS NULL")
+	case model.LoanStatusReturned:
+		db = db.Where("returned_at IS NOT NULL")
+	}
+
+	return db
+}
@@ -0,0 +1,367 @@
+package repository
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+var (
+	testCheckedOutAt = time.Date(2026, 10, 1, 10, 0, 0, 0, time.UTC)
+	testLoan         = model.Loan{
+		ID:           int64(1),
+		CopyID:       testBookCopy.ID,
+		BookID:       testBookCopy.BookID,
+		MemberID:     testMember.ID,
+		CheckedOutAt: testCheckedOutAt,
+		DueAt:        testCheckedOutAt.AddDate(0, 0, 14),
+	}
+)
+
+const (
+	testLockLoanQuery             = `SELECT * FROM "loans" WHERE id = $1 LIMIT 1 FOR UPDATE`
+	testUpdateBookCopyStatusQuery = `UPDATE "book_copies" SET "status"=$1,"updated_at"=$2 WHERE id = $3 AND "book_copies"."deleted_at" IS NULL`
+)
+
+func testLoanRows(loan model.Loan) *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"id", "copy_id", "book_id", "member_id", "checked_out_at", "due_at", "returned_at"}).
+		AddRow(loan.ID, loan.CopyID, loan.BookID, loan.MemberID, loan.CheckedOutAt, loan.DueAt, loan.ReturnedAt)
+}
+
+func TestLoanRepository_Create(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := loanRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	cacheKeys := []string{
+		repo.cacheHash(),
+		repo.findByIDCacheKey(testLoan.ID),
+		holdCacheHash,
+		bookCopyCacheHash,
+		"book_copy:1",
+		bookCacheHash,
+		"book:10",
+		"book:10:version",
+	}
+
+	memberQuery := `SELECT * FROM "members" WHERE id = $1 AND "members"."deleted_at" IS NULL LIMIT 1 FOR SHARE`
+	readyHoldQuery := `SELECT * FROM "holds" WHERE copy_id = $1 AND status = $2 LIMIT 1 FOR UPDATE`
+	query := `INSERT INTO "loans" ("copy_id","book_id","member_id","checked_out_at","due_at","returned_at","created_at","updated_at","id") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9) RETURNING "id"`
+
+	t.Run("success", func(t *testing.T) {
+		loan := testLoan
+		loan.BookID = 0
+
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testLockBookCopyQuery)).WithArgs(loan.CopyID).WillReturnRows(testBookCopyRows(testBookCopy))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(memberQuery)).WithArgs(loan.MemberID).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(loan.MemberID))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(loan.ID))
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(testUpdateBookCopyStatusQuery)).
+			WithArgs(model.BookCopyStatusOnLoan, sqlmock.AnyArg(), loan.CopyID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(testAddBookCopiesQuery)).WithArgs(int64(0), int64(-1), testBookCopy.BookID).WillReturnResult(sqlmock.NewResult(0, 1))
+		mockedDependency.sql.ExpectCommit()
+		mockedDependency.cacheRepo.EXPECT().Delete(ctx, cacheKeys).Times(1).Return(nil)
+
+		err := repo.Create(ctx, &loan)
+		assert.NoError(t, err)
+		assert.Equal(t, testBookCopy.BookID, loan.BookID)
+	})
+
+	t.Run("success - copy is reserved for the member", func(t *testing.T) {
+		loan := testLoan
+		bookCopy := testBookCopy
+		bookCopy.Status = model.BookCopyStatusReserved
+
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testLockBookCopyQuery)).WithArgs(loan.CopyID).WillReturnRows(testBookCopyRows(bookCopy))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(memberQuery)).WithArgs(loan.MemberID).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(loan.MemberID))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testLockBookQuery)).WithArgs(bookCopy.BookID).WillReturnRows(testBookCountRows(bookCopy.BookID, 1, 0, false))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(readyHoldQuery)).WithArgs(loan.CopyID, model.HoldStatusReady).WillReturnRows(testHoldRows(testReadyHold))
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(testUpdateHoldStatusQuery)).WithArgs(model.HoldStatusFulfilled, sqlmock.AnyArg(), testReadyHold.ID).WillReturnResult(sqlmock.NewResult(0, 1))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(loan.ID))
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(testUpdateBookCopyStatusQuery)).
+			WithArgs(model.BookCopyStatusOnLoan, sqlmock.AnyArg(), loan.CopyID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockedDependency.sql.ExpectCommit()
+		mockedDependency.cacheRepo.EXPECT().Delete(ctx, cacheKeys).Times(1).Return(nil)
+
+		err := repo.Create(ctx, &loan)
+		assert.NoError(t, err)
+	})
+
+	t.Run("failed - copy is reserved for another member", func(t *testing.T) {
+		loan := testLoan
+		bookCopy := testBookCopy
+		bookCopy.Status = model.BookCopyStatusReserved
+		hold := testReadyHold
+		hold.MemberID = loan.MemberID + 1
+
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testLockBookCopyQuery)).WithArgs(loan.CopyID).WillReturnRows(testBookCopyRows(bookCopy))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(memberQuery)).WithArgs(loan.MemberID).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(loan.MemberID))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testLockBookQuery)).WithArgs(bookCopy.BookID).WillReturnRows(testBookCountRows(bookCopy.BookID, 1, 0, false))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(readyHoldQuery)).WithArgs(loan.CopyID, model.HoldStatusReady).WillReturnRows(testHoldRows(hold))
+		mockedDependency.sql.ExpectRollback()
+
+		err := repo.Create(ctx, &loan)
+		assert.ErrorIs(t, err, domainerr.ErrConflict)
+	})
+
+	t.Run("failed - copy is already on loan", func(t *testing.T) {
+		loan := testLoan
+		bookCopy := testBookCopy
+		bookCopy.Status = model.BookCopyStatusOnLoan
+
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testLockBookCopyQuery)).WithArgs(loan.CopyID).WillReturnRows(testBookCopyRows(bookCopy))
+		mockedDependency.sql.ExpectRollback()
+
+		err := repo.Create(ctx, &loan)
+		assert.ErrorIs(t, err, domainerr.ErrConflict)
+	})
+
+	t.Run("failed - member not found", func(t *testing.T) {
+		loan := testLoan
+
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testLockBookCopyQuery)).WithArgs(loan.CopyID).WillReturnRows(testBookCopyRows(testBookCopy))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(memberQuery)).WithArgs(loan.MemberID).WillReturnError(gorm.ErrRecordNotFound)
+		mockedDependency.sql.ExpectRollback()
+
+		err := repo.Create(ctx, &loan)
+		assert.ErrorIs(t, err, domainerr.ErrNotFound)
+	})
+
+	t.Run("failed - copy has an open loan", func(t *testing.T) {
+		loan := testLoan
+
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testLockBookCopyQuery)).WithArgs(loan.CopyID).WillReturnRows(testBookCopyRows(testBookCopy))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(memberQuery)).WithArgs(loan.MemberID).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(loan.MemberID))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(&pgconn.PgError{Code: pgUniqueViolationCode})
+		mockedDependency.sql.ExpectRollback()
+
+		err := repo.Create(ctx, &loan)
+		assert.ErrorIs(t, err, domainerr.ErrConflict)
+	})
+}
+
+func TestLoanRepository_Return(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := loanRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	cacheKeys := []string{
+		repo.cacheHash(),
+		repo.findByIDCacheKey(testLoan.ID),
+		holdCacheHash,
+		bookCopyCacheHash,
+		"book_copy:1",
+		bookCacheHash,
+		"book:10",
+		"book:10:version",
+	}
+
+	returnedAt := testCheckedOutAt.AddDate(0, 0, 7)
+	pickupBy := returnedAt.Add(72 * time.Hour)
+	query := `UPDATE "loans" SET "returned_at"=$1,"updated_at"=$2 WHERE id = $3`
+
+	returned := testLoan
+	returned.ReturnedAt = &returnedAt
+	bytes, err := json.Marshal(returned)
+	assert.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testLockLoanQuery)).WithArgs(testLoan.ID).WillReturnRows(testLoanRows(testLoan))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testLockBookCopyQuery)).WithArgs(testLoan.CopyID).WillReturnRows(testBookCopyRows(testBookCopy))
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(query)).WithArgs(returnedAt, sqlmock.AnyArg(), testLoan.ID).WillReturnResult(sqlmock.NewResult(0, 1))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testLockBookQuery)).WithArgs(testLoan.BookID).WillReturnRows(testBookCountRows(testLoan.BookID, 1, 0, false))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testNextHoldQuery)).WithArgs(testLoan.BookID, model.HoldStatusWaiting).WillReturnRows(sqlmock.NewRows(nil))
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(testUpdateBookCopyStatusQuery)).
+			WithArgs(model.BookCopyStatusAvailable, sqlmock.AnyArg(), testLoan.CopyID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(testAddBookCopiesQuery)).WithArgs(int64(0), int64(1), testLoan.BookID).WillReturnResult(sqlmock.NewResult(0, 1))
+		mockedDependency.sql.ExpectCommit()
+		mockedDependency.cacheRepo.EXPECT().Delete(ctx, cacheKeys).Times(1).Return(nil)
+		mockedDependency.cacheRepo.EXPECT().Get(ctx, repo.findByIDCacheKey(testLoan.ID)).Times(1).Return(string(bytes), nil)
+
+		res, err := repo.Return(ctx, testLoan.ID, returnedAt, pickupBy)
+		assert.NoError(t, err)
+		assert.False(t, res.IsOpen())
+	})
+
+	t.Run("success - copy is reserved for the next hold", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testLockLoanQuery)).WithArgs(testLoan.ID).WillReturnRows(testLoanRows(testLoan))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testLockBookCopyQuery)).WithArgs(testLoan.CopyID).WillReturnRows(testBookCopyRows(testBookCopy))
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(query)).WithArgs(returnedAt, sqlmock.AnyArg(), testLoan.ID).WillReturnResult(sqlmock.NewResult(0, 1))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testLockBookQuery)).WithArgs(testLoan.BookID).WillReturnRows(testBookCountRows(testLoan.BookID, 1, 0, false))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testNextHoldQuery)).WithArgs(testLoan.BookID, model.HoldStatusWaiting).WillReturnRows(testHoldRows(testHold))
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(testReserveHoldQuery)).
+			WithArgs(testLoan.CopyID, pickupBy, returnedAt, model.HoldStatusReady, sqlmock.AnyArg(), testHold.ID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(testUpdateBookCopyStatusQuery)).
+			WithArgs(model.BookCopyStatusReserved, sqlmock.AnyArg(), testLoan.CopyID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockedDependency.sql.ExpectCommit()
+		mockedDependency.cacheRepo.EXPECT().Delete(ctx, cacheKeys).Times(1).Return(nil)
+		mockedDependency.cacheRepo.EXPECT().Get(ctx, repo.findByIDCacheKey(testLoan.ID)).Times(1).Return(string(bytes), nil)
+
+		res, err := repo.Return(ctx, testLoan.ID, returnedAt, pickupBy)
+		assert.NoError(t, err)
+		assert.False(t, res.IsOpen())
+	})
+
+	t.Run("failed - loan is already returned", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testLockLoanQuery)).WithArgs(testLoan.ID).WillReturnRows(testLoanRows(returned))
+		mockedDependency.sql.ExpectRollback()
+
+		res, err := repo.Return(ctx, testLoan.ID, returnedAt, pickupBy)
+		assert.ErrorIs(t, err, domainerr.ErrConflict)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - loan not found", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testLockLoanQuery)).WillReturnError(gorm.ErrRecordNotFound)
+		mockedDependency.sql.ExpectRollback()
+
+		res, err := repo.Return(ctx, testLoan.ID, returnedAt, pickupBy)
+		assert.ErrorIs(t, err, domainerr.ErrNotFound)
+		assert.Nil(t, res)
+	})
+}
+
+func TestLoanRepository_UpdateDueDate(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := loanRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	cacheKeys := []string{
+		repo.cacheHash(),
+		repo.findByIDCacheKey(testLoan.ID),
+	}
+
+	dueAt := testLoan.DueAt.AddDate(0, 0, 7)
+	query := `UPDATE "loans" SET "due_at"=$1,"updated_at"=$2 WHERE id = $3`
+	bytes, err := json.Marshal(testLoan)
+	assert.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testLockLoanQuery)).WithArgs(testLoan.ID).WillReturnRows(testLoanRows(testLoan))
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(query)).WithArgs(dueAt, sqlmock.AnyArg(), testLoan.ID).WillReturnResult(sqlmock.NewResult(0, 1))
+		mockedDependency.sql.ExpectCommit()
+		mockedDependency.cacheRepo.EXPECT().Delete(ctx, cacheKeys).Times(1).Return(nil)
+		mockedDependency.cacheRepo.EXPECT().Get(ctx, repo.findByIDCacheKey(testLoan.ID)).Times(1).Return(string(bytes), nil)
+
+		res, err := repo.UpdateDueDate(ctx, testLoan.ID, dueAt)
+		assert.NoError(t, err)
+		assert.Equal(t, testLoan.ID, res.ID)
+	})
+
+	t.Run("failed - due date before the checkout", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testLockLoanQuery)).WithArgs(testLoan.ID).WillReturnRows(testLoanRows(testLoan))
+		mockedDependency.sql.ExpectRollback()
+
+		res, err := repo.UpdateDueDate(ctx, testLoan.ID, testCheckedOutAt.Add(-time.Hour))
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, res)
+	})
+}
+
+func TestLoanRepository_FindAllByMemberID(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := loanRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	queryParams := model.GetLoansQueryParams{MemberID: testMember.ID, Page: 1, Size: 5, Status: model.LoanStatusOpen}
+	query := `SELECT * FROM "loans" WHERE member_id = $1 AND returned_at IS NULL ORDER BY checked_out_at DESC,id DESC LIMIT 5`
+
+	cacheHash := repo.cacheHash()
+	cacheKey := repo.findAllByMemberIDCacheKey(queryParams)
+
+	t.Run("success - fetch from db", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(testMember.ID).WillReturnRows(testLoanRows(testLoan))
+		mockedDependency.cacheRepo.EXPECT().HashSet(ctx, cacheHash, cacheKey, gomock.Any()).Times(1).Return(nil)
+
+		res, err := repo.FindAllByMemberID(ctx, queryParams)
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+	})
+
+	t.Run("success - fetch returned loans from db", func(t *testing.T) {
+		queryParams := queryParams
+		queryParams.Status = model.LoanStatusReturned
+		query := `SELECT * FROM "loans" WHERE member_id = $1 AND returned_at IS NOT NULL ORDER BY checked_out_at DESC,id DESC LIMIT 5`
+
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, repo.findAllByMemberIDCacheKey(queryParams)).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(testMember.ID).WillReturnRows(testLoanRows(testLoan))
+		mockedDependency.cacheRepo.EXPECT().HashSet(ctx, cacheHash, repo.findAllByMemberIDCacheKey(queryParams), gomock.Any()).Times(1).Return(nil)
+
+		res, err := repo.FindAllByMemberID(ctx, queryParams)
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+	})
+}
+
+func TestLoanRepository_CountAllByMemberID(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := loanRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	queryParams := model.GetLoansQueryParams{MemberID: testMember.ID, Page: 1, Size: 5, Status: model.LoanStatusAll}
+	query := `SELECT count(*) FROM "loans" WHERE member_id = $1`
+
+	cacheHash := repo.cacheHash()
+	cacheKey := repo.countAllByMemberIDCacheKey(queryParams)
+
+	t.Run("success - fetch from db", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(testMember.ID).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(4))
+		mockedDependency.cacheRepo.EXPECT().HashSet(ctx, cacheHash, cacheKey, "4").Times(1).Return(nil)
+
+		res, err := repo.CountAllByMemberID(ctx, queryParams)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(4), res)
+	})
+}
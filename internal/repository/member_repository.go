@@ -0,0 +1,310 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/sirupsen/logrus"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+	"github.com/ssentinull/create-apis-using-golang/internal/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type memberRepo struct {
+	db        *gorm.DB
+	cacheRepo model.CacheRepository
+}
+
+func NewMemberRepository(db *gorm.DB, cacheRepo model.CacheRepository) model.MemberRepository {
+	return &memberRepo{
+		db:        db,
+		cacheRepo: cacheRepo,
+	}
+}
+
+func (mr *memberRepo) Create(ctx context.Context, member *model.Member) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":    utils.Dump(ctx),
+		"member": utils.Dump(member),
+	})
+
+	err := mr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(member).Error; err != nil {
+			return parseDBError(err)
+		}
+		return nil
+	})
+
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if err := mr.cacheRepo.Delete(ctx, mr.cacheHash()); err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// DeleteByID deletes a member without open loans, the loans of the member
+// are kept. The member is locked first, so that a concurrent checkout
+// can't lend a copy to it while it is being deleted
+func (mr *memberRepo) DeleteByID(ctx context.Context, ID int64) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx": utils.Dump(ctx),
+		"ID":  ID,
+	})
+
+	err := mr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", ID).Take(&model.Member{}).Error
+		if err != nil {
+			return parseDBError(err)
+		}
+
+		openLoans := int64(0)
+		err = tx.Model(&model.Loan{}).Where("member_id = ? AND returned_at IS NULL", ID).Count(&openLoans).Error
+		if err != nil {
+			return parseDBError(err)
+		}
+
+		if openLoans > 0 {
+			return domainerr.Conflict(fmt.Sprintf("member %d has %d open loans", ID, openLoans), nil)
+		}
+
+		if err := tx.Delete(&model.Member{}, ID).Error; err != nil {
+			return parseDBError(err)
+		}
+		return nil
+	})
+
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	cacheKeys := []string{
+		mr.findByIDCacheKey(ID),
+		mr.cacheHash(),
+	}
+
+	if err := mr.cacheRepo.Delete(ctx, cacheKeys...); err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (mr *memberRepo) FindByID(ctx context.Context, ID int64) (*model.Member, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx": utils.Dump(ctx),
+		"ID":  ID,
+	})
+
+	cacheKey := mr.findByIDCacheKey(ID)
+	reply, err := mr.cacheRepo.Get(ctx, cacheKey)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if reply != "" {
+		member := &model.Member{}
+		if err := json.Unmarshal([]byte(reply), &member); err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+		return member, nil
+	}
+
+	member := &model.Member{}
+	err = mr.db.WithContext(ctx).Where("id = ?", ID).Take(member).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, parseDBError(err)
+	}
+
+	bytes, err := json.Marshal(member)
+	if err != nil {
+		logger.Error(err)
+		return member, nil
+	}
+
+	if err := mr.cacheRepo.Set(ctx, cacheKey, string(bytes)); err != nil {
+		logger.Error(err)
+	}
+
+	return member, nil
+}
+
+func (mr *memberRepo) FindAll(ctx context.Context, query model.GetMembersQueryParams) ([]*model.Member, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   utils.Dump(ctx),
+		"query": utils.Dump(query),
+	})
+
+	cacheHash := mr.cacheHash()
+	cacheKey := mr.findAllCacheKey(query)
+	reply, err := mr.cacheRepo.HashGet(ctx, cacheHash, cacheKey)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if reply != "" {
+		members := []*model.Member{}
+		if err := json.Unmarshal([]byte(reply), &members); err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+		return members, nil
+	}
+
+	members := []*model.Member{}
+	err = mr.applyFilters(mr.db.WithContext(ctx), query).
+		Order("name ASC").
+		Order("id ASC").
+		Offset(int(model.Offset(query.Page, query.Size))).
+		Limit(int(query.Size)).
+		Find(&members).
+		Error
+	if err != nil {
+		logger.Error(err)
+		return nil, parseDBError(err)
+	}
+
+	bytes, err := json.Marshal(members)
+	if err != nil {
+		logger.Error(err)
+		return members, nil
+	}
+
+	if err := mr.cacheRepo.HashSet(ctx, cacheHash, cacheKey, string(bytes)); err != nil {
+		logger.Error(err)
+	}
+
+	return members, nil
+}
+
+func (mr *memberRepo) CountAll(ctx context.Context, query model.GetMembersQueryParams) (int64, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   utils.Dump(ctx),
+		"query": utils.Dump(query),
+	})
+
+	cacheHash := mr.cacheHash()
+	cacheKey := mr.countAllCacheKey(query)
+	reply, err := mr.cacheRepo.HashGet(ctx, cacheHash, cacheKey)
+	if err != nil {
+		logger.Error(err)
+		return 0, err
+	}
+
+	if reply != "" {
+		count := int64(0)
+		if err := json.Unmarshal([]byte(reply), &count); err != nil {
+			logger.Error(err)
+			return 0, err
+		}
+		return count, nil
+	}
+
+	count := int64(0)
+	err = mr.applyFilters(mr.db.WithContext(ctx), query).
+		Model(&model.Member{}).
+		Count(&count).
+		Error
+	if err != nil {
+		logger.Error(err)
+		return int64(0), parseDBError(err)
+	}
+
+	bytes, err := json.Marshal(count)
+	if err != nil {
+		logger.Error(err)
+		return 0, err
+	}
+
+	if err := mr.cacheRepo.HashSet(ctx, cacheHash, cacheKey, string(bytes)); err != nil {
+		logger.Error(err)
+	}
+
+	return count, nil
+}
+
+func (mr *memberRepo) Update(ctx context.Context, member *model.Member) (*model.Member, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":    utils.Dump(ctx),
+		"member": utils.Dump(member),
+	})
+
+	err := mr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(member).Updates(map[string]interface{}{
+			"name":  member.Name,
+			"email": member.Email,
+		})
+		if err := res.Error; err != nil {
+			return parseDBError(err)
+		}
+
+		if res.RowsAffected == 0 {
+			return domainerr.NotFound(fmt.Sprintf("member %d not found", member.ID), nil)
+		}
+		return nil
+	})
+
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	cacheKeys := []string{
+		mr.cacheHash(),
+		mr.findByIDCacheKey(member.ID),
+	}
+
+	if err := mr.cacheRepo.Delete(ctx, cacheKeys...); err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return mr.FindByID(ctx, member.ID)
+}
+
+func (mr *memberRepo) cacheHash() string {
+	return "member"
+}
+
+func (mr *memberRepo) findByIDCacheKey(ID int64) string {
+	return fmt.Sprintf("member:%d", ID)
+}
+
+func (mr *memberRepo) findAllCacheKey(query model.GetMembersQueryParams) string {
+	return fmt.Sprintf("member:page:%d:size:%d:%s", query.Page, query.Size, mr.filtersCacheKey(query))
+}
+
+func (mr *memberRepo) countAllCacheKey(query model.GetMembersQueryParams) string {
+	return fmt.Sprintf("member:count:%s", mr.filtersCacheKey(query))
+}
+
+func (mr *memberRepo) filtersCacheKey(query model.GetMembersQueryParams) string {
+	filters := url.Values{}
+	filters.Set("q", query.Q)
+	return filters.Encode()
+}
+
+// applyFilters narrows db down to the members whose name or email matches Q
+func (mr *memberRepo) applyFilters(db *gorm.DB, query model.GetMembersQueryParams) *gorm.DB {
+	if query.Q != "" {
+		pattern := "%" + escapeLike(query.Q) + "%"
+		db = db.Where("name ILIKE ? OR email ILIKE ?", pattern, pattern)
+	}
+
+	return db
+}
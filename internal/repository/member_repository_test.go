@@ -0,0 +1,316 @@
+package repository
+
+import (
+	"encoding/json"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+var testMember = model.Member{
+	ID:    int64(1),
+	Name:  "Hermione Granger",
+	Email: "hermione@hogwarts.edu",
+}
+
+func TestMemberRepository_Create(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := memberRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	member := testMember
+	query := `INSERT INTO "members" ("name","email","created_at","updated_at","deleted_at","id") VALUES ($1,$2,$3,$4,$5,$6) RETURNING "id"`
+
+	t.Run("success", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(member.ID))
+		mockedDependency.sql.ExpectCommit()
+		mockedDependency.cacheRepo.EXPECT().Delete(ctx, repo.cacheHash()).Times(1).Return(nil)
+
+		err := repo.Create(ctx, &member)
+		assert.NoError(t, err)
+	})
+
+	t.Run("failed - create member in db return error", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(errors.New("db error"))
+		mockedDependency.sql.ExpectRollback()
+
+		err := repo.Create(ctx, &member)
+		assert.Error(t, err)
+	})
+
+	t.Run("failed - delete cache return error", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(member.ID))
+		mockedDependency.sql.ExpectCommit()
+		mockedDependency.cacheRepo.EXPECT().Delete(ctx, repo.cacheHash()).Times(1).Return(errors.New("cache error"))
+
+		err := repo.Create(ctx, &member)
+		assert.Error(t, err)
+	})
+}
+
+func TestMemberRepository_DeleteByID(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := memberRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	cacheKeys := []string{
+		repo.findByIDCacheKey(testMember.ID),
+		repo.cacheHash(),
+	}
+
+	lockQuery := `SELECT * FROM "members" WHERE id = $1 AND "members"."deleted_at" IS NULL LIMIT 1 FOR UPDATE`
+	countQuery := `SELECT count(*) FROM "loans" WHERE member_id = $1 AND returned_at IS NULL`
+	query := `UPDATE "members" SET "deleted_at"=$1 WHERE "members"."id" = $2 AND "members"."deleted_at" IS NULL`
+
+	t.Run("success", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(lockQuery)).WithArgs(testMember.ID).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(testMember.ID))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(countQuery)).WithArgs(testMember.ID).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(query)).WithArgs(sqlmock.AnyArg(), testMember.ID).WillReturnResult(sqlmock.NewResult(1, 1))
+		mockedDependency.sql.ExpectCommit()
+		mockedDependency.cacheRepo.EXPECT().Delete(ctx, cacheKeys).Times(1).Return(nil)
+
+		err := repo.DeleteByID(ctx, testMember.ID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("failed - member not found", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(lockQuery)).WillReturnError(gorm.ErrRecordNotFound)
+		mockedDependency.sql.ExpectRollback()
+
+		err := repo.DeleteByID(ctx, testMember.ID)
+		assert.ErrorIs(t, err, domainerr.ErrNotFound)
+	})
+
+	t.Run("failed - member has open loans", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(lockQuery)).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(testMember.ID))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(countQuery)).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+		mockedDependency.sql.ExpectRollback()
+
+		err := repo.DeleteByID(ctx, testMember.ID)
+		assert.ErrorIs(t, err, domainerr.ErrConflict)
+	})
+
+	t.Run("failed - delete cache return error", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(lockQuery)).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(testMember.ID))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(countQuery)).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(query)).WillReturnResult(sqlmock.NewResult(1, 1))
+		mockedDependency.sql.ExpectCommit()
+		mockedDependency.cacheRepo.EXPECT().Delete(ctx, cacheKeys).Times(1).Return(errors.New("cache error"))
+
+		err := repo.DeleteByID(ctx, testMember.ID)
+		assert.Error(t, err)
+	})
+}
+
+func TestMemberRepository_FindByID(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := memberRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	query := `SELECT * FROM "members" WHERE id = $1 AND "members"."deleted_at" IS NULL LIMIT 1`
+
+	cacheKey := repo.findByIDCacheKey(testMember.ID)
+	bytes, err := json.Marshal(testMember)
+	assert.NoError(t, err)
+
+	t.Run("success - fetch from cache", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().Get(ctx, cacheKey).Times(1).Return(string(bytes), nil)
+		res, err := repo.FindByID(ctx, testMember.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, testMember.Name, res.Name)
+	})
+
+	t.Run("success - fetch from db", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "name"}).AddRow(testMember.ID, testMember.Name)
+
+		mockedDependency.cacheRepo.EXPECT().Get(ctx, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(testMember.ID).WillReturnRows(rows)
+		mockedDependency.cacheRepo.EXPECT().Set(ctx, cacheKey, gomock.Any()).Times(1).Return(nil)
+
+		res, err := repo.FindByID(ctx, testMember.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, testMember.Name, res.Name)
+	})
+
+	t.Run("failed - member not found", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().Get(ctx, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(gorm.ErrRecordNotFound)
+
+		res, err := repo.FindByID(ctx, testMember.ID)
+		assert.ErrorIs(t, err, domainerr.ErrNotFound)
+		assert.Nil(t, res)
+	})
+}
+
+func TestMemberRepository_FindAll(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := memberRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	queryParams := model.GetMembersQueryParams{PageQuery: model.PageQuery{Page: 2, Size: 5}, Q: "herm"}
+	query := `SELECT * FROM "members" WHERE (name ILIKE $1 OR email ILIKE $2) AND "members"."deleted_at" IS NULL ORDER BY name ASC,id ASC LIMIT 5 OFFSET 5`
+
+	cacheHash := repo.cacheHash()
+	cacheKey := repo.findAllCacheKey(queryParams)
+	bytes, err := json.Marshal([]*model.Member{&testMember})
+	assert.NoError(t, err)
+
+	t.Run("success - fetch from cache", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return(string(bytes), nil)
+		res, err := repo.FindAll(ctx, queryParams)
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+	})
+
+	t.Run("success - fetch from db", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "name"}).AddRow(testMember.ID, testMember.Name)
+
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WithArgs("%herm%", "%herm%").WillReturnRows(rows)
+		mockedDependency.cacheRepo.EXPECT().HashSet(ctx, cacheHash, cacheKey, gomock.Any()).Times(1).Return(nil)
+
+		res, err := repo.FindAll(ctx, queryParams)
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+	})
+
+	t.Run("failed - fetch from db return error", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(errors.New("db error"))
+
+		res, err := repo.FindAll(ctx, queryParams)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestMemberRepository_CountAll(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := memberRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	queryParams := model.GetMembersQueryParams{PageQuery: model.PageQuery{Page: 1, Size: 5}}
+	query := `SELECT count(*) FROM "members" WHERE "members"."deleted_at" IS NULL`
+
+	cacheHash := repo.cacheHash()
+	cacheKey := repo.countAllCacheKey(queryParams)
+
+	t.Run("success - fetch from cache", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("2", nil)
+		res, err := repo.CountAll(ctx, queryParams)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), res)
+	})
+
+	t.Run("success - fetch from db", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+		mockedDependency.cacheRepo.EXPECT().HashSet(ctx, cacheHash, cacheKey, "2").Times(1).Return(nil)
+
+		res, err := repo.CountAll(ctx, queryParams)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), res)
+	})
+
+	t.Run("failed - fetch from db return error", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(errors.New("db error"))
+
+		res, err := repo.CountAll(ctx, queryParams)
+		assert.Error(t, err)
+		assert.Zero(t, res)
+	})
+}
+
+func TestMemberRepository_Update(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := memberRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	member := testMember
+	cacheKeys := []string{
+		repo.cacheHash(),
+		repo.findByIDCacheKey(member.ID),
+	}
+
+	query := `UPDATE "members" SET "email"=$1,"name"=$2,"updated_at"=$3 WHERE "members"."deleted_at" IS NULL AND "id" = $4`
+	bytes, err := json.Marshal(member)
+	assert.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(query)).WillReturnResult(sqlmock.NewResult(1, 1))
+		mockedDependency.sql.ExpectCommit()
+		mockedDependency.cacheRepo.EXPECT().Delete(ctx, cacheKeys).Times(1).Return(nil)
+		mockedDependency.cacheRepo.EXPECT().Get(ctx, repo.findByIDCacheKey(member.ID)).Times(1).Return(string(bytes), nil)
+
+		res, err := repo.Update(ctx, &member)
+		assert.NoError(t, err)
+		assert.Equal(t, member.Name, res.Name)
+	})
+
+	t.Run("failed - member not found", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(query)).WillReturnResult(sqlmock.NewResult(0, 0))
+		mockedDependency.sql.ExpectRollback()
+
+		res, err := repo.Update(ctx, &member)
+		assert.ErrorIs(t, err, domainerr.ErrNotFound)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - email is taken", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(query)).WillReturnError(&pgconn.PgError{Code: pgUniqueViolationCode})
+		mockedDependency.sql.ExpectRollback()
+
+		res, err := repo.Update(ctx, &member)
+		assert.ErrorIs(t, err, domainerr.ErrConflict)
+		assert.Nil(t, res)
+	})
+}
@@ -0,0 +1,233 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+	"github.com/ssentinull/create-apis-using-golang/internal/utils"
+)
+
+// openLibraryMaxBodySize caps the responses read from Open Library at 1 MiB
+const openLibraryMaxBodySize = 1 << 20
+
+// openLibraryDateLayouts are the publish date formats seen in Open Library
+// editions, a date without a day or month falls on the first of the period
+var openLibraryDateLayouts = []string{
+	utils.DateLayout,
+	"January 2, 2006",
+	"Jan 2, 2006",
+	"2 January 2006",
+	"January 2006",
+	"Jan 2006",
+	"2006",
+}
+
+type openLibraryProvider struct {
+	client    *http.Client
+	baseURL   string
+	cacheRepo model.CacheRepository
+	cacheTTL  time.Duration
+}
+
+// openLibraryKey references another Open Library document, eg: /authors/OL23919A
+type openLibraryKey struct {
+	Key string `json:"key"`
+}
+
+type openLibraryEdition struct {
+	Title       string           `json:"title"`
+	PublishDate string           `json:"publish_date"`
+	Description openLibraryText  `json:"description"`
+	Authors     []openLibraryKey `json:"authors"`
+	Works       []openLibraryKey `json:"works"`
+}
+
+type openLibraryWork struct {
+	Description openLibraryText `json:"description"`
+	Authors     []struct {
+		Author openLibraryKey `json:"author"`
+	} `json:"authors"`
+}
+
+type openLibraryAuthor struct {
+	Name string `json:"name"`
+}
+
+// openLibraryText is either a plain string or a {"type": "/type/text", "value": "..."} object
+type openLibraryText string
+
+func (t *openLibraryText) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		*t = openLibraryText(text)
+		return nil
+	}
+
+	typed := struct {
+		Value string `json:"value"`
+	}{}
+	if err := json.Unmarshal(data, &typed); err != nil {
+		return err
+	}
+
+	*t = openLibraryText(typed.Value)
+	return nil
+}
+
+// NewOpenLibraryMetadataProvider looks the ISBNs up in the Open Library API
+// at baseURL and caches the metadata it finds for cacheTTL, the deadline of
+// a lookup is left to the context
+func NewOpenLibraryMetadataProvider(client *http.Client, baseURL string, cacheRepo model.CacheRepository, cacheTTL time.Duration) model.MetadataProvider {
+	return &openLibraryProvider{
+		client:    client,
+		baseURL:   strings.TrimSuffix(baseURL, "/"),
+		cacheRepo: cacheRepo,
+		cacheTTL:  cacheTTL,
+	}
+}
+
+func (op *openLibraryProvider) FindByISBN(ctx context.Context, isbn13 string) (*model.BookMetadata, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":    utils.Dump(ctx),
+		"isbn13": isbn13,
+	})
+
+	cacheKey := op.findByISBNCacheKey(isbn13)
+	reply, err := op.cacheRepo.Get(ctx, cacheKey)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if reply != "" {
+		metadata := &model.BookMetadata{}
+		if err := json.Unmarshal([]byte(reply), &metadata); err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+		return metadata, nil
+	}
+
+	metadata, err := op.fetch(ctx, isbn13)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	bytes, err := json.Marshal(metadata)
+	if err != nil {
+		logger.Error(err)
+		return metadata, nil
+	}
+
+	if err := op.cacheRepo.SetWithTTL(ctx, cacheKey, string(bytes), op.cacheTTL); err != nil {
+		logger.Error(err)
+	}
+
+	return metadata, nil
+}
+
+// fetch reads the edition of isbn13 and the names of its authors, the work
+// of the edition fills in the authors and description the edition lacks
+func (op *openLibraryProvider) fetch(ctx context.Context, isbn13 string) (*model.BookMetadata, error) {
+	edition := &openLibraryEdition{}
+	if err := op.get(ctx, fmt.Sprintf("/isbn/%s.json", isbn13), edition); err != nil {
+		return nil, err
+	}
+
+	description, authorKeys := edition.Description, edition.Authors
+	if len(edition.Works) > 0 && (description == "" || len(authorKeys) == 0) {
+		work := &openLibraryWork{}
+		if err := op.get(ctx, edition.Works[0].Key+".json", work); err != nil && !errors.Is(err, domainerr.ErrNotFound) {
+			return nil, err
+		}
+
+		if description == "" {
+			description = work.Description
+		}
+		if len(authorKeys) == 0 {
+			for _, author := range work.Authors {
+				authorKeys = append(authorKeys, author.Author)
+			}
+		}
+	}
+
+	names := []string{}
+	for _, authorKey := range authorKeys {
+		author := &openLibraryAuthor{}
+		err := op.get(ctx, authorKey.Key+".json", author)
+		if errors.Is(err, domainerr.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if author.Name != "" {
+			names = append(names, author.Name)
+		}
+	}
+
+	return &model.BookMetadata{
+		ISBN13:        isbn13,
+		Title:         edition.Title,
+		Author:        strings.Join(names, ", "),
+		Description:   string(description),
+		PublishedDate: parseOpenLibraryDate(edition.PublishDate),
+	}, nil
+}
+
+// get decodes the JSON document at path into v, a 404 means Open Library
+// doesn't know the document and any other failure makes it unavailable
+func (op *openLibraryProvider) get(ctx context.Context, path string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, op.baseURL+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	res, err := op.client.Do(req)
+	if err != nil {
+		return domainerr.Unavailable("metadata provider is unavailable", err)
+	}
+	defer res.Body.Close()
+
+	switch {
+	case res.StatusCode == http.StatusNotFound:
+		return domainerr.NotFound(fmt.Sprintf("metadata provider has no %s", path), nil)
+	case res.StatusCode != http.StatusOK:
+		return domainerr.Unavailable("metadata provider is unavailable", fmt.Errorf("GET %s returned %d", path, res.StatusCode))
+	}
+
+	if err := json.NewDecoder(io.LimitReader(res.Body, openLibraryMaxBodySize)).Decode(v); err != nil {
+		return domainerr.Unavailable("metadata provider returned an invalid response", err)
+	}
+
+	return nil
+}
+
+func (op *openLibraryProvider) findByISBNCacheKey(isbn13 string) string {
+	return fmt.Sprintf("metadata:isbn:%s", isbn13)
+}
+
+// parseOpenLibraryDate formats a free form publish date in the utils.DateLayout,
+// a date in none of the known formats is dropped
+func parseOpenLibraryDate(date string) string {
+	date = strings.TrimSpace(date)
+	for _, layout := range openLibraryDateLayouts {
+		if publishedDate, err := time.Parse(layout, date); err == nil {
+			return publishedDate.Format(utils.DateLayout)
+		}
+	}
+
+	return ""
+}
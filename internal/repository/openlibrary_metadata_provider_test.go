@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+// newOpenLibraryServer serves the documents by path and answers 404 to any other path
+func newOpenLibraryServer(documents map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		document, ok := documents[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(document))
+	}))
+}
+
+func TestOpenLibraryProvider_FindByISBN(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	isbn13 := "9780747532699"
+	cacheTTL := time.Hour
+
+	server := newOpenLibraryServer(map[string]string{
+		"/isbn/9780747532699.json": `{"title":"Harry Potter and the Philosopher's Stone","publish_date":"June 26, 1997",` +
+			`"works":[{"key":"/works/OL82563W"}]}`,
+		"/works/OL82563W.json":     `{"description":{"type":"/type/text","value":"A book about wizards"},"authors":[{"author":{"key":"/authors/OL23919A"}}]}`,
+		"/authors/OL23919A.json":   `{"name":"J. K. Rowling"}`,
+		"/isbn/9780441172719.json": `{"title":"Dune","publish_date":"1990","description":"Spice","authors":[{"key":"/authors/OL79034A"},{"key":"/authors/OL404A"}]}`,
+		"/authors/OL79034A.json":   `{"name":"Frank Herbert"}`,
+	})
+	defer server.Close()
+
+	provider := openLibraryProvider{
+		client:    server.Client(),
+		baseURL:   server.URL,
+		cacheRepo: mockedDependency.cacheRepo,
+		cacheTTL:  cacheTTL,
+	}
+
+	cacheKey := provider.findByISBNCacheKey(isbn13)
+
+	t.Run("success - fetch from cache", func(t *testing.T) {
+		bytes, err := json.Marshal(model.BookMetadata{ISBN13: isbn13, Title: "Harry Potter"})
+		assert.NoError(t, err)
+
+		mockedDependency.cacheRepo.EXPECT().Get(ctx, cacheKey).Times(1).Return(string(bytes), nil)
+		res, err := provider.FindByISBN(ctx, isbn13)
+		assert.NoError(t, err)
+		assert.Equal(t, "Harry Potter", res.Title)
+	})
+
+	t.Run("success - work fills in the description and authors", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().Get(ctx, cacheKey).Times(1).Return("", nil)
+		mockedDependency.cacheRepo.EXPECT().SetWithTTL(ctx, cacheKey, gomock.Any(), cacheTTL).Times(1).Return(nil)
+
+		res, err := provider.FindByISBN(ctx, isbn13)
+		assert.NoError(t, err)
+		assert.Equal(t, &model.BookMetadata{
+			ISBN13:        isbn13,
+			Title:         "Harry Potter and the Philosopher's Stone",
+			Author:        "J. K. Rowling",
+			Description:   "A book about wizards",
+			PublishedDate: "1997-06-26",
+		}, res)
+	})
+
+	t.Run("success - unknown author is skipped", func(t *testing.T) {
+		duneCacheKey := provider.findByISBNCacheKey("9780441172719")
+		mockedDependency.cacheRepo.EXPECT().Get(ctx, duneCacheKey).Times(1).Return("", nil)
+		mockedDependency.cacheRepo.EXPECT().SetWithTTL(ctx, duneCacheKey, gomock.Any(), cacheTTL).Times(1).Return(nil)
+
+		res, err := provider.FindByISBN(ctx, "9780441172719")
+		assert.NoError(t, err)
+		assert.Equal(t, "Frank Herbert", res.Author)
+		assert.Equal(t, "Spice", res.Description)
+		assert.Equal(t, "1990-01-01", res.PublishedDate)
+	})
+
+	t.Run("failed - isbn not found", func(t *testing.T) {
+		unknownCacheKey := provider.findByISBNCacheKey("9780000000002")
+		mockedDependency.cacheRepo.EXPECT().Get(ctx, unknownCacheKey).Times(1).Return("", nil)
+
+		res, err := provider.FindByISBN(ctx, "9780000000002")
+		assert.ErrorIs(t, err, domainerr.ErrNotFound)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - provider times out", func(t *testing.T) {
+		slowServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+		}))
+		defer slowServer.Close()
+
+		slowProvider := provider
+		slowProvider.baseURL = slowServer.URL
+
+		timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+
+		mockedDependency.cacheRepo.EXPECT().Get(timeoutCtx, cacheKey).Times(1).Return("", nil)
+
+		res, err := slowProvider.FindByISBN(timeoutCtx, isbn13)
+		assert.ErrorIs(t, err, domainerr.ErrUnavailable)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - provider returns server error", func(t *testing.T) {
+		brokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer brokenServer.Close()
+
+		brokenProvider := provider
+		brokenProvider.baseURL = brokenServer.URL
+
+		mockedDependency.cacheRepo.EXPECT().Get(ctx, cacheKey).Times(1).Return("", nil)
+
+		res, err := brokenProvider.FindByISBN(ctx, isbn13)
+		assert.ErrorIs(t, err, domainerr.ErrUnavailable)
+		assert.Nil(t, res)
+	})
+}
+
+func TestParseOpenLibraryDate(t *testing.T) {
+	assert.Equal(t, "1997-06-26", parseOpenLibraryDate("June 26, 1997"))
+	assert.Equal(t, "1997-06-26", parseOpenLibraryDate("Jun 26, 1997"))
+	assert.Equal(t, "1997-06-01", parseOpenLibraryDate("June 1997"))
+	assert.Equal(t, "1997-01-01", parseOpenLibraryDate(" 1997 "))
+	assert.Equal(t, "", parseOpenLibraryDate("circa 1997"))
+}
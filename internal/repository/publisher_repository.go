@@ -0,0 +1,294 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/sirupsen/logrus"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+	"github.com/ssentinull/create-apis-using-golang/internal/utils"
+	"gorm.io/gorm"
+)
+
+type publisherRepo struct {
+	db        *gorm.DB
+	cacheRepo model.CacheRepository
+}
+
+func NewPublisherRepository(db *gorm.DB, cacheRepo model.CacheRepository) model.PublisherRepository {
+	return &publisherRepo{
+		db:        db,
+		cacheRepo: cacheRepo,
+	}
+}
+
+func (pr *publisherRepo) Create(ctx context.Context, publisher *model.Publisher) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":       utils.Dump(ctx),
+		"publisher": utils.Dump(publisher),
+	})
+
+	err := pr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(publisher).Error; err != nil {
+			return parseDBError(err)
+		}
+		return nil
+	})
+
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if err := pr.cacheRepo.Delete(ctx, pr.cacheHash()); err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (pr *publisherRepo) DeleteByID(ctx context.Context, ID int64) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx": utils.Dump(ctx),
+		"ID":  ID,
+	})
+
+	err := pr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Delete(&model.Publisher{}, ID)
+		if err := res.Error; err != nil {
+			return parseDBError(err)
+		}
+
+		if res.RowsAffected == 0 {
+			return domainerr.NotFound(fmt.Sprintf("publisher %d not found", ID), nil)
+		}
+		return nil
+	})
+
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	cacheKeys := []string{
+		pr.findByIDCacheKey(ID),
+		pr.cacheHash(),
+	}
+
+	if err := pr.cacheRepo.Delete(ctx, cacheKeys...); err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (pr *publisherRepo) FindByID(ctx context.Context, ID int64) (*model.Publisher, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx": utils.Dump(ctx),
+		"ID":  ID,
+	})
+
+	cacheKey := pr.findByIDCacheKey(ID)
+	reply, err := pr.cacheRepo.Get(ctx, cacheKey)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if reply != "" {
+		publisher := &model.Publisher{}
+		if err := json.Unmarshal([]byte(reply), &publisher); err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+		return publisher, nil
+	}
+
+	publisher := &model.Publisher{}
+	err = pr.db.WithContext(ctx).Where("id = ?", ID).Take(publisher).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, parseDBError(err)
+	}
+
+	bytes, err := json.Marshal(publisher)
+	if err != nil {
+		logger.Error(err)
+		return publisher, nil
+	}
+
+	if err := pr.cacheRepo.Set(ctx, cacheKey, string(bytes)); err != nil {
+		logger.Error(err)
+	}
+
+	return publisher, nil
+}
+
+func (pr *publisherRepo) FindAll(ctx context.Context, query model.GetPublishersQueryParams) ([]*model.Publisher, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   utils.Dump(ctx),
+		"query": utils.Dump(query),
+	})
+
+	cacheHash := pr.cacheHash()
+	cacheKey := pr.findAllCacheKey(query)
+	reply, err := pr.cacheRepo.HashGet(ctx, cacheHash, cacheKey)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if reply != "" {
+		publishers := []*model.Publisher{}
+		if err := json.Unmarshal([]byte(reply), &publishers); err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+		return publishers, nil
+	}
+
+	publishers := []*model.Publisher{}
+	err = pr.applyFilters(pr.db.WithContext(ctx), query).
+		Order("name ASC").
+		Order("id ASC").
+		Offset(int(model.Offset(query.Page, query.Size))).
+		Limit(int(query.Size)).
+		Find(&publishers).
+		Error
+	if err != nil {
+		logger.Error(err)
+		return nil, parseDBError(err)
+	}
+
+	bytes, err := json.Marshal(publishers)
+	if err != nil {
+		logger.Error(err)
+		return publishers, nil
+	}
+
+	if err := pr.cacheRepo.HashSet(ctx, cacheHash, cacheKey, string(bytes)); err != nil {
+		logger.Error(err)
+	}
+
+	return publishers, nil
+}
+
+func (pr *publisherRepo) CountAll(ctx context.Context, query model.GetPublishersQueryParams) (int64, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   utils.Dump(ctx),
+		"query": utils.Dump(query),
+	})
+
+	cacheHash := pr.cacheHash()
+	cacheKey := pr.countAllCacheKey(query)
+	reply, err := pr.cacheRepo.HashGet(ctx, cacheHash, cacheKey)
+	if err != nil {
+		logger.Error(err)
+		return 0, err
+	}
+
+	if reply != "" {
+		count := int64(0)
+		if err := json.Unmarshal([]byte(reply), &count); err != nil {
+			logger.Error(err)
+			return 0, err
+		}
+		return count, nil
+	}
+
+	count := int64(0)
+	err = pr.applyFilters(pr.db.WithContext(ctx), query).
+		Model(&model.Publisher{}).
+		Count(&count).
+		Error
+	if err != nil {
+		logger.Error(err)
+		return int64(0), parseDBError(err)
+	}
+
+	bytes, err := json.Marshal(count)
+	if err != nil {
+		logger.Error(err)
+		return 0, err
+	}
+
+	if err := pr.cacheRepo.HashSet(ctx, cacheHash, cacheKey, string(bytes)); err != nil {
+		logger.Error(err)
+	}
+
+	return count, nil
+}
+
+func (pr *publisherRepo) Update(ctx context.Context, publisher *model.Publisher) (*model.Publisher, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":       utils.Dump(ctx),
+		"publisher": utils.Dump(publisher),
+	})
+
+	err := pr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(publisher).Updates(map[string]interface{}{
+			"name": publisher.Name,
+		})
+		if err := res.Error; err != nil {
+			return parseDBError(err)
+		}
+
+		if res.RowsAffected == 0 {
+			return domainerr.NotFound(fmt.Sprintf("publisher %d not found", publisher.ID), nil)
+		}
+		return nil
+	})
+
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	cacheKeys := []string{
+		pr.cacheHash(),
+		pr.findByIDCacheKey(publisher.ID),
+	}
+
+	if err := pr.cacheRepo.Delete(ctx, cacheKeys...); err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return pr.FindByID(ctx, publisher.ID)
+}
+
+func (pr *publisherRepo) cacheHash() string {
+	return "publisher"
+}
+
+func (pr *publisherRepo) findByIDCacheKey(ID int64) string {
+	return fmt.Sprintf("publisher:%d", ID)
+}
+
+func (pr *publisherRepo) findAllCacheKey(query model.GetPublishersQueryParams) string {
+	return fmt.Sprintf("publisher:page:%d:size:%d:%s", query.Page, query.Size, pr.filtersCacheKey(query))
+}
+
+func (pr *publisherRepo) countAllCacheKey(query model.GetPublishersQueryParams) string {
+	return fmt.Sprintf("publisher:count:%s", pr.filtersCacheKey(query))
+}
+
+func (pr *publisherRepo) filtersCacheKey(query model.GetPublishersQueryParams) string {
+	filters := url.Values{}
+	filters.Set("name", query.Name)
+	return filters.Encode()
+}
+
+// applyFilters narrows db down to the publishers matching the filters in query
+func (pr *publisherRepo) applyFilters(db *gorm.DB, query model.GetPublishersQueryParams) *gorm.DB {
+	if query.Name != "" {
+		db = db.Where("name ILIKE ?", "%"+escapeLike(query.Name)+"%")
+	}
+
+	return db
+}
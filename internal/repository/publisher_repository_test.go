@@ -0,0 +1,299 @@
+package repository
+
+import (
+	"encoding/json"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+var testPublisher = model.Publisher{
+	ID:   int64(1),
+	Name: "Bloomsbury",
+}
+
+func TestPublisherRepository_Create(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := publisherRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	publisher := testPublisher
+	query := `INSERT INTO "publishers" ("name","created_at","updated_at","deleted_at","id") VALUES ($1,$2,$3,$4,$5) RETURNING "id"`
+
+	t.Run("success", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(publisher.ID))
+		mockedDependency.sql.ExpectCommit()
+		mockedDependency.cacheRepo.EXPECT().Delete(ctx, repo.cacheHash()).Times(1).Return(nil)
+
+		err := repo.Create(ctx, &publisher)
+		assert.NoError(t, err)
+	})
+
+	t.Run("failed - create publisher in db return error", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(errors.New("db error"))
+		mockedDependency.sql.ExpectRollback()
+
+		err := repo.Create(ctx, &publisher)
+		assert.Error(t, err)
+	})
+
+	t.Run("failed - delete cache return error", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(publisher.ID))
+		mockedDependency.sql.ExpectCommit()
+		mockedDependency.cacheRepo.EXPECT().Delete(ctx, repo.cacheHash()).Times(1).Return(errors.New("cache error"))
+
+		err := repo.Create(ctx, &publisher)
+		assert.Error(t, err)
+	})
+}
+
+func TestPublisherRepository_DeleteByID(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := publisherRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	cacheKeys := []string{
+		repo.findByIDCacheKey(testPublisher.ID),
+		repo.cacheHash(),
+	}
+
+	query := `UPDATE "publishers" SET "deleted_at"=$1 WHERE "publishers"."id" = $2 AND "publishers"."deleted_at" IS NULL`
+
+	t.Run("success", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(query)).WithArgs(sqlmock.AnyArg(), testPublisher.ID).WillReturnResult(sqlmock.NewResult(1, 1))
+		mockedDependency.sql.ExpectCommit()
+		mockedDependency.cacheRepo.EXPECT().Delete(ctx, cacheKeys).Times(1).Return(nil)
+
+		err := repo.DeleteByID(ctx, testPublisher.ID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("failed - publisher not found", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(query)).WillReturnResult(sqlmock.NewResult(0, 0))
+		mockedDependency.sql.ExpectRollback()
+
+		err := repo.DeleteByID(ctx, testPublisher.ID)
+		assert.ErrorIs(t, err, domainerr.ErrNotFound)
+	})
+
+	t.Run("failed - delete cache return error", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(query)).WillReturnResult(sqlmock.NewResult(1, 1))
+		mockedDependency.sql.ExpectCommit()
+		mockedDependency.cacheRepo.EXPECT().Delete(ctx, cacheKeys).Times(1).Return(errors.New("cache error"))
+
+		err := repo.DeleteByID(ctx, testPublisher.ID)
+		assert.Error(t, err)
+	})
+}
+
+func TestPublisherRepository_FindByID(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := publisherRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	query := `SELECT * FROM "publishers" WHERE id = $1 AND "publishers"."deleted_at" IS NULL LIMIT 1`
+
+	cacheKey := repo.findByIDCacheKey(testPublisher.ID)
+	bytes, err := json.Marshal(testPublisher)
+	assert.NoError(t, err)
+
+	t.Run("success - fetch from cache", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().Get(ctx, cacheKey).Times(1).Return(string(bytes), nil)
+		res, err := repo.FindByID(ctx, testPublisher.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, testPublisher.Name, res.Name)
+	})
+
+	t.Run("success - fetch from db", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "name"}).AddRow(testPublisher.ID, testPublisher.Name)
+
+		mockedDependency.cacheRepo.EXPECT().Get(ctx, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(testPublisher.ID).WillReturnRows(rows)
+		mockedDependency.cacheRepo.EXPECT().Set(ctx, cacheKey, gomock.Any()).Times(1).Return(nil)
+
+		res, err := repo.FindByID(ctx, testPublisher.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, testPublisher.Name, res.Name)
+	})
+
+	t.Run("failed - publisher not found", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().Get(ctx, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(gorm.ErrRecordNotFound)
+
+		res, err := repo.FindByID(ctx, testPublisher.ID)
+		assert.ErrorIs(t, err, domainerr.ErrNotFound)
+		assert.Nil(t, res)
+	})
+}
+
+func TestPublisherRepository_FindAll(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := publisherRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	queryParams := model.GetPublishersQueryParams{PageQuery: model.PageQuery{Page: 2, Size: 5}, Name: "bloom"}
+	query := `SELECT * FROM "publishers" WHERE name ILIKE $1 AND "publishers"."deleted_at" IS NULL ORDER BY name ASC,id ASC LIMIT 5 OFFSET 5`
+
+	cacheHash := repo.cacheHash()
+	cacheKey := repo.findAllCacheKey(queryParams)
+	bytes, err := json.Marshal([]*model.Publisher{&testPublisher})
+	assert.NoError(t, err)
+
+	t.Run("success - fetch from cache", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return(string(bytes), nil)
+		res, err := repo.FindAll(ctx, queryParams)
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+	})
+
+	t.Run("success - fetch from db", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "name"}).AddRow(testPublisher.ID, testPublisher.Name)
+
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WithArgs("%bloom%").WillReturnRows(rows)
+		mockedDependency.cacheRepo.EXPECT().HashSet(ctx, cacheHash, cacheKey, gomock.Any()).Times(1).Return(nil)
+
+		res, err := repo.FindAll(ctx, queryParams)
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+	})
+
+	t.Run("failed - fetch from db return error", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(errors.New("db error"))
+
+		res, err := repo.FindAll(ctx, queryParams)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestPublisherRepository_CountAll(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := publisherRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	queryParams := model.GetPublishersQueryParams{PageQuery: model.PageQuery{Page: 1, Size: 5}}
+	query := `SELECT count(*) FROM "publishers" WHERE "publishers"."deleted_at" IS NULL`
+
+	cacheHash := repo.cacheHash()
+	cacheKey := repo.countAllCacheKey(queryParams)
+
+	t.Run("success - fetch from cache", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("2", nil)
+		res, err := repo.CountAll(ctx, queryParams)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), res)
+	})
+
+	t.Run("success - fetch from db", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+		mockedDependency.cacheRepo.EXPECT().HashSet(ctx, cacheHash, cacheKey, "2").Times(1).Return(nil)
+
+		res, err := repo.CountAll(ctx, queryParams)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), res)
+	})
+
+	t.Run("failed - fetch from db return error", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(errors.New("db error"))
+
+		res, err := repo.CountAll(ctx, queryParams)
+		assert.Error(t, err)
+		assert.Zero(t, res)
+	})
+}
+
+func TestPublisherRepository_Update(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := publisherRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	publisher := testPublisher
+	cacheKeys := []string{
+		repo.cacheHash(),
+		repo.findByIDCacheKey(publisher.ID),
+	}
+
+	query := `UPDATE "publishers" SET "name"=$1,"updated_at"=$2 WHERE "publishers"."deleted_at" IS NULL AND "id" = $3`
+	bytes, err := json.Marshal(publisher)
+	assert.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(query)).WillReturnResult(sqlmock.NewResult(1, 1))
+		mockedDependency.sql.ExpectCommit()
+		mockedDependency.cacheRepo.EXPECT().Delete(ctx, cacheKeys).Times(1).Return(nil)
+		mockedDependency.cacheRepo.EXPECT().Get(ctx, repo.findByIDCacheKey(publisher.ID)).Times(1).Return(string(bytes), nil)
+
+		res, err := repo.Update(ctx, &publisher)
+		assert.NoError(t, err)
+		assert.Equal(t, publisher.Name, res.Name)
+	})
+
+	t.Run("failed - publisher not found", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(query)).WillReturnResult(sqlmock.NewResult(0, 0))
+		mockedDependency.sql.ExpectRollback()
+
+		res, err := repo.Update(ctx, &publisher)
+		assert.ErrorIs(t, err, domainerr.ErrNotFound)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - name is taken", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(query)).WillReturnError(&pgconn.PgError{Code: pgUniqueViolationCode})
+		mockedDependency.sql.ExpectRollback()
+
+		res, err := repo.Update(ctx, &publisher)
+		assert.ErrorIs(t, err, domainerr.ErrConflict)
+		assert.Nil(t, res)
+	})
+}
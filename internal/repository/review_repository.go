@@ -0,0 +1,384 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+	"github.com/ssentinull/create-apis-using-golang/internal/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type reviewRepo struct {
+	db        *gorm.DB
+	cacheRepo model.CacheRepository
+}
+
+func NewReviewRepository(db *gorm.DB, cacheRepo model.CacheRepository) model.ReviewRepository {
+	return &reviewRepo{
+		db:        db,
+		cacheRepo: cacheRepo,
+	}
+}
+
+// Create reviews a book, which must not be in the trash
+func (rr *reviewRepo) Create(ctx context.Context, review *model.Review) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":    utils.Dump(ctx),
+		"review": utils.Dump(review),
+	})
+
+	err := rr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		count := int64(0)
+		if err := tx.Model(&model.Book{}).Where("id = ?", review.BookID).Count(&count).Error; err != nil {
+			return parseDBError(err)
+		}
+
+		if count == 0 {
+			return domainerr.NotFound(fmt.Sprintf("book %d not found", review.BookID), nil)
+		}
+
+		if err := tx.Create(review).Error; err != nil {
+			return parseDBError(err)
+		}
+
+		if review.CountsTowardsRating() {
+			return rr.addRating(tx, review.BookID, 1, int64(review.Rating))
+		}
+		return nil
+	})
+
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return rr.deleteCache(ctx, logger, review.BookID)
+}
+
+func (rr *reviewRepo) DeleteByID(ctx context.Context, ID int64) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx": utils.Dump(ctx),
+		"ID":  ID,
+	})
+
+	review := &model.Review{}
+	err := rr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := rr.lock(tx, ID, review); err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&model.Review{}, ID).Error; err != nil {
+			return parseDBError(err)
+		}
+
+		if review.CountsTowardsRating() {
+			return rr.addRating(tx, review.BookID, -1, -int64(review.Rating))
+		}
+		return nil
+	})
+
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return rr.deleteCache(ctx, logger, review.BookID, ID)
+}
+
+func (rr *reviewRepo) FindByID(ctx context.Context, ID int64) (*model.Review, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx": utils.Dump(ctx),
+		"ID":  ID,
+	})
+
+	cacheKey := rr.findByIDCacheKey(ID)
+	reply, err := rr.cacheRepo.Get(ctx, cacheKey)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if reply != "" {
+		review := &model.Review{}
+		if err := json.Unmarshal([]byte(reply), &review); err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+		return review, nil
+	}
+
+	review := &model.Review{}
+	err = rr.db.WithContext(ctx).Where("id = ?", ID).Take(review).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, parseDBError(err)
+	}
+
+	bytes, err := json.Marshal(review)
+	if err != nil {
+		logger.Error(err)
+		return review, nil
+	}
+
+	if err := rr.cacheRepo.Set(ctx, cacheKey, string(bytes)); err != nil {
+		logger.Error(err)
+	}
+
+	return review, nil
+}
+
+// FindAllByBookID returns the newest reviews of a book first
+func (rr *reviewRepo) FindAllByBookID(ctx context.Context, query model.GetReviewsQueryParams) ([]*model.Review, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   utils.Dump(ctx),
+		"query": utils.Dump(query),
+	})
+
+	cacheHash := rr.cacheHash()
+	cacheKey := rr.findAllByBookIDCacheKey(query)
+	reply, err := rr.cacheRepo.HashGet(ctx, cacheHash, cacheKey)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if reply != "" {
+		reviews := []*model.Review{}
+		if err := json.Unmarshal([]byte(reply), &reviews); err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+		return reviews, nil
+	}
+
+	reviews := []*model.Review{}
+	err = rr.applyFilters(rr.db.WithContext(ctx), query).
+		Order("created_at DESC").
+		Order("id DESC").
+		Offset(int(model.Offset(query.Page, query.Size))).
+		Limit(int(query.Size)).
+		Find(&reviews).
+		Error
+	if err != nil {
+		logger.Error(err)
+		return nil, parseDBError(err)
+	}
+
+	bytes, err := json.Marshal(reviews)
+	if err != nil {
+		logger.Error(err)
+		return reviews, nil
+	}
+
+	if err := rr.cacheRepo.HashSet(ctx, cacheHash, cacheKey, string(bytes)); err != nil {
+		logger.Error(err)
+	}
+
+	return reviews, nil
+}
+
+func (rr *reviewRepo) CountAllByBookID(ctx context.Context, query model.GetReviewsQueryParams) (int64, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   utils.Dump(ctx),
+		"query": utils.Dump(query),
+	})
+
+	cacheHash := rr.cacheHash()
+	cacheKey := rr.countAllByBookIDCacheKey(query)
+	reply, err := rr.cacheRepo.HashGet(ctx, cacheHash, cacheKey)
+	if err != nil {
+		logger.Error(err)
+		return 0, err
+	}
+
+	if reply != "" {
+		count := int64(0)
+		if err := json.Unmarshal([]byte(reply), &count); err != nil {
+			logger.Error(err)
+			return 0, err
+		}
+		return count, nil
+	}
+
+	count := int64(0)
+	err = rr.applyFilters(rr.db.WithContext(ctx), query).
+		Model(&model.Review{}).
+		Count(&count).
+		Error
+	if err != nil {
+		logger.Error(err)
+		return int64(0), parseDBError(err)
+	}
+
+	bytes, err := json.Marshal(count)
+	if err != nil {
+		logger.Error(err)
+		return 0, err
+	}
+
+	if err := rr.cacheRepo.HashSet(ctx, cacheHash, cacheKey, string(bytes)); err != nil {
+		logger.Error(err)
+	}
+
+	return count, nil
+}
+
+// Update replaces the rating and the body of a review and sends it back to
+// moderation, taking it out of the rating aggregate if it was approved
+func (rr *reviewRepo) Update(ctx context.Context, input model.UpdateReviewInput) (*model.Review, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   utils.Dump(ctx),
+		"input": utils.Dump(input),
+	})
+
+	review := &model.Review{}
+	err := rr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := rr.lock(tx, input.ID, review); err != nil {
+			return err
+		}
+
+		counted, rating := review.CountsTowardsRating(), int64(review.Rating)
+		err := tx.Model(&model.Review{}).Where("id = ?", input.ID).Updates(map[string]interface{}{
+			"rating":       input.Rating,
+			"body":         input.Body,
+			"status":       model.ReviewStatusPending,
+			"moderated_at": nil,
+		}).Error
+		if err != nil {
+			return parseDBError(err)
+		}
+
+		if counted {
+			return rr.addRating(tx, review.BookID, -1, -rating)
+		}
+		return nil
+	})
+
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if err := rr.deleteCache(ctx, logger, review.BookID, input.ID); err != nil {
+		return nil, err
+	}
+
+	return rr.FindByID(ctx, input.ID)
+}
+
+// UpdateStatus moderates a review, the review enters the rating aggregate
+// when it gets approved and leaves it when it stops being approved
+func (rr *reviewRepo) UpdateStatus(ctx context.Context, ID int64, status string) (*model.Review, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":    utils.Dump(ctx),
+		"ID":     ID,
+		"status": status,
+	})
+
+	review := &model.Review{}
+	err := rr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := rr.lock(tx, ID, review); err != nil {
+			return err
+		}
+
+		counted, approved := review.CountsTowardsRating(), status == model.ReviewStatusApproved
+		err := tx.Model(&model.Review{}).Where("id = ?", ID).Updates(map[string]interface{}{
+			"status":       status,
+			"moderated_at": time.Now(),
+		}).Error
+		if err != nil {
+			return parseDBError(err)
+		}
+
+		switch {
+		case !counted && approved:
+			return rr.addRating(tx, review.BookID, 1, int64(review.Rating))
+		case counted && !approved:
+			return rr.addRating(tx, review.BookID, -1, -int64(review.Rating))
+		}
+		return nil
+	})
+
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if err := rr.deleteCache(ctx, logger, review.BookID, ID); err != nil {
+		return nil, err
+	}
+
+	return rr.FindByID(ctx, ID)
+}
+
+// lock reads the review into dest and locks its row until the end of tx, so
+// that concurrent writes of the review can't count it twice in the aggregate
+func (rr *reviewRepo) lock(tx *gorm.DB, ID int64, dest *model.Review) error {
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", ID).Take(dest).Error
+	if err != nil {
+		return parseDBError(err)
+	}
+	return nil
+}
+
+// addRating adds count ratings summing up to sum to the rating aggregate of
+// a book, the book may be in the trash. The version of the book is bumped,
+// since the aggregate is part of its representation
+func (rr *reviewRepo) addRating(tx *gorm.DB, bookID, count, sum int64) error {
+	err := tx.Exec(`UPDATE "books" SET "ratings_count" = "ratings_count" + ?, "ratings_sum" = "ratings_sum" + ?, `+
+		`"average_rating" = CASE WHEN "ratings_count" + ? > 0 THEN ROUND(("ratings_sum" + ?)::NUMERIC / ("ratings_count" + ?), 2) ELSE 0 END, `+
+		`"version" = "version" + 1 WHERE "id" = ?`, count, sum, count, sum, count, bookID).Error
+	return parseDBError(err)
+}
+
+// deleteCache invalidates the reviews along with the book and its version,
+// whose rating aggregate is cached as part of it
+func (rr *reviewRepo) deleteCache(ctx context.Context, logger *logrus.Entry, bookID int64, IDs ...int64) error {
+	cacheKeys := append([]string{
+		rr.cacheHash(),
+		bookCacheHash,
+	}, bookCacheKeys(bookID)...)
+
+	for _, ID := range IDs {
+		cacheKeys = append(cacheKeys, rr.findByIDCacheKey(ID))
+	}
+
+	if err := rr.cacheRepo.Delete(ctx, cacheKeys...); err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (rr *reviewRepo) cacheHash() string {
+	return "review"
+}
+
+func (rr *reviewRepo) findByIDCacheKey(ID int64) string {
+	return fmt.Sprintf("review:%d", ID)
+}
+
+func (rr *reviewRepo) findAllByBookIDCacheKey(query model.GetReviewsQueryParams) string {
+	return fmt.Sprintf("review:book:%d:page:%d:size:%d:status:%s", query.BookID, query.Page, query.Size, query.Status)
+}
+
+func (rr *reviewRepo) countAllByBookIDCacheKey(query model.GetReviewsQueryParams) string {
+	return fmt.Sprintf("review:book:%d:count:status:%s", query.BookID, query.Status)
+}
+
+// applyFilters narrows db down to the reviews of the book in the status of query
+func (rr *reviewRepo) applyFilters(db *gorm.DB, query model.GetReviewsQueryParams) *gorm.DB {
+	db = db.Where("book_id = ?", query.BookID)
+	if query.Status != model.ReviewStatusAll {
+		db = db.Where("status = ?", query.Status)
+	}
+
+	return db
+}
@@ -0,0 +1,451 @@
+package repository
+
+import (
+	"encoding/json"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+var testReview = model.Review{
+	ID:       int64(1),
+	BookID:   int64(10),
+	Rating:   4,
+	Body:     "A magical read",
+	Reviewer: "hermione",
+	Status:   model.ReviewStatusApproved,
+}
+
+const (
+	testLockReviewQuery = `SELECT * FROM "reviews" WHERE id = $1 LIMIT 1 FOR UPDATE`
+	testAddRatingQuery  = `UPDATE "books" SET "ratings_count" = "ratings_count" + $1, "ratings_sum" = "ratings_sum" + $2, ` +
+		`"average_rating" = CASE WHEN "ratings_count" + $3 > 0 THEN ROUND(("ratings_sum" + $4)::NUMERIC / ("ratings_count" + $5), 2) ELSE 0 END, ` +
+		`"version" = "version" + 1 WHERE "id" = $6`
+)
+
+func testReviewRows(review model.Review) *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"id", "book_id", "rating", "body", "reviewer", "status"}).
+		AddRow(review.ID, review.BookID, review.Rating, review.Body, review.Reviewer, review.Status)
+}
+
+func TestReviewRepository_Create(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := reviewRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	cacheKeys := []string{
+		repo.cacheHash(),
+		bookCacheHash,
+		"book:10",
+		"book:10:version",
+	}
+
+	countQuery := `SELECT count(*) FROM "books" WHERE id = $1 AND "books"."deleted_at" IS NULL`
+	query := `INSERT INTO "reviews" ("book_id","rating","body","reviewer","status","moderated_at","created_at","updated_at","id") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9) RETURNING "id"`
+
+	t.Run("success - pending review leaves the rating alone", func(t *testing.T) {
+		review := testReview
+		review.Status = model.ReviewStatusPending
+
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(countQuery)).WithArgs(review.BookID).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(review.ID))
+		mockedDependency.sql.ExpectCommit()
+		mockedDependency.cacheRepo.EXPECT().Delete(ctx, cacheKeys).Times(1).Return(nil)
+
+		err := repo.Create(ctx, &review)
+		assert.NoError(t, err)
+	})
+
+	t.Run("success - approved review is added to the rating", func(t *testing.T) {
+		review := testReview
+
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(countQuery)).WithArgs(review.BookID).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(review.ID))
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(testAddRatingQuery)).
+			WithArgs(int64(1), int64(4), int64(1), int64(4), int64(1), review.BookID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockedDependency.sql.ExpectCommit()
+		mockedDependency.cacheRepo.EXPECT().Delete(ctx, cacheKeys).Times(1).Return(nil)
+
+		err := repo.Create(ctx, &review)
+		assert.NoError(t, err)
+	})
+
+	t.Run("failed - book does not exist", func(t *testing.T) {
+		review := testReview
+
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(countQuery)).WithArgs(review.BookID).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mockedDependency.sql.ExpectRollback()
+
+		err := repo.Create(ctx, &review)
+		assert.ErrorIs(t, err, domainerr.ErrNotFound)
+	})
+
+	t.Run("failed - reviewer has already reviewed the book", func(t *testing.T) {
+		review := testReview
+
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(countQuery)).WithArgs(review.BookID).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(&pgconn.PgError{Code: pgUniqueViolationCode})
+		mockedDependency.sql.ExpectRollback()
+
+		err := repo.Create(ctx, &review)
+		assert.ErrorIs(t, err, domainerr.ErrConflict)
+	})
+}
+
+func TestReviewRepository_DeleteByID(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := reviewRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	cacheKeys := []string{
+		repo.cacheHash(),
+		bookCacheHash,
+		"book:10",
+		"book:10:version",
+		repo.findByIDCacheKey(testReview.ID),
+	}
+
+	query := `DELETE FROM "reviews" WHERE "reviews"."id" = $1`
+
+	t.Run("success - approved review is taken out of the rating", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testLockReviewQuery)).WithArgs(testReview.ID).WillReturnRows(testReviewRows(testReview))
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(query)).WithArgs(testReview.ID).WillReturnResult(sqlmock.NewResult(0, 1))
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(testAddRatingQuery)).
+			WithArgs(int64(-1), int64(-4), int64(-1), int64(-4), int64(-1), testReview.BookID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockedDependency.sql.ExpectCommit()
+		mockedDependency.cacheRepo.EXPECT().Delete(ctx, cacheKeys).Times(1).Return(nil)
+
+		err := repo.DeleteByID(ctx, testReview.ID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("success - rejected review leaves the rating alone", func(t *testing.T) {
+		review := testReview
+		review.Status = model.ReviewStatusRejected
+
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testLockReviewQuery)).WithArgs(review.ID).WillReturnRows(testReviewRows(review))
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(query)).WithArgs(review.ID).WillReturnResult(sqlmock.NewResult(0, 1))
+		mockedDependency.sql.ExpectCommit()
+		mockedDependency.cacheRepo.EXPECT().Delete(ctx, cacheKeys).Times(1).Return(nil)
+
+		err := repo.DeleteByID(ctx, review.ID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("failed - review not found", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testLockReviewQuery)).WithArgs(testReview.ID).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mockedDependency.sql.ExpectRollback()
+
+		err := repo.DeleteByID(ctx, testReview.ID)
+		assert.ErrorIs(t, err, domainerr.ErrNotFound)
+	})
+}
+
+func TestReviewRepository_FindByID(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := reviewRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	cacheKey := repo.findByIDCacheKey(testReview.ID)
+	query := `SELECT * FROM "reviews" WHERE id = $1 LIMIT 1`
+	bytes, err := json.Marshal(testReview)
+	assert.NoError(t, err)
+
+	t.Run("success - fetch from cache", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().Get(ctx, cacheKey).Times(1).Return(string(bytes), nil)
+		res, err := repo.FindByID(ctx, testReview.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, testReview.Rating, res.Rating)
+	})
+
+	t.Run("success - fetch from db", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().Get(ctx, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(testReview.ID).WillReturnRows(testReviewRows(testReview))
+		mockedDependency.cacheRepo.EXPECT().Set(ctx, cacheKey, gomock.Any()).Times(1).Return(nil)
+
+		res, err := repo.FindByID(ctx, testReview.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, testReview.Reviewer, res.Reviewer)
+	})
+
+	t.Run("failed - review not found", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().Get(ctx, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(testReview.ID).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		res, err := repo.FindByID(ctx, testReview.ID)
+		assert.ErrorIs(t, err, domainerr.ErrNotFound)
+		assert.Nil(t, res)
+	})
+}
+
+func TestReviewRepository_FindAllByBookID(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := reviewRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	queryParams := model.GetReviewsQueryParams{BookID: 10, PageQuery: model.PageQuery{Page: 2, Size: 5}, Status: model.ReviewStatusApproved}
+	query := `SELECT * FROM "reviews" WHERE book_id = $1 AND status = $2 ORDER BY created_at DESC,id DESC LIMIT 5 OFFSET 5`
+
+	cacheHash := repo.cacheHash()
+	cacheKey := repo.findAllByBookIDCacheKey(queryParams)
+	bytes, err := json.Marshal([]*model.Review{&testReview})
+	assert.NoError(t, err)
+
+	t.Run("success - fetch from cache", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return(string(bytes), nil)
+		res, err := repo.FindAllByBookID(ctx, queryParams)
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+	})
+
+	t.Run("success - fetch from db", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(int64(10), model.ReviewStatusApproved).WillReturnRows(testReviewRows(testReview))
+		mockedDependency.cacheRepo.EXPECT().HashSet(ctx, cacheHash, cacheKey, gomock.Any()).Times(1).Return(nil)
+
+		res, err := repo.FindAllByBookID(ctx, queryParams)
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+	})
+
+	t.Run("success - any status", func(t *testing.T) {
+		queryParams := queryParams
+		queryParams.Status = model.ReviewStatusAll
+		query := `SELECT * FROM "reviews" WHERE book_id = $1 ORDER BY created_at DESC,id DESC LIMIT 5 OFFSET 5`
+
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, repo.findAllByBookIDCacheKey(queryParams)).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(int64(10)).WillReturnRows(testReviewRows(testReview))
+		mockedDependency.cacheRepo.EXPECT().HashSet(ctx, cacheHash, repo.findAllByBookIDCacheKey(queryParams), gomock.Any()).Times(1).Return(nil)
+
+		res, err := repo.FindAllByBookID(ctx, queryParams)
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+	})
+
+	t.Run("failed - fetch from db return error", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(errors.New("db error"))
+
+		res, err := repo.FindAllByBookID(ctx, queryParams)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestReviewRepository_CountAllByBookID(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := reviewRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	queryParams := model.GetReviewsQueryParams{BookID: 10, PageQuery: model.PageQuery{Page: 1, Size: 5}, Status: model.ReviewStatusPending}
+	query := `SELECT count(*) FROM "reviews" WHERE book_id = $1 AND status = $2`
+
+	cacheHash := repo.cacheHash()
+	cacheKey := repo.countAllByBookIDCacheKey(queryParams)
+
+	t.Run("success - fetch from cache", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("2", nil)
+		res, err := repo.CountAllByBookID(ctx, queryParams)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), res)
+	})
+
+	t.Run("success - fetch from db", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(int64(10), model.ReviewStatusPending).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+		mockedDependency.cacheRepo.EXPECT().HashSet(ctx, cacheHash, cacheKey, "2").Times(1).Return(nil)
+
+		res, err := repo.CountAllByBookID(ctx, queryParams)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), res)
+	})
+
+	t.Run("failed - fetch from db return error", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(errors.New("db error"))
+
+		res, err := repo.CountAllByBookID(ctx, queryParams)
+		assert.Error(t, err)
+		assert.Zero(t, res)
+	})
+}
+
+func TestReviewRepository_Update(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := reviewRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	input := model.UpdateReviewInput{ID: testReview.ID, Rating: 2, Body: "Not that magical"}
+	cacheKeys := []string{
+		repo.cacheHash(),
+		bookCacheHash,
+		"book:10",
+		"book:10:version",
+		repo.findByIDCacheKey(testReview.ID),
+	}
+
+	query := `UPDATE "reviews" SET "body"=$1,"moderated_at"=$2,"rating"=$3,"status"=$4,"updated_at"=$5 WHERE id = $6`
+	updated := testReview
+	updated.Rating, updated.Body, updated.Status = input.Rating, input.Body, model.ReviewStatusPending
+	bytes, err := json.Marshal(updated)
+	assert.NoError(t, err)
+
+	t.Run("success - approved review is sent back to moderation", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testLockReviewQuery)).WithArgs(testReview.ID).WillReturnRows(testReviewRows(testReview))
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(input.Body, nil, input.Rating, model.ReviewStatusPending, sqlmock.AnyArg(), input.ID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(testAddRatingQuery)).
+			WithArgs(int64(-1), int64(-4), int64(-1), int64(-4), int64(-1), testReview.BookID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockedDependency.sql.ExpectCommit()
+		mockedDependency.cacheRepo.EXPECT().Delete(ctx, cacheKeys).Times(1).Return(nil)
+		mockedDependency.cacheRepo.EXPECT().Get(ctx, repo.findByIDCacheKey(testReview.ID)).Times(1).Return(string(bytes), nil)
+
+		res, err := repo.Update(ctx, input)
+		assert.NoError(t, err)
+		assert.Equal(t, model.ReviewStatusPending, res.Status)
+	})
+
+	t.Run("failed - review not found", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testLockReviewQuery)).WithArgs(testReview.ID).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mockedDependency.sql.ExpectRollback()
+
+		res, err := repo.Update(ctx, input)
+		assert.ErrorIs(t, err, domainerr.ErrNotFound)
+		assert.Nil(t, res)
+	})
+}
+
+func TestReviewRepository_UpdateStatus(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := reviewRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	cacheKeys := []string{
+		repo.cacheHash(),
+		bookCacheHash,
+		"book:10",
+		"book:10:version",
+		repo.findByIDCacheKey(testReview.ID),
+	}
+
+	query := `UPDATE "reviews" SET "moderated_at"=$1,"status"=$2,"updated_at"=$3 WHERE id = $4`
+	bytes, err := json.Marshal(testReview)
+	assert.NoError(t, err)
+
+	t.Run("success - approved review is added to the rating", func(t *testing.T) {
+		pending := testReview
+		pending.Status = model.ReviewStatusPending
+
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testLockReviewQuery)).WithArgs(testReview.ID).WillReturnRows(testReviewRows(pending))
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(sqlmock.AnyArg(), model.ReviewStatusApproved, sqlmock.AnyArg(), testReview.ID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(testAddRatingQuery)).
+			WithArgs(int64(1), int64(4), int64(1), int64(4), int64(1), testReview.BookID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockedDependency.sql.ExpectCommit()
+		mockedDependency.cacheRepo.EXPECT().Delete(ctx, cacheKeys).Times(1).Return(nil)
+		mockedDependency.cacheRepo.EXPECT().Get(ctx, repo.findByIDCacheKey(testReview.ID)).Times(1).Return(string(bytes), nil)
+
+		res, err := repo.UpdateStatus(ctx, testReview.ID, model.ReviewStatusApproved)
+		assert.NoError(t, err)
+		assert.Equal(t, model.ReviewStatusApproved, res.Status)
+	})
+
+	t.Run("success - rejected review is taken out of the rating", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testLockReviewQuery)).WithArgs(testReview.ID).WillReturnRows(testReviewRows(testReview))
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(sqlmock.AnyArg(), model.ReviewStatusRejected, sqlmock.AnyArg(), testReview.ID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(testAddRatingQuery)).
+			WithArgs(int64(-1), int64(-4), int64(-1), int64(-4), int64(-1), testReview.BookID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockedDependency.sql.ExpectCommit()
+		mockedDependency.cacheRepo.EXPECT().Delete(ctx, cacheKeys).Times(1).Return(nil)
+		mockedDependency.cacheRepo.EXPECT().Get(ctx, repo.findByIDCacheKey(testReview.ID)).Times(1).Return(string(bytes), nil)
+
+		_, err := repo.UpdateStatus(ctx, testReview.ID, model.ReviewStatusRejected)
+		assert.NoError(t, err)
+	})
+
+	t.Run("success - approving an approved review leaves the rating alone", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testLockReviewQuery)).WithArgs(testReview.ID).WillReturnRows(testReviewRows(testReview))
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(query)).WillReturnResult(sqlmock.NewResult(0, 1))
+		mockedDependency.sql.ExpectCommit()
+		mockedDependency.cacheRepo.EXPECT().Delete(ctx, cacheKeys).Times(1).Return(nil)
+		mockedDependency.cacheRepo.EXPECT().Get(ctx, repo.findByIDCacheKey(testReview.ID)).Times(1).Return(string(bytes), nil)
+
+		_, err := repo.UpdateStatus(ctx, testReview.ID, model.ReviewStatusApproved)
+		assert.NoError(t, err)
+	})
+
+	t.Run("failed - update rating return error", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(testLockReviewQuery)).WithArgs(testReview.ID).WillReturnRows(testReviewRows(testReview))
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(query)).WillReturnResult(sqlmock.NewResult(0, 1))
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(testAddRatingQuery)).WillReturnError(errors.New("db error"))
+		mockedDependency.sql.ExpectRollback()
+
+		res, err := repo.UpdateStatus(ctx, testReview.ID, model.ReviewStatusPending)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
@@ -0,0 +1,193 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+)
+
+const (
+	// s3MaxBlobSize caps the blobs read back from the bucket at 64 MiB
+	s3MaxBlobSize = 64 << 20
+
+	// s3UnsignedPayload stands in for the payload hash when the payload is left out of the signature
+	s3UnsignedPayload = "UNSIGNED-PAYLOAD"
+)
+
+// S3Config points an S3 blob store at a bucket, Endpoint is the base URL of
+// any S3 compatible service, eg: https://s3.us-east-1.amazonaws.com or a local MinIO.
+// SessionToken is only set for temporary credentials, and UnsignedPayload
+// leaves the payloads out of the signatures for the stores behind TLS
+type S3Config struct {
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKey       string
+	SecretKey       string
+	SessionToken    string
+	UnsignedPayload bool
+}
+
+type s3BlobStore struct {
+	client *http.Client
+	signer *v4.Signer
+	config S3Config
+	now    func() time.Time
+}
+
+// NewS3BlobStore keeps the blobs as objects of an S3 compatible bucket, it
+// addresses the bucket path style and signs the requests with the signature V4
+// signer of the AWS SDK
+func NewS3BlobStore(client *http.Client, config S3Config) model.BlobStore {
+	config.Endpoint = strings.TrimSuffix(config.Endpoint, "/")
+	return &s3BlobStore{client: client, signer: newS3Signer(), config: config, now: time.Now}
+}
+
+// newS3Signer signs the S3 requests, S3 expects the object keys escaped once
+// in the canonical request, unlike the other AWS services
+func newS3Signer() *v4.Signer {
+	return v4.NewSigner(func(options *v4.SignerOptions) {
+		options.DisableURIPathEscaping = true
+	})
+}
+
+func (sb *s3BlobStore) Put(ctx context.Context, key string, blob *model.Blob) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"key":         key,
+		"contentType": blob.ContentType,
+	})
+
+	res, err := sb.do(ctx, http.MethodPut, key, blob.Body, blob.ContentType)
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		err := sb.statusError(http.MethodPut, key, res)
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (sb *s3BlobStore) Get(ctx context.Context, key string) (*model.Blob, error) {
+	logger := logrus.WithField("key", key)
+
+	res, err := sb.do(ctx, http.MethodGet, key, nil, "")
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, domainerr.NotFound(fmt.Sprintf("blob %s not found", key), nil)
+	default:
+		err := sb.statusError(http.MethodGet, key, res)
+		logger.Error(err)
+		return nil, err
+	}
+
+	body, err := io.ReadAll(io.LimitReader(res.Body, s3MaxBlobSize))
+	if err != nil {
+		logger.Error(err)
+		return nil, domainerr.Unavailable("blob store is unavailable", err)
+	}
+
+	return &model.Blob{ContentType: res.Header.Get("Content-Type"), Body: body}, nil
+}
+
+// Delete deletes the objects one by one, deleting a missing object is not an error
+func (sb *s3BlobStore) Delete(ctx context.Context, keys ...string) error {
+	for _, key := range keys {
+		res, err := sb.do(ctx, http.MethodDelete, key, nil, "")
+		if err != nil {
+			logrus.WithField("key", key).Error(err)
+			return err
+		}
+		res.Body.Close()
+
+		switch res.StatusCode {
+		case http.StatusOK, http.StatusNoContent, http.StatusNotFound:
+		default:
+			err := sb.statusError(http.MethodDelete, key, res)
+			logrus.WithField("key", key).Error(err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (sb *s3BlobStore) do(ctx context.Context, method, key string, body []byte, contentType string) (*http.Response, error) {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+
+	objectURL := fmt.Sprintf("%s/%s/%s", sb.config.Endpoint, url.PathEscape(sb.config.Bucket), strings.Join(segments, "/"))
+	req, err := http.NewRequestWithContext(ctx, method, objectURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	payloadHash := s3UnsignedPayload
+	if !sb.config.UnsignedPayload {
+		payloadHash = sha256Hex(body)
+	}
+	if err := sb.sign(ctx, req, payloadHash); err != nil {
+		return nil, err
+	}
+
+	res, err := sb.client.Do(req)
+	if err != nil {
+		return nil, domainerr.Unavailable("blob store is unavailable", err)
+	}
+	return res, nil
+}
+
+// sign adds the payload hash, the date, the session token if any and the
+// signature V4 Authorization header to req
+func (sb *s3BlobStore) sign(ctx context.Context, req *http.Request, payloadHash string) error {
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	credentials := aws.Credentials{
+		AccessKeyID:     sb.config.AccessKey,
+		SecretAccessKey: sb.config.SecretKey,
+		SessionToken:    sb.config.SessionToken,
+	}
+	return sb.signer.SignHTTP(ctx, credentials, req, payloadHash, "s3", sb.config.Region, sb.now())
+}
+
+func (sb *s3BlobStore) statusError(method, key string, res *http.Response) error {
+	message, _ := io.ReadAll(io.LimitReader(res.Body, 1<<10))
+	err := fmt.Errorf("%s %s returned %d: %s", method, key, res.StatusCode, message)
+	return domainerr.Unavailable("blob store is unavailable", err)
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,295 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/sirupsen/logrus"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+	"github.com/ssentinull/create-apis-using-golang/internal/utils"
+	"gorm.io/gorm"
+)
+
+type seriesRepo struct {
+	db        *gorm.DB
+	cacheRepo model.CacheRepository
+}
+
+func NewSeriesRepository(db *gorm.DB, cacheRepo model.CacheRepository) model.SeriesRepository {
+	return &seriesRepo{
+		db:        db,
+		cacheRepo: cacheRepo,
+	}
+}
+
+func (sr *seriesRepo) Create(ctx context.Context, series *model.Series) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":    utils.Dump(ctx),
+		"series": utils.Dump(series),
+	})
+
+	err := sr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(series).Error; err != nil {
+			return parseDBError(err)
+		}
+		return nil
+	})
+
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	if err := sr.cacheRepo.Delete(ctx, sr.cacheHash()); err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (sr *seriesRepo) DeleteByID(ctx context.Context, ID int64) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx": utils.Dump(ctx),
+		"ID":  ID,
+	})
+
+	err := sr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Delete(&model.Series{}, ID)
+		if err := res.Error; err != nil {
+			return parseDBError(err)
+		}
+
+		if res.RowsAffected == 0 {
+			return domainerr.NotFound(fmt.Sprintf("series %d not found", ID), nil)
+		}
+		return nil
+	})
+
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	cacheKeys := []string{
+		sr.findByIDCacheKey(ID),
+		sr.cacheHash(),
+	}
+
+	if err := sr.cacheRepo.Delete(ctx, cacheKeys...); err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (sr *seriesRepo) FindByID(ctx context.Context, ID int64) (*model.Series, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx": utils.Dump(ctx),
+		"ID":  ID,
+	})
+
+	cacheKey := sr.findByIDCacheKey(ID)
+	reply, err := sr.cacheRepo.Get(ctx, cacheKey)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if reply != "" {
+		series := &model.Series{}
+		if err := json.Unmarshal([]byte(reply), &series); err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+		return series, nil
+	}
+
+	series := &model.Series{}
+	err = sr.db.WithContext(ctx).Where("id = ?", ID).Take(series).Error
+	if err != nil {
+		logger.Error(err)
+		return nil, parseDBError(err)
+	}
+
+	bytes, err := json.Marshal(series)
+	if err != nil {
+		logger.Error(err)
+		return series, nil
+	}
+
+	if err := sr.cacheRepo.Set(ctx, cacheKey, string(bytes)); err != nil {
+		logger.Error(err)
+	}
+
+	return series, nil
+}
+
+func (sr *seriesRepo) FindAll(ctx context.Context, query model.GetSeriesQueryParams) ([]*model.Series, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   utils.Dump(ctx),
+		"query": utils.Dump(query),
+	})
+
+	cacheHash := sr.cacheHash()
+	cacheKey := sr.findAllCacheKey(query)
+	reply, err := sr.cacheRepo.HashGet(ctx, cacheHash, cacheKey)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if reply != "" {
+		series := []*model.Series{}
+		if err := json.Unmarshal([]byte(reply), &series); err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+		return series, nil
+	}
+
+	series := []*model.Series{}
+	err = sr.applyFilters(sr.db.WithContext(ctx), query).
+		Order("name ASC").
+		Order("id ASC").
+		Offset(int(model.Offset(query.Page, query.Size))).
+		Limit(int(query.Size)).
+		Find(&series).
+		Error
+	if err != nil {
+		logger.Error(err)
+		return nil, parseDBError(err)
+	}
+
+	bytes, err := json.Marshal(series)
+	if err != nil {
+		logger.Error(err)
+		return series, nil
+	}
+
+	if err := sr.cacheRepo.HashSet(ctx, cacheHash, cacheKey, string(bytes)); err != nil {
+		logger.Error(err)
+	}
+
+	return series, nil
+}
+
+func (sr *seriesRepo) CountAll(ctx context.Context, query model.GetSeriesQueryParams) (int64, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   utils.Dump(ctx),
+		"query": utils.Dump(query),
+	})
+
+	cacheHash := sr.cacheHash()
+	cacheKey := sr.countAllCacheKey(query)
+	reply, err := sr.cacheRepo.HashGet(ctx, cacheHash, cacheKey)
+	if err != nil {
+		logger.Error(err)
+		return 0, err
+	}
+
+	if reply != "" {
+		count := int64(0)
+		if err := json.Unmarshal([]byte(reply), &count); err != nil {
+			logger.Error(err)
+			return 0, err
+		}
+		return count, nil
+	}
+
+	count := int64(0)
+	err = sr.applyFilters(sr.db.WithContext(ctx), query).
+		Model(&model.Series{}).
+		Count(&count).
+		Error
+	if err != nil {
+		logger.Error(err)
+		return int64(0), parseDBError(err)
+	}
+
+	bytes, err := json.Marshal(count)
+	if err != nil {
+		logger.Error(err)
+		return 0, err
+	}
+
+	if err := sr.cacheRepo.HashSet(ctx, cacheHash, cacheKey, string(bytes)); err != nil {
+		logger.Error(err)
+	}
+
+	return count, nil
+}
+
+func (sr *seriesRepo) Update(ctx context.Context, series *model.Series) (*model.Series, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":    utils.Dump(ctx),
+		"series": utils.Dump(series),
+	})
+
+	err := sr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(series).Updates(map[string]interface{}{
+			"name":        series.Name,
+			"description": series.Description,
+		})
+		if err := res.Error; err != nil {
+			return parseDBError(err)
+		}
+
+		if res.RowsAffected == 0 {
+			return domainerr.NotFound(fmt.Sprintf("series %d not found", series.ID), nil)
+		}
+		return nil
+	})
+
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	cacheKeys := []string{
+		sr.cacheHash(),
+		sr.findByIDCacheKey(series.ID),
+	}
+
+	if err := sr.cacheRepo.Delete(ctx, cacheKeys...); err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return sr.FindByID(ctx, series.ID)
+}
+
+func (sr *seriesRepo) cacheHash() string {
+	return "series"
+}
+
+func (sr *seriesRepo) findByIDCacheKey(ID int64) string {
+	return fmt.Sprintf("series:%d", ID)
+}
+
+func (sr *seriesRepo) findAllCacheKey(query model.GetSeriesQueryParams) string {
+	return fmt.Sprintf("series:page:%d:size:%d:%s", query.Page, query.Size, sr.filtersCacheKey(query))
+}
+
+func (sr *seriesRepo) countAllCacheKey(query model.GetSeriesQueryParams) string {
+	return fmt.Sprintf("series:count:%s", sr.filtersCacheKey(query))
+}
+
+func (sr *seriesRepo) filtersCacheKey(query model.GetSeriesQueryParams) string {
+	filters := url.Values{}
+	filters.Set("name", query.Name)
+	return filters.Encode()
+}
+
+// applyFilters narrows db down to the series matching the filters in query
+func (sr *seriesRepo) applyFilters(db *gorm.DB, query model.GetSeriesQueryParams) *gorm.DB {
+	if query.Name != "" {
+		db = db.Where("name ILIKE ?", "%"+escapeLike(query.Name)+"%")
+	}
+
+	return db
+}
@@ -0,0 +1,310 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+	"github.com/ssentinull/create-apis-using-golang/internal/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type tagRepo struct {
+	db        *gorm.DB
+	cacheRepo model.CacheRepository
+}
+
+func NewTagRepository(db *gorm.DB, cacheRepo model.CacheRepository) model.TagRepository {
+	return &tagRepo{
+		db:        db,
+		cacheRepo: cacheRepo,
+	}
+}
+
+func (tr *tagRepo) FindAll(ctx context.Context, query model.GetTagsQueryParams) ([]*model.Tag, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   utils.Dump(ctx),
+		"query": utils.Dump(query),
+	})
+
+	cacheHash := tr.cacheHash()
+	cacheKey := tr.findAllCacheKey(query)
+	reply, err := tr.cacheRepo.HashGet(ctx, cacheHash, cacheKey)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if reply != "" {
+		tags := []*model.Tag{}
+		if err := json.Unmarshal([]byte(reply), &tags); err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+		return tags, nil
+	}
+
+	tags := []*model.Tag{}
+	err = tr.applyFilters(tr.db.WithContext(ctx), query).
+		Order("name ASC").
+		Offset(int(model.Offset(query.Page, query.Size))).
+		Limit(int(query.Size)).
+		Find(&tags).
+		Error
+	if err != nil {
+		logger.Error(err)
+		return nil, parseDBError(err)
+	}
+
+	bytes, err := json.Marshal(tags)
+	if err != nil {
+		logger.Error(err)
+		return tags, nil
+	}
+
+	if err := tr.cacheRepo.HashSet(ctx, cacheHash, cacheKey, string(bytes)); err != nil {
+		logger.Error(err)
+	}
+
+	return tags, nil
+}
+
+func (tr *tagRepo) CountAll(ctx context.Context, query model.GetTagsQueryParams) (int64, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   utils.Dump(ctx),
+		"query": utils.Dump(query),
+	})
+
+	cacheHash := tr.cacheHash()
+	cacheKey := tr.countAllCacheKey(query)
+	reply, err := tr.cacheRepo.HashGet(ctx, cacheHash, cacheKey)
+	if err != nil {
+		logger.Error(err)
+		return 0, err
+	}
+
+	if reply != "" {
+		count := int64(0)
+		if err := json.Unmarshal([]byte(reply), &count); err != nil {
+			logger.Error(err)
+			return 0, err
+		}
+		return count, nil
+	}
+
+	count := int64(0)
+	err = tr.applyFilters(tr.db.WithContext(ctx), query).
+		Model(&model.Tag{}).
+		Count(&count).
+		Error
+	if err != nil {
+		logger.Error(err)
+		return int64(0), parseDBError(err)
+	}
+
+	bytes, err := json.Marshal(count)
+	if err != nil {
+		logger.Error(err)
+		return 0, err
+	}
+
+	if err := tr.cacheRepo.HashSet(ctx, cacheHash, cacheKey, string(bytes)); err != nil {
+		logger.Error(err)
+	}
+
+	return count, nil
+}
+
+func (tr *tagRepo) FindAllByBookID(ctx context.Context, bookID int64) ([]*model.Tag, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":    utils.Dump(ctx),
+		"bookID": bookID,
+	})
+
+	cacheHash := tr.cacheHash()
+	cacheKey := tr.findAllByBookIDCacheKey(bookID)
+	reply, err := tr.cacheRepo.HashGet(ctx, cacheHash, cacheKey)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if reply != "" {
+		tags := []*model.Tag{}
+		if err := json.Unmarshal([]byte(reply), &tags); err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+		return tags, nil
+	}
+
+	tags := []*model.Tag{}
+	err = tr.db.WithContext(ctx).
+		Joins("JOIN book_tags ON book_tags.tag_id = tags.id").
+		Where("book_tags.book_id = ?", bookID).
+		Order("tags.name ASC").
+		Find(&tags).
+		Error
+	if err != nil {
+		logger.Error(err)
+		return nil, parseDBError(err)
+	}
+
+	bytes, err := json.Marshal(tags)
+	if err != nil {
+		logger.Error(err)
+		return tags, nil
+	}
+
+	if err := tr.cacheRepo.HashSet(ctx, cacheHash, cacheKey, string(bytes)); err != nil {
+		logger.Error(err)
+	}
+
+	return tags, nil
+}
+
+// AddBookTags tags the book with the given normalized names, creating the
+// tags that don't exist yet. Tags the book already has are left as they are
+func (tr *tagRepo) AddBookTags(ctx context.Context, bookID int64, names []string) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":    utils.Dump(ctx),
+		"bookID": bookID,
+		"names":  names,
+	})
+
+	err := tr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tr.checkBook(tx, bookID); err != nil {
+			return err
+		}
+
+		tags := make([]*model.Tag, 0, len(names))
+		for _, name := range names {
+			tags = append(tags, &model.Tag{ID: utils.GenerateID(), Name: name, CreatedAt: time.Now()})
+		}
+
+		err := tx.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "name"}}, DoNothing: true}).Create(tags).Error
+		if err != nil {
+			return parseDBError(err)
+		}
+
+		tagIDs := []int64{}
+		if err := tx.Model(&model.Tag{}).Where("name IN ?", names).Pluck("id", &tagIDs).Error; err != nil {
+			return parseDBError(err)
+		}
+
+		links := make([]*model.BookTag, 0, len(tagIDs))
+		for _, tagID := range tagIDs {
+			links = append(links, &model.BookTag{BookID: bookID, TagID: tagID})
+		}
+
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(links).Error; err != nil {
+			return parseDBError(err)
+		}
+		return nil
+	})
+
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return tr.deleteBookTagsCache(ctx, logger)
+}
+
+// RemoveBookTags untags the book from the given normalized names, the names
+// the book isn't tagged with are ignored
+func (tr *tagRepo) RemoveBookTags(ctx context.Context, bookID int64, names []string) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":    utils.Dump(ctx),
+		"bookID": bookID,
+		"names":  names,
+	})
+
+	err := tr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tr.checkBook(tx, bookID); err != nil {
+			return err
+		}
+
+		err := tx.Where("book_id = ? AND tag_id IN (SELECT id FROM tags WHERE name IN ?)", bookID, names).
+			Delete(&model.BookTag{}).
+			Error
+		if err != nil {
+			return parseDBError(err)
+		}
+		return nil
+	})
+
+	if err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return tr.deleteBookTagsCache(ctx, logger)
+}
+
+// checkBook checks that the book exists
+func (tr *tagRepo) checkBook(tx *gorm.DB, bookID int64) error {
+	count := int64(0)
+	if err := tx.Model(&model.Book{}).Where("id = ?", bookID).Count(&count).Error; err != nil {
+		return parseDBError(err)
+	}
+
+	if count == 0 {
+		return domainerr.NotFound(fmt.Sprintf("book %d not found", bookID), nil)
+	}
+	return nil
+}
+
+// deleteBookTagsCache invalidates the tags along with the book listings,
+// which are filtered by tags
+func (tr *tagRepo) deleteBookTagsCache(ctx context.Context, logger *logrus.Entry) error {
+	cacheKeys := []string{
+		tr.cacheHash(),
+		bookCacheHash,
+	}
+
+	if err := tr.cacheRepo.Delete(ctx, cacheKeys...); err != nil {
+		logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (tr *tagRepo) cacheHash() string {
+	return "tag"
+}
+
+func (tr *tagRepo) findAllCacheKey(query model.GetTagsQueryParams) string {
+	return fmt.Sprintf("tag:page:%d:size:%d:%s", query.Page, query.Size, tr.filtersCacheKey(query))
+}
+
+func (tr *tagRepo) countAllCacheKey(query model.GetTagsQueryParams) string {
+	return fmt.Sprintf("tag:count:%s", tr.filtersCacheKey(query))
+}
+
+func (tr *tagRepo) findAllByBookIDCacheKey(bookID int64) string {
+	return fmt.Sprintf("tag:book:%d", bookID)
+}
+
+func (tr *tagRepo) filtersCacheKey(query model.GetTagsQueryParams) string {
+	filters := url.Values{}
+	filters.Set("name", model.NormalizeTagName(query.Name))
+	return filters.Encode()
+}
+
+// applyFilters narrows db down to the tags matching the filters in query,
+// the name filter matches the beginning of the tag names
+func (tr *tagRepo) applyFilters(db *gorm.DB, query model.GetTagsQueryParams) *gorm.DB {
+	if name := model.NormalizeTagName(query.Name); name != "" {
+		db = db.Where("name LIKE ?", escapeLike(name)+"%")
+	}
+
+	return db
+}
@@ -0,0 +1,226 @@
+package repository
+
+import (
+	"encoding/json"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+var testTag = model.Tag{
+	ID:   int64(1),
+	Name: "magic",
+}
+
+func TestTagRepository_FindAll(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := tagRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	queryParams := model.GetTagsQueryParams{PageQuery: model.PageQuery{Page: 2, Size: 5}, Name: " Mag"}
+	query := `SELECT * FROM "tags" WHERE name LIKE $1 ORDER BY name ASC LIMIT 5 OFFSET 5`
+
+	cacheHash := repo.cacheHash()
+	cacheKey := repo.findAllCacheKey(queryParams)
+	bytes, err := json.Marshal([]*model.Tag{&testTag})
+	assert.NoError(t, err)
+
+	t.Run("success - fetch from cache", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return(string(bytes), nil)
+		res, err := repo.FindAll(ctx, queryParams)
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+	})
+
+	t.Run("success - fetch from db", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "name"}).AddRow(testTag.ID, testTag.Name)
+
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WithArgs("mag%").WillReturnRows(rows)
+		mockedDependency.cacheRepo.EXPECT().HashSet(ctx, cacheHash, cacheKey, gomock.Any()).Times(1).Return(nil)
+
+		res, err := repo.FindAll(ctx, queryParams)
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+	})
+
+	t.Run("failed - fetch from db return error", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(errors.New("db error"))
+
+		res, err := repo.FindAll(ctx, queryParams)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestTagRepository_CountAll(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := tagRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	queryParams := model.GetTagsQueryParams{PageQuery: model.PageQuery{Page: 1, Size: 5}}
+	query := `SELECT count(*) FROM "tags"`
+
+	cacheHash := repo.cacheHash()
+	cacheKey := repo.countAllCacheKey(queryParams)
+
+	t.Run("success - fetch from cache", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("2", nil)
+		res, err := repo.CountAll(ctx, queryParams)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), res)
+	})
+
+	t.Run("success - fetch from db", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+		mockedDependency.cacheRepo.EXPECT().HashSet(ctx, cacheHash, cacheKey, "2").Times(1).Return(nil)
+
+		res, err := repo.CountAll(ctx, queryParams)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), res)
+	})
+}
+
+func TestTagRepository_FindAllByBookID(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := tagRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	bookID := int64(7)
+	query := `SELECT "tags"."id","tags"."name","tags"."created_at" FROM "tags" JOIN book_tags ON book_tags.tag_id = tags.id WHERE book_tags.book_id = $1 ORDER BY tags.name ASC`
+
+	cacheHash := repo.cacheHash()
+	cacheKey := repo.findAllByBookIDCacheKey(bookID)
+
+	t.Run("success - fetch from db", func(t *testing.T) {
+		rows := sqlmock.NewRows([]string{"id", "name"}).AddRow(testTag.ID, testTag.Name)
+
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(bookID).WillReturnRows(rows)
+		mockedDependency.cacheRepo.EXPECT().HashSet(ctx, cacheHash, cacheKey, gomock.Any()).Times(1).Return(nil)
+
+		res, err := repo.FindAllByBookID(ctx, bookID)
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+	})
+
+	t.Run("failed - fetch from db return error", func(t *testing.T) {
+		mockedDependency.cacheRepo.EXPECT().HashGet(ctx, cacheHash, cacheKey).Times(1).Return("", nil)
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(errors.New("db error"))
+
+		res, err := repo.FindAllByBookID(ctx, bookID)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestTagRepository_AddBookTags(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := tagRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	bookID := int64(7)
+	names := []string{"magic", "school"}
+	cacheKeys := []string{
+		repo.cacheHash(),
+		bookCacheHash,
+	}
+
+	bookQuery := `SELECT count(*) FROM "books" WHERE id = $1 AND "books"."deleted_at" IS NULL`
+	tagsQuery := `INSERT INTO "tags" ("name","created_at","id") VALUES ($1,$2,$3),($4,$5,$6) ON CONFLICT ("name") DO NOTHING RETURNING "id"`
+	tagIDsQuery := `SELECT "id" FROM "tags" WHERE name IN ($1,$2)`
+	linksQuery := `INSERT INTO "book_tags" ("book_id","tag_id") VALUES ($1,$2),($3,$4) ON CONFLICT DO NOTHING`
+
+	t.Run("success", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(bookQuery)).WithArgs(bookID).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(tagsQuery)).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(tagIDsQuery)).WithArgs("magic", "school").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(linksQuery)).WithArgs(bookID, 1, bookID, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+		mockedDependency.sql.ExpectCommit()
+		mockedDependency.cacheRepo.EXPECT().Delete(ctx, cacheKeys).Times(1).Return(nil)
+
+		err := repo.AddBookTags(ctx, bookID, names)
+		assert.NoError(t, err)
+	})
+
+	t.Run("failed - book not found", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(bookQuery)).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mockedDependency.sql.ExpectRollback()
+
+		err := repo.AddBookTags(ctx, bookID, names)
+		assert.ErrorIs(t, err, domainerr.ErrNotFound)
+	})
+}
+
+func TestTagRepository_RemoveBookTags(t *testing.T) {
+	mockedDependency := newMockedDependency(t)
+	defer mockedDependency.close()
+
+	ctx := mockedDependency.ctx
+	repo := tagRepo{
+		db:        mockedDependency.db,
+		cacheRepo: mockedDependency.cacheRepo,
+	}
+
+	bookID := int64(7)
+	names := []string{"magic"}
+	cacheKeys := []string{
+		repo.cacheHash(),
+		bookCacheHash,
+	}
+
+	bookQuery := `SELECT count(*) FROM "books" WHERE id = $1 AND "books"."deleted_at" IS NULL`
+	query := `DELETE FROM "book_tags" WHERE book_id = $1 AND tag_id IN (SELECT id FROM tags WHERE name IN ($2))`
+
+	t.Run("success", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(bookQuery)).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(query)).WithArgs(bookID, "magic").WillReturnResult(sqlmock.NewResult(0, 1))
+		mockedDependency.sql.ExpectCommit()
+		mockedDependency.cacheRepo.EXPECT().Delete(ctx, cacheKeys).Times(1).Return(nil)
+
+		err := repo.RemoveBookTags(ctx, bookID, names)
+		assert.NoError(t, err)
+	})
+
+	t.Run("failed - delete cache return error", func(t *testing.T) {
+		mockedDependency.sql.ExpectBegin()
+		mockedDependency.sql.ExpectQuery(regexp.QuoteMeta(bookQuery)).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mockedDependency.sql.ExpectExec(regexp.QuoteMeta(query)).WillReturnResult(sqlmock.NewResult(0, 1))
+		mockedDependency.sql.ExpectCommit()
+		mockedDependency.cacheRepo.EXPECT().Delete(ctx, cacheKeys).Times(1).Return(errors.New("cache error"))
+
+		err := repo.RemoveBookTags(ctx, bookID, names)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,162 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	"github.com/ssentinull/create-apis-using-golang/internal/config"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+	"github.com/ssentinull/create-apis-using-golang/internal/utils"
+)
+
+var errInvalidAuthorID = domainerr.Validation("author ID must be a positive number", nil)
+
+type authorUsecase struct {
+	authorRepo model.AuthorRepository
+}
+
+func NewAuthorUsecase(ar model.AuthorRepository) model.AuthorUsecase {
+	return &authorUsecase{authorRepo: ar}
+}
+
+func (au *authorUsecase) Create(ctx context.Context, author *model.Author) (*model.Author, error) {
+	if err := utils.ValidateStruct(author); err != nil {
+		return nil, err
+	}
+
+	if err := au.authorRepo.Create(ctx, author); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":    utils.Dump(ctx),
+			"author": utils.Dump(author),
+		}).Error(err)
+		return nil, err
+	}
+
+	return author, nil
+}
+
+func (au *authorUsecase) DeleteByID(ctx context.Context, ID int64) error {
+	if ID <= 0 {
+		return errInvalidAuthorID
+	}
+
+	if err := au.authorRepo.DeleteByID(ctx, ID); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx": utils.Dump(ctx),
+			"ID":  ID,
+		}).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (au *authorUsecase) FindByID(ctx context.Context, ID int64) (*model.Author, error) {
+	if ID <= 0 {
+		return nil, errInvalidAuthorID
+	}
+
+	author, err := au.authorRepo.FindByID(ctx, ID)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx": utils.Dump(ctx),
+			"ID":  ID,
+		}).Error(err)
+		return nil, err
+	}
+
+	return author, nil
+}
+
+func (au *authorUsecase) FindAll(ctx context.Context, params model.GetAuthorsQueryParams) ([]*model.Author, int64, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":    utils.Dump(ctx),
+		"params": utils.Dump(params),
+	})
+
+	params.Normalize(config.PaginationDefaultSize(), config.PaginationMaxSize())
+	if err := utils.ValidateStruct(params); err != nil {
+		return nil, int64(0), err
+	}
+
+	authors, err := au.authorRepo.FindAll(ctx, params)
+	if err != nil {
+		logger.Error(err)
+		return nil, int64(0), err
+	}
+
+	count, err := au.authorRepo.CountAll(ctx, params)
+	if err != nil {
+		logger.Error(err)
+		return nil, int64(0), err
+	}
+
+	return authors, count, nil
+}
+
+func (au *authorUsecase) FindAllByBookID(ctx context.Context, bookID int64) ([]*model.Author, error) {
+	if bookID <= 0 {
+		return nil, errInvalidBookID
+	}
+
+	authors, err := au.authorRepo.FindAllByBookID(ctx, bookID)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":    utils.Dump(ctx),
+			"bookID": bookID,
+		}).Error(err)
+		return nil, err
+	}
+
+	return authors, nil
+}
+
+func (au *authorUsecase) Update(ctx context.Context, author *model.Author) (*model.Author, error) {
+	if author.ID <= 0 {
+		return nil, errInvalidAuthorID
+	}
+
+	if err := utils.ValidateStruct(author); err != nil {
+		return nil, err
+	}
+
+	updated, err := au.authorRepo.Update(ctx, author)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":    utils.Dump(ctx),
+			"author": utils.Dump(author),
+		}).Error(err)
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+func (au *authorUsecase) ReplaceBookAuthors(ctx context.Context, input model.ReplaceBookAuthorsInput) ([]*model.Author, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   utils.Dump(ctx),
+		"input": utils.Dump(input),
+	})
+
+	if input.BookID <= 0 {
+		return nil, errInvalidBookID
+	}
+
+	if err := utils.ValidateStruct(input); err != nil {
+		return nil, err
+	}
+
+	if err := au.authorRepo.ReplaceBookAuthors(ctx, input.BookID, input.AuthorIDs); err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	authors, err := au.authorRepo.FindAllByBookID(ctx, input.BookID)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return authors, nil
+}
@@ -0,0 +1,259 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+	"github.com/ssentinull/create-apis-using-golang/internal/model/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	authorID = int64(1)
+	author   = &model.Author{
+		ID:   authorID,
+		Name: "J. K. Rowling",
+		Bio:  "British author",
+	}
+	authors = []*model.Author{author}
+)
+
+func TestAuthorUsecase_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedAuthorRepo := mock.NewMockAuthorRepository(ctrl)
+	usecase := authorUsecase{authorRepo: mockedAuthorRepo}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	t.Run("success", func(t *testing.T) {
+		mockedAuthorRepo.EXPECT().Create(ctx, author).Times(1).Return(nil)
+		res, err := usecase.Create(ctx, author)
+		assert.NoError(t, err)
+		assert.Equal(t, author, res)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		mockedAuthorRepo.EXPECT().Create(ctx, author).Times(1).Return(errors.New("db error"))
+		res, err := usecase.Create(ctx, author)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - invalid author", func(t *testing.T) {
+		res, err := usecase.Create(ctx, &model.Author{Name: " "})
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, res)
+	})
+}
+
+func TestAuthorUsecase_DeleteByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedAuthorRepo := mock.NewMockAuthorRepository(ctrl)
+	usecase := authorUsecase{authorRepo: mockedAuthorRepo}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	t.Run("success", func(t *testing.T) {
+		mockedAuthorRepo.EXPECT().DeleteByID(ctx, authorID).Times(1).Return(nil)
+		err := usecase.DeleteByID(ctx, authorID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		mockedAuthorRepo.EXPECT().DeleteByID(ctx, authorID).Times(1).Return(errors.New("db error"))
+		err := usecase.DeleteByID(ctx, authorID)
+		assert.Error(t, err)
+	})
+
+	t.Run("failed - invalid ID", func(t *testing.T) {
+		err := usecase.DeleteByID(ctx, 0)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+	})
+}
+
+func TestAuthorUsecase_FindByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedAuthorRepo := mock.NewMockAuthorRepository(ctrl)
+	usecase := authorUsecase{authorRepo: mockedAuthorRepo}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	t.Run("success", func(t *testing.T) {
+		mockedAuthorRepo.EXPECT().FindByID(ctx, authorID).Times(1).Return(author, nil)
+		res, err := usecase.FindByID(ctx, authorID)
+		assert.NoError(t, err)
+		assert.Equal(t, author, res)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		mockedAuthorRepo.EXPECT().FindByID(ctx, authorID).Times(1).Return(nil, errors.New("db error"))
+		res, err := usecase.FindByID(ctx, authorID)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - invalid ID", func(t *testing.T) {
+		res, err := usecase.FindByID(ctx, -1)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, res)
+	})
+}
+
+func TestAuthorUsecase_FindAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedAuthorRepo := mock.NewMockAuthorRepository(ctrl)
+	usecase := authorUsecase{authorRepo: mockedAuthorRepo}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	params := model.GetAuthorsQueryParams{PageQuery: model.PageQuery{Page: 1, Size: 5}}
+
+	t.Run("success", func(t *testing.T) {
+		mockedAuthorRepo.EXPECT().FindAll(ctx, params).Times(1).Return(authors, nil)
+		mockedAuthorRepo.EXPECT().CountAll(ctx, params).Times(1).Return(int64(1), nil)
+
+		res, count, err := usecase.FindAll(ctx, params)
+		assert.NoError(t, err)
+		assert.Equal(t, authors, res)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("failed - find all return error", func(t *testing.T) {
+		mockedAuthorRepo.EXPECT().FindAll(ctx, params).Times(1).Return(nil, errors.New("db error"))
+
+		res, count, err := usecase.FindAll(ctx, params)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+		assert.Zero(t, count)
+	})
+
+	t.Run("failed - count all return error", func(t *testing.T) {
+		mockedAuthorRepo.EXPECT().FindAll(ctx, params).Times(1).Return(authors, nil)
+		mockedAuthorRepo.EXPECT().CountAll(ctx, params).Times(1).Return(int64(0), errors.New("db error"))
+
+		res, _, err := usecase.FindAll(ctx, params)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestAuthorUsecase_FindAllByBookID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedAuthorRepo := mock.NewMockAuthorRepository(ctrl)
+	usecase := authorUsecase{authorRepo: mockedAuthorRepo}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	t.Run("success", func(t *testing.T) {
+		mockedAuthorRepo.EXPECT().FindAllByBookID(ctx, bookID).Times(1).Return(authors, nil)
+		res, err := usecase.FindAllByBookID(ctx, bookID)
+		assert.NoError(t, err)
+		assert.Equal(t, authors, res)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		mockedAuthorRepo.EXPECT().FindAllByBookID(ctx, bookID).Times(1).Return(nil, errors.New("db error"))
+		res, err := usecase.FindAllByBookID(ctx, bookID)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - invalid book ID", func(t *testing.T) {
+		res, err := usecase.FindAllByBookID(ctx, 0)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, res)
+	})
+}
+
+func TestAuthorUsecase_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedAuthorRepo := mock.NewMockAuthorRepository(ctrl)
+	usecase := authorUsecase{authorRepo: mockedAuthorRepo}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	t.Run("success", func(t *testing.T) {
+		mockedAuthorRepo.EXPECT().Update(ctx, author).Times(1).Return(author, nil)
+		res, err := usecase.Update(ctx, author)
+		assert.NoError(t, err)
+		assert.Equal(t, author, res)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		mockedAuthorRepo.EXPECT().Update(ctx, author).Times(1).Return(nil, errors.New("db error"))
+		res, err := usecase.Update(ctx, author)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - invalid author", func(t *testing.T) {
+		res, err := usecase.Update(ctx, &model.Author{ID: authorID, Name: ""})
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, res)
+	})
+}
+
+func TestAuthorUsecase_ReplaceBookAuthors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedAuthorRepo := mock.NewMockAuthorRepository(ctrl)
+	usecase := authorUsecase{authorRepo: mockedAuthorRepo}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	input := model.ReplaceBookAuthorsInput{BookID: bookID, AuthorIDs: []int64{authorID}}
+
+	t.Run("success", func(t *testing.T) {
+		mockedAuthorRepo.EXPECT().ReplaceBookAuthors(ctx, bookID, input.AuthorIDs).Times(1).Return(nil)
+		mockedAuthorRepo.EXPECT().FindAllByBookID(ctx, bookID).Times(1).Return(authors, nil)
+
+		res, err := usecase.ReplaceBookAuthors(ctx, input)
+		assert.NoError(t, err)
+		assert.Equal(t, authors, res)
+	})
+
+	t.Run("failed - replace return error", func(t *testing.T) {
+		mockedAuthorRepo.EXPECT().ReplaceBookAuthors(ctx, bookID, input.AuthorIDs).Times(1).Return(domainerr.NotFound("author 1 not found", nil))
+
+		res, err := usecase.ReplaceBookAuthors(ctx, input)
+		assert.ErrorIs(t, err, domainerr.ErrNotFound)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - invalid author ID", func(t *testing.T) {
+		res, err := usecase.ReplaceBookAuthors(ctx, model.ReplaceBookAuthorsInput{BookID: bookID, AuthorIDs: []int64{0}})
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, res)
+	})
+}
@@ -0,0 +1,119 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/ssentinull/create-apis-using-golang/internal/config"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+	"github.com/ssentinull/create-apis-using-golang/internal/utils"
+)
+
+var errInvalidBookCopyID = domainerr.Validation("copy ID must be a positive number", nil)
+
+type bookCopyUsecase struct {
+	bookCopyRepo model.BookCopyRepository
+}
+
+func NewBookCopyUsecase(cr model.BookCopyRepository) model.BookCopyUsecase {
+	return &bookCopyUsecase{bookCopyRepo: cr}
+}
+
+func (cu *bookCopyUsecase) Create(ctx context.Context, bookCopy *model.BookCopy) (*model.BookCopy, error) {
+	if err := utils.ValidateStruct(bookCopy); err != nil {
+		return nil, err
+	}
+
+	pickupBy := time.Now().Add(config.HoldPickupWindow())
+	if err := cu.bookCopyRepo.Create(ctx, bookCopy, pickupBy); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":      utils.Dump(ctx),
+			"bookCopy": utils.Dump(bookCopy),
+		}).Error(err)
+		return nil, err
+	}
+
+	return bookCopy, nil
+}
+
+func (cu *bookCopyUsecase) DeleteByID(ctx context.Context, ID int64) error {
+	if ID <= 0 {
+		return errInvalidBookCopyID
+	}
+
+	if err := cu.bookCopyRepo.DeleteByID(ctx, ID); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx": utils.Dump(ctx),
+			"ID":  ID,
+		}).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (cu *bookCopyUsecase) FindByID(ctx context.Context, ID int64) (*model.BookCopy, error) {
+	if ID <= 0 {
+		return nil, errInvalidBookCopyID
+	}
+
+	bookCopy, err := cu.bookCopyRepo.FindByID(ctx, ID)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx": utils.Dump(ctx),
+			"ID":  ID,
+		}).Error(err)
+		return nil, err
+	}
+
+	return bookCopy, nil
+}
+
+func (cu *bookCopyUsecase) FindAllByBookID(ctx context.Context, params model.GetBookCopiesQueryParams) ([]*model.BookCopy, int64, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":    utils.Dump(ctx),
+		"params": utils.Dump(params),
+	})
+
+	params.Normalize(config.PaginationDefaultSize(), config.PaginationMaxSize())
+	if err := utils.ValidateStruct(params); err != nil {
+		return nil, int64(0), err
+	}
+
+	bookCopies, err := cu.bookCopyRepo.FindAllByBookID(ctx, params)
+	if err != nil {
+		logger.Error(err)
+		return nil, int64(0), err
+	}
+
+	count, err := cu.bookCopyRepo.CountAllByBookID(ctx, params)
+	if err != nil {
+		logger.Error(err)
+		return nil, int64(0), err
+	}
+
+	return bookCopies, count, nil
+}
+
+func (cu *bookCopyUsecase) Update(ctx context.Context, input model.UpdateBookCopyInput) (*model.BookCopy, error) {
+	if input.ID <= 0 {
+		return nil, errInvalidBookCopyID
+	}
+
+	if err := utils.ValidateStruct(input); err != nil {
+		return nil, err
+	}
+
+	bookCopy, err := cu.bookCopyRepo.Update(ctx, input)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":   utils.Dump(ctx),
+			"input": utils.Dump(input),
+		}).Error(err)
+		return nil, err
+	}
+
+	return bookCopy, nil
+}
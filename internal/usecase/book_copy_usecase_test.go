@@ -0,0 +1,178 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+	"github.com/ssentinull/create-apis-using-golang/internal/model/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	bookCopyID = int64(1)
+	bookCopy   = &model.BookCopy{
+		ID:        bookCopyID,
+		BookID:    bookID,
+		Barcode:   "LIB-0001",
+		Condition: "good",
+		Status:    model.BookCopyStatusAvailable,
+	}
+	bookCopies = []*model.BookCopy{bookCopy}
+)
+
+func TestBookCopyUsecase_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedBookCopyRepo := mock.NewMockBookCopyRepository(ctrl)
+	usecase := bookCopyUsecase{bookCopyRepo: mockedBookCopyRepo}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	t.Run("success", func(t *testing.T) {
+		mockedBookCopyRepo.EXPECT().Create(ctx, bookCopy, gomock.Any()).Times(1).Return(nil)
+		res, err := usecase.Create(ctx, bookCopy)
+		assert.NoError(t, err)
+		assert.Equal(t, bookCopy, res)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		mockedBookCopyRepo.EXPECT().Create(ctx, bookCopy, gomock.Any()).Times(1).Return(domainerr.Conflict("record already exists", nil))
+		res, err := usecase.Create(ctx, bookCopy)
+		assert.ErrorIs(t, err, domainerr.ErrConflict)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - unknown condition", func(t *testing.T) {
+		res, err := usecase.Create(ctx, &model.BookCopy{BookID: bookID, Barcode: "LIB-0002", Condition: "mint", Status: model.BookCopyStatusAvailable})
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, res)
+	})
+}
+
+func TestBookCopyUsecase_DeleteByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedBookCopyRepo := mock.NewMockBookCopyRepository(ctrl)
+	usecase := bookCopyUsecase{bookCopyRepo: mockedBookCopyRepo}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	t.Run("success", func(t *testing.T) {
+		mockedBookCopyRepo.EXPECT().DeleteByID(ctx, bookCopyID).Times(1).Return(nil)
+		err := usecase.DeleteByID(ctx, bookCopyID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("failed - invalid ID", func(t *testing.T) {
+		err := usecase.DeleteByID(ctx, 0)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+	})
+}
+
+func TestBookCopyUsecase_FindByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedBookCopyRepo := mock.NewMockBookCopyRepository(ctrl)
+	usecase := bookCopyUsecase{bookCopyRepo: mockedBookCopyRepo}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	t.Run("success", func(t *testing.T) {
+		mockedBookCopyRepo.EXPECT().FindByID(ctx, bookCopyID).Times(1).Return(bookCopy, nil)
+		res, err := usecase.FindByID(ctx, bookCopyID)
+		assert.NoError(t, err)
+		assert.Equal(t, bookCopy, res)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		mockedBookCopyRepo.EXPECT().FindByID(ctx, bookCopyID).Times(1).Return(nil, errors.New("db error"))
+		res, err := usecase.FindByID(ctx, bookCopyID)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestBookCopyUsecase_FindAllByBookID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedBookCopyRepo := mock.NewMockBookCopyRepository(ctrl)
+	usecase := bookCopyUsecase{bookCopyRepo: mockedBookCopyRepo}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	params := model.GetBookCopiesQueryParams{BookID: bookID, PageQuery: model.PageQuery{Page: 1, Size: 5}}
+
+	t.Run("success", func(t *testing.T) {
+		mockedBookCopyRepo.EXPECT().FindAllByBookID(ctx, params).Times(1).Return(bookCopies, nil)
+		mockedBookCopyRepo.EXPECT().CountAllByBookID(ctx, params).Times(1).Return(int64(1), nil)
+
+		res, count, err := usecase.FindAllByBookID(ctx, params)
+		assert.NoError(t, err)
+		assert.Equal(t, bookCopies, res)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("failed - count all return error", func(t *testing.T) {
+		mockedBookCopyRepo.EXPECT().FindAllByBookID(ctx, params).Times(1).Return(bookCopies, nil)
+		mockedBookCopyRepo.EXPECT().CountAllByBookID(ctx, params).Times(1).Return(int64(0), errors.New("db error"))
+
+		res, _, err := usecase.FindAllByBookID(ctx, params)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - invalid book ID", func(t *testing.T) {
+		res, _, err := usecase.FindAllByBookID(ctx, model.GetBookCopiesQueryParams{})
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, res)
+	})
+}
+
+func TestBookCopyUsecase_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedBookCopyRepo := mock.NewMockBookCopyRepository(ctrl)
+	usecase := bookCopyUsecase{bookCopyRepo: mockedBookCopyRepo}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	input := model.UpdateBookCopyInput{ID: bookCopyID, Barcode: "LIB-0001", Condition: "fair"}
+
+	t.Run("success", func(t *testing.T) {
+		mockedBookCopyRepo.EXPECT().Update(ctx, input).Times(1).Return(bookCopy, nil)
+		res, err := usecase.Update(ctx, input)
+		assert.NoError(t, err)
+		assert.Equal(t, bookCopy, res)
+	})
+
+	t.Run("failed - barcode is missing", func(t *testing.T) {
+		res, err := usecase.Update(ctx, model.UpdateBookCopyInput{ID: bookCopyID, Condition: "fair"})
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - invalid ID", func(t *testing.T) {
+		res, err := usecase.Update(ctx, model.UpdateBookCopyInput{Barcode: "LIB-0001", Condition: "fair"})
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, res)
+	})
+}
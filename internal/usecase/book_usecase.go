@@ -2,21 +2,48 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
 	"github.com/sirupsen/logrus"
+	"github.com/ssentinull/create-apis-using-golang/internal/config"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
 	"github.com/ssentinull/create-apis-using-golang/internal/model"
 	"github.com/ssentinull/create-apis-using-golang/internal/utils"
 )
 
+var (
+	errInvalidBookID = domainerr.Validation("book ID must be a positive number", nil)
+	errInvalidWorkID = domainerr.Validation("work ID must be a positive number", nil)
+	errInvalidISBN   = domainerr.Validation("ISBN must be a valid ISBN-10 or ISBN-13", nil)
+
+	errDuplicateBookID = domainerr.Validation("book ID is already in the batch", nil)
+)
+
 type bookUsecase struct {
-	bookRepo model.BookRepository
+	bookRepo         model.BookRepository
+	metadataProvider model.MetadataProvider
+	blobStore        model.BlobStore
 }
 
-func NewBookUsecase(br model.BookRepository) model.BookUsecase {
-	return &bookUsecase{bookRepo: br}
+func NewBookUsecase(br model.BookRepository, mp model.MetadataProvider, bs model.BlobStore) model.BookUsecase {
+	return &bookUsecase{bookRepo: br, metadataProvider: mp, blobStore: bs}
 }
 
-func (bu *bookUsecase) Create(ctx context.Context, book *model.Book) (*model.Book, error) {
+// Create stores a new book, with enrich its blank fields are first filled in
+// from the metadata of its ISBN
+func (bu *bookUsecase) Create(ctx context.Context, book *model.Book, enrich bool) (*model.Book, error) {
+	if enrich {
+		if err := bu.enrich(ctx, book); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := utils.ValidateStruct(book); err != nil {
+		return nil, err
+	}
+
 	if err := bu.bookRepo.Create(ctx, book); err != nil {
 		logrus.WithFields(logrus.Fields{
 			"ctx":  utils.Dump(ctx),
@@ -28,11 +55,45 @@ func (bu *bookUsecase) Create(ctx context.Context, book *model.Book) (*model.Boo
 	return book, nil
 }
 
-func (bu *bookUsecase) DeleteByID(ctx context.Context, ID int64) error {
-	if err := bu.bookRepo.DeleteByID(ctx, ID); err != nil {
+// enrich fills in the blank fields of book from the metadata provider within
+// the configured timeout, a book the provider doesn't know is left as it is
+func (bu *bookUsecase) enrich(ctx context.Context, book *model.Book) error {
+	if book.ISBN13 == nil || !utils.ValidISBN13(*book.ISBN13) {
+		return domainerr.ValidationFields("request contains invalid fields", map[string]string{
+			"isbn13": "must be a valid ISBN-13 to enrich the book",
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, config.MetadataTimeout())
+	defer cancel()
+
+	metadata, err := bu.metadataProvider.FindByISBN(ctx, *book.ISBN13)
+	if errors.Is(err, domainerr.ErrNotFound) {
+		return nil
+	}
+
+	if err != nil {
 		logrus.WithFields(logrus.Fields{
-			"ctx": utils.Dump(ctx),
-			"ID":  ID,
+			"ctx":  utils.Dump(ctx),
+			"book": utils.Dump(book),
+		}).Error(err)
+		return err
+	}
+
+	metadata.Apply(book)
+	return nil
+}
+
+func (bu *bookUsecase) DeleteByID(ctx context.Context, ID, version int64) error {
+	if ID <= 0 {
+		return errInvalidBookID
+	}
+
+	if err := bu.bookRepo.DeleteByID(ctx, ID, version); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":     utils.Dump(ctx),
+			"ID":      ID,
+			"version": version,
 		}).Error(err)
 		return err
 	}
@@ -40,7 +101,89 @@ func (bu *bookUsecase) DeleteByID(ctx context.Context, ID int64) error {
 	return nil
 }
 
+func (bu *bookUsecase) HardDeleteByID(ctx context.Context, ID, version int64) error {
+	if ID <= 0 {
+		return errInvalidBookID
+	}
+
+	deleted, err := bu.bookRepo.HardDeleteByID(ctx, ID, version)
+	if deleted != nil {
+		bu.deleteCovers(ctx, deleted)
+	}
+
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":     utils.Dump(ctx),
+			"ID":      ID,
+			"version": version,
+		}).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (bu *bookUsecase) Restore(ctx context.Context, ID int64) (*model.Book, error) {
+	if ID <= 0 {
+		return nil, errInvalidBookID
+	}
+
+	book, err := bu.bookRepo.Restore(ctx, ID)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx": utils.Dump(ctx),
+			"ID":  ID,
+		}).Error(err)
+		return nil, err
+	}
+
+	return book, nil
+}
+
+// PurgeTrash permanently deletes the books that have been in the trash
+// for longer than the configured retention
+func (bu *bookUsecase) PurgeTrash(ctx context.Context) (int64, error) {
+	deletedBefore := time.Now().AddDate(0, 0, -config.TrashRetentionDays())
+	purged, err := bu.bookRepo.PurgeTrashed(ctx, deletedBefore)
+	bu.deleteCovers(ctx, purged...)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":           utils.Dump(ctx),
+			"deletedBefore": deletedBefore,
+		}).Error(err)
+		return int64(len(purged)), err
+	}
+
+	return int64(len(purged)), nil
+}
+
+// deleteCovers deletes the cover blobs of the permanently deleted books, a
+// failure only leaves orphaned blobs behind so it is logged and not returned
+func (bu *bookUsecase) deleteCovers(ctx context.Context, books ...*model.Book) {
+	keys := []string{}
+	for _, book := range books {
+		if book.CoverUpdatedAt != nil {
+			keys = append(keys, model.CoverKeys(book.ID, book.CoverVersion())...)
+		}
+	}
+
+	if len(keys) == 0 {
+		return
+	}
+
+	if err := bu.blobStore.Delete(ctx, keys...); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":  utils.Dump(ctx),
+			"keys": keys,
+		}).Error(err)
+	}
+}
+
 func (bu *bookUsecase) FindByID(ctx context.Context, ID int64) (*model.Book, error) {
+	if ID <= 0 {
+		return nil, errInvalidBookID
+	}
+
 	book, err := bu.bookRepo.FindByID(ctx, ID)
 	if err != nil {
 		logrus.WithFields(logrus.Fields{
@@ -53,19 +196,68 @@ func (bu *bookUsecase) FindByID(ctx context.Context, ID int64) (*model.Book, err
 	return book, nil
 }
 
+// FindByISBN looks a book up by either of its ISBNs, hyphenated or not
+func (bu *bookUsecase) FindByISBN(ctx context.Context, isbn string) (*model.Book, error) {
+	if !utils.ValidISBN(isbn) {
+		return nil, errInvalidISBN
+	}
+
+	book, err := bu.bookRepo.FindByISBN(ctx, utils.NormalizeISBN(isbn))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":  utils.Dump(ctx),
+			"isbn": isbn,
+		}).Error(err)
+		return nil, err
+	}
+
+	return book, nil
+}
+
+func (bu *bookUsecase) FindVersionByID(ctx context.Context, ID int64) (int64, error) {
+	if ID <= 0 {
+		return 0, errInvalidBookID
+	}
+
+	version, err := bu.bookRepo.FindVersionByID(ctx, ID)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx": utils.Dump(ctx),
+			"ID":  ID,
+		}).Error(err)
+		return 0, err
+	}
+
+	return version, nil
+}
+
 func (bu *bookUsecase) FindAll(ctx context.Context, params model.GetBooksQueryParams) ([]*model.Book, int64, error) {
 	logger := logrus.WithFields(logrus.Fields{
 		"ctx":    utils.Dump(ctx),
 		"params": utils.Dump(params),
 	})
 
+	params.Normalize(config.PaginationDefaultSize(), config.PaginationMaxSize())
+
+	if err := utils.ValidateStruct(params); err != nil {
+		return nil, int64(0), err
+	}
+
+	if _, err := params.SortFields(); err != nil {
+		return nil, int64(0), err
+	}
+
+	if _, err := params.FacetFields(); err != nil {
+		return nil, int64(0), err
+	}
+
 	books, err := bu.bookRepo.FindAll(ctx, params)
 	if err != nil {
 		logger.Error(err)
 		return nil, int64(0), err
 	}
 
-	count, err := bu.bookRepo.CountAll(ctx)
+	count, err := bu.bookRepo.CountAll(ctx, params)
 	if err != nil {
 		logger.Error(err)
 		return nil, int64(0), err
@@ -74,7 +266,206 @@ func (bu *bookUsecase) FindAll(ctx context.Context, params model.GetBooksQueryPa
 	return books, count, nil
 }
 
+func (bu *bookUsecase) FindAllByCursor(ctx context.Context, params model.GetBooksQueryParams) ([]*model.Book, model.CursorPagination, int64, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":    utils.Dump(ctx),
+		"params": utils.Dump(params),
+	})
+
+	params.Normalize(config.PaginationDefaultSize(), config.PaginationMaxSize())
+
+	cursors := model.CursorPagination{}
+	if err := utils.ValidateStruct(params); err != nil {
+		return nil, cursors, int64(0), err
+	}
+
+	cursor, err := params.ParseCursor()
+	if err != nil {
+		return nil, cursors, int64(0), err
+	}
+
+	fields, err := params.KeysetFields()
+	if err != nil {
+		return nil, cursors, int64(0), err
+	}
+
+	if _, err := params.FacetFields(); err != nil {
+		return nil, cursors, int64(0), err
+	}
+
+	books, hasMore, err := bu.bookRepo.FindAllByCursor(ctx, params)
+	if err != nil {
+		logger.Error(err)
+		return nil, cursors, int64(0), err
+	}
+
+	count, err := bu.bookRepo.CountAll(ctx, params)
+	if err != nil {
+		logger.Error(err)
+		return nil, cursors, int64(0), err
+	}
+
+	if len(books) == 0 {
+		return books, cursors, count, nil
+	}
+
+	first, last := books[0], books[len(books)-1]
+	hasNext, hasPrev := hasMore, params.Cursor != ""
+	if cursor.Backward {
+		hasNext, hasPrev = true, hasMore
+	}
+
+	if hasNext {
+		cursors.NextCursor = model.NewBookCursor(last, fields, params.Sort, false)
+	}
+
+	if hasPrev {
+		cursors.PrevCursor = model.NewBookCursor(first, fields, params.Sort, true)
+	}
+
+	return books, cursors, count, nil
+}
+
+func (bu *bookUsecase) FindAllTrashed(ctx context.Context, params model.GetBooksQueryParams) ([]*model.Book, int64, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":    utils.Dump(ctx),
+		"params": utils.Dump(params),
+	})
+
+	// the trash is only paginated by page
+	params.Cursor, params.Limit = "", 0
+	params.Normalize(config.PaginationDefaultSize(), config.PaginationMaxSize())
+
+	if err := utils.ValidateStruct(params); err != nil {
+		return nil, int64(0), err
+	}
+
+	if _, err := params.SortFields(); err != nil {
+		return nil, int64(0), err
+	}
+
+	books, err := bu.bookRepo.FindAllTrashed(ctx, params)
+	if err != nil {
+		logger.Error(err)
+		return nil, int64(0), err
+	}
+
+	count, err := bu.bookRepo.CountAllTrashed(ctx, params)
+	if err != nil {
+		logger.Error(err)
+		return nil, int64(0), err
+	}
+
+	return books, count, nil
+}
+
+// FindAllByWorkID returns the editions of a work, a work exists as long as
+// one of its editions does
+func (bu *bookUsecase) FindAllByWorkID(ctx context.Context, workID int64) ([]*model.Book, error) {
+	if workID <= 0 {
+		return nil, errInvalidWorkID
+	}
+
+	books, err := bu.bookRepo.FindAllByWorkID(ctx, workID)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":    utils.Dump(ctx),
+			"workID": workID,
+		}).Error(err)
+		return nil, err
+	}
+
+	if len(books) == 0 {
+		return nil, domainerr.NotFound(fmt.Sprintf("work %d not found", workID), nil)
+	}
+
+	return books, nil
+}
+
+// FindFacets counts the books matching the filters of params by each of its facets
+func (bu *bookUsecase) FindFacets(ctx context.Context, params model.GetBooksQueryParams) (map[string][]*model.FacetCount, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":    utils.Dump(ctx),
+		"params": utils.Dump(params),
+	})
+
+	if err := utils.ValidateStruct(params); err != nil {
+		return nil, err
+	}
+
+	facetFields, err := params.FacetFields()
+	if err != nil {
+		return nil, err
+	}
+
+	facets := make(map[string][]*model.FacetCount, len(facetFields))
+	for _, facet := range facetFields {
+		counts, err := bu.bookRepo.CountByFacet(ctx, params, facet)
+		if err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+
+		facets[facet] = counts
+	}
+
+	return facets, nil
+}
+
+func (bu *bookUsecase) Search(ctx context.Context, params model.SearchBooksQueryParams) ([]*model.BookSearchResult, int64, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":    utils.Dump(ctx),
+		"params": utils.Dump(params),
+	})
+
+	params.Normalize(config.PaginationDefaultSize(), config.PaginationMaxSize())
+	if err := utils.ValidateStruct(params); err != nil {
+		return nil, int64(0), err
+	}
+
+	results, err := bu.bookRepo.Search(ctx, params)
+	if err != nil {
+		logger.Error(err)
+		return nil, int64(0), err
+	}
+
+	count, err := bu.bookRepo.CountSearch(ctx, params)
+	if err != nil {
+		logger.Error(err)
+		return nil, int64(0), err
+	}
+
+	return results, count, nil
+}
+
+func (bu *bookUsecase) Suggest(ctx context.Context, params model.SuggestBooksQueryParams) ([]*model.BookSuggestion, error) {
+	params.Threshold = config.SuggestThreshold()
+	params.Limit = model.NormalizePageSize(params.Limit, config.SuggestLimit(), config.SuggestLimit())
+	if err := utils.ValidateStruct(params); err != nil {
+		return nil, err
+	}
+
+	suggestions, err := bu.bookRepo.Suggest(ctx, params)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":    utils.Dump(ctx),
+			"params": utils.Dump(params),
+		}).Error(err)
+		return nil, err
+	}
+
+	return suggestions, nil
+}
+
 func (bu *bookUsecase) Update(ctx context.Context, book *model.Book) (*model.Book, error) {
+	if book.ID <= 0 {
+		return nil, errInvalidBookID
+	}
+
+	if err := utils.ValidateStruct(book); err != nil {
+		return nil, err
+	}
+
 	book, err := bu.bookRepo.Update(ctx, book)
 	if err != nil {
 		logrus.WithFields(logrus.Fields{
@@ -86,3 +477,230 @@ func (bu *bookUsecase) Update(ctx context.Context, book *model.Book) (*model.Boo
 
 	return book, nil
 }
+
+func (bu *bookUsecase) Patch(ctx context.Context, ID int64, input model.PatchBookInput) (*model.Book, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   utils.Dump(ctx),
+		"ID":    ID,
+		"input": utils.Dump(input),
+	})
+
+	book, err := bu.FindByID(ctx, ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Version > 0 && input.Version != book.Version {
+		return nil, domainerr.PreconditionFailed(fmt.Sprintf("book %d has been modified", ID), nil)
+	}
+
+	updateInput, err := input.Apply(book)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if err := utils.ValidateStruct(updateInput); err != nil {
+		return nil, err
+	}
+
+	return bu.Update(ctx, updateInput.ToModel())
+}
+
+func (bu *bookUsecase) BatchCreate(ctx context.Context, input model.BatchCreateBooksInput) ([]model.BatchItemResult, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   utils.Dump(ctx),
+		"input": utils.Dump(input),
+	})
+
+	if err := validateBatch(input, "books", len(input.Books)); err != nil {
+		return nil, err
+	}
+
+	results := make([]model.BatchItemResult, len(input.Books))
+	books, indexes := []*model.Book{}, []int{}
+	for i, bookInput := range input.Books {
+		results[i].Index = i
+		if err := utils.ValidateStruct(bookInput); err != nil {
+			if input.Mode.Atomic() {
+				return nil, batchItemError("books", i, err)
+			}
+			results[i].Err = err
+			continue
+		}
+
+		book := bookInput.ToModel()
+		books, indexes = append(books, book), append(indexes, i)
+	}
+
+	if len(books) == 0 {
+		return results, nil
+	}
+
+	errs, err := bu.bookRepo.BatchCreate(ctx, books, input.Mode.Atomic())
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	for j, i := range indexes {
+		results[i].ID = books[j].ID
+		if results[i].Err = errs[j]; results[i].Err == nil {
+			results[i].Book = books[j]
+		}
+	}
+
+	return results, nil
+}
+
+func (bu *bookUsecase) BatchUpdate(ctx context.Context, input model.BatchUpdateBooksInput) ([]model.BatchItemResult, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   utils.Dump(ctx),
+		"input": utils.Dump(input),
+	})
+
+	if err := validateBatch(input, "books", len(input.Books)); err != nil {
+		return nil, err
+	}
+
+	// a book is updated once per batch, its later items are rejected
+	results := make([]model.BatchItemResult, len(input.Books))
+	books, indexes, seen := []*model.Book{}, []int{}, map[int64]bool{}
+	for i, bookInput := range input.Books {
+		results[i].Index, results[i].ID = i, bookInput.ID
+		err := utils.ValidateStruct(bookInput)
+		if err == nil && seen[bookInput.ID] {
+			err = errDuplicateBookID
+		}
+		seen[bookInput.ID] = true
+
+		if err != nil {
+			if input.Mode.Atomic() {
+				return nil, batchItemError("books", i, err)
+			}
+			results[i].Err = err
+			continue
+		}
+
+		books, indexes = append(books, bookInput.ToModel()), append(indexes, i)
+	}
+
+	if len(books) == 0 {
+		return results, nil
+	}
+
+	updated, errs, err := bu.bookRepo.BatchUpdate(ctx, books, input.Mode.Atomic())
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	for j, i := range indexes {
+		if errs[j] != nil && input.Mode.Atomic() {
+			return nil, batchItemError("books", i, errs[j])
+		}
+	}
+
+	for j, i := range indexes {
+		results[i].Book, results[i].Err = updated[j], errs[j]
+	}
+
+	return results, nil
+}
+
+func (bu *bookUsecase) BatchDelete(ctx context.Context, input model.BatchDeleteBooksInput) ([]model.BatchItemResult, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   utils.Dump(ctx),
+		"input": utils.Dump(input),
+	})
+
+	if err := validateBatch(input, "ids", len(input.IDs)); err != nil {
+		return nil, err
+	}
+
+	// a book is deleted once per batch, its later items are rejected
+	results := make([]model.BatchItemResult, len(input.IDs))
+	IDs, indexes, seen := []int64{}, []int{}, map[int64]bool{}
+	for i, ID := range input.IDs {
+		results[i].Index, results[i].ID = i, ID
+
+		var err error
+		switch {
+		case ID <= 0:
+			err = errInvalidBookID
+		case seen[ID]:
+			err = errDuplicateBookID
+		}
+		seen[ID] = true
+
+		if err != nil {
+			if input.Mode.Atomic() {
+				return nil, batchItemError("ids", i, err)
+			}
+			results[i].Err = err
+			continue
+		}
+
+		IDs, indexes = append(IDs, ID), append(indexes, i)
+	}
+
+	if len(IDs) == 0 {
+		return results, nil
+	}
+
+	errs, err := bu.bookRepo.BatchDelete(ctx, IDs, input.Mode.Atomic())
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	for j, i := range indexes {
+		if errs[j] != nil && input.Mode.Atomic() {
+			return nil, batchItemError("ids", i, errs[j])
+		}
+		results[i].Err = errs[j]
+	}
+
+	return results, nil
+}
+
+// validateBatch validates the batch request input and caps the number of
+// its items, held by field, at the configured max size
+func validateBatch(input interface{}, field string, size int) error {
+	if err := utils.ValidateStruct(input); err != nil {
+		return err
+	}
+
+	if maxSize := config.BatchMaxSize(); size > maxSize {
+		return domainerr.ValidationFields("request contains invalid fields", map[string]string{
+			field: fmt.Sprintf("must contain at most %d items", maxSize),
+		})
+	}
+
+	return nil
+}
+
+// batchItemError points the domain error of the item at index of a batch
+// at its position in the request, eg: books[2].title
+func batchItemError(field string, index int, err error) error {
+	domainErr := &domainerr.Error{}
+	if !errors.As(err, &domainErr) {
+		return err
+	}
+
+	prefix := fmt.Sprintf("%s[%d]", field, index)
+	itemErr := &domainerr.Error{
+		Kind:    domainErr.Kind,
+		Message: fmt.Sprintf("%s: %s", prefix, domainErr.Error()),
+		Err:     err,
+	}
+
+	if len(domainErr.Fields) > 0 {
+		itemErr.Fields = make(map[string]string, len(domainErr.Fields))
+		for name, message := range domainErr.Fields {
+			itemErr.Fields[prefix+"."+name] = message
+		}
+	}
+
+	return itemErr
+}
@@ -3,10 +3,12 @@ package usecase
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
 	"github.com/ssentinull/create-apis-using-golang/internal/model"
 	"github.com/ssentinull/create-apis-using-golang/internal/model/mock"
 	"github.com/stretchr/testify/assert"
@@ -43,17 +45,132 @@ func TestBookUsecase_Create(t *testing.T) {
 
 	t.Run("success", func(t *testing.T) {
 		mockedBookRepo.EXPECT().Create(ctx, book).Times(1).Return(nil)
-		res, err := usecase.Create(ctx, book)
+		res, err := usecase.Create(ctx, book, false)
 		assert.NoError(t, err)
 		assert.NotNil(t, res)
 	})
 
 	t.Run("failed", func(t *testing.T) {
 		mockedBookRepo.EXPECT().Create(ctx, book).Times(1).Return(errors.New("db error"))
-		res, err := usecase.Create(ctx, book)
+		res, err := usecase.Create(ctx, book, false)
 		assert.Error(t, err)
 		assert.Nil(t, res)
 	})
+
+	t.Run("failed - series position without series", func(t *testing.T) {
+		position := 1
+		res, err := usecase.Create(ctx, &model.Book{Title: "Harry Potter", SeriesPosition: &position}, false)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - invalid book", func(t *testing.T) {
+		res, err := usecase.Create(ctx, &model.Book{Title: "", PublishedDate: "2023-13-01"}, false)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - isbn checksum does not match", func(t *testing.T) {
+		isbn13 := "9780747532690"
+		res, err := usecase.Create(ctx, &model.Book{Title: "Harry Potter", ISBN13: &isbn13}, false)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, res)
+	})
+}
+
+func TestBookUsecase_CreateEnriched(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedBookRepo := mock.NewMockBookRepository(ctrl)
+	mockedMetadataProvider := mock.NewMockMetadataProvider(ctrl)
+	usecase := bookUsecase{bookRepo: mockedBookRepo, metadataProvider: mockedMetadataProvider}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	isbn13 := "9780747532699"
+	metadata := &model.BookMetadata{
+		ISBN13:        isbn13,
+		Title:         "Harry Potter and the Philosopher's Stone",
+		Author:        "J. K. Rowling",
+		Description:   "A book about wizards",
+		PublishedDate: "1997-06-26",
+	}
+
+	t.Run("success - blank fields are filled in", func(t *testing.T) {
+		input := &model.Book{ID: bookID, Description: "Book one", ISBN13: &isbn13}
+
+		mockedMetadataProvider.EXPECT().FindByISBN(gomock.Any(), isbn13).Times(1).Return(metadata, nil)
+		mockedBookRepo.EXPECT().Create(ctx, input).Times(1).Return(nil)
+
+		res, err := usecase.Create(ctx, input, true)
+		assert.NoError(t, err)
+		assert.Equal(t, metadata.Title, res.Title)
+		assert.Equal(t, metadata.Author, res.Author)
+		assert.Equal(t, "Book one", res.Description)
+		assert.Equal(t, metadata.PublishedDate, res.PublishedDate)
+	})
+
+	t.Run("success - oversized metadata is cut down to the book limits", func(t *testing.T) {
+		input := &model.Book{ID: bookID, ISBN13: &isbn13}
+		oversized := &model.BookMetadata{
+			ISBN13:        isbn13,
+			Title:         strings.Repeat("é", 300),
+			Author:        strings.Repeat("a", 254) + " Rowling",
+			Description:   strings.Repeat("d", 6000),
+			PublishedDate: "June 1997",
+		}
+
+		mockedMetadataProvider.EXPECT().FindByISBN(gomock.Any(), isbn13).Times(1).Return(oversized, nil)
+		mockedBookRepo.EXPECT().Create(ctx, input).Times(1).Return(nil)
+
+		res, err := usecase.Create(ctx, input, true)
+		assert.NoError(t, err)
+		assert.Equal(t, strings.Repeat("é", 255), res.Title)
+		assert.Equal(t, strings.Repeat("a", 254), res.Author)
+		assert.Equal(t, strings.Repeat("d", 5000), res.Description)
+		assert.Empty(t, res.PublishedDate)
+	})
+
+	t.Run("success - unknown isbn keeps the given fields", func(t *testing.T) {
+		input := &model.Book{ID: bookID, Title: "Harry Potter", ISBN13: &isbn13}
+
+		mockedMetadataProvider.EXPECT().FindByISBN(gomock.Any(), isbn13).Times(1).Return(nil, domainerr.NotFound("metadata not found", nil))
+		mockedBookRepo.EXPECT().Create(ctx, input).Times(1).Return(nil)
+
+		res, err := usecase.Create(ctx, input, true)
+		assert.NoError(t, err)
+		assert.Equal(t, "Harry Potter", res.Title)
+	})
+
+	t.Run("failed - unknown isbn without a title", func(t *testing.T) {
+		mockedMetadataProvider.EXPECT().FindByISBN(gomock.Any(), isbn13).Times(1).Return(nil, domainerr.NotFound("metadata not found", nil))
+
+		res, err := usecase.Create(ctx, &model.Book{ID: bookID, ISBN13: &isbn13}, true)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - provider is unavailable", func(t *testing.T) {
+		mockedMetadataProvider.EXPECT().FindByISBN(gomock.Any(), isbn13).Times(1).
+			DoAndReturn(func(ctx context.Context, _ string) (*model.BookMetadata, error) {
+				_, ok := ctx.Deadline()
+				assert.True(t, ok)
+				return nil, domainerr.Unavailable("metadata provider is unavailable", nil)
+			})
+
+		res, err := usecase.Create(ctx, &model.Book{ID: bookID, ISBN13: &isbn13}, true)
+		assert.ErrorIs(t, err, domainerr.ErrUnavailable)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - isbn is missing", func(t *testing.T) {
+		res, err := usecase.Create(ctx, &model.Book{ID: bookID, Title: "Harry Potter"}, true)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, res)
+	})
 }
 
 func TestBookUsecase_DeleteByID(t *testing.T) {
@@ -68,15 +185,151 @@ func TestBookUsecase_DeleteByID(t *testing.T) {
 	}()
 
 	t.Run("success", func(t *testing.T) {
-		mockedBookRepo.EXPECT().DeleteByID(ctx, bookID).Times(1).Return(nil)
-		err := usecase.DeleteByID(ctx, bookID)
+		mockedBookRepo.EXPECT().DeleteByID(ctx, bookID, int64(0)).Times(1).Return(nil)
+		err := usecase.DeleteByID(ctx, bookID, int64(0))
+		assert.NoError(t, err)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		mockedBookRepo.EXPECT().DeleteByID(ctx, bookID, int64(0)).Times(1).Return(errors.New("db error"))
+		err := usecase.DeleteByID(ctx, bookID, int64(0))
+		assert.Error(t, err)
+	})
+
+	t.Run("failed - invalid ID", func(t *testing.T) {
+		err := usecase.DeleteByID(ctx, 0, 0)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+	})
+}
+
+func TestBookUsecase_HardDeleteByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedBookRepo := mock.NewMockBookRepository(ctrl)
+	mockedBlobStore := mock.NewMockBlobStore(ctrl)
+	usecase := bookUsecase{bookRepo: mockedBookRepo, blobStore: mockedBlobStore}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	coverUpdatedAt := time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC)
+	deleted := &model.Book{ID: bookID, CoverUpdatedAt: &coverUpdatedAt}
+	coverKeys := model.CoverKeys(bookID, deleted.CoverVersion())
+
+	t.Run("success", func(t *testing.T) {
+		mockedBookRepo.EXPECT().HardDeleteByID(ctx, bookID, int64(2)).Times(1).Return(deleted, nil)
+		mockedBlobStore.EXPECT().Delete(ctx, coverKeys).Times(1).Return(nil)
+		err := usecase.HardDeleteByID(ctx, bookID, int64(2))
+		assert.NoError(t, err)
+	})
+
+	t.Run("success - book without cover", func(t *testing.T) {
+		mockedBookRepo.EXPECT().HardDeleteByID(ctx, bookID, int64(2)).Times(1).Return(&model.Book{ID: bookID}, nil)
+		err := usecase.HardDeleteByID(ctx, bookID, int64(2))
+		assert.NoError(t, err)
+	})
+
+	t.Run("success - covers fail to be deleted", func(t *testing.T) {
+		mockedBookRepo.EXPECT().HardDeleteByID(ctx, bookID, int64(2)).Times(1).Return(deleted, nil)
+		mockedBlobStore.EXPECT().Delete(ctx, coverKeys).Times(1).Return(errors.New("blob store error"))
+		err := usecase.HardDeleteByID(ctx, bookID, int64(2))
+		assert.NoError(t, err)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		mockedBookRepo.EXPECT().HardDeleteByID(ctx, bookID, int64(0)).Times(1).Return(nil, errors.New("db error"))
+		err := usecase.HardDeleteByID(ctx, bookID, int64(0))
+		assert.Error(t, err)
+	})
+
+	t.Run("failed - invalid ID", func(t *testing.T) {
+		err := usecase.HardDeleteByID(ctx, 0, 0)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+	})
+}
+
+func TestBookUsecase_Restore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedBookRepo := mock.NewMockBookRepository(ctrl)
+	usecase := bookUsecase{bookRepo: mockedBookRepo}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	t.Run("success", func(t *testing.T) {
+		mockedBookRepo.EXPECT().Restore(ctx, bookID).Times(1).Return(book, nil)
+		res, err := usecase.Restore(ctx, bookID)
+		assert.NoError(t, err)
+		assert.NotNil(t, res)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		mockedBookRepo.EXPECT().Restore(ctx, bookID).Times(1).Return(nil, domainerr.NotFound("deleted book 1 not found", nil))
+		res, err := usecase.Restore(ctx, bookID)
+		assert.ErrorIs(t, err, domainerr.ErrNotFound)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - invalid ID", func(t *testing.T) {
+		res, err := usecase.Restore(ctx, 0)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, res)
+	})
+}
+
+func TestBookUsecase_PurgeTrash(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedBookRepo := mock.NewMockBookRepository(ctrl)
+	mockedBlobStore := mock.NewMockBlobStore(ctrl)
+	usecase := bookUsecase{bookRepo: mockedBookRepo, blobStore: mockedBlobStore}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	coverUpdatedAt := time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC)
+	purged := []*model.Book{
+		{ID: 1, CoverUpdatedAt: &coverUpdatedAt},
+		{ID: 2},
+		{ID: 3, CoverUpdatedAt: &coverUpdatedAt},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockedBookRepo.EXPECT().PurgeTrashed(ctx, gomock.Any()).Times(1).DoAndReturn(
+			func(_ context.Context, deletedBefore time.Time) ([]*model.Book, error) {
+				retention := time.Since(deletedBefore)
+				assert.InDelta(t, float64(30*24*time.Hour), float64(retention), float64(time.Minute))
+				return purged, nil
+			})
+		mockedBlobStore.EXPECT().Delete(ctx,
+			"covers/1/1792317600000000/original", "covers/1/1792317600000000/thumb",
+			"covers/3/1792317600000000/original", "covers/3/1792317600000000/thumb",
+		).Times(1).Return(nil)
+
+		res, err := usecase.PurgeTrash(ctx)
 		assert.NoError(t, err)
+		assert.Equal(t, int64(3), res)
+	})
+
+	t.Run("success - nothing to purge", func(t *testing.T) {
+		mockedBookRepo.EXPECT().PurgeTrashed(ctx, gomock.Any()).Times(1).Return([]*model.Book{}, nil)
+		res, err := usecase.PurgeTrash(ctx)
+		assert.NoError(t, err)
+		assert.Zero(t, res)
 	})
 
 	t.Run("failed", func(t *testing.T) {
-		mockedBookRepo.EXPECT().DeleteByID(ctx, bookID).Times(1).Return(errors.New("db error"))
-		err := usecase.DeleteByID(ctx, bookID)
+		mockedBookRepo.EXPECT().PurgeTrashed(ctx, gomock.Any()).Times(1).Return(nil, errors.New("db error"))
+		res, err := usecase.PurgeTrash(ctx)
 		assert.Error(t, err)
+		assert.Zero(t, res)
 	})
 }
 
@@ -104,6 +357,76 @@ func TestBookUsecase_FindByID(t *testing.T) {
 		assert.Error(t, err)
 		assert.Nil(t, res)
 	})
+
+	t.Run("failed - invalid ID", func(t *testing.T) {
+		res, err := usecase.FindByID(ctx, 0)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, res)
+	})
+}
+
+func TestBookUsecase_FindByISBN(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedBookRepo := mock.NewMockBookRepository(ctrl)
+	usecase := bookUsecase{bookRepo: mockedBookRepo}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	t.Run("success - isbn10 is looked up by its isbn13", func(t *testing.T) {
+		mockedBookRepo.EXPECT().FindByISBN(ctx, "9780747532699").Times(1).Return(book, nil)
+		res, err := usecase.FindByISBN(ctx, "0-7475-3269-9")
+		assert.NoError(t, err)
+		assert.Equal(t, book, res)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		mockedBookRepo.EXPECT().FindByISBN(ctx, "9780747532699").Times(1).Return(nil, domainerr.NotFound("record not found", nil))
+		res, err := usecase.FindByISBN(ctx, "978-0-7475-3269-9")
+		assert.ErrorIs(t, err, domainerr.ErrNotFound)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - invalid isbn", func(t *testing.T) {
+		res, err := usecase.FindByISBN(ctx, "978-0-7475-3269-0")
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, res)
+	})
+}
+
+func TestBookUsecase_FindVersionByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedBookRepo := mock.NewMockBookRepository(ctrl)
+	usecase := bookUsecase{bookRepo: mockedBookRepo}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	t.Run("success", func(t *testing.T) {
+		mockedBookRepo.EXPECT().FindVersionByID(ctx, bookID).Times(1).Return(int64(2), nil)
+		res, err := usecase.FindVersionByID(ctx, bookID)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), res)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		mockedBookRepo.EXPECT().FindVersionByID(ctx, bookID).Times(1).Return(int64(0), errors.New("db error"))
+		res, err := usecase.FindVersionByID(ctx, bookID)
+		assert.Error(t, err)
+		assert.Zero(t, res)
+	})
+
+	t.Run("failed - invalid ID", func(t *testing.T) {
+		res, err := usecase.FindVersionByID(ctx, 0)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Zero(t, res)
+	})
 }
 
 func TestBookUsecase_FindAll(t *testing.T) {
@@ -119,7 +442,7 @@ func TestBookUsecase_FindAll(t *testing.T) {
 
 	t.Run("success", func(t *testing.T) {
 		mockedBookRepo.EXPECT().FindAll(ctx, findAllParams).Times(1).Return(books, nil)
-		mockedBookRepo.EXPECT().CountAll(ctx).Times(1).Return(lenBooks, nil)
+		mockedBookRepo.EXPECT().CountAll(ctx, findAllParams).Times(1).Return(lenBooks, nil)
 
 		resBooks, resCount, err := usecase.FindAll(ctx, findAllParams)
 		assert.NoError(t, err)
@@ -138,16 +461,128 @@ func TestBookUsecase_FindAll(t *testing.T) {
 
 	t.Run("failed - count all return error", func(t *testing.T) {
 		mockedBookRepo.EXPECT().FindAll(ctx, findAllParams).Times(1).Return(books, nil)
-		mockedBookRepo.EXPECT().CountAll(ctx).Times(1).Return(int64(0), errors.New("db error"))
+		mockedBookRepo.EXPECT().CountAll(ctx, findAllParams).Times(1).Return(int64(0), errors.New("db error"))
 
 		resBooks, resCount, err := usecase.FindAll(ctx, findAllParams)
 		assert.Error(t, err)
 		assert.Nil(t, resBooks)
 		assert.Zero(t, resCount)
 	})
+
+	t.Run("failed - sort column is not sortable", func(t *testing.T) {
+		params := findAllParams
+		params.Sort = "title,-description"
+
+		resBooks, resCount, err := usecase.FindAll(ctx, params)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, resBooks)
+		assert.Zero(t, resCount)
+	})
+
+	t.Run("failed - facet is unknown", func(t *testing.T) {
+		params := findAllParams
+		params.Facets = "author,publisher"
+
+		resBooks, resCount, err := usecase.FindAll(ctx, params)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, resBooks)
+		assert.Zero(t, resCount)
+	})
 }
 
-func TestBookUsecase_Update(t *testing.T) {
+func TestBookUsecase_FindAllByCursor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedBookRepo := mock.NewMockBookRepository(ctrl)
+	usecase := bookUsecase{bookRepo: mockedBookRepo}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	params := model.GetBooksQueryParams{Limit: 1}
+	fields, err := params.KeysetFields()
+	assert.NoError(t, err)
+
+	t.Run("success - first page", func(t *testing.T) {
+		mockedBookRepo.EXPECT().FindAllByCursor(ctx, params).Times(1).Return(books, true, nil)
+		mockedBookRepo.EXPECT().CountAll(ctx, params).Times(1).Return(int64(2), nil)
+
+		resBooks, resCursors, resCount, err := usecase.FindAllByCursor(ctx, params)
+		assert.NoError(t, err)
+		assert.Equal(t, books, resBooks)
+		assert.Equal(t, model.NewBookCursor(book, fields, "", false), resCursors.NextCursor)
+		assert.Empty(t, resCursors.PrevCursor)
+		assert.Equal(t, int64(2), resCount)
+	})
+
+	t.Run("success - last page walking backward", func(t *testing.T) {
+		backwardParams := params
+		backwardParams.Cursor = model.NewBookCursor(book, fields, "", true)
+
+		mockedBookRepo.EXPECT().FindAllByCursor(ctx, backwardParams).Times(1).Return(books, false, nil)
+		mockedBookRepo.EXPECT().CountAll(ctx, backwardParams).Times(1).Return(int64(2), nil)
+
+		_, resCursors, _, err := usecase.FindAllByCursor(ctx, backwardParams)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, resCursors.NextCursor)
+		assert.Empty(t, resCursors.PrevCursor)
+	})
+
+	t.Run("failed - cursor does not match sort", func(t *testing.T) {
+		sortedParams := params
+		sortedParams.Sort = "title"
+		sortedParams.Cursor = model.NewBookCursor(book, fields, "", false)
+
+		resBooks, _, _, err := usecase.FindAllByCursor(ctx, sortedParams)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, resBooks)
+	})
+
+	t.Run("success - cursor sorted by the typed columns", func(t *testing.T) {
+		sortedParams := params
+		sortedParams.Sort = "-created_at,updated_at,published_date,average_rating,ratings_count"
+		sortedFields, err := sortedParams.KeysetFields()
+		assert.NoError(t, err)
+		sortedParams.Cursor = model.NewBookCursor(book, sortedFields, sortedParams.Sort, false)
+
+		mockedBookRepo.EXPECT().FindAllByCursor(ctx, sortedParams).Times(1).Return(books, false, nil)
+		mockedBookRepo.EXPECT().CountAll(ctx, sortedParams).Times(1).Return(int64(2), nil)
+
+		_, _, _, err = usecase.FindAllByCursor(ctx, sortedParams)
+		assert.NoError(t, err)
+	})
+
+	t.Run("failed - cursor value does not match its column", func(t *testing.T) {
+		for sort, value := range map[string]string{
+			"created_at":     "2026-10-18",
+			"updated_at":     "yesterday",
+			"published_date": "2026-10-18T10:00:00Z",
+			"average_rating": "high",
+			"ratings_count":  "1.5",
+		} {
+			sortedParams := params
+			sortedParams.Sort = sort
+			sortedParams.Cursor = model.Cursor{Sort: sort, Values: []string{value, "1"}}.Encode()
+
+			resBooks, _, _, err := usecase.FindAllByCursor(ctx, sortedParams)
+			assert.ErrorIs(t, err, domainerr.ErrValidation, sort)
+			assert.Nil(t, resBooks)
+		}
+	})
+
+	t.Run("failed - find all by cursor return error", func(t *testing.T) {
+		mockedBookRepo.EXPECT().FindAllByCursor(ctx, params).Times(1).Return(nil, false, errors.New("db error"))
+
+		resBooks, _, resCount, err := usecase.FindAllByCursor(ctx, params)
+		assert.Error(t, err)
+		assert.Nil(t, resBooks)
+		assert.Zero(t, resCount)
+	})
+}
+
+func TestBookUsecase_FindAllTrashed(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mockedBookRepo := mock.NewMockBookRepository(ctrl)
 	usecase := bookUsecase{bookRepo: mockedBookRepo}
@@ -159,16 +594,588 @@ func TestBookUsecase_Update(t *testing.T) {
 	}()
 
 	t.Run("success", func(t *testing.T) {
-		mockedBookRepo.EXPECT().Update(ctx, book).Times(1).Return(book, nil)
-		res, err := usecase.Update(ctx, book)
+		mockedBookRepo.EXPECT().FindAllTrashed(ctx, findAllParams).Times(1).Return(books, nil)
+		mockedBookRepo.EXPECT().CountAllTrashed(ctx, findAllParams).Times(1).Return(lenBooks, nil)
+
+		resBooks, resCount, err := usecase.FindAllTrashed(ctx, findAllParams)
 		assert.NoError(t, err)
-		assert.NotNil(t, res)
+		assert.NotNil(t, resBooks)
+		assert.Equal(t, lenBooks, resCount)
 	})
 
-	t.Run("failed", func(t *testing.T) {
-		mockedBookRepo.EXPECT().Update(ctx, book).Times(1).Return(nil, errors.New("db error"))
-		res, err := usecase.Update(ctx, book)
+	t.Run("success - cursor is ignored", func(t *testing.T) {
+		params := findAllParams
+		params.Cursor, params.Limit = "cursor", 5
+
+		mockedBookRepo.EXPECT().FindAllTrashed(ctx, findAllParams).Times(1).Return(books, nil)
+		mockedBookRepo.EXPECT().CountAllTrashed(ctx, findAllParams).Times(1).Return(lenBooks, nil)
+
+		_, _, err := usecase.FindAllTrashed(ctx, params)
+		assert.NoError(t, err)
+	})
+
+	t.Run("failed - find all trashed return error", func(t *testing.T) {
+		mockedBookRepo.EXPECT().FindAllTrashed(ctx, findAllParams).Times(1).Return(nil, errors.New("db error"))
+
+		resBooks, resCount, err := usecase.FindAllTrashed(ctx, findAllParams)
 		assert.Error(t, err)
+		assert.Nil(t, resBooks)
+		assert.Zero(t, resCount)
+	})
+
+	t.Run("failed - sort column is not sortable", func(t *testing.T) {
+		params := findAllParams
+		params.Sort = "password"
+
+		resBooks, _, err := usecase.FindAllTrashed(ctx, params)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, resBooks)
+	})
+}
+
+func TestBookUsecase_FindAllByWorkID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedBookRepo := mock.NewMockBookRepository(ctrl)
+	usecase := bookUsecase{bookRepo: mockedBookRepo}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	t.Run("success", func(t *testing.T) {
+		mockedBookRepo.EXPECT().FindAllByWorkID(ctx, bookID).Times(1).Return(books, nil)
+		res, err := usecase.FindAllByWorkID(ctx, bookID)
+		assert.NoError(t, err)
+		assert.Equal(t, books, res)
+	})
+
+	t.Run("failed - work not found", func(t *testing.T) {
+		mockedBookRepo.EXPECT().FindAllByWorkID(ctx, bookID).Times(1).Return([]*model.Book{}, nil)
+		res, err := usecase.FindAllByWorkID(ctx, bookID)
+		assert.ErrorIs(t, err, domainerr.ErrNotFound)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		mockedBookRepo.EXPECT().FindAllByWorkID(ctx, bookID).Times(1).Return(nil, errors.New("db error"))
+		res, err := usecase.FindAllByWorkID(ctx, bookID)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - invalid work ID", func(t *testing.T) {
+		res, err := usecase.FindAllByWorkID(ctx, 0)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, res)
+	})
+}
+
+func TestBookUsecase_FindFacets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedBookRepo := mock.NewMockBookRepository(ctrl)
+	usecase := bookUsecase{bookRepo: mockedBookRepo}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	params := findAllParams
+	params.Facets = "author, decade,author"
+	authorCounts := []*model.FacetCount{{Value: "J. K. Rowling", Count: 7}}
+	decadeCounts := []*model.FacetCount{{Value: "1990", Count: 3}}
+
+	t.Run("success", func(t *testing.T) {
+		mockedBookRepo.EXPECT().CountByFacet(ctx, params, model.AuthorFacet).Times(1).Return(authorCounts, nil)
+		mockedBookRepo.EXPECT().CountByFacet(ctx, params, model.DecadeFacet).Times(1).Return(decadeCounts, nil)
+
+		res, err := usecase.FindFacets(ctx, params)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string][]*model.FacetCount{
+			model.AuthorFacet: authorCounts,
+			model.DecadeFacet: decadeCounts,
+		}, res)
+	})
+
+	t.Run("failed - count by facet return error", func(t *testing.T) {
+		mockedBookRepo.EXPECT().CountByFacet(ctx, params, model.AuthorFacet).Times(1).Return(nil, errors.New("db error"))
+
+		res, err := usecase.FindFacets(ctx, params)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - facet is unknown", func(t *testing.T) {
+		params := findAllParams
+		params.Facets = "password"
+
+		res, err := usecase.FindFacets(ctx, params)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, res)
+	})
+}
+
+func TestBookUsecase_Search(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedBookRepo := mock.NewMockBookRepository(ctrl)
+	usecase := bookUsecase{bookRepo: mockedBookRepo}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	params := model.SearchBooksQueryParams{Q: "wizard", PageQuery: model.PageQuery{Page: 1, Size: 5}}
+	results := []*model.BookSearchResult{{Book: *book, Rank: 0.6}}
+
+	t.Run("success", func(t *testing.T) {
+		mockedBookRepo.EXPECT().Search(ctx, params).Times(1).Return(results, nil)
+		mockedBookRepo.EXPECT().CountSearch(ctx, params).Times(1).Return(int64(1), nil)
+
+		res, count, err := usecase.Search(ctx, params)
+		assert.NoError(t, err)
+		assert.Equal(t, results, res)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("failed - search return error", func(t *testing.T) {
+		mockedBookRepo.EXPECT().Search(ctx, params).Times(1).Return(nil, errors.New("db error"))
+
+		res, count, err := usecase.Search(ctx, params)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+		assert.Zero(t, count)
+	})
+
+	t.Run("failed - count search return error", func(t *testing.T) {
+		mockedBookRepo.EXPECT().Search(ctx, params).Times(1).Return(results, nil)
+		mockedBookRepo.EXPECT().CountSearch(ctx, params).Times(1).Return(int64(0), errors.New("db error"))
+
+		res, _, err := usecase.Search(ctx, params)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - q is blank", func(t *testing.T) {
+		res, _, err := usecase.Search(ctx, model.SearchBooksQueryParams{Q: " "})
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, res)
+	})
+}
+
+func TestBookUsecase_Suggest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedBookRepo := mock.NewMockBookRepository(ctrl)
+	usecase := bookUsecase{bookRepo: mockedBookRepo}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	suggestions := []*model.BookSuggestion{{Field: "author", Value: "J. K. Rowling", Similarity: 0.5}}
+	params := model.SuggestBooksQueryParams{Q: "rowlnig", Limit: 10, Threshold: 0.3}
+
+	t.Run("success", func(t *testing.T) {
+		mockedBookRepo.EXPECT().Suggest(ctx, params).Times(1).Return(suggestions, nil)
+
+		res, err := usecase.Suggest(ctx, model.SuggestBooksQueryParams{Q: "rowlnig"})
+		assert.NoError(t, err)
+		assert.Equal(t, suggestions, res)
+	})
+
+	t.Run("success - limit is capped", func(t *testing.T) {
+		mockedBookRepo.EXPECT().Suggest(ctx, params).Times(1).Return(suggestions, nil)
+
+		_, err := usecase.Suggest(ctx, model.SuggestBooksQueryParams{Q: "rowlnig", Limit: 1000})
+		assert.NoError(t, err)
+	})
+
+	t.Run("failed - suggest return error", func(t *testing.T) {
+		mockedBookRepo.EXPECT().Suggest(ctx, params).Times(1).Return(nil, errors.New("db error"))
+
+		res, err := usecase.Suggest(ctx, model.SuggestBooksQueryParams{Q: "rowlnig"})
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - q is blank", func(t *testing.T) {
+		res, err := usecase.Suggest(ctx, model.SuggestBooksQueryParams{Q: " "})
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, res)
+	})
+}
+
+func TestBookUsecase_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedBookRepo := mock.NewMockBookRepository(ctrl)
+	usecase := bookUsecase{bookRepo: mockedBookRepo}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	t.Run("success", func(t *testing.T) {
+		mockedBookRepo.EXPECT().Update(ctx, book).Times(1).Return(book, nil)
+		res, err := usecase.Update(ctx, book)
+		assert.NoError(t, err)
+		assert.NotNil(t, res)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		mockedBookRepo.EXPECT().Update(ctx, book).Times(1).Return(nil, errors.New("db error"))
+		res, err := usecase.Update(ctx, book)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - invalid book", func(t *testing.T) {
+		res, err := usecase.Update(ctx, &model.Book{ID: bookID, Title: "  "})
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, res)
+	})
+}
+
+func TestBookUsecase_Patch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedBookRepo := mock.NewMockBookRepository(ctrl)
+	usecase := bookUsecase{bookRepo: mockedBookRepo}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	currentBook := func() *model.Book {
+		return &model.Book{
+			ID:            bookID,
+			Title:         "Harry Potter",
+			Author:        "J. K. Rowling",
+			Description:   "A series about wizards",
+			PublishedDate: "1997-06-26",
+		}
+	}
+
+	t.Run("success - merge patch null clears the field", func(t *testing.T) {
+		input := model.PatchBookInput{
+			Type:     model.MergePatch,
+			Document: []byte(`{"title":"Harry Potter and the Philosopher's Stone","description":null}`),
+		}
+
+		mockedBookRepo.EXPECT().FindByID(ctx, bookID).Times(1).Return(currentBook(), nil)
+		mockedBookRepo.EXPECT().Update(ctx, gomock.Any()).Times(1).DoAndReturn(
+			func(_ context.Context, patched *model.Book) (*model.Book, error) {
+				assert.Equal(t, "Harry Potter and the Philosopher's Stone", patched.Title)
+				assert.Equal(t, "J. K. Rowling", patched.Author)
+				assert.Empty(t, patched.Description)
+				assert.Equal(t, "1997-06-26", patched.PublishedDate)
+				return patched, nil
+			})
+
+		res, err := usecase.Patch(ctx, bookID, input)
+		assert.NoError(t, err)
+		assert.NotNil(t, res)
+	})
+
+	t.Run("success - json patch", func(t *testing.T) {
+		input := model.PatchBookInput{
+			Type:     model.JSONPatch,
+			Document: []byte(`[{"op":"remove","path":"/published_date"},{"op":"replace","path":"/author","value":"Rowling"}]`),
+		}
+
+		mockedBookRepo.EXPECT().FindByID(ctx, bookID).Times(1).Return(currentBook(), nil)
+		mockedBookRepo.EXPECT().Update(ctx, gomock.Any()).Times(1).DoAndReturn(
+			func(_ context.Context, patched *model.Book) (*model.Book, error) {
+				assert.Equal(t, "Rowling", patched.Author)
+				assert.Empty(t, patched.PublishedDate)
+				return patched, nil
+			})
+
+		res, err := usecase.Patch(ctx, bookID, input)
+		assert.NoError(t, err)
+		assert.NotNil(t, res)
+	})
+
+	t.Run("failed - patched book is invalid", func(t *testing.T) {
+		input := model.PatchBookInput{Type: model.MergePatch, Document: []byte(`{"title":null}`)}
+
+		mockedBookRepo.EXPECT().FindByID(ctx, bookID).Times(1).Return(currentBook(), nil)
+
+		res, err := usecase.Patch(ctx, bookID, input)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, res)
+	})
+
+	t.Run("success - isbn10 fills in the isbn13", func(t *testing.T) {
+		input := model.PatchBookInput{Type: model.MergePatch, Document: []byte(`{"isbn10":"0-7475-3269-9"}`)}
+
+		mockedBookRepo.EXPECT().FindByID(ctx, bookID).Times(1).Return(currentBook(), nil)
+		mockedBookRepo.EXPECT().Update(ctx, gomock.Any()).Times(1).DoAndReturn(
+			func(_ context.Context, patched *model.Book) (*model.Book, error) {
+				assert.Equal(t, "9780747532699", *patched.ISBN13)
+				assert.Equal(t, "0747532699", *patched.ISBN10)
+				return patched, nil
+			})
+
+		res, err := usecase.Patch(ctx, bookID, input)
+		assert.NoError(t, err)
+		assert.NotNil(t, res)
+	})
+
+	t.Run("failed - patched isbn10 is another book", func(t *testing.T) {
+		input := model.PatchBookInput{Type: model.MergePatch, Document: []byte(`{"isbn13":"9780747532699","isbn10":"080442957X"}`)}
+
+		mockedBookRepo.EXPECT().FindByID(ctx, bookID).Times(1).Return(currentBook(), nil)
+
+		res, err := usecase.Patch(ctx, bookID, input)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - patch document is invalid", func(t *testing.T) {
+		input := model.PatchBookInput{Type: model.JSONPatch, Document: []byte(`{"title":"Dune"}`)}
+
+		mockedBookRepo.EXPECT().FindByID(ctx, bookID).Times(1).Return(currentBook(), nil)
+
+		res, err := usecase.Patch(ctx, bookID, input)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - version does not match", func(t *testing.T) {
+		input := model.PatchBookInput{Type: model.MergePatch, Document: []byte(`{}`), Version: 1}
+
+		modifiedBook := currentBook()
+		modifiedBook.Version = 2
+		mockedBookRepo.EXPECT().FindByID(ctx, bookID).Times(1).Return(modifiedBook, nil)
+
+		res, err := usecase.Patch(ctx, bookID, input)
+		assert.ErrorIs(t, err, domainerr.ErrPreconditionFailed)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - find by id return error", func(t *testing.T) {
+		input := model.PatchBookInput{Type: model.MergePatch, Document: []byte(`{}`)}
+
+		mockedBookRepo.EXPECT().FindByID(ctx, bookID).Times(1).Return(nil, domainerr.NotFound("book not found", nil))
+
+		res, err := usecase.Patch(ctx, bookID, input)
+		assert.ErrorIs(t, err, domainerr.ErrNotFound)
+		assert.Nil(t, res)
+	})
+}
+
+func TestBookUsecase_BatchCreate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedBookRepo := mock.NewMockBookRepository(ctrl)
+	usecase := bookUsecase{bookRepo: mockedBookRepo}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	t.Run("success", func(t *testing.T) {
+		input := model.BatchCreateBooksInput{
+			Books: []model.CreateBookInput{{Title: "Harry Potter"}, {Title: "Dune"}},
+		}
+
+		mockedBookRepo.EXPECT().BatchCreate(ctx, gomock.Len(2), true).Times(1).Return([]error{nil, nil}, nil)
+
+		res, err := usecase.BatchCreate(ctx, input)
+		assert.NoError(t, err)
+		assert.Len(t, res, 2)
+		assert.Equal(t, "Dune", res[1].Book.Title)
+		assert.NotZero(t, res[1].ID)
+	})
+
+	t.Run("success - best effort skips the invalid books", func(t *testing.T) {
+		input := model.BatchCreateBooksInput{
+			Mode:  model.BatchBestEffort,
+			Books: []model.CreateBookInput{{Title: ""}, {Title: "Dune"}, {Title: "Emma"}},
+		}
+
+		conflictErr := domainerr.Conflict("resource already exists", nil)
+		mockedBookRepo.EXPECT().BatchCreate(ctx, gomock.Len(2), false).Times(1).Return([]error{nil, conflictErr}, nil)
+
+		res, err := usecase.BatchCreate(ctx, input)
+		assert.NoError(t, err)
+		assert.ErrorIs(t, res[0].Err, domainerr.ErrValidation)
+		assert.NoError(t, res[1].Err)
+		assert.NotNil(t, res[1].Book)
+		assert.ErrorIs(t, res[2].Err, domainerr.ErrConflict)
+		assert.Nil(t, res[2].Book)
+	})
+
+	t.Run("failed - transactional batch with an invalid book", func(t *testing.T) {
+		input := model.BatchCreateBooksInput{
+			Books: []model.CreateBookInput{{Title: "Harry Potter"}, {Title: " "}},
+		}
+
+		res, err := usecase.BatchCreate(ctx, input)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, res)
+
+		domainErr := &domainerr.Error{}
+		assert.ErrorAs(t, err, &domainErr)
+		assert.Contains(t, domainErr.Fields, "books[1].title")
+	})
+
+	t.Run("failed - too many books", func(t *testing.T) {
+		input := model.BatchCreateBooksInput{Books: make([]model.CreateBookInput, 101)}
+
+		res, err := usecase.BatchCreate(ctx, input)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - mode is invalid", func(t *testing.T) {
+		input := model.BatchCreateBooksInput{
+			Mode:  "sometimes",
+			Books: []model.CreateBookInput{{Title: "Dune"}},
+		}
+
+		res, err := usecase.BatchCreate(ctx, input)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, res)
+	})
+}
+
+func TestBookUsecase_BatchUpdate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedBookRepo := mock.NewMockBookRepository(ctrl)
+	usecase := bookUsecase{bookRepo: mockedBookRepo}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	input := model.BatchUpdateBooksInput{
+		Books: []model.BatchUpdateBookInput{{ID: 1, Title: "Harry Potter"}, {ID: 2, Title: "Dune", Version: 3}},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		updated := []*model.Book{{ID: 1, Version: 2}, {ID: 2, Version: 4}}
+		mockedBookRepo.EXPECT().BatchUpdate(ctx, gomock.Len(2), true).Times(1).Return(updated, []error{nil, nil}, nil)
+
+		res, err := usecase.BatchUpdate(ctx, input)
+		assert.NoError(t, err)
+		assert.Equal(t, updated[1], res[1].Book)
+	})
+
+	t.Run("failed - transactional batch with a stale book", func(t *testing.T) {
+		staleErr := domainerr.PreconditionFailed("book 2 has been modified", nil)
+		mockedBookRepo.EXPECT().BatchUpdate(ctx, gomock.Len(2), true).Times(1).Return(nil, []error{nil, staleErr}, nil)
+
+		res, err := usecase.BatchUpdate(ctx, input)
+		assert.ErrorIs(t, err, domainerr.ErrPreconditionFailed)
+		assert.Contains(t, err.Error(), "books[1]")
+		assert.Nil(t, res)
+	})
+
+	t.Run("success - best effort with a duplicate book", func(t *testing.T) {
+		duplicateInput := model.BatchUpdateBooksInput{
+			Mode:  model.BatchBestEffort,
+			Books: []model.BatchUpdateBookInput{{ID: 1, Title: "Harry Potter"}, {ID: 1, Title: "Dune"}},
+		}
+		updated := []*model.Book{{ID: 1, Version: 2}}
+		mockedBookRepo.EXPECT().BatchUpdate(ctx, gomock.Len(1), false).Times(1).Return(updated, []error{nil}, nil)
+
+		res, err := usecase.BatchUpdate(ctx, duplicateInput)
+		assert.NoError(t, err)
+		assert.Equal(t, updated[0], res[0].Book)
+		assert.ErrorIs(t, res[1].Err, domainerr.ErrValidation)
+	})
+
+	t.Run("failed - transactional batch with a duplicate book", func(t *testing.T) {
+		duplicateInput := model.BatchUpdateBooksInput{
+			Books: []model.BatchUpdateBookInput{{ID: 1, Title: "Harry Potter"}, {ID: 2, Title: "Dune"}, {ID: 1, Title: "Emma"}},
+		}
+
+		res, err := usecase.BatchUpdate(ctx, duplicateInput)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Contains(t, err.Error(), "books[2]")
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - batch update return error", func(t *testing.T) {
+		mockedBookRepo.EXPECT().BatchUpdate(ctx, gomock.Len(2), true).Times(1).Return(nil, nil, errors.New("redis error"))
+
+		res, err := usecase.BatchUpdate(ctx, input)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestBookUsecase_BatchDelete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedBookRepo := mock.NewMockBookRepository(ctrl)
+	usecase := bookUsecase{bookRepo: mockedBookRepo}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	t.Run("success - best effort", func(t *testing.T) {
+		input := model.BatchDeleteBooksInput{Mode: model.BatchBestEffort, IDs: []int64{1, 0, 2}}
+		notFoundErr := domainerr.NotFound("book 2 not found", nil)
+
+		mockedBookRepo.EXPECT().BatchDelete(ctx, []int64{1, 2}, false).Times(1).Return([]error{nil, notFoundErr}, nil)
+
+		res, err := usecase.BatchDelete(ctx, input)
+		assert.NoError(t, err)
+		assert.NoError(t, res[0].Err)
+		assert.ErrorIs(t, res[1].Err, domainerr.ErrValidation)
+		assert.ErrorIs(t, res[2].Err, domainerr.ErrNotFound)
+	})
+
+	t.Run("failed - transactional batch with a missing book", func(t *testing.T) {
+		input := model.BatchDeleteBooksInput{IDs: []int64{1, 2}}
+		notFoundErr := domainerr.NotFound("book 2 not found", nil)
+
+		mockedBookRepo.EXPECT().BatchDelete(ctx, []int64{1, 2}, true).Times(1).Return([]error{nil, notFoundErr}, nil)
+
+		res, err := usecase.BatchDelete(ctx, input)
+		assert.ErrorIs(t, err, domainerr.ErrNotFound)
+		assert.Contains(t, err.Error(), "ids[1]")
+		assert.Nil(t, res)
+	})
+
+	t.Run("success - best effort with a duplicate id", func(t *testing.T) {
+		input := model.BatchDeleteBooksInput{Mode: model.BatchBestEffort, IDs: []int64{1, 2, 1}}
+
+		mockedBookRepo.EXPECT().BatchDelete(ctx, []int64{1, 2}, false).Times(1).Return([]error{nil, nil}, nil)
+
+		res, err := usecase.BatchDelete(ctx, input)
+		assert.NoError(t, err)
+		assert.NoError(t, res[0].Err)
+		assert.NoError(t, res[1].Err)
+		assert.ErrorIs(t, res[2].Err, domainerr.ErrValidation)
+	})
+
+	t.Run("failed - transactional batch with a duplicate id", func(t *testing.T) {
+		res, err := usecase.BatchDelete(ctx, model.BatchDeleteBooksInput{IDs: []int64{1, 1}})
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Contains(t, err.Error(), "ids[1]")
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - ids are required", func(t *testing.T) {
+		res, err := usecase.BatchDelete(ctx, model.BatchDeleteBooksInput{})
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
 		assert.Nil(t, res)
 	})
 }
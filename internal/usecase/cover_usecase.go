@@ -0,0 +1,154 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/ssentinull/create-apis-using-golang/internal/config"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+	"github.com/ssentinull/create-apis-using-golang/internal/utils"
+)
+
+var (
+	errUnsupportedCover = domainerr.Validation("cover must be a JPEG, PNG or WebP image", nil)
+	errCoverNotFound    = domainerr.NotFound("book cover not found", nil)
+)
+
+type coverUsecase struct {
+	bookRepo  model.BookRepository
+	blobStore model.BlobStore
+}
+
+func NewCoverUsecase(br model.BookRepository, bs model.BlobStore) model.CoverUsecase {
+	return &coverUsecase{bookRepo: br, blobStore: bs}
+}
+
+// Upload stores body as a new version of the cover of a book along with its
+// thumbnail, the previous version is deleted once the book points at the new
+// one. The image header is checked against the pixel limit before the whole
+// image is decoded
+func (cu *coverUsecase) Upload(ctx context.Context, bookID int64, body []byte) (*model.Book, error) {
+	if bookID <= 0 {
+		return nil, errInvalidBookID
+	}
+
+	if int64(len(body)) > config.CoverMaxSize() {
+		return nil, domainerr.Validation(fmt.Sprintf("cover must be at most %d bytes", config.CoverMaxSize()), nil)
+	}
+
+	contentType, ok := utils.SniffImage(body)
+	if !ok {
+		return nil, errUnsupportedCover
+	}
+
+	imageConfig, _, err := image.DecodeConfig(bytes.NewReader(body))
+	if err != nil {
+		return nil, domainerr.Validation("cover is not a valid image", err)
+	}
+
+	if int64(imageConfig.Width)*int64(imageConfig.Height) > config.CoverMaxPixels() {
+		return nil, domainerr.Validation(fmt.Sprintf("cover must be at most %d pixels", config.CoverMaxPixels()), nil)
+	}
+
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":         utils.Dump(ctx),
+		"bookID":      bookID,
+		"contentType": contentType,
+	})
+
+	previous, err := cu.bookRepo.FindByID(ctx, bookID)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(body))
+	if err != nil {
+		return nil, domainerr.Validation("cover is not a valid image", err)
+	}
+
+	thumb, thumbContentType, err := utils.EncodeThumbnail(utils.Thumbnail(img, config.CoverThumbWidth()), contentType)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	// postgres keeps microseconds, the version has to survive the round trip
+	coverUpdatedAt := time.Now().UTC().Truncate(time.Microsecond)
+	version := model.FormatCoverVersion(coverUpdatedAt)
+
+	original := &model.Blob{ContentType: contentType, Body: body}
+	if err := cu.blobStore.Put(ctx, model.CoverKey(bookID, version, model.CoverSizeOriginal), original); err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if err := cu.blobStore.Put(ctx, model.CoverKey(bookID, version, model.CoverSizeThumb), &model.Blob{ContentType: thumbContentType, Body: thumb}); err != nil {
+		logger.Error(err)
+		cu.deleteCover(ctx, logger, bookID, version)
+		return nil, err
+	}
+
+	book, err := cu.bookRepo.UpdateCover(ctx, bookID, coverUpdatedAt)
+	if err != nil {
+		logger.Error(err)
+		cu.deleteCover(ctx, logger, bookID, version)
+		return nil, err
+	}
+
+	if previous.CoverUpdatedAt != nil && previous.CoverVersion() != version {
+		cu.deleteCover(ctx, logger, bookID, previous.CoverVersion())
+	}
+
+	return book, nil
+}
+
+// deleteCover deletes a version of the cover of a book which no cover URL
+// points at, a failure only leaves orphaned blobs behind so it is logged
+func (cu *coverUsecase) deleteCover(ctx context.Context, logger *logrus.Entry, bookID int64, version string) {
+	if err := cu.blobStore.Delete(ctx, model.CoverKeys(bookID, version)...); err != nil {
+		logger.WithField("version", version).Error(err)
+	}
+}
+
+// FindByBookID returns the cover of a book in size, the covers of the
+// deleted books are not served
+func (cu *coverUsecase) FindByBookID(ctx context.Context, bookID int64, size string) (*model.Cover, error) {
+	if bookID <= 0 {
+		return nil, errInvalidBookID
+	}
+
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":    utils.Dump(ctx),
+		"bookID": bookID,
+		"size":   size,
+	})
+
+	book, err := cu.bookRepo.FindByID(ctx, bookID)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	if book.CoverUpdatedAt == nil {
+		return nil, errCoverNotFound
+	}
+
+	cover, err := cu.blobStore.Get(ctx, model.CoverKey(bookID, book.CoverVersion(), size))
+	if errors.Is(err, domainerr.ErrNotFound) {
+		return nil, errCoverNotFound
+	}
+
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return &model.Cover{Blob: *cover, Version: book.CoverVersion()}, nil
+}
@@ -0,0 +1,208 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/png"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+	"github.com/ssentinull/create-apis-using-golang/internal/model/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func newPNG(t *testing.T, width, height int) []byte {
+	buf := &bytes.Buffer{}
+	assert.NoError(t, png.Encode(buf, image.NewRGBA(image.Rect(0, 0, width, height))))
+	return buf.Bytes()
+}
+
+// resizePNG rewrites the dimensions in the header of a PNG, without encoding all its pixels
+func resizePNG(body []byte, width, height uint32) []byte {
+	resized := append([]byte{}, body...)
+	binary.BigEndian.PutUint32(resized[16:], width)
+	binary.BigEndian.PutUint32(resized[20:], height)
+	binary.BigEndian.PutUint32(resized[29:], crc32.ChecksumIEEE(resized[12:29]))
+	return resized
+}
+
+func TestCoverUsecase_Upload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedBookRepo := mock.NewMockBookRepository(ctrl)
+	mockedBlobStore := mock.NewMockBlobStore(ctrl)
+	usecase := coverUsecase{bookRepo: mockedBookRepo, blobStore: mockedBlobStore}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	cover := newPNG(t, 600, 900)
+
+	previousUpdatedAt := time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC)
+	coveredBook := &model.Book{ID: bookID, Title: "Harry Potter", CoverUpdatedAt: &previousUpdatedAt}
+
+	t.Run("success", func(t *testing.T) {
+		version := ""
+		mockedBookRepo.EXPECT().FindByID(ctx, bookID).Times(1).Return(book, nil)
+		mockedBlobStore.EXPECT().Put(ctx, gomock.Any(), &model.Blob{ContentType: "image/png", Body: cover}).Times(1).
+			DoAndReturn(func(_ context.Context, key string, _ *model.Blob) error {
+				assert.Regexp(t, `^covers/1/\d+/original$`, key)
+				return nil
+			})
+		mockedBlobStore.EXPECT().Put(ctx, gomock.Any(), gomock.Any()).Times(1).
+			DoAndReturn(func(_ context.Context, key string, blob *model.Blob) error {
+				thumb, err := png.DecodeConfig(bytes.NewReader(blob.Body))
+				assert.NoError(t, err)
+				assert.Equal(t, 300, thumb.Width)
+				assert.Equal(t, 450, thumb.Height)
+				assert.Regexp(t, `^covers/1/\d+/thumb$`, key)
+				return nil
+			})
+		mockedBookRepo.EXPECT().UpdateCover(ctx, bookID, gomock.Any()).Times(1).
+			DoAndReturn(func(_ context.Context, _ int64, coverUpdatedAt time.Time) (*model.Book, error) {
+				version = model.FormatCoverVersion(coverUpdatedAt)
+				return book, nil
+			})
+
+		res, err := usecase.Upload(ctx, bookID, cover)
+		assert.NoError(t, err)
+		assert.Equal(t, book, res)
+		assert.NotEmpty(t, version)
+	})
+
+	t.Run("success - previous version is deleted", func(t *testing.T) {
+		mockedBookRepo.EXPECT().FindByID(ctx, bookID).Times(1).Return(coveredBook, nil)
+		mockedBlobStore.EXPECT().Put(ctx, gomock.Any(), gomock.Any()).Times(2).Return(nil)
+		mockedBookRepo.EXPECT().UpdateCover(ctx, bookID, gomock.Any()).Times(1).Return(coveredBook, nil)
+		mockedBlobStore.EXPECT().Delete(ctx, model.CoverKeys(bookID, coveredBook.CoverVersion())).Times(1).Return(nil)
+
+		res, err := usecase.Upload(ctx, bookID, cover)
+		assert.NoError(t, err)
+		assert.Equal(t, coveredBook, res)
+	})
+
+	t.Run("failed - new version is deleted when the book can't be updated", func(t *testing.T) {
+		keys := []string{}
+		mockedBookRepo.EXPECT().FindByID(ctx, bookID).Times(1).Return(coveredBook, nil)
+		mockedBlobStore.EXPECT().Put(ctx, gomock.Any(), gomock.Any()).Times(2).
+			DoAndReturn(func(_ context.Context, key string, _ *model.Blob) error {
+				keys = append(keys, key)
+				return nil
+			})
+		mockedBookRepo.EXPECT().UpdateCover(ctx, bookID, gomock.Any()).Times(1).Return(nil, errors.New("db error"))
+		mockedBlobStore.EXPECT().Delete(ctx, gomock.Any()).Times(1).
+			DoAndReturn(func(_ context.Context, deleted ...string) error {
+				assert.Equal(t, keys, deleted)
+				return nil
+			})
+
+		res, err := usecase.Upload(ctx, bookID, cover)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - book does not exist", func(t *testing.T) {
+		mockedBookRepo.EXPECT().FindByID(ctx, bookID).Times(1).Return(nil, domainerr.NotFound("book 1 not found", nil))
+
+		res, err := usecase.Upload(ctx, bookID, cover)
+		assert.ErrorIs(t, err, domainerr.ErrNotFound)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - blob store return error", func(t *testing.T) {
+		mockedBookRepo.EXPECT().FindByID(ctx, bookID).Times(1).Return(book, nil)
+		mockedBlobStore.EXPECT().Put(ctx, gomock.Any(), gomock.Any()).Times(1).Return(domainerr.Unavailable("blob store is unavailable", nil))
+
+		res, err := usecase.Upload(ctx, bookID, cover)
+		assert.ErrorIs(t, err, domainerr.ErrUnavailable)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - not an image", func(t *testing.T) {
+		res, err := usecase.Upload(ctx, bookID, []byte("GIF89a"))
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - truncated image", func(t *testing.T) {
+		res, err := usecase.Upload(ctx, bookID, cover[:16])
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - too many pixels", func(t *testing.T) {
+		res, err := usecase.Upload(ctx, bookID, resizePNG(cover, 10000, 10000))
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - invalid book ID", func(t *testing.T) {
+		res, err := usecase.Upload(ctx, 0, cover)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, res)
+	})
+}
+
+func TestCoverUsecase_FindByBookID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedBookRepo := mock.NewMockBookRepository(ctrl)
+	mockedBlobStore := mock.NewMockBlobStore(ctrl)
+	usecase := coverUsecase{bookRepo: mockedBookRepo, blobStore: mockedBlobStore}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	coverUpdatedAt := time.Now()
+	coveredBook := &model.Book{ID: bookID, Title: "Harry Potter", CoverUpdatedAt: &coverUpdatedAt}
+	thumb := &model.Blob{ContentType: "image/jpeg", Body: []byte("thumb")}
+	thumbKey := model.CoverKey(bookID, coveredBook.CoverVersion(), model.CoverSizeThumb)
+
+	t.Run("success", func(t *testing.T) {
+		mockedBookRepo.EXPECT().FindByID(ctx, bookID).Times(1).Return(coveredBook, nil)
+		mockedBlobStore.EXPECT().Get(ctx, thumbKey).Times(1).Return(thumb, nil)
+
+		res, err := usecase.FindByBookID(ctx, bookID, model.CoverSizeThumb)
+		assert.NoError(t, err)
+		assert.Equal(t, *thumb, res.Blob)
+		assert.Equal(t, strconv.FormatInt(coverUpdatedAt.UnixMicro(), 10), res.Version)
+	})
+
+	t.Run("failed - book has no cover", func(t *testing.T) {
+		mockedBookRepo.EXPECT().FindByID(ctx, bookID).Times(1).Return(&model.Book{ID: bookID}, nil)
+
+		res, err := usecase.FindByBookID(ctx, bookID, model.CoverSizeThumb)
+		assert.ErrorIs(t, err, domainerr.ErrNotFound)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - cover is missing from the blob store", func(t *testing.T) {
+		mockedBookRepo.EXPECT().FindByID(ctx, bookID).Times(1).Return(coveredBook, nil)
+		mockedBlobStore.EXPECT().Get(ctx, thumbKey).Times(1).Return(nil, domainerr.NotFound("blob not found", nil))
+
+		res, err := usecase.FindByBookID(ctx, bookID, model.CoverSizeThumb)
+		assert.ErrorIs(t, err, domainerr.ErrNotFound)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - blob store return error", func(t *testing.T) {
+		mockedBookRepo.EXPECT().FindByID(ctx, bookID).Times(1).Return(coveredBook, nil)
+		mockedBlobStore.EXPECT().Get(ctx, thumbKey).Times(1).Return(nil, errors.New("blob store error"))
+
+		res, err := usecase.FindByBookID(ctx, bookID, model.CoverSizeThumb)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
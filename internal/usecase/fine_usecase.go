@@ -0,0 +1,142 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/ssentinull/create-apis-using-golang/internal/config"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+	"github.com/ssentinull/create-apis-using-golang/internal/utils"
+)
+
+type fineUsecase struct {
+	fineRepo model.FineRepository
+	schedule model.FeeSchedule
+	now      func() time.Time
+}
+
+// NewFineUsecase prices the overdue loans with schedule as of the time told
+// by now
+func NewFineUsecase(fr model.FineRepository, schedule model.FeeSchedule, now func() time.Time) model.FineUsecase {
+	return &fineUsecase{
+		fineRepo: fr,
+		schedule: schedule,
+		now:      now,
+	}
+}
+
+// Settle records a payment or a waiver of the balance of a member
+func (fu *fineUsecase) Settle(ctx context.Context, fine *model.Fine) (*model.Fine, error) {
+	if err := utils.ValidateStruct(fine); err != nil {
+		return nil, err
+	}
+
+	if fine.Kind == model.FineKindAccrual {
+		return nil, domainerr.Validation("fines accrue from overdue loans only", nil)
+	}
+
+	fine.CreatedAt = fu.now()
+	if err := fu.fineRepo.Create(ctx, fine); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":  utils.Dump(ctx),
+			"fine": utils.Dump(fine),
+		}).Error(err)
+		return nil, err
+	}
+
+	return fine, nil
+}
+
+func (fu *fineUsecase) FindAllByMemberID(ctx context.Context, params model.GetFinesQueryParams) ([]*model.Fine, int64, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":    utils.Dump(ctx),
+		"params": utils.Dump(params),
+	})
+
+	params.Normalize(config.PaginationDefaultSize(), config.PaginationMaxSize())
+	if err := utils.ValidateStruct(params); err != nil {
+		return nil, int64(0), err
+	}
+
+	fines, err := fu.fineRepo.FindAllByMemberID(ctx, params)
+	if err != nil {
+		logger.Error(err)
+		return nil, int64(0), err
+	}
+
+	count, err := fu.fineRepo.CountAllByMemberID(ctx, params)
+	if err != nil {
+		logger.Error(err)
+		return nil, int64(0), err
+	}
+
+	return fines, count, nil
+}
+
+func (fu *fineUsecase) BalanceByMemberID(ctx context.Context, memberID int64) (int64, error) {
+	if memberID <= 0 {
+		return 0, errInvalidMemberID
+	}
+
+	balance, err := fu.fineRepo.BalanceByMemberID(ctx, memberID)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":      utils.Dump(ctx),
+			"memberID": memberID,
+		}).Error(err)
+		return 0, err
+	}
+
+	return balance, nil
+}
+
+// AccrueOverdue brings the fines of every overdue loan up to what the fee
+// schedule charges for it now, it returns the number of loans fined. A
+// loan returned or renewed meanwhile is left to the next run
+func (fu *fineUsecase) AccrueOverdue(ctx context.Context) (int64, error) {
+	now := fu.now()
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx": utils.Dump(ctx),
+		"now": now,
+	})
+
+	loans, err := fu.fineRepo.FindAllAccruingLoans(ctx, now.AddDate(0, 0, -int(fu.schedule.GracePeriodDays)))
+	if err != nil {
+		logger.Error(err)
+		return 0, err
+	}
+
+	count := int64(0)
+	for _, loan := range loans {
+		overdueDays, amountCents := fu.schedule.Fine(loan, now)
+		loanID := loan.ID
+		fine := &model.Fine{
+			ID:          utils.GenerateID(),
+			MemberID:    loan.MemberID,
+			LoanID:      &loanID,
+			Kind:        model.FineKindAccrual,
+			AmountCents: amountCents,
+			OverdueDays: overdueDays,
+			CreatedAt:   now,
+		}
+
+		accrued, err := fu.fineRepo.Accrue(ctx, loan, fine)
+		if errors.Is(err, domainerr.ErrConflict) {
+			continue
+		}
+
+		if err != nil {
+			logger.Error(err)
+			return count, err
+		}
+
+		if accrued {
+			count++
+		}
+	}
+
+	return count, nil
+}
@@ -0,0 +1,188 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+	"github.com/ssentinull/create-apis-using-golang/internal/model/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	fineNow      = time.Date(2026, 10, 20, 10, 0, 0, 0, time.UTC)
+	feeSchedule  = model.FeeSchedule{PerDayCents: 25, GracePeriodDays: 2, CapCents: 300}
+	finePayment  = &model.Fine{ID: 1, MemberID: memberID, Kind: model.FineKindPayment, AmountCents: 50}
+	fines        = []*model.Fine{finePayment}
+	fixedFineNow = func() time.Time { return fineNow }
+)
+
+func TestFeeSchedule_Fine(t *testing.T) {
+	dueAt := fineNow.AddDate(0, 0, -5)
+	returnedAt := dueAt.Add(36 * time.Hour)
+
+	tests := []struct {
+		name        string
+		loan        *model.Loan
+		overdueDays int64
+		amountCents int64
+	}{
+		{"not due yet", &model.Loan{DueAt: fineNow.Add(time.Hour)}, 0, 0},
+		{"within the grace period", &model.Loan{DueAt: fineNow.Add(-47 * time.Hour)}, 1, 0},
+		{"past the grace period", &model.Loan{DueAt: dueAt}, 5, 75},
+		{"capped", &model.Loan{DueAt: fineNow.AddDate(0, -2, 0)}, 61, 300},
+		{"returned within the grace period", &model.Loan{DueAt: dueAt, ReturnedAt: &returnedAt}, 1, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			overdueDays, amountCents := feeSchedule.Fine(test.loan, fineNow)
+			assert.Equal(t, test.overdueDays, overdueDays)
+			assert.Equal(t, test.amountCents, amountCents)
+		})
+	}
+
+	t.Run("no cap", func(t *testing.T) {
+		schedule := model.FeeSchedule{PerDayCents: 25}
+		_, amountCents := schedule.Fine(&model.Loan{DueAt: fineNow.AddDate(0, -2, 0)}, fineNow)
+		assert.Equal(t, int64(61*25), amountCents)
+	})
+}
+
+func TestFineUsecase_AccrueOverdue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedFineRepo := mock.NewMockFineRepository(ctrl)
+	usecase := fineUsecase{fineRepo: mockedFineRepo, schedule: feeSchedule, now: fixedFineNow}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	dueBefore := fineNow.AddDate(0, 0, -2)
+	overdue := &model.Loan{ID: 1, MemberID: memberID, DueAt: fineNow.AddDate(0, 0, -5)}
+	renewed := &model.Loan{ID: 2, MemberID: memberID, DueAt: fineNow.AddDate(0, 0, -3)}
+
+	t.Run("success", func(t *testing.T) {
+		mockedFineRepo.EXPECT().FindAllAccruingLoans(ctx, dueBefore).Times(1).Return([]*model.Loan{overdue, renewed}, nil)
+		mockedFineRepo.EXPECT().Accrue(ctx, overdue, gomock.Any()).Times(1).DoAndReturn(
+			func(_ context.Context, _ *model.Loan, fine *model.Fine) (bool, error) {
+				assert.Equal(t, overdue.ID, *fine.LoanID)
+				assert.Equal(t, memberID, fine.MemberID)
+				assert.Equal(t, model.FineKindAccrual, fine.Kind)
+				assert.Equal(t, int64(5), fine.OverdueDays)
+				assert.Equal(t, int64(75), fine.AmountCents)
+				assert.Equal(t, fineNow, fine.CreatedAt)
+				return true, nil
+			})
+		mockedFineRepo.EXPECT().Accrue(ctx, renewed, gomock.Any()).Times(1).Return(false, domainerr.Conflict("loan 2 changed while accruing its fines", nil))
+
+		res, err := usecase.AccrueOverdue(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), res)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		mockedFineRepo.EXPECT().FindAllAccruingLoans(ctx, dueBefore).Times(1).Return([]*model.Loan{overdue}, nil)
+		mockedFineRepo.EXPECT().Accrue(ctx, overdue, gomock.Any()).Times(1).Return(false, errors.New("db error"))
+
+		res, err := usecase.AccrueOverdue(ctx)
+		assert.Error(t, err)
+		assert.Zero(t, res)
+	})
+}
+
+func TestFineUsecase_Settle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedFineRepo := mock.NewMockFineRepository(ctrl)
+	usecase := fineUsecase{fineRepo: mockedFineRepo, schedule: feeSchedule, now: fixedFineNow}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	t.Run("success", func(t *testing.T) {
+		mockedFineRepo.EXPECT().Create(ctx, finePayment).Times(1).Return(nil)
+		res, err := usecase.Settle(ctx, finePayment)
+		assert.NoError(t, err)
+		assert.Equal(t, fineNow, res.CreatedAt)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		mockedFineRepo.EXPECT().Create(ctx, finePayment).Times(1).Return(domainerr.Conflict("amount exceeds the balance of 0 cents", nil))
+		res, err := usecase.Settle(ctx, finePayment)
+		assert.ErrorIs(t, err, domainerr.ErrConflict)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - accrual", func(t *testing.T) {
+		res, err := usecase.Settle(ctx, &model.Fine{MemberID: memberID, Kind: model.FineKindAccrual, AmountCents: 50})
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, res)
+	})
+}
+
+func TestFineUsecase_FindAllByMemberID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedFineRepo := mock.NewMockFineRepository(ctrl)
+	usecase := fineUsecase{fineRepo: mockedFineRepo, schedule: feeSchedule, now: fixedFineNow}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	params := model.GetFinesQueryParams{MemberID: memberID, PageQuery: model.PageQuery{Page: 1, Size: 5}}
+
+	t.Run("success", func(t *testing.T) {
+		mockedFineRepo.EXPECT().FindAllByMemberID(ctx, params).Times(1).Return(fines, nil)
+		mockedFineRepo.EXPECT().CountAllByMemberID(ctx, params).Times(1).Return(int64(1), nil)
+
+		res, count, err := usecase.FindAllByMemberID(ctx, params)
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		mockedFineRepo.EXPECT().FindAllByMemberID(ctx, params).Times(1).Return(nil, errors.New("db error"))
+
+		res, count, err := usecase.FindAllByMemberID(ctx, params)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+		assert.Zero(t, count)
+	})
+}
+
+func TestFineUsecase_BalanceByMemberID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedFineRepo := mock.NewMockFineRepository(ctrl)
+	usecase := fineUsecase{fineRepo: mockedFineRepo, schedule: feeSchedule, now: fixedFineNow}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	t.Run("success", func(t *testing.T) {
+		mockedFineRepo.EXPECT().BalanceByMemberID(ctx, memberID).Times(1).Return(int64(75), nil)
+		res, err := usecase.BalanceByMemberID(ctx, memberID)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(75), res)
+	})
+
+	t.Run("failed - invalid member ID", func(t *testing.T) {
+		res, err := usecase.BalanceByMemberID(ctx, 0)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Zero(t, res)
+	})
+}
@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+	"github.com/ssentinull/create-apis-using-golang/internal/utils"
+)
+
+var errInvalidGenreID = domainerr.Validation("genre ID must be a positive number", nil)
+
+type genreUsecase struct {
+	genreRepo model.GenreRepository
+}
+
+func NewGenreUsecase(gr model.GenreRepository) model.GenreUsecase {
+	return &genreUsecase{genreRepo: gr}
+}
+
+func (gu *genreUsecase) Create(ctx context.Context, genre *model.Genre) (*model.Genre, error) {
+	if err := utils.ValidateStruct(genre); err != nil {
+		return nil, err
+	}
+
+	if err := gu.genreRepo.Create(ctx, genre); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":   utils.Dump(ctx),
+			"genre": utils.Dump(genre),
+		}).Error(err)
+		return nil, err
+	}
+
+	return genre, nil
+}
+
+func (gu *genreUsecase) DeleteByID(ctx context.Context, ID int64) error {
+	if ID <= 0 {
+		return errInvalidGenreID
+	}
+
+	if err := gu.genreRepo.DeleteByID(ctx, ID); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx": utils.Dump(ctx),
+			"ID":  ID,
+		}).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (gu *genreUsecase) FindByID(ctx context.Context, ID int64) (*model.Genre, error) {
+	if ID <= 0 {
+		return nil, errInvalidGenreID
+	}
+
+	genre, err := gu.genreRepo.FindByID(ctx, ID)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx": utils.Dump(ctx),
+			"ID":  ID,
+		}).Error(err)
+		return nil, err
+	}
+
+	return genre, nil
+}
+
+// FindTree returns the root genres with their subgenres nested under them
+func (gu *genreUsecase) FindTree(ctx context.Context) ([]*model.Genre, error) {
+	genres, err := gu.genreRepo.FindAll(ctx)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx": utils.Dump(ctx),
+		}).Error(err)
+		return nil, err
+	}
+
+	return model.NewGenreTree(genres), nil
+}
+
+func (gu *genreUsecase) Update(ctx context.Context, genre *model.Genre) (*model.Genre, error) {
+	if genre.ID <= 0 {
+		return nil, errInvalidGenreID
+	}
+
+	if err := utils.ValidateStruct(genre); err != nil {
+		return nil, err
+	}
+
+	updated, err := gu.genreRepo.Update(ctx, genre)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":   utils.Dump(ctx),
+			"genre": utils.Dump(genre),
+		}).Error(err)
+		return nil, err
+	}
+
+	return updated, nil
+}
@@ -0,0 +1,178 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+	"github.com/ssentinull/create-apis-using-golang/internal/model/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	genreID = int64(1)
+	genre   = &model.Genre{
+		ID:   genreID,
+		Name: "Fiction",
+	}
+)
+
+func TestGenreUsecase_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedGenreRepo := mock.NewMockGenreRepository(ctrl)
+	usecase := genreUsecase{genreRepo: mockedGenreRepo}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	t.Run("success", func(t *testing.T) {
+		mockedGenreRepo.EXPECT().Create(ctx, genre).Times(1).Return(nil)
+		res, err := usecase.Create(ctx, genre)
+		assert.NoError(t, err)
+		assert.Equal(t, genre, res)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		mockedGenreRepo.EXPECT().Create(ctx, genre).Times(1).Return(errors.New("db error"))
+		res, err := usecase.Create(ctx, genre)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - invalid genre", func(t *testing.T) {
+		res, err := usecase.Create(ctx, &model.Genre{Name: " "})
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, res)
+	})
+}
+
+func TestGenreUsecase_DeleteByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedGenreRepo := mock.NewMockGenreRepository(ctrl)
+	usecase := genreUsecase{genreRepo: mockedGenreRepo}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	t.Run("success", func(t *testing.T) {
+		mockedGenreRepo.EXPECT().DeleteByID(ctx, genreID).Times(1).Return(nil)
+		err := usecase.DeleteByID(ctx, genreID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("failed - genre has subgenres", func(t *testing.T) {
+		mockedGenreRepo.EXPECT().DeleteByID(ctx, genreID).Times(1).Return(domainerr.Conflict("genre has subgenres", nil))
+		err := usecase.DeleteByID(ctx, genreID)
+		assert.ErrorIs(t, err, domainerr.ErrConflict)
+	})
+
+	t.Run("failed - invalid ID", func(t *testing.T) {
+		err := usecase.DeleteByID(ctx, int64(0))
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+	})
+}
+
+func TestGenreUsecase_FindByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedGenreRepo := mock.NewMockGenreRepository(ctrl)
+	usecase := genreUsecase{genreRepo: mockedGenreRepo}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	t.Run("success", func(t *testing.T) {
+		mockedGenreRepo.EXPECT().FindByID(ctx, genreID).Times(1).Return(genre, nil)
+		res, err := usecase.FindByID(ctx, genreID)
+		assert.NoError(t, err)
+		assert.Equal(t, genre, res)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		mockedGenreRepo.EXPECT().FindByID(ctx, genreID).Times(1).Return(nil, errors.New("db error"))
+		res, err := usecase.FindByID(ctx, genreID)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - invalid ID", func(t *testing.T) {
+		res, err := usecase.FindByID(ctx, int64(-1))
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, res)
+	})
+}
+
+func TestGenreUsecase_FindTree(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedGenreRepo := mock.NewMockGenreRepository(ctrl)
+	usecase := genreUsecase{genreRepo: mockedGenreRepo}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	t.Run("success", func(t *testing.T) {
+		fantasy := &model.Genre{ID: int64(2), Name: "Fantasy", ParentID: &genreID}
+		mockedGenreRepo.EXPECT().FindAll(ctx).Times(1).Return([]*model.Genre{fantasy, genre}, nil)
+
+		res, err := usecase.FindTree(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+		assert.Equal(t, "Fiction", res[0].Name)
+		assert.Len(t, res[0].Children, 1)
+		assert.Equal(t, "Fantasy", res[0].Children[0].Name)
+		assert.Nil(t, genre.Children)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		mockedGenreRepo.EXPECT().FindAll(ctx).Times(1).Return(nil, errors.New("db error"))
+		res, err := usecase.FindTree(ctx)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestGenreUsecase_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedGenreRepo := mock.NewMockGenreRepository(ctrl)
+	usecase := genreUsecase{genreRepo: mockedGenreRepo}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	t.Run("success", func(t *testing.T) {
+		mockedGenreRepo.EXPECT().Update(ctx, genre).Times(1).Return(genre, nil)
+		res, err := usecase.Update(ctx, genre)
+		assert.NoError(t, err)
+		assert.Equal(t, genre, res)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		mockedGenreRepo.EXPECT().Update(ctx, genre).Times(1).Return(nil, errors.New("db error"))
+		res, err := usecase.Update(ctx, genre)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - invalid ID", func(t *testing.T) {
+		res, err := usecase.Update(ctx, &model.Genre{Name: "Fiction"})
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, res)
+	})
+}
@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/ssentinull/create-apis-using-golang/internal/config"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+	"github.com/ssentinull/create-apis-using-golang/internal/utils"
+)
+
+type holdUsecase struct {
+	holdRepo model.HoldRepository
+	now      func() time.Time
+}
+
+// NewHoldUsecase dates the pickup windows with now, the clock the loans are
+// dated by
+func NewHoldUsecase(hr model.HoldRepository, now func() time.Time) model.HoldUsecase {
+	return &holdUsecase{holdRepo: hr, now: now}
+}
+
+func (hu *holdUsecase) Place(ctx context.Context, hold *model.Hold) (*model.Hold, error) {
+	if err := utils.ValidateStruct(hold); err != nil {
+		return nil, err
+	}
+
+	if err := hu.holdRepo.Create(ctx, hold); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":  utils.Dump(ctx),
+			"hold": utils.Dump(hold),
+		}).Error(err)
+		return nil, err
+	}
+
+	return hold, nil
+}
+
+// Cancel takes a member out of the queue of a book, the copy of a ready
+// hold is reserved for the next hold for the configured pickup window
+func (hu *holdUsecase) Cancel(ctx context.Context, input model.CancelHoldInput) (*model.Hold, error) {
+	if err := utils.ValidateStruct(input); err != nil {
+		return nil, err
+	}
+
+	now := hu.now()
+	pickupBy := now.Add(config.HoldPickupWindow())
+	hold, err := hu.holdRepo.Cancel(ctx, input.BookID, input.MemberID, now, pickupBy)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":   utils.Dump(ctx),
+			"input": utils.Dump(input),
+		}).Error(err)
+		return nil, err
+	}
+
+	return hold, nil
+}
+
+func (hu *holdUsecase) FindAllByBookID(ctx context.Context, params model.GetHoldsQueryParams) ([]*model.Hold, int64, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":    utils.Dump(ctx),
+		"params": utils.Dump(params),
+	})
+
+	params.Normalize(config.PaginationDefaultSize(), config.PaginationMaxSize())
+	if err := utils.ValidateStruct(params); err != nil {
+		return nil, int64(0), err
+	}
+
+	holds, err := hu.holdRepo.FindAllByBookID(ctx, params)
+	if err != nil {
+		logger.Error(err)
+		return nil, int64(0), err
+	}
+
+	count, err := hu.holdRepo.CountAllByBookID(ctx, params)
+	if err != nil {
+		logger.Error(err)
+		return nil, int64(0), err
+	}
+
+	return holds, count, nil
+}
+
+// ExpireReady expires the ready holds whose pickup window has closed, their
+// copies are reserved for the next holds for the configured pickup window
+func (hu *holdUsecase) ExpireReady(ctx context.Context) (int64, error) {
+	now := hu.now()
+	pickupBy := now.Add(config.HoldPickupWindow())
+	count, err := hu.holdRepo.ExpireReady(ctx, now, pickupBy)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":      utils.Dump(ctx),
+			"now":      now,
+			"pickupBy": pickupBy,
+		}).Error(err)
+		return count, err
+	}
+
+	return count, nil
+}
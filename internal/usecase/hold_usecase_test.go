@@ -0,0 +1,152 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+	"github.com/ssentinull/create-apis-using-golang/internal/model/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	holdID = int64(1)
+	hold   = &model.Hold{
+		ID:       holdID,
+		BookID:   bookID,
+		MemberID: memberID,
+		Status:   model.HoldStatusWaiting,
+	}
+	holds = []*model.Hold{hold}
+)
+
+func TestHoldUsecase_Place(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedHoldRepo := mock.NewMockHoldRepository(ctrl)
+	usecase := holdUsecase{holdRepo: mockedHoldRepo, now: fixedLoanNow}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	t.Run("success", func(t *testing.T) {
+		mockedHoldRepo.EXPECT().Create(ctx, hold).Times(1).Return(nil)
+		res, err := usecase.Place(ctx, hold)
+		assert.NoError(t, err)
+		assert.Equal(t, hold, res)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		mockedHoldRepo.EXPECT().Create(ctx, hold).Times(1).Return(domainerr.Conflict("book 1 has available copies", nil))
+		res, err := usecase.Place(ctx, hold)
+		assert.ErrorIs(t, err, domainerr.ErrConflict)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - member is missing", func(t *testing.T) {
+		res, err := usecase.Place(ctx, &model.Hold{BookID: bookID, Status: model.HoldStatusWaiting})
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, res)
+	})
+}
+
+func TestHoldUsecase_Cancel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedHoldRepo := mock.NewMockHoldRepository(ctrl)
+	usecase := holdUsecase{holdRepo: mockedHoldRepo, now: fixedLoanNow}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	input := model.CancelHoldInput{BookID: bookID, MemberID: memberID}
+
+	t.Run("success", func(t *testing.T) {
+		mockedHoldRepo.EXPECT().Cancel(ctx, bookID, memberID, loanNow, loanNow.Add(72*time.Hour)).Times(1).Return(hold, nil)
+
+		res, err := usecase.Cancel(ctx, input)
+		assert.NoError(t, err)
+		assert.Equal(t, hold, res)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		mockedHoldRepo.EXPECT().Cancel(ctx, bookID, memberID, gomock.Any(), gomock.Any()).Times(1).Return(nil, domainerr.NotFound("record not found", nil))
+		res, err := usecase.Cancel(ctx, input)
+		assert.ErrorIs(t, err, domainerr.ErrNotFound)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - invalid member ID", func(t *testing.T) {
+		res, err := usecase.Cancel(ctx, model.CancelHoldInput{BookID: bookID})
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, res)
+	})
+}
+
+func TestHoldUsecase_FindAllByBookID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedHoldRepo := mock.NewMockHoldRepository(ctrl)
+	usecase := holdUsecase{holdRepo: mockedHoldRepo, now: fixedLoanNow}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	params := model.GetHoldsQueryParams{BookID: bookID, PageQuery: model.PageQuery{Page: 1, Size: 5}}
+
+	t.Run("success", func(t *testing.T) {
+		mockedHoldRepo.EXPECT().FindAllByBookID(ctx, params).Times(1).Return(holds, nil)
+		mockedHoldRepo.EXPECT().CountAllByBookID(ctx, params).Times(1).Return(int64(1), nil)
+
+		res, count, err := usecase.FindAllByBookID(ctx, params)
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		mockedHoldRepo.EXPECT().FindAllByBookID(ctx, params).Times(1).Return(nil, errors.New("db error"))
+
+		res, count, err := usecase.FindAllByBookID(ctx, params)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+		assert.Zero(t, count)
+	})
+}
+
+func TestHoldUsecase_ExpireReady(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedHoldRepo := mock.NewMockHoldRepository(ctrl)
+	usecase := holdUsecase{holdRepo: mockedHoldRepo, now: fixedLoanNow}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	t.Run("success", func(t *testing.T) {
+		mockedHoldRepo.EXPECT().ExpireReady(ctx, loanNow, loanNow.Add(72*time.Hour)).Times(1).Return(int64(2), nil)
+
+		res, err := usecase.ExpireReady(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), res)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		mockedHoldRepo.EXPECT().ExpireReady(ctx, gomock.Any(), gomock.Any()).Times(1).Return(int64(0), errors.New("db error"))
+		res, err := usecase.ExpireReady(ctx)
+		assert.Error(t, err)
+		assert.Zero(t, res)
+	})
+}
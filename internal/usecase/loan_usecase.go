@@ -0,0 +1,163 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/ssentinull/create-apis-using-golang/internal/config"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+	"github.com/ssentinull/create-apis-using-golang/internal/utils"
+)
+
+var (
+	errInvalidLoanID = domainerr.Validation("loan ID must be a positive number", nil)
+	errPastDueDate   = domainerr.Validation("due date must be in the future", nil)
+)
+
+type loanUsecase struct {
+	loanRepo     model.LoanRepository
+	bookCopyRepo model.BookCopyRepository
+	now          func() time.Time
+}
+
+// NewLoanUsecase dates the checkouts and the returns with now, the clock
+// the fines are accrued by
+func NewLoanUsecase(lr model.LoanRepository, cr model.BookCopyRepository, now func() time.Time) model.LoanUsecase {
+	return &loanUsecase{
+		loanRepo:     lr,
+		bookCopyRepo: cr,
+		now:          now,
+	}
+}
+
+// Checkout lends a copy to a member, a copy known by its barcode is looked
+// up first
+func (lu *loanUsecase) Checkout(ctx context.Context, input model.CheckoutInput) (*model.Loan, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   utils.Dump(ctx),
+		"input": utils.Dump(input),
+	})
+
+	if err := utils.ValidateStruct(input); err != nil {
+		return nil, err
+	}
+
+	now := lu.now()
+	dueAt := now.AddDate(0, 0, config.LoanPeriodDays())
+	if input.DueAt != nil {
+		if !input.DueAt.After(now) {
+			return nil, errPastDueDate
+		}
+		dueAt = *input.DueAt
+	}
+
+	copyID := input.CopyID
+	if copyID == 0 {
+		bookCopy, err := lu.bookCopyRepo.FindByBarcode(ctx, input.Barcode)
+		if err != nil {
+			logger.Error(err)
+			return nil, err
+		}
+		copyID = bookCopy.ID
+	}
+
+	loan := &model.Loan{
+		ID:           utils.GenerateID(),
+		CopyID:       copyID,
+		MemberID:     input.MemberID,
+		CheckedOutAt: now,
+		DueAt:        dueAt,
+		CreatedAt:    now,
+	}
+
+	if err := lu.loanRepo.Create(ctx, loan); err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return loan, nil
+}
+
+func (lu *loanUsecase) Return(ctx context.Context, ID int64) (*model.Loan, error) {
+	if ID <= 0 {
+		return nil, errInvalidLoanID
+	}
+
+	now := lu.now()
+	loan, err := lu.loanRepo.Return(ctx, ID, now, now.Add(config.HoldPickupWindow()))
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx": utils.Dump(ctx),
+			"ID":  ID,
+		}).Error(err)
+		return nil, err
+	}
+
+	return loan, nil
+}
+
+func (lu *loanUsecase) UpdateDueDate(ctx context.Context, input model.UpdateLoanDueDateInput) (*model.Loan, error) {
+	if input.ID <= 0 {
+		return nil, errInvalidLoanID
+	}
+
+	if err := utils.ValidateStruct(input); err != nil {
+		return nil, err
+	}
+
+	loan, err := lu.loanRepo.UpdateDueDate(ctx, input.ID, input.DueAt)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":   utils.Dump(ctx),
+			"input": utils.Dump(input),
+		}).Error(err)
+		return nil, err
+	}
+
+	return loan, nil
+}
+
+func (lu *loanUsecase) FindByID(ctx context.Context, ID int64) (*model.Loan, error) {
+	if ID <= 0 {
+		return nil, errInvalidLoanID
+	}
+
+	loan, err := lu.loanRepo.FindByID(ctx, ID)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx": utils.Dump(ctx),
+			"ID":  ID,
+		}).Error(err)
+		return nil, err
+	}
+
+	return loan, nil
+}
+
+func (lu *loanUsecase) FindAllByMemberID(ctx context.Context, params model.GetLoansQueryParams) ([]*model.Loan, int64, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":    utils.Dump(ctx),
+		"params": utils.Dump(params),
+	})
+
+	params.Normalize(config.PaginationDefaultSize(), config.PaginationMaxSize())
+	if err := utils.ValidateStruct(params); err != nil {
+		return nil, int64(0), err
+	}
+
+	loans, err := lu.loanRepo.FindAllByMemberID(ctx, params)
+	if err != nil {
+		logger.Error(err)
+		return nil, int64(0), err
+	}
+
+	count, err := lu.loanRepo.CountAllByMemberID(ctx, params)
+	if err != nil {
+		logger.Error(err)
+		return nil, int64(0), err
+	}
+
+	return loans, count, nil
+}
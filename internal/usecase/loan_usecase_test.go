@@ -0,0 +1,190 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ssentinull/create-apis-using-golang/internal/config"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+	"github.com/ssentinull/create-apis-using-golang/internal/model/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	loanID = int64(1)
+	loan   = &model.Loan{
+		ID:       loanID,
+		CopyID:   bookCopyID,
+		BookID:   bookID,
+		MemberID: memberID,
+	}
+	loans = []*model.Loan{loan}
+	// loanNow is the fixed time of the loan and hold usecases under test
+	loanNow      = time.Now()
+	fixedLoanNow = func() time.Time { return loanNow }
+)
+
+func TestLoanUsecase_Checkout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedLoanRepo := mock.NewMockLoanRepository(ctrl)
+	mockedBookCopyRepo := mock.NewMockBookCopyRepository(ctrl)
+	usecase := loanUsecase{loanRepo: mockedLoanRepo, bookCopyRepo: mockedBookCopyRepo, now: fixedLoanNow}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	t.Run("success - due after the loan period", func(t *testing.T) {
+		mockedLoanRepo.EXPECT().Create(ctx, gomock.Any()).Times(1).
+			DoAndReturn(func(_ context.Context, loan *model.Loan) error {
+				assert.NotZero(t, loan.ID)
+				assert.Equal(t, bookCopyID, loan.CopyID)
+				assert.Equal(t, memberID, loan.MemberID)
+				assert.Equal(t, loanNow, loan.CheckedOutAt)
+				assert.Equal(t, loanNow.AddDate(0, 0, config.LoanPeriodDays()), loan.DueAt)
+				return nil
+			})
+
+		res, err := usecase.Checkout(ctx, model.CheckoutInput{CopyID: bookCopyID, MemberID: memberID})
+		assert.NoError(t, err)
+		assert.True(t, res.IsOpen())
+	})
+
+	t.Run("success - copy looked up by barcode", func(t *testing.T) {
+		dueAt := time.Now().Add(72 * time.Hour)
+		mockedBookCopyRepo.EXPECT().FindByBarcode(ctx, "LIB-0001").Times(1).Return(bookCopy, nil)
+		mockedLoanRepo.EXPECT().Create(ctx, gomock.Any()).Times(1).
+			DoAndReturn(func(_ context.Context, loan *model.Loan) error {
+				assert.Equal(t, bookCopyID, loan.CopyID)
+				assert.Equal(t, dueAt, loan.DueAt)
+				return nil
+			})
+
+		_, err := usecase.Checkout(ctx, model.CheckoutInput{Barcode: "LIB-0001", MemberID: memberID, DueAt: &dueAt})
+		assert.NoError(t, err)
+	})
+
+	t.Run("failed - copy is on loan", func(t *testing.T) {
+		mockedLoanRepo.EXPECT().Create(ctx, gomock.Any()).Times(1).Return(domainerr.Conflict("copy 1 is on_loan", nil))
+
+		res, err := usecase.Checkout(ctx, model.CheckoutInput{CopyID: bookCopyID, MemberID: memberID})
+		assert.ErrorIs(t, err, domainerr.ErrConflict)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - unknown barcode", func(t *testing.T) {
+		mockedBookCopyRepo.EXPECT().FindByBarcode(ctx, "LIB-9999").Times(1).Return(nil, domainerr.NotFound("record not found", nil))
+
+		res, err := usecase.Checkout(ctx, model.CheckoutInput{Barcode: "LIB-9999", MemberID: memberID})
+		assert.ErrorIs(t, err, domainerr.ErrNotFound)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - due date in the past", func(t *testing.T) {
+		dueAt := time.Now().Add(-time.Hour)
+
+		res, err := usecase.Checkout(ctx, model.CheckoutInput{CopyID: bookCopyID, MemberID: memberID, DueAt: &dueAt})
+		assert.Equal(t, errPastDueDate, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - neither copy ID nor barcode", func(t *testing.T) {
+		res, err := usecase.Checkout(ctx, model.CheckoutInput{MemberID: memberID})
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, res)
+	})
+}
+
+func TestLoanUsecase_Return(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedLoanRepo := mock.NewMockLoanRepository(ctrl)
+	usecase := loanUsecase{loanRepo: mockedLoanRepo, now: fixedLoanNow}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	t.Run("success", func(t *testing.T) {
+		mockedLoanRepo.EXPECT().Return(ctx, loanID, loanNow, loanNow.Add(config.HoldPickupWindow())).Times(1).Return(loan, nil)
+		res, err := usecase.Return(ctx, loanID)
+		assert.NoError(t, err)
+		assert.Equal(t, loan, res)
+	})
+
+	t.Run("failed - loan is already returned", func(t *testing.T) {
+		mockedLoanRepo.EXPECT().Return(ctx, loanID, gomock.Any(), gomock.Any()).Times(1).Return(nil, domainerr.Conflict("loan 1 is already returned", nil))
+		res, err := usecase.Return(ctx, loanID)
+		assert.ErrorIs(t, err, domainerr.ErrConflict)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - invalid ID", func(t *testing.T) {
+		res, err := usecase.Return(ctx, 0)
+		assert.Equal(t, errInvalidLoanID, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestLoanUsecase_UpdateDueDate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedLoanRepo := mock.NewMockLoanRepository(ctrl)
+	usecase := loanUsecase{loanRepo: mockedLoanRepo, now: fixedLoanNow}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	dueAt := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("success", func(t *testing.T) {
+		mockedLoanRepo.EXPECT().UpdateDueDate(ctx, loanID, dueAt).Times(1).Return(loan, nil)
+		res, err := usecase.UpdateDueDate(ctx, model.UpdateLoanDueDateInput{ID: loanID, DueAt: dueAt})
+		assert.NoError(t, err)
+		assert.Equal(t, loan, res)
+	})
+
+	t.Run("failed - due date is missing", func(t *testing.T) {
+		res, err := usecase.UpdateDueDate(ctx, model.UpdateLoanDueDateInput{ID: loanID})
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, res)
+	})
+}
+
+func TestLoanUsecase_FindAllByMemberID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedLoanRepo := mock.NewMockLoanRepository(ctrl)
+	usecase := loanUsecase{loanRepo: mockedLoanRepo, now: fixedLoanNow}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	params := model.GetLoansQueryParams{MemberID: memberID, Page: 1, Size: 5, Status: model.LoanStatusAll}
+
+	t.Run("success - loans in any status by default", func(t *testing.T) {
+		mockedLoanRepo.EXPECT().FindAllByMemberID(ctx, params).Times(1).Return(loans, nil)
+		mockedLoanRepo.EXPECT().CountAllByMemberID(ctx, params).Times(1).Return(int64(1), nil)
+
+		res, count, err := usecase.FindAllByMemberID(ctx, model.GetLoansQueryParams{MemberID: memberID, Page: 1, Size: 5})
+		assert.NoError(t, err)
+		assert.Equal(t, loans, res)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("failed - invalid member ID", func(t *testing.T) {
+		res, _, err := usecase.FindAllByMemberID(ctx, model.GetLoansQueryParams{})
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, res)
+	})
+}
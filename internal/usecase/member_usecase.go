@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	"github.com/ssentinull/create-apis-using-golang/internal/config"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+	"github.com/ssentinull/create-apis-using-golang/internal/utils"
+)
+
+var errInvalidMemberID = domainerr.Validation("member ID must be a positive number", nil)
+
+type memberUsecase struct {
+	memberRepo model.MemberRepository
+}
+
+func NewMemberUsecase(mr model.MemberRepository) model.MemberUsecase {
+	return &memberUsecase{memberRepo: mr}
+}
+
+func (mu *memberUsecase) Create(ctx context.Context, member *model.Member) (*model.Member, error) {
+	if err := utils.ValidateStruct(member); err != nil {
+		return nil, err
+	}
+
+	if err := mu.memberRepo.Create(ctx, member); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":    utils.Dump(ctx),
+			"member": utils.Dump(member),
+		}).Error(err)
+		return nil, err
+	}
+
+	return member, nil
+}
+
+func (mu *memberUsecase) DeleteByID(ctx context.Context, ID int64) error {
+	if ID <= 0 {
+		return errInvalidMemberID
+	}
+
+	if err := mu.memberRepo.DeleteByID(ctx, ID); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx": utils.Dump(ctx),
+			"ID":  ID,
+		}).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (mu *memberUsecase) FindByID(ctx context.Context, ID int64) (*model.Member, error) {
+	if ID <= 0 {
+		return nil, errInvalidMemberID
+	}
+
+	member, err := mu.memberRepo.FindByID(ctx, ID)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx": utils.Dump(ctx),
+			"ID":  ID,
+		}).Error(err)
+		return nil, err
+	}
+
+	return member, nil
+}
+
+func (mu *memberUsecase) FindAll(ctx context.Context, params model.GetMembersQueryParams) ([]*model.Member, int64, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":    utils.Dump(ctx),
+		"params": utils.Dump(params),
+	})
+
+	params.Normalize(config.PaginationDefaultSize(), config.PaginationMaxSize())
+	if err := utils.ValidateStruct(params); err != nil {
+		return nil, int64(0), err
+	}
+
+	members, err := mu.memberRepo.FindAll(ctx, params)
+	if err != nil {
+		logger.Error(err)
+		return nil, int64(0), err
+	}
+
+	count, err := mu.memberRepo.CountAll(ctx, params)
+	if err != nil {
+		logger.Error(err)
+		return nil, int64(0), err
+	}
+
+	return members, count, nil
+}
+
+func (mu *memberUsecase) Update(ctx context.Context, member *model.Member) (*model.Member, error) {
+	if member.ID <= 0 {
+		return nil, errInvalidMemberID
+	}
+
+	if err := utils.ValidateStruct(member); err != nil {
+		return nil, err
+	}
+
+	updated, err := mu.memberRepo.Update(ctx, member)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":    utils.Dump(ctx),
+			"member": utils.Dump(member),
+		}).Error(err)
+		return nil, err
+	}
+
+	return updated, nil
+}
@@ -0,0 +1,190 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+	"github.com/ssentinull/create-apis-using-golang/internal/model/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	memberID = int64(1)
+	member   = &model.Member{
+		ID:    memberID,
+		Name:  "Hermione Granger",
+		Email: "hermione@hogwarts.edu",
+	}
+	members = []*model.Member{member}
+)
+
+func TestMemberUsecase_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedMemberRepo := mock.NewMockMemberRepository(ctrl)
+	usecase := memberUsecase{memberRepo: mockedMemberRepo}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	t.Run("success", func(t *testing.T) {
+		mockedMemberRepo.EXPECT().Create(ctx, member).Times(1).Return(nil)
+		res, err := usecase.Create(ctx, member)
+		assert.NoError(t, err)
+		assert.Equal(t, member, res)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		mockedMemberRepo.EXPECT().Create(ctx, member).Times(1).Return(errors.New("db error"))
+		res, err := usecase.Create(ctx, member)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - invalid email", func(t *testing.T) {
+		res, err := usecase.Create(ctx, &model.Member{Name: "Hermione Granger", Email: "hermione"})
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, res)
+	})
+}
+
+func TestMemberUsecase_DeleteByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedMemberRepo := mock.NewMockMemberRepository(ctrl)
+	usecase := memberUsecase{memberRepo: mockedMemberRepo}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	t.Run("success", func(t *testing.T) {
+		mockedMemberRepo.EXPECT().DeleteByID(ctx, memberID).Times(1).Return(nil)
+		err := usecase.DeleteByID(ctx, memberID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		mockedMemberRepo.EXPECT().DeleteByID(ctx, memberID).Times(1).Return(errors.New("db error"))
+		err := usecase.DeleteByID(ctx, memberID)
+		assert.Error(t, err)
+	})
+
+	t.Run("failed - invalid ID", func(t *testing.T) {
+		err := usecase.DeleteByID(ctx, 0)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+	})
+}
+
+func TestMemberUsecase_FindByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedMemberRepo := mock.NewMockMemberRepository(ctrl)
+	usecase := memberUsecase{memberRepo: mockedMemberRepo}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	t.Run("success", func(t *testing.T) {
+		mockedMemberRepo.EXPECT().FindByID(ctx, memberID).Times(1).Return(member, nil)
+		res, err := usecase.FindByID(ctx, memberID)
+		assert.NoError(t, err)
+		assert.Equal(t, member, res)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		mockedMemberRepo.EXPECT().FindByID(ctx, memberID).Times(1).Return(nil, errors.New("db error"))
+		res, err := usecase.FindByID(ctx, memberID)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - invalid ID", func(t *testing.T) {
+		res, err := usecase.FindByID(ctx, -1)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, res)
+	})
+}
+
+func TestMemberUsecase_FindAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedMemberRepo := mock.NewMockMemberRepository(ctrl)
+	usecase := memberUsecase{memberRepo: mockedMemberRepo}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	params := model.GetMembersQueryParams{PageQuery: model.PageQuery{Page: 1, Size: 5}}
+
+	t.Run("success", func(t *testing.T) {
+		mockedMemberRepo.EXPECT().FindAll(ctx, params).Times(1).Return(members, nil)
+		mockedMemberRepo.EXPECT().CountAll(ctx, params).Times(1).Return(int64(1), nil)
+
+		res, count, err := usecase.FindAll(ctx, params)
+		assert.NoError(t, err)
+		assert.Equal(t, members, res)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("failed - find all return error", func(t *testing.T) {
+		mockedMemberRepo.EXPECT().FindAll(ctx, params).Times(1).Return(nil, errors.New("db error"))
+
+		res, count, err := usecase.FindAll(ctx, params)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+		assert.Zero(t, count)
+	})
+
+	t.Run("failed - count all return error", func(t *testing.T) {
+		mockedMemberRepo.EXPECT().FindAll(ctx, params).Times(1).Return(members, nil)
+		mockedMemberRepo.EXPECT().CountAll(ctx, params).Times(1).Return(int64(0), errors.New("db error"))
+
+		res, _, err := usecase.FindAll(ctx, params)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestMemberUsecase_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedMemberRepo := mock.NewMockMemberRepository(ctrl)
+	usecase := memberUsecase{memberRepo: mockedMemberRepo}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	t.Run("success", func(t *testing.T) {
+		mockedMemberRepo.EXPECT().Update(ctx, member).Times(1).Return(member, nil)
+		res, err := usecase.Update(ctx, member)
+		assert.NoError(t, err)
+		assert.Equal(t, member, res)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		mockedMemberRepo.EXPECT().Update(ctx, member).Times(1).Return(nil, errors.New("db error"))
+		res, err := usecase.Update(ctx, member)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - invalid email", func(t *testing.T) {
+		res, err := usecase.Update(ctx, &model.Member{ID: memberID, Name: ""})
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, res)
+	})
+}
@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	"github.com/ssentinull/create-apis-using-golang/internal/config"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+	"github.com/ssentinull/create-apis-using-golang/internal/utils"
+)
+
+var errInvalidPublisherID = domainerr.Validation("publisher ID must be a positive number", nil)
+
+type publisherUsecase struct {
+	publisherRepo model.PublisherRepository
+}
+
+func NewPublisherUsecase(pr model.PublisherRepository) model.PublisherUsecase {
+	return &publisherUsecase{publisherRepo: pr}
+}
+
+func (pu *publisherUsecase) Create(ctx context.Context, publisher *model.Publisher) (*model.Publisher, error) {
+	if err := utils.ValidateStruct(publisher); err != nil {
+		return nil, err
+	}
+
+	if err := pu.publisherRepo.Create(ctx, publisher); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":       utils.Dump(ctx),
+			"publisher": utils.Dump(publisher),
+		}).Error(err)
+		return nil, err
+	}
+
+	return publisher, nil
+}
+
+func (pu *publisherUsecase) DeleteByID(ctx context.Context, ID int64) error {
+	if ID <= 0 {
+		return errInvalidPublisherID
+	}
+
+	if err := pu.publisherRepo.DeleteByID(ctx, ID); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx": utils.Dump(ctx),
+			"ID":  ID,
+		}).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (pu *publisherUsecase) FindByID(ctx context.Context, ID int64) (*model.Publisher, error) {
+	if ID <= 0 {
+		return nil, errInvalidPublisherID
+	}
+
+	publisher, err := pu.publisherRepo.FindByID(ctx, ID)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx": utils.Dump(ctx),
+			"ID":  ID,
+		}).Error(err)
+		return nil, err
+	}
+
+	return publisher, nil
+}
+
+func (pu *publisherUsecase) FindAll(ctx context.Context, params model.GetPublishersQueryParams) ([]*model.Publisher, int64, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":    utils.Dump(ctx),
+		"params": utils.Dump(params),
+	})
+
+	params.Normalize(config.PaginationDefaultSize(), config.PaginationMaxSize())
+	if err := utils.ValidateStruct(params); err != nil {
+		return nil, int64(0), err
+	}
+
+	publishers, err := pu.publisherRepo.FindAll(ctx, params)
+	if err != nil {
+		logger.Error(err)
+		return nil, int64(0), err
+	}
+
+	count, err := pu.publisherRepo.CountAll(ctx, params)
+	if err != nil {
+		logger.Error(err)
+		return nil, int64(0), err
+	}
+
+	return publishers, count, nil
+}
+
+func (pu *publisherUsecase) Update(ctx context.Context, publisher *model.Publisher) (*model.Publisher, error) {
+	if publisher.ID <= 0 {
+		return nil, errInvalidPublisherID
+	}
+
+	if err := utils.ValidateStruct(publisher); err != nil {
+		return nil, err
+	}
+
+	updated, err := pu.publisherRepo.Update(ctx, publisher)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":       utils.Dump(ctx),
+			"publisher": utils.Dump(publisher),
+		}).Error(err)
+		return nil, err
+	}
+
+	return updated, nil
+}
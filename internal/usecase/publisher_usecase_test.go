@@ -0,0 +1,189 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+	"github.com/ssentinull/create-apis-using-golang/internal/model/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	publisherID = int64(1)
+	publisher   = &model.Publisher{
+		ID:   publisherID,
+		Name: "Bloomsbury",
+	}
+	publishers = []*model.Publisher{publisher}
+)
+
+func TestPublisherUsecase_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedPublisherRepo := mock.NewMockPublisherRepository(ctrl)
+	usecase := publisherUsecase{publisherRepo: mockedPublisherRepo}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	t.Run("success", func(t *testing.T) {
+		mockedPublisherRepo.EXPECT().Create(ctx, publisher).Times(1).Return(nil)
+		res, err := usecase.Create(ctx, publisher)
+		assert.NoError(t, err)
+		assert.Equal(t, publisher, res)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		mockedPublisherRepo.EXPECT().Create(ctx, publisher).Times(1).Return(errors.New("db error"))
+		res, err := usecase.Create(ctx, publisher)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - invalid publisher", func(t *testing.T) {
+		res, err := usecase.Create(ctx, &model.Publisher{Name: " "})
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, res)
+	})
+}
+
+func TestPublisherUsecase_DeleteByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedPublisherRepo := mock.NewMockPublisherRepository(ctrl)
+	usecase := publisherUsecase{publisherRepo: mockedPublisherRepo}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	t.Run("success", func(t *testing.T) {
+		mockedPublisherRepo.EXPECT().DeleteByID(ctx, publisherID).Times(1).Return(nil)
+		err := usecase.DeleteByID(ctx, publisherID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		mockedPublisherRepo.EXPECT().DeleteByID(ctx, publisherID).Times(1).Return(errors.New("db error"))
+		err := usecase.DeleteByID(ctx, publisherID)
+		assert.Error(t, err)
+	})
+
+	t.Run("failed - invalid ID", func(t *testing.T) {
+		err := usecase.DeleteByID(ctx, 0)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+	})
+}
+
+func TestPublisherUsecase_FindByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedPublisherRepo := mock.NewMockPublisherRepository(ctrl)
+	usecase := publisherUsecase{publisherRepo: mockedPublisherRepo}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	t.Run("success", func(t *testing.T) {
+		mockedPublisherRepo.EXPECT().FindByID(ctx, publisherID).Times(1).Return(publisher, nil)
+		res, err := usecase.FindByID(ctx, publisherID)
+		assert.NoError(t, err)
+		assert.Equal(t, publisher, res)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		mockedPublisherRepo.EXPECT().FindByID(ctx, publisherID).Times(1).Return(nil, errors.New("db error"))
+		res, err := usecase.FindByID(ctx, publisherID)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - invalid ID", func(t *testing.T) {
+		res, err := usecase.FindByID(ctx, -1)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, res)
+	})
+}
+
+func TestPublisherUsecase_FindAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedPublisherRepo := mock.NewMockPublisherRepository(ctrl)
+	usecase := publisherUsecase{publisherRepo: mockedPublisherRepo}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	params := model.GetPublishersQueryParams{PageQuery: model.PageQuery{Page: 1, Size: 5}}
+
+	t.Run("success", func(t *testing.T) {
+		mockedPublisherRepo.EXPECT().FindAll(ctx, params).Times(1).Return(publishers, nil)
+		mockedPublisherRepo.EXPECT().CountAll(ctx, params).Times(1).Return(int64(1), nil)
+
+		res, count, err := usecase.FindAll(ctx, params)
+		assert.NoError(t, err)
+		assert.Equal(t, publishers, res)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("failed - find all return error", func(t *testing.T) {
+		mockedPublisherRepo.EXPECT().FindAll(ctx, params).Times(1).Return(nil, errors.New("db error"))
+
+		res, count, err := usecase.FindAll(ctx, params)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+		assert.Zero(t, count)
+	})
+
+	t.Run("failed - count all return error", func(t *testing.T) {
+		mockedPublisherRepo.EXPECT().FindAll(ctx, params).Times(1).Return(publishers, nil)
+		mockedPublisherRepo.EXPECT().CountAll(ctx, params).Times(1).Return(int64(0), errors.New("db error"))
+
+		res, _, err := usecase.FindAll(ctx, params)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestPublisherUsecase_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedPublisherRepo := mock.NewMockPublisherRepository(ctrl)
+	usecase := publisherUsecase{publisherRepo: mockedPublisherRepo}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	t.Run("success", func(t *testing.T) {
+		mockedPublisherRepo.EXPECT().Update(ctx, publisher).Times(1).Return(publisher, nil)
+		res, err := usecase.Update(ctx, publisher)
+		assert.NoError(t, err)
+		assert.Equal(t, publisher, res)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		mockedPublisherRepo.EXPECT().Update(ctx, publisher).Times(1).Return(nil, errors.New("db error"))
+		res, err := usecase.Update(ctx, publisher)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - invalid publisher", func(t *testing.T) {
+		res, err := usecase.Update(ctx, &model.Publisher{ID: publisherID, Name: ""})
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, res)
+	})
+}
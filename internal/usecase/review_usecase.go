@@ -0,0 +1,138 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	"github.com/ssentinull/create-apis-using-golang/internal/config"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+	"github.com/ssentinull/create-apis-using-golang/internal/utils"
+)
+
+var errInvalidReviewID = domainerr.Validation("review ID must be a positive number", nil)
+
+type reviewUsecase struct {
+	reviewRepo model.ReviewRepository
+}
+
+func NewReviewUsecase(rr model.ReviewRepository) model.ReviewUsecase {
+	return &reviewUsecase{reviewRepo: rr}
+}
+
+func (ru *reviewUsecase) Create(ctx context.Context, review *model.Review) (*model.Review, error) {
+	if err := utils.ValidateStruct(review); err != nil {
+		return nil, err
+	}
+
+	if err := ru.reviewRepo.Create(ctx, review); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":    utils.Dump(ctx),
+			"review": utils.Dump(review),
+		}).Error(err)
+		return nil, err
+	}
+
+	return review, nil
+}
+
+func (ru *reviewUsecase) DeleteByID(ctx context.Context, ID int64) error {
+	if ID <= 0 {
+		return errInvalidReviewID
+	}
+
+	if err := ru.reviewRepo.DeleteByID(ctx, ID); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx": utils.Dump(ctx),
+			"ID":  ID,
+		}).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (ru *reviewUsecase) FindByID(ctx context.Context, ID int64) (*model.Review, error) {
+	if ID <= 0 {
+		return nil, errInvalidReviewID
+	}
+
+	review, err := ru.reviewRepo.FindByID(ctx, ID)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx": utils.Dump(ctx),
+			"ID":  ID,
+		}).Error(err)
+		return nil, err
+	}
+
+	return review, nil
+}
+
+func (ru *reviewUsecase) FindAllByBookID(ctx context.Context, params model.GetReviewsQueryParams) ([]*model.Review, int64, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":    utils.Dump(ctx),
+		"params": utils.Dump(params),
+	})
+
+	params.Normalize(config.PaginationDefaultSize(), config.PaginationMaxSize())
+	if err := utils.ValidateStruct(params); err != nil {
+		return nil, int64(0), err
+	}
+
+	reviews, err := ru.reviewRepo.FindAllByBookID(ctx, params)
+	if err != nil {
+		logger.Error(err)
+		return nil, int64(0), err
+	}
+
+	count, err := ru.reviewRepo.CountAllByBookID(ctx, params)
+	if err != nil {
+		logger.Error(err)
+		return nil, int64(0), err
+	}
+
+	return reviews, count, nil
+}
+
+func (ru *reviewUsecase) Update(ctx context.Context, input model.UpdateReviewInput) (*model.Review, error) {
+	if input.ID <= 0 {
+		return nil, errInvalidReviewID
+	}
+
+	if err := utils.ValidateStruct(input); err != nil {
+		return nil, err
+	}
+
+	review, err := ru.reviewRepo.Update(ctx, input)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":   utils.Dump(ctx),
+			"input": utils.Dump(input),
+		}).Error(err)
+		return nil, err
+	}
+
+	return review, nil
+}
+
+func (ru *reviewUsecase) Moderate(ctx context.Context, input model.ModerateReviewInput) (*model.Review, error) {
+	if input.ID <= 0 {
+		return nil, errInvalidReviewID
+	}
+
+	if err := utils.ValidateStruct(input); err != nil {
+		return nil, err
+	}
+
+	review, err := ru.reviewRepo.UpdateStatus(ctx, input.ID, input.Status)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":   utils.Dump(ctx),
+			"input": utils.Dump(input),
+		}).Error(err)
+		return nil, err
+	}
+
+	return review, nil
+}
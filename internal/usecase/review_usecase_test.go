@@ -0,0 +1,211 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+	"github.com/ssentinull/create-apis-using-golang/internal/model/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	reviewID = int64(1)
+	review   = &model.Review{
+		ID:       reviewID,
+		BookID:   bookID,
+		Rating:   4,
+		Body:     "A magical read",
+		Reviewer: "hermione",
+		Status:   model.ReviewStatusPending,
+	}
+	reviews = []*model.Review{review}
+)
+
+func TestReviewUsecase_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedReviewRepo := mock.NewMockReviewRepository(ctrl)
+	usecase := reviewUsecase{reviewRepo: mockedReviewRepo}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	t.Run("success", func(t *testing.T) {
+		mockedReviewRepo.EXPECT().Create(ctx, review).Times(1).Return(nil)
+		res, err := usecase.Create(ctx, review)
+		assert.NoError(t, err)
+		assert.Equal(t, review, res)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		mockedReviewRepo.EXPECT().Create(ctx, review).Times(1).Return(domainerr.Conflict("record already exists", nil))
+		res, err := usecase.Create(ctx, review)
+		assert.ErrorIs(t, err, domainerr.ErrConflict)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - rating is out of range", func(t *testing.T) {
+		res, err := usecase.Create(ctx, &model.Review{BookID: bookID, Rating: 6, Reviewer: "ron", Status: model.ReviewStatusPending})
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, res)
+	})
+}
+
+func TestReviewUsecase_DeleteByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedReviewRepo := mock.NewMockReviewRepository(ctrl)
+	usecase := reviewUsecase{reviewRepo: mockedReviewRepo}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	t.Run("success", func(t *testing.T) {
+		mockedReviewRepo.EXPECT().DeleteByID(ctx, reviewID).Times(1).Return(nil)
+		err := usecase.DeleteByID(ctx, reviewID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("failed - invalid ID", func(t *testing.T) {
+		err := usecase.DeleteByID(ctx, 0)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+	})
+}
+
+func TestReviewUsecase_FindByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedReviewRepo := mock.NewMockReviewRepository(ctrl)
+	usecase := reviewUsecase{reviewRepo: mockedReviewRepo}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	t.Run("success", func(t *testing.T) {
+		mockedReviewRepo.EXPECT().FindByID(ctx, reviewID).Times(1).Return(review, nil)
+		res, err := usecase.FindByID(ctx, reviewID)
+		assert.NoError(t, err)
+		assert.Equal(t, review, res)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		mockedReviewRepo.EXPECT().FindByID(ctx, reviewID).Times(1).Return(nil, errors.New("db error"))
+		res, err := usecase.FindByID(ctx, reviewID)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestReviewUsecase_FindAllByBookID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedReviewRepo := mock.NewMockReviewRepository(ctrl)
+	usecase := reviewUsecase{reviewRepo: mockedReviewRepo}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	params := model.GetReviewsQueryParams{BookID: bookID, PageQuery: model.PageQuery{Page: 1, Size: 5}, Status: model.ReviewStatusApproved}
+
+	t.Run("success - approved reviews by default", func(t *testing.T) {
+		mockedReviewRepo.EXPECT().FindAllByBookID(ctx, params).Times(1).Return(reviews, nil)
+		mockedReviewRepo.EXPECT().CountAllByBookID(ctx, params).Times(1).Return(int64(1), nil)
+
+		res, count, err := usecase.FindAllByBookID(ctx, model.GetReviewsQueryParams{BookID: bookID, PageQuery: model.PageQuery{Page: 1, Size: 5}})
+		assert.NoError(t, err)
+		assert.Equal(t, reviews, res)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("failed - count all return error", func(t *testing.T) {
+		mockedReviewRepo.EXPECT().FindAllByBookID(ctx, params).Times(1).Return(reviews, nil)
+		mockedReviewRepo.EXPECT().CountAllByBookID(ctx, params).Times(1).Return(int64(0), errors.New("db error"))
+
+		res, _, err := usecase.FindAllByBookID(ctx, params)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - invalid book ID", func(t *testing.T) {
+		res, _, err := usecase.FindAllByBookID(ctx, model.GetReviewsQueryParams{})
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, res)
+	})
+}
+
+func TestReviewUsecase_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedReviewRepo := mock.NewMockReviewRepository(ctrl)
+	usecase := reviewUsecase{reviewRepo: mockedReviewRepo}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	input := model.UpdateReviewInput{ID: reviewID, Rating: 5, Body: "Even better the second time"}
+
+	t.Run("success", func(t *testing.T) {
+		mockedReviewRepo.EXPECT().Update(ctx, input).Times(1).Return(review, nil)
+		res, err := usecase.Update(ctx, input)
+		assert.NoError(t, err)
+		assert.Equal(t, review, res)
+	})
+
+	t.Run("failed - rating is missing", func(t *testing.T) {
+		res, err := usecase.Update(ctx, model.UpdateReviewInput{ID: reviewID})
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - invalid ID", func(t *testing.T) {
+		res, err := usecase.Update(ctx, model.UpdateReviewInput{Rating: 5})
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, res)
+	})
+}
+
+func TestReviewUsecase_Moderate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedReviewRepo := mock.NewMockReviewRepository(ctrl)
+	usecase := reviewUsecase{reviewRepo: mockedReviewRepo}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	t.Run("success", func(t *testing.T) {
+		mockedReviewRepo.EXPECT().UpdateStatus(ctx, reviewID, model.ReviewStatusApproved).Times(1).Return(review, nil)
+		res, err := usecase.Moderate(ctx, model.ModerateReviewInput{ID: reviewID, Status: model.ReviewStatusApproved})
+		assert.NoError(t, err)
+		assert.Equal(t, review, res)
+	})
+
+	t.Run("failed - unknown status", func(t *testing.T) {
+		res, err := usecase.Moderate(ctx, model.ModerateReviewInput{ID: reviewID, Status: "spam"})
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - review not found", func(t *testing.T) {
+		mockedReviewRepo.EXPECT().UpdateStatus(ctx, reviewID, model.ReviewStatusRejected).Times(1).Return(nil, domainerr.NotFound("record not found", nil))
+		res, err := usecase.Moderate(ctx, model.ModerateReviewInput{ID: reviewID, Status: model.ReviewStatusRejected})
+		assert.ErrorIs(t, err, domainerr.ErrNotFound)
+		assert.Nil(t, res)
+	})
+}
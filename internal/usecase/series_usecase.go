@@ -0,0 +1,143 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	"github.com/ssentinull/create-apis-using-golang/internal/config"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+	"github.com/ssentinull/create-apis-using-golang/internal/utils"
+)
+
+var errInvalidSeriesID = domainerr.Validation("series ID must be a positive number", nil)
+
+type seriesUsecase struct {
+	seriesRepo model.SeriesRepository
+	bookRepo   model.BookRepository
+}
+
+func NewSeriesUsecase(sr model.SeriesRepository, br model.BookRepository) model.SeriesUsecase {
+	return &seriesUsecase{seriesRepo: sr, bookRepo: br}
+}
+
+func (su *seriesUsecase) Create(ctx context.Context, series *model.Series) (*model.Series, error) {
+	if err := utils.ValidateStruct(series); err != nil {
+		return nil, err
+	}
+
+	if err := su.seriesRepo.Create(ctx, series); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":    utils.Dump(ctx),
+			"series": utils.Dump(series),
+		}).Error(err)
+		return nil, err
+	}
+
+	return series, nil
+}
+
+func (su *seriesUsecase) DeleteByID(ctx context.Context, ID int64) error {
+	if ID <= 0 {
+		return errInvalidSeriesID
+	}
+
+	if err := su.seriesRepo.DeleteByID(ctx, ID); err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx": utils.Dump(ctx),
+			"ID":  ID,
+		}).Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (su *seriesUsecase) FindByID(ctx context.Context, ID int64) (*model.Series, error) {
+	if ID <= 0 {
+		return nil, errInvalidSeriesID
+	}
+
+	series, err := su.seriesRepo.FindByID(ctx, ID)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx": utils.Dump(ctx),
+			"ID":  ID,
+		}).Error(err)
+		return nil, err
+	}
+
+	return series, nil
+}
+
+func (su *seriesUsecase) FindAll(ctx context.Context, params model.GetSeriesQueryParams) ([]*model.Series, int64, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":    utils.Dump(ctx),
+		"params": utils.Dump(params),
+	})
+
+	params.Normalize(config.PaginationDefaultSize(), config.PaginationMaxSize())
+	if err := utils.ValidateStruct(params); err != nil {
+		return nil, int64(0), err
+	}
+
+	series, err := su.seriesRepo.FindAll(ctx, params)
+	if err != nil {
+		logger.Error(err)
+		return nil, int64(0), err
+	}
+
+	count, err := su.seriesRepo.CountAll(ctx, params)
+	if err != nil {
+		logger.Error(err)
+		return nil, int64(0), err
+	}
+
+	return series, count, nil
+}
+
+// FindBooks returns the books of an existing series in their reading order
+func (su *seriesUsecase) FindBooks(ctx context.Context, ID int64) ([]*model.Book, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx": utils.Dump(ctx),
+		"ID":  ID,
+	})
+
+	if ID <= 0 {
+		return nil, errInvalidSeriesID
+	}
+
+	if _, err := su.seriesRepo.FindByID(ctx, ID); err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	books, err := su.bookRepo.FindAllBySeriesID(ctx, ID)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return books, nil
+}
+
+func (su *seriesUsecase) Update(ctx context.Context, series *model.Series) (*model.Series, error) {
+	if series.ID <= 0 {
+		return nil, errInvalidSeriesID
+	}
+
+	if err := utils.ValidateStruct(series); err != nil {
+		return nil, err
+	}
+
+	updated, err := su.seriesRepo.Update(ctx, series)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":    utils.Dump(ctx),
+			"series": utils.Dump(series),
+		}).Error(err)
+		return nil, err
+	}
+
+	return updated, nil
+}
@@ -0,0 +1,235 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+	"github.com/ssentinull/create-apis-using-golang/internal/model/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	seriesID = int64(1)
+	series   = &model.Series{
+		ID:          seriesID,
+		Name:        "Harry Potter",
+		Description: "A series about wizards",
+	}
+	seriesList = []*model.Series{series}
+)
+
+func TestSeriesUsecase_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedSeriesRepo := mock.NewMockSeriesRepository(ctrl)
+	usecase := seriesUsecase{seriesRepo: mockedSeriesRepo}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	t.Run("success", func(t *testing.T) {
+		mockedSeriesRepo.EXPECT().Create(ctx, series).Times(1).Return(nil)
+		res, err := usecase.Create(ctx, series)
+		assert.NoError(t, err)
+		assert.Equal(t, series, res)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		mockedSeriesRepo.EXPECT().Create(ctx, series).Times(1).Return(errors.New("db error"))
+		res, err := usecase.Create(ctx, series)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - invalid series", func(t *testing.T) {
+		res, err := usecase.Create(ctx, &model.Series{Name: " "})
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, res)
+	})
+}
+
+func TestSeriesUsecase_DeleteByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedSeriesRepo := mock.NewMockSeriesRepository(ctrl)
+	usecase := seriesUsecase{seriesRepo: mockedSeriesRepo}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	t.Run("success", func(t *testing.T) {
+		mockedSeriesRepo.EXPECT().DeleteByID(ctx, seriesID).Times(1).Return(nil)
+		err := usecase.DeleteByID(ctx, seriesID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		mockedSeriesRepo.EXPECT().DeleteByID(ctx, seriesID).Times(1).Return(errors.New("db error"))
+		err := usecase.DeleteByID(ctx, seriesID)
+		assert.Error(t, err)
+	})
+
+	t.Run("failed - invalid ID", func(t *testing.T) {
+		err := usecase.DeleteByID(ctx, 0)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+	})
+}
+
+func TestSeriesUsecase_FindByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedSeriesRepo := mock.NewMockSeriesRepository(ctrl)
+	usecase := seriesUsecase{seriesRepo: mockedSeriesRepo}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	t.Run("success", func(t *testing.T) {
+		mockedSeriesRepo.EXPECT().FindByID(ctx, seriesID).Times(1).Return(series, nil)
+		res, err := usecase.FindByID(ctx, seriesID)
+		assert.NoError(t, err)
+		assert.Equal(t, series, res)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		mockedSeriesRepo.EXPECT().FindByID(ctx, seriesID).Times(1).Return(nil, errors.New("db error"))
+		res, err := usecase.FindByID(ctx, seriesID)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - invalid ID", func(t *testing.T) {
+		res, err := usecase.FindByID(ctx, -1)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, res)
+	})
+}
+
+func TestSeriesUsecase_FindAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedSeriesRepo := mock.NewMockSeriesRepository(ctrl)
+	usecase := seriesUsecase{seriesRepo: mockedSeriesRepo}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	params := model.GetSeriesQueryParams{PageQuery: model.PageQuery{Page: 1, Size: 5}}
+
+	t.Run("success", func(t *testing.T) {
+		mockedSeriesRepo.EXPECT().FindAll(ctx, params).Times(1).Return(seriesList, nil)
+		mockedSeriesRepo.EXPECT().CountAll(ctx, params).Times(1).Return(int64(1), nil)
+
+		res, count, err := usecase.FindAll(ctx, params)
+		assert.NoError(t, err)
+		assert.Equal(t, seriesList, res)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("failed - find all return error", func(t *testing.T) {
+		mockedSeriesRepo.EXPECT().FindAll(ctx, params).Times(1).Return(nil, errors.New("db error"))
+
+		res, count, err := usecase.FindAll(ctx, params)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+		assert.Zero(t, count)
+	})
+
+	t.Run("failed - count all return error", func(t *testing.T) {
+		mockedSeriesRepo.EXPECT().FindAll(ctx, params).Times(1).Return(seriesList, nil)
+		mockedSeriesRepo.EXPECT().CountAll(ctx, params).Times(1).Return(int64(0), errors.New("db error"))
+
+		res, _, err := usecase.FindAll(ctx, params)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+}
+
+func TestSeriesUsecase_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedSeriesRepo := mock.NewMockSeriesRepository(ctrl)
+	usecase := seriesUsecase{seriesRepo: mockedSeriesRepo}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	t.Run("success", func(t *testing.T) {
+		mockedSeriesRepo.EXPECT().Update(ctx, series).Times(1).Return(series, nil)
+		res, err := usecase.Update(ctx, series)
+		assert.NoError(t, err)
+		assert.Equal(t, series, res)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		mockedSeriesRepo.EXPECT().Update(ctx, series).Times(1).Return(nil, errors.New("db error"))
+		res, err := usecase.Update(ctx, series)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - invalid series", func(t *testing.T) {
+		res, err := usecase.Update(ctx, &model.Series{ID: seriesID, Name: ""})
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, res)
+	})
+}
+
+func TestSeriesUsecase_FindBooks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedSeriesRepo := mock.NewMockSeriesRepository(ctrl)
+	mockedBookRepo := mock.NewMockBookRepository(ctrl)
+	usecase := seriesUsecase{seriesRepo: mockedSeriesRepo, bookRepo: mockedBookRepo}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	t.Run("success", func(t *testing.T) {
+		mockedSeriesRepo.EXPECT().FindByID(ctx, seriesID).Times(1).Return(series, nil)
+		mockedBookRepo.EXPECT().FindAllBySeriesID(ctx, seriesID).Times(1).Return(books, nil)
+
+		res, err := usecase.FindBooks(ctx, seriesID)
+		assert.NoError(t, err)
+		assert.Equal(t, books, res)
+	})
+
+	t.Run("failed - series not found", func(t *testing.T) {
+		mockedSeriesRepo.EXPECT().FindByID(ctx, seriesID).Times(1).Return(nil, domainerr.NotFound("record not found", nil))
+
+		res, err := usecase.FindBooks(ctx, seriesID)
+		assert.ErrorIs(t, err, domainerr.ErrNotFound)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - find all by series ID return error", func(t *testing.T) {
+		mockedSeriesRepo.EXPECT().FindByID(ctx, seriesID).Times(1).Return(series, nil)
+		mockedBookRepo.EXPECT().FindAllBySeriesID(ctx, seriesID).Times(1).Return(nil, errors.New("db error"))
+
+		res, err := usecase.FindBooks(ctx, seriesID)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - invalid ID", func(t *testing.T) {
+		res, err := usecase.FindBooks(ctx, 0)
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, res)
+	})
+}
@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	"github.com/ssentinull/create-apis-using-golang/internal/config"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+	"github.com/ssentinull/create-apis-using-golang/internal/utils"
+)
+
+type tagUsecase struct {
+	tagRepo model.TagRepository
+}
+
+func NewTagUsecase(tr model.TagRepository) model.TagUsecase {
+	return &tagUsecase{tagRepo: tr}
+}
+
+func (tu *tagUsecase) FindAll(ctx context.Context, params model.GetTagsQueryParams) ([]*model.Tag, int64, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":    utils.Dump(ctx),
+		"params": utils.Dump(params),
+	})
+
+	params.Normalize(config.PaginationDefaultSize(), config.PaginationMaxSize())
+	if err := utils.ValidateStruct(params); err != nil {
+		return nil, int64(0), err
+	}
+
+	tags, err := tu.tagRepo.FindAll(ctx, params)
+	if err != nil {
+		logger.Error(err)
+		return nil, int64(0), err
+	}
+
+	count, err := tu.tagRepo.CountAll(ctx, params)
+	if err != nil {
+		logger.Error(err)
+		return nil, int64(0), err
+	}
+
+	return tags, count, nil
+}
+
+func (tu *tagUsecase) FindAllByBookID(ctx context.Context, bookID int64) ([]*model.Tag, error) {
+	if bookID <= 0 {
+		return nil, errInvalidBookID
+	}
+
+	tags, err := tu.tagRepo.FindAllByBookID(ctx, bookID)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{
+			"ctx":    utils.Dump(ctx),
+			"bookID": bookID,
+		}).Error(err)
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+func (tu *tagUsecase) AddBookTags(ctx context.Context, input model.BookTagsInput) ([]*model.Tag, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   utils.Dump(ctx),
+		"input": utils.Dump(input),
+	})
+
+	if err := tu.validateBookTags(input); err != nil {
+		return nil, err
+	}
+
+	if err := tu.tagRepo.AddBookTags(ctx, input.BookID, input.TagNames()); err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	tags, err := tu.tagRepo.FindAllByBookID(ctx, input.BookID)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+func (tu *tagUsecase) RemoveBookTags(ctx context.Context, input model.BookTagsInput) ([]*model.Tag, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"ctx":   utils.Dump(ctx),
+		"input": utils.Dump(input),
+	})
+
+	if err := tu.validateBookTags(input); err != nil {
+		return nil, err
+	}
+
+	if err := tu.tagRepo.RemoveBookTags(ctx, input.BookID, input.TagNames()); err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	tags, err := tu.tagRepo.FindAllByBookID(ctx, input.BookID)
+	if err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+func (tu *tagUsecase) validateBookTags(input model.BookTagsInput) error {
+	if input.BookID <= 0 {
+		return errInvalidBookID
+	}
+
+	return utils.ValidateStruct(input)
+}
@@ -0,0 +1,148 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+	"github.com/ssentinull/create-apis-using-golang/internal/model"
+	"github.com/ssentinull/create-apis-using-golang/internal/model/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	tag  = &model.Tag{ID: int64(1), Name: "magic"}
+	tags = []*model.Tag{tag}
+)
+
+func TestTagUsecase_FindAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedTagRepo := mock.NewMockTagRepository(ctrl)
+	usecase := tagUsecase{tagRepo: mockedTagRepo}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	params := model.GetTagsQueryParams{PageQuery: model.PageQuery{Page: 1, Size: 10}}
+
+	t.Run("success", func(t *testing.T) {
+		mockedTagRepo.EXPECT().FindAll(ctx, params).Times(1).Return(tags, nil)
+		mockedTagRepo.EXPECT().CountAll(ctx, params).Times(1).Return(int64(1), nil)
+
+		res, count, err := usecase.FindAll(ctx, params)
+		assert.NoError(t, err)
+		assert.Equal(t, tags, res)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		mockedTagRepo.EXPECT().FindAll(ctx, params).Times(1).Return(nil, errors.New("db error"))
+
+		res, count, err := usecase.FindAll(ctx, params)
+		assert.Error(t, err)
+		assert.Nil(t, res)
+		assert.Equal(t, int64(0), count)
+	})
+}
+
+func TestTagUsecase_FindAllByBookID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedTagRepo := mock.NewMockTagRepository(ctrl)
+	usecase := tagUsecase{tagRepo: mockedTagRepo}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	t.Run("success", func(t *testing.T) {
+		mockedTagRepo.EXPECT().FindAllByBookID(ctx, bookID).Times(1).Return(tags, nil)
+		res, err := usecase.FindAllByBookID(ctx, bookID)
+		assert.NoError(t, err)
+		assert.Equal(t, tags, res)
+	})
+
+	t.Run("failed - invalid ID", func(t *testing.T) {
+		res, err := usecase.FindAllByBookID(ctx, int64(0))
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, res)
+	})
+}
+
+func TestTagUsecase_AddBookTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedTagRepo := mock.NewMockTagRepository(ctrl)
+	usecase := tagUsecase{tagRepo: mockedTagRepo}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	input := model.BookTagsInput{BookID: bookID, Tags: []string{" Magic", "school", "MAGIC"}}
+
+	t.Run("success", func(t *testing.T) {
+		mockedTagRepo.EXPECT().AddBookTags(ctx, bookID, []string{"magic", "school"}).Times(1).Return(nil)
+		mockedTagRepo.EXPECT().FindAllByBookID(ctx, bookID).Times(1).Return(tags, nil)
+
+		res, err := usecase.AddBookTags(ctx, input)
+		assert.NoError(t, err)
+		assert.Equal(t, tags, res)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		mockedTagRepo.EXPECT().AddBookTags(ctx, bookID, gomock.Any()).Times(1).Return(domainerr.NotFound("book not found", nil))
+
+		res, err := usecase.AddBookTags(ctx, input)
+		assert.ErrorIs(t, err, domainerr.ErrNotFound)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - invalid ID", func(t *testing.T) {
+		res, err := usecase.AddBookTags(ctx, model.BookTagsInput{Tags: []string{"magic"}})
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, res)
+	})
+
+	t.Run("failed - blank tag", func(t *testing.T) {
+		res, err := usecase.AddBookTags(ctx, model.BookTagsInput{BookID: bookID, Tags: []string{" "}})
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, res)
+	})
+}
+
+func TestTagUsecase_RemoveBookTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockedTagRepo := mock.NewMockTagRepository(ctrl)
+	usecase := tagUsecase{tagRepo: mockedTagRepo}
+	ctx := context.Background()
+
+	defer func() {
+		ctrl.Finish()
+		ctx.Done()
+	}()
+
+	input := model.BookTagsInput{BookID: bookID, Tags: []string{"magic"}}
+
+	t.Run("success", func(t *testing.T) {
+		mockedTagRepo.EXPECT().RemoveBookTags(ctx, bookID, []string{"magic"}).Times(1).Return(nil)
+		mockedTagRepo.EXPECT().FindAllByBookID(ctx, bookID).Times(1).Return([]*model.Tag{}, nil)
+
+		res, err := usecase.RemoveBookTags(ctx, input)
+		assert.NoError(t, err)
+		assert.Empty(t, res)
+	})
+
+	t.Run("failed - empty tags", func(t *testing.T) {
+		res, err := usecase.RemoveBookTags(ctx, model.BookTagsInput{BookID: bookID})
+		assert.ErrorIs(t, err, domainerr.ErrValidation)
+		assert.Nil(t, res)
+	})
+}
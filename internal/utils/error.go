@@ -1,9 +1,26 @@
 package utils
 
-import "net/http"
+import (
+	"errors"
+	"net/http"
+
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+)
 
 func ParseHTTPErrorStatusCode(err error) int {
-	switch err {
+	switch {
+	case errors.Is(err, domainerr.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domainerr.ErrConflict):
+		return http.StatusConflict
+	case errors.Is(err, domainerr.ErrValidation):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, domainerr.ErrUnauthorized):
+		return http.StatusUnauthorized
+	case errors.Is(err, domainerr.ErrUnavailable):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, domainerr.ErrPreconditionFailed):
+		return http.StatusPreconditionFailed
 	default:
 		return http.StatusInternalServerError
 	}
@@ -1,10 +1,183 @@
 package utils
 
 import (
-	"math/rand"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	mathrand "math/rand"
+	"sync"
 	"time"
 )
 
+const (
+	// SnowflakeIDGenerator :nodoc:
+	SnowflakeIDGenerator = "snowflake"
+	// ULIDIDGenerator :nodoc:
+	ULIDIDGenerator = "ulid"
+)
+
+const (
+	snowflakeNodeBits     = 10
+	snowflakeSequenceBits = 12
+	snowflakeMaxNodeID    = 1<<snowflakeNodeBits - 1
+	snowflakeMaxSequence  = 1<<snowflakeSequenceBits - 1
+
+	ulidNodeBits    = snowflakeNodeBits
+	ulidEntropyBits = snowflakeSequenceBits
+	ulidMaxEntropy  = 1<<ulidEntropyBits - 1
+)
+
+// snowflakeEpoch is the Twitter snowflake epoch, it keeps the IDs of both
+// generators above the nanosecond timestamps that were used as IDs before
+var snowflakeEpoch = time.UnixMilli(1288834974657)
+
+// IDGenerator generates unique int64 IDs that are ordered by creation time
+type IDGenerator interface {
+	NextID() int64
+}
+
+var (
+	idGenerator   IDGenerator = &snowflakeGenerator{now: time.Now}
+	idGeneratorMu sync.RWMutex
+)
+
+// GenerateID returns a new ID from the generator set by SetIDGenerator,
+// a snowflake generator of node 0 by default
 func GenerateID() int64 {
-	return time.Now().UnixNano() + int64(rand.Intn(10000))
+	idGeneratorMu.RLock()
+	defer idGeneratorMu.RUnlock()
+	return idGenerator.NextID()
+}
+
+// SetIDGenerator replaces the generator used by GenerateID
+func SetIDGenerator(generator IDGenerator) {
+	idGeneratorMu.Lock()
+	defer idGeneratorMu.Unlock()
+	idGenerator = generator
+}
+
+// NewIDGenerator creates the ID generator of the given type for the node
+func NewIDGenerator(generatorType string, nodeID int64) (IDGenerator, error) {
+	switch generatorType {
+	case SnowflakeIDGenerator:
+		return NewSnowflakeGenerator(nodeID)
+	case ULIDIDGenerator:
+		return NewULIDGenerator(nodeID)
+	default:
+		return nil, fmt.Errorf("unknown ID generator %q", generatorType)
+	}
+}
+
+// snowflakeGenerator lays an ID out as 41 bits of milliseconds since the
+// snowflake epoch, 10 bits of node ID and a 12 bits sequence. IDs are unique
+// across nodes with distinct node IDs and monotonic within a node
+type snowflakeGenerator struct {
+	mu       sync.Mutex
+	now      func() time.Time
+	nodeID   int64
+	lastMs   int64
+	sequence int64
+}
+
+// NewSnowflakeGenerator :nodoc:
+func NewSnowflakeGenerator(nodeID int64) (IDGenerator, error) {
+	if nodeID < 0 || nodeID > snowflakeMaxNodeID {
+		return nil, fmt.Errorf("snowflake node ID must be between 0 and %d", snowflakeMaxNodeID)
+	}
+
+	return &snowflakeGenerator{now: time.Now, nodeID: nodeID}, nil
+}
+
+// NextID :nodoc:
+func (g *snowflakeGenerator) NextID() int64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	// never go back in time, even when the clock does
+	ms := g.now().Sub(snowflakeEpoch).Milliseconds()
+	if ms <= g.lastMs {
+		ms = g.lastMs
+		g.sequence++
+		// the sequence of this millisecond is exhausted, borrow the next one
+		if g.sequence > snowflakeMaxSequence {
+			ms++
+			g.sequence = 0
+		}
+	} else {
+		g.sequence = 0
+	}
+
+	g.lastMs = ms
+	return ms<<(snowflakeNodeBits+snowflakeSequenceBits) | g.nodeID<<snowflakeSequenceBits | g.sequence
+}
+
+// ulidGenerator fits a monotonic ULID in an int64, since book IDs are
+// BIGINT. It keeps the layout of the snowflake generator, 41 bits of
+// milliseconds since the snowflake epoch, 10 bits of node ID and 12 bits of
+// entropy instead of 80, so that its IDs stay above the nanosecond IDs of
+// the existing books and interleave with the snowflake IDs by time. The
+// entropy is random on every new millisecond and incremented within it, so
+// IDs are unique across nodes with distinct node IDs and monotonic within a
+// node
+type ulidGenerator struct {
+	mu      sync.Mutex
+	now     func() time.Time
+	random  *mathrand.Rand
+	nodeID  int64
+	lastMs  int64
+	entropy int64
+}
+
+// NewULIDGenerator :nodoc:
+func NewULIDGenerator(nodeID int64) (IDGenerator, error) {
+	if nodeID < 0 || nodeID > snowflakeMaxNodeID {
+		return nil, fmt.Errorf("ULID node ID must be between 0 and %d", snowflakeMaxNodeID)
+	}
+
+	random, err := newSeededRand()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ulidGenerator{now: time.Now, random: random, nodeID: nodeID}, nil
+}
+
+// NextID :nodoc:
+func (g *ulidGenerator) NextID() int64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	// never go back in time, even when the clock does
+	ms := g.now().Sub(snowflakeEpoch).Milliseconds()
+	if ms <= g.lastMs {
+		ms = g.lastMs
+		g.entropy++
+		// the entropy of this millisecond overflowed, borrow the next one
+		if g.entropy > ulidMaxEntropy {
+			ms++
+			g.entropy = g.randomEntropy()
+		}
+	} else {
+		g.entropy = g.randomEntropy()
+	}
+
+	g.lastMs = ms
+	return ms<<(ulidNodeBits+ulidEntropyBits) | g.nodeID<<ulidEntropyBits | g.entropy
+}
+
+// randomEntropy returns random entropy from the lower half of its range,
+// leaving room to increment it within the same millisecond
+func (g *ulidGenerator) randomEntropy() int64 {
+	return g.random.Int63() & (ulidMaxEntropy >> 1)
+}
+
+// newSeededRand returns a pseudo random source seeded from crypto/rand, so
+// that reading the entropy of every millisecond can't fail
+func newSeededRand() (*mathrand.Rand, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return nil, fmt.Errorf("failed to seed the ULID entropy: %w", err)
+	}
+
+	return mathrand.New(mathrand.NewSource(int64(binary.BigEndian.Uint64(b)))), nil
 }
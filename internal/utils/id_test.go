@@ -0,0 +1,168 @@
+package utils
+
+import (
+	mathrand "math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// generateConcurrently generates workers * perWorker IDs from concurrent goroutines
+func generateConcurrently(generator IDGenerator, workers, perWorker int) []int64 {
+	IDs := make(chan int64, workers*perWorker)
+	wg := sync.WaitGroup{}
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < perWorker; j++ {
+				IDs <- generator.NextID()
+			}
+		}()
+	}
+
+	wg.Wait()
+	close(IDs)
+
+	res := []int64{}
+	for ID := range IDs {
+		res = append(res, ID)
+	}
+	return res
+}
+
+func assertUnique(t *testing.T, IDs []int64) {
+	seen := make(map[int64]bool, len(IDs))
+	for _, ID := range IDs {
+		assert.False(t, seen[ID], "ID %d is generated twice", ID)
+		assert.Positive(t, ID)
+		seen[ID] = true
+	}
+}
+
+func TestSnowflakeGenerator_NextID(t *testing.T) {
+	t.Run("success - unique under concurrency", func(t *testing.T) {
+		generator, err := NewSnowflakeGenerator(1)
+		assert.NoError(t, err)
+		assertUnique(t, generateConcurrently(generator, 16, 5000))
+	})
+
+	t.Run("success - unique across nodes", func(t *testing.T) {
+		now := time.Now()
+		nodeA := &snowflakeGenerator{now: func() time.Time { return now }, nodeID: 1}
+		nodeB := &snowflakeGenerator{now: func() time.Time { return now }, nodeID: 2}
+
+		IDs := append(generateConcurrently(nodeA, 4, 1000), generateConcurrently(nodeB, 4, 1000)...)
+		assertUnique(t, IDs)
+	})
+
+	t.Run("success - monotonic when the clock goes back and the sequence overflows", func(t *testing.T) {
+		now := time.Now()
+		generator := &snowflakeGenerator{now: func() time.Time { return now }, nodeID: 3}
+
+		last := generator.NextID()
+		now = now.Add(-time.Second)
+		for i := 0; i < 2*snowflakeMaxSequence; i++ {
+			ID := generator.NextID()
+			assert.Greater(t, ID, last)
+			last = ID
+		}
+	})
+
+	t.Run("success - ID holds the node ID and stays above nanosecond IDs", func(t *testing.T) {
+		now := time.Now()
+		generator := &snowflakeGenerator{now: func() time.Time { return now }, nodeID: 5}
+
+		ID := generator.NextID()
+		assert.Equal(t, int64(5), ID>>snowflakeSequenceBits&snowflakeMaxNodeID)
+		assert.Greater(t, ID, now.UnixNano())
+	})
+
+	t.Run("failed - node ID is out of range", func(t *testing.T) {
+		generator, err := NewSnowflakeGenerator(snowflakeMaxNodeID + 1)
+		assert.Error(t, err)
+		assert.Nil(t, generator)
+	})
+}
+
+func TestULIDGenerator_NextID(t *testing.T) {
+	t.Run("success - unique under concurrency", func(t *testing.T) {
+		generator, err := NewULIDGenerator(1)
+		assert.NoError(t, err)
+		assertUnique(t, generateConcurrently(generator, 16, 5000))
+	})
+
+	t.Run("success - unique across nodes", func(t *testing.T) {
+		now := time.Now()
+		random, err := newSeededRand()
+		assert.NoError(t, err)
+
+		// both nodes draw the same entropy, only the node ID tells their IDs apart
+		nodeA := &ulidGenerator{now: func() time.Time { return now }, random: mathrand.New(mathrand.NewSource(1)), nodeID: 1}
+		nodeB := &ulidGenerator{now: func() time.Time { return now }, random: mathrand.New(mathrand.NewSource(1)), nodeID: 2}
+		nodeC := &ulidGenerator{now: func() time.Time { return now }, random: random, nodeID: 3}
+
+		IDs := append(generateConcurrently(nodeA, 4, 1000), generateConcurrently(nodeB, 4, 1000)...)
+		IDs = append(IDs, generateConcurrently(nodeC, 4, 1000)...)
+		assertUnique(t, IDs)
+	})
+
+	t.Run("success - monotonic when the clock goes back and the entropy overflows", func(t *testing.T) {
+		now := time.Now()
+		generator := &ulidGenerator{now: func() time.Time { return now }, random: mathrand.New(mathrand.NewSource(1))}
+
+		last := generator.NextID()
+		now = now.Add(-time.Second)
+		for i := 0; i < 2*ulidMaxEntropy; i++ {
+			ID := generator.NextID()
+			assert.Greater(t, ID, last)
+			last = ID
+		}
+	})
+
+	t.Run("success - ID holds the timestamp and the node ID and stays above nanosecond IDs", func(t *testing.T) {
+		now := time.Now()
+		generator := &ulidGenerator{now: func() time.Time { return now }, random: mathrand.New(mathrand.NewSource(1)), nodeID: 5}
+
+		ID := generator.NextID()
+		assert.Equal(t, now.Sub(snowflakeEpoch).Milliseconds(), ID>>(ulidNodeBits+ulidEntropyBits))
+		assert.Equal(t, int64(5), ID>>ulidEntropyBits&snowflakeMaxNodeID)
+		assert.Greater(t, ID, now.UnixNano())
+	})
+
+	t.Run("success - sorts by time with the snowflake IDs", func(t *testing.T) {
+		now := time.Now()
+		snowflake := &snowflakeGenerator{now: func() time.Time { return now }, nodeID: 1}
+		ulid := &ulidGenerator{now: func() time.Time { return now.Add(time.Millisecond) }, random: mathrand.New(mathrand.NewSource(1)), nodeID: 1}
+
+		earlier := snowflake.NextID()
+		ID := ulid.NextID()
+		now = now.Add(2 * time.Millisecond)
+		assert.Greater(t, ID, earlier)
+		assert.Greater(t, snowflake.NextID(), ID)
+	})
+
+	t.Run("failed - node ID is out of range", func(t *testing.T) {
+		generator, err := NewULIDGenerator(snowflakeMaxNodeID + 1)
+		assert.Error(t, err)
+		assert.Nil(t, generator)
+	})
+}
+
+func TestNewIDGenerator(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		for _, generatorType := range []string{SnowflakeIDGenerator, ULIDIDGenerator} {
+			generator, err := NewIDGenerator(generatorType, 1)
+			assert.NoError(t, err)
+			assert.NotNil(t, generator)
+		}
+	})
+
+	t.Run("failed - unknown generator", func(t *testing.T) {
+		generator, err := NewIDGenerator("uuid", 1)
+		assert.Error(t, err)
+		assert.Nil(t, generator)
+	})
+}
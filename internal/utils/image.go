@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"bytes"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+
+	"golang.org/x/image/draw"
+
+	// register the decoders of the accepted image formats
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	// MIMEImageJPEG :nodoc:
+	MIMEImageJPEG = "image/jpeg"
+	// MIMEImagePNG :nodoc:
+	MIMEImagePNG = "image/png"
+	// MIMEImageWebP :nodoc:
+	MIMEImageWebP = "image/webp"
+)
+
+// thumbnailJPEGQuality is a good enough quality for a small preview
+const thumbnailJPEGQuality = 85
+
+// SniffImage detects the content type of an image from its first bytes, ok
+// is false for anything but a JPEG, PNG or WebP image
+func SniffImage(body []byte) (contentType string, ok bool) {
+	contentType = http.DetectContentType(body)
+	switch contentType {
+	case MIMEImageJPEG, MIMEImagePNG, MIMEImageWebP:
+		return contentType, true
+	default:
+		return contentType, false
+	}
+}
+
+// Thumbnail scales img down to width keeping its aspect ratio, an image
+// narrower than width is returned as it is
+func Thumbnail(img image.Image, width int) image.Image {
+	bounds := img.Bounds()
+	if bounds.Dx() <= width {
+		return img
+	}
+
+	height := bounds.Dy() * width / bounds.Dx()
+	if height < 1 {
+		height = 1
+	}
+
+	thumb := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(thumb, thumb.Bounds(), img, bounds, draw.Over, nil)
+	return thumb
+}
+
+// EncodeThumbnail encodes a thumbnail as a PNG when it comes from a PNG, to
+// keep its transparency, and as a JPEG otherwise
+func EncodeThumbnail(img image.Image, sourceContentType string) ([]byte, string, error) {
+	buf := &bytes.Buffer{}
+	if sourceContentType == MIMEImagePNG {
+		if err := png.Encode(buf, img); err != nil {
+			return nil, "", err
+		}
+		return buf.Bytes(), MIMEImagePNG, nil
+	}
+
+	if err := jpeg.Encode(buf, img, &jpeg.Options{Quality: thumbnailJPEGQuality}); err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), MIMEImageJPEG, nil
+}
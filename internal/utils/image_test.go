@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSniffImage(t *testing.T) {
+	buf := &bytes.Buffer{}
+	assert.NoError(t, png.Encode(buf, image.NewRGBA(image.Rect(0, 0, 1, 1))))
+
+	t.Run("success - png", func(t *testing.T) {
+		contentType, ok := SniffImage(buf.Bytes())
+		assert.True(t, ok)
+		assert.Equal(t, MIMEImagePNG, contentType)
+	})
+
+	t.Run("success - webp", func(t *testing.T) {
+		contentType, ok := SniffImage([]byte("RIFF\x00\x00\x00\x00WEBPVP"))
+		assert.True(t, ok)
+		assert.Equal(t, MIMEImageWebP, contentType)
+	})
+
+	t.Run("failed - gif is not accepted", func(t *testing.T) {
+		_, ok := SniffImage([]byte("GIF89a"))
+		assert.False(t, ok)
+	})
+}
+
+func TestThumbnail(t *testing.T) {
+	t.Run("success - keeps the aspect ratio", func(t *testing.T) {
+		thumb := Thumbnail(image.NewRGBA(image.Rect(0, 0, 600, 900)), 300)
+		assert.Equal(t, image.Rect(0, 0, 300, 450), thumb.Bounds())
+	})
+
+	t.Run("success - narrow image is not upscaled", func(t *testing.T) {
+		thumb := Thumbnail(image.NewRGBA(image.Rect(0, 0, 200, 300)), 300)
+		assert.Equal(t, image.Rect(0, 0, 200, 300), thumb.Bounds())
+	})
+}
+
+func TestEncodeThumbnail(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 10, 10))
+
+	t.Run("success - png stays png", func(t *testing.T) {
+		_, contentType, err := EncodeThumbnail(img, MIMEImagePNG)
+		assert.NoError(t, err)
+		assert.Equal(t, MIMEImagePNG, contentType)
+	})
+
+	t.Run("success - webp becomes jpeg", func(t *testing.T) {
+		body, contentType, err := EncodeThumbnail(img, MIMEImageWebP)
+		assert.NoError(t, err)
+		assert.Equal(t, MIMEImageJPEG, contentType)
+		assert.Equal(t, MIMEImageJPEG, http.DetectContentType(body))
+	})
+}
@@ -0,0 +1,97 @@
+package utils
+
+import "strings"
+
+// NormalizeISBN strips the hyphens and spaces of isbn and converts a valid
+// ISBN-10 to its ISBN-13, anything else is returned stripped but unchecked
+func NormalizeISBN(isbn string) string {
+	isbn = stripISBN(isbn)
+	if !ValidISBN10(isbn) {
+		return isbn
+	}
+
+	isbn13 := "978" + isbn[:9]
+	return isbn13 + string(isbn13CheckDigit(isbn13))
+}
+
+// ISBN10 returns the ISBN-10 of a valid 978 prefixed ISBN-13, or "" as
+// the 979 prefixed ones have no ISBN-10
+func ISBN10(isbn13 string) string {
+	isbn13 = stripISBN(isbn13)
+	if !ValidISBN13(isbn13) || !strings.HasPrefix(isbn13, "978") {
+		return ""
+	}
+
+	isbn10 := isbn13[3:12]
+	return isbn10 + string(isbn10CheckDigit(isbn10))
+}
+
+// ValidISBN reports whether isbn, hyphenated or not, is a valid ISBN-10 or ISBN-13
+func ValidISBN(isbn string) bool {
+	isbn = stripISBN(isbn)
+	return ValidISBN10(isbn) || ValidISBN13(isbn)
+}
+
+// ValidISBN10 reports whether isbn is 10 characters long with a matching check digit
+func ValidISBN10(isbn string) bool {
+	isbn = stripISBN(isbn)
+	return len(isbn) == 10 && allDigits(isbn[:9]) && isbn[9] == isbn10CheckDigit(isbn[:9])
+}
+
+// ValidISBN13 reports whether isbn is a 978 or 979 prefixed ISBN-13 with a matching check digit
+func ValidISBN13(isbn string) bool {
+	isbn = stripISBN(isbn)
+	if len(isbn) != 13 || !allDigits(isbn) {
+		return false
+	}
+
+	if !strings.HasPrefix(isbn, "978") && !strings.HasPrefix(isbn, "979") {
+		return false
+	}
+
+	return isbn[12] == isbn13CheckDigit(isbn[:12])
+}
+
+func stripISBN(isbn string) string {
+	isbn = strings.NewReplacer("-", "", " ", "").Replace(isbn)
+	return strings.ToUpper(isbn)
+}
+
+// isbn10CheckDigit weighs the first 9 digits from 10 down to 2, the check
+// digit makes the sum a multiple of 11 where X stands for 10
+func isbn10CheckDigit(digits string) byte {
+	sum := 0
+	for i := 0; i < 9; i++ {
+		sum += int(digits[i]-'0') * (10 - i)
+	}
+
+	check := (11 - sum%11) % 11
+	if check == 10 {
+		return 'X'
+	}
+	return byte('0' + check)
+}
+
+// isbn13CheckDigit weighs the first 12 digits alternately by 1 and 3, the
+// check digit makes the sum a multiple of 10
+func isbn13CheckDigit(digits string) byte {
+	sum := 0
+	for i := 0; i < 12; i++ {
+		weight := 1
+		if i%2 == 1 {
+			weight = 3
+		}
+		sum += int(digits[i]-'0') * weight
+	}
+
+	return byte('0' + (10-sum%10)%10)
+}
+
+func allDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
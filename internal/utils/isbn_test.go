@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeISBN(t *testing.T) {
+	t.Run("success - hyphenated ISBN-13", func(t *testing.T) {
+		assert.Equal(t, "9780747532699", NormalizeISBN("978-0-7475-3269-9"))
+	})
+
+	t.Run("success - ISBN-10 is converted to ISBN-13", func(t *testing.T) {
+		assert.Equal(t, "9780747532699", NormalizeISBN("0-7475-3269-9"))
+	})
+
+	t.Run("success - ISBN-10 with an X check digit", func(t *testing.T) {
+		assert.Equal(t, "9780804429573", NormalizeISBN("080442957x"))
+	})
+
+	t.Run("failed - invalid ISBN is only stripped", func(t *testing.T) {
+		assert.Equal(t, "0747532690", NormalizeISBN("0-7475-3269-0"))
+	})
+}
+
+func TestISBN10(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		assert.Equal(t, "0747532699", ISBN10("978-0-7475-3269-9"))
+		assert.Equal(t, "080442957X", ISBN10("9780804429573"))
+	})
+
+	t.Run("failed - 979 prefix has no ISBN-10", func(t *testing.T) {
+		assert.Equal(t, "", ISBN10("9791032305690"))
+	})
+
+	t.Run("failed - invalid ISBN-13", func(t *testing.T) {
+		assert.Equal(t, "", ISBN10("9780747532690"))
+	})
+}
+
+func TestValidISBN(t *testing.T) {
+	assert.True(t, ValidISBN("978-0-7475-3269-9"))
+	assert.True(t, ValidISBN("0 7475 3269 9"))
+	assert.True(t, ValidISBN("9791032305690"))
+	assert.False(t, ValidISBN("9780747532690"))
+	assert.False(t, ValidISBN("9770747532694"))
+	assert.False(t, ValidISBN("07475X3269"))
+	assert.False(t, ValidISBN(""))
+}
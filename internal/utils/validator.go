@@ -0,0 +1,110 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/ssentinull/create-apis-using-golang/internal/domainerr"
+)
+
+// DateLayout is the layout used for date only fields such as published_date
+const DateLayout = "2006-01-02"
+
+var validate = newValidator()
+
+func newValidator() *validator.Validate {
+	v := validator.New()
+
+	// report fields by their json name so the messages match the request body
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "" || name == "-" {
+			return field.Name
+		}
+		return name
+	})
+
+	_ = v.RegisterValidation("notblank", func(fl validator.FieldLevel) bool {
+		return strings.TrimSpace(fl.Field().String()) != ""
+	})
+
+	_ = v.RegisterValidation("date", func(fl validator.FieldLevel) bool {
+		_, err := time.Parse(DateLayout, fl.Field().String())
+		return err == nil
+	})
+
+	// the isbn tags replace the stock ones, which only allow up to 4 hyphens or spaces
+	_ = v.RegisterValidation("isbn", func(fl validator.FieldLevel) bool {
+		return ValidISBN(fl.Field().String())
+	})
+
+	_ = v.RegisterValidation("isbn10", func(fl validator.FieldLevel) bool {
+		return ValidISBN10(fl.Field().String())
+	})
+
+	_ = v.RegisterValidation("isbn13", func(fl validator.FieldLevel) bool {
+		return ValidISBN13(fl.Field().String())
+	})
+
+	// sameisbn=Field holds when the other ISBN field is empty or is the same
+	// book once both are normalized, eg: an ISBN-10 and its ISBN-13
+	_ = v.RegisterValidation("sameisbn", func(fl validator.FieldLevel) bool {
+		other := fl.Parent().FieldByName(fl.Param())
+		if !other.IsValid() || other.String() == "" {
+			return true
+		}
+		return NormalizeISBN(fl.Field().String()) == NormalizeISBN(other.String())
+	})
+
+	return v
+}
+
+// ValidateStruct validates i against its `validate` struct tags and returns
+// a domainerr validation error carrying a message per invalid field
+func ValidateStruct(i interface{}) error {
+	err := validate.Struct(i)
+	if err == nil {
+		return nil
+	}
+
+	fieldErrs := validator.ValidationErrors{}
+	if !errors.As(err, &fieldErrs) {
+		return err
+	}
+
+	fields := make(map[string]string, len(fieldErrs))
+	for _, fieldErr := range fieldErrs {
+		fields[fieldErr.Field()] = validationMessage(fieldErr)
+	}
+
+	return domainerr.ValidationFields("request contains invalid fields", fields)
+}
+
+func validationMessage(fieldErr validator.FieldError) string {
+	switch fieldErr.Tag() {
+	case "required", "required_without":
+		return "is required"
+	case "notblank":
+		return "must not be blank"
+	case "max":
+		return fmt.Sprintf("must be at most %s characters long", fieldErr.Param())
+	case "min":
+		return fmt.Sprintf("must be at least %s", fieldErr.Param())
+	case "date":
+		return "must be a valid date in YYYY-MM-DD format"
+	case "isbn":
+		return "must be a valid ISBN-10 or ISBN-13"
+	case "isbn10":
+		return "must be a valid ISBN-10"
+	case "isbn13":
+		return "must be a valid ISBN-13"
+	case "sameisbn":
+		return fmt.Sprintf("must be the same book as %s", strings.ToLower(fieldErr.Param()))
+	default:
+		return "is invalid"
+	}
+}